@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/pflag"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -31,6 +32,9 @@ func init() {
 	if err := appsv1.AddToScheme(scheme); err != nil {
 		util.LogFatalAndExit(err, "failed to add apps v1 scheme")
 	}
+	if err := netv1.AddToScheme(scheme); err != nil {
+		util.LogFatalAndExit(err, "failed to add networking v1 scheme")
+	}
 	if err := ovnv1.AddToScheme(scheme); err != nil {
 		util.LogFatalAndExit(err, "failed to add ovn v1 scheme")
 	}
@@ -124,6 +124,12 @@ kubectl delete --ignore-not-found sa vpc-dns -n kube-system
 kubectl delete --ignore-not-found crd \
   htbqoses.kubeovn.io \
   security-groups.kubeovn.io \
+  address-groups.kubeovn.io \
+  interconnections.kubeovn.io \
+  vpc-route-tables.kubeovn.io \
+  dhcp-options.kubeovn.io \
+  boot-servers.kubeovn.io \
+  ip-handoffs.kubeovn.io \
   ippools.kubeovn.io \
   vpc-nat-gateways.kubeovn.io \
   vpcs.kubeovn.io \
@@ -13,6 +13,10 @@ VLAN_NIC=${VLAN_NIC:-}
 HW_OFFLOAD=${HW_OFFLOAD:-false}
 ENABLE_LB=${ENABLE_LB:-true}
 ENABLE_NP=${ENABLE_NP:-true}
+ENABLE_ANP=${ENABLE_ANP:-false}
+ENABLE_NP_ACL_STATS=${ENABLE_NP_ACL_STATS:-false}
+ENABLE_ACL_LOG_SHIPPER=${ENABLE_ACL_LOG_SHIPPER:-false}
+ACL_LOG_OUTPUT=${ACL_LOG_OUTPUT:-stdout}
 ENABLE_EIP_SNAT=${ENABLE_EIP_SNAT:-true}
 LS_DNAT_MOD_DL_DST=${LS_DNAT_MOD_DL_DST:-true}
 LS_CT_SKIP_DST_LPORT_IPS=${LS_CT_SKIP_DST_LPORT_IPS:-true}
@@ -20,6 +24,10 @@ ENABLE_EXTERNAL_VPC=${ENABLE_EXTERNAL_VPC:-true}
 CNI_CONFIG_PRIORITY=${CNI_CONFIG_PRIORITY:-01}
 ENABLE_LB_SVC=${ENABLE_LB_SVC:-false}
 ENABLE_NAT_GW=${ENABLE_NAT_GW:-true}
+# backend of vpc nat gateway rules, iptables or nftables
+NAT_GW_BACKEND=${NAT_GW_BACKEND:-iptables}
+# export conntrack and traffic metrics of vpc nat gateway eips and rules
+ENABLE_NAT_GW_METRICS=${ENABLE_NAT_GW_METRICS:-false}
 ENABLE_KEEP_VM_IP=${ENABLE_KEEP_VM_IP:-true}
 ENABLE_ARP_DETECT_IP_CONFLICT=${ENABLE_ARP_DETECT_IP_CONFLICT:-true}
 NODE_LOCAL_DNS_IP=${NODE_LOCAL_DNS_IP:-}
@@ -192,6 +200,9 @@ echo "Default Subnet CIDR:  $POD_CIDR"
 echo "Join Subnet CIDR:     $JOIN_CIDR"
 echo "Enable SVC LB:        $ENABLE_LB"
 echo "Enable Networkpolicy: $ENABLE_NP"
+echo "Enable AdminNetworkpolicy: $ENABLE_ANP"
+echo "Enable Networkpolicy ACL Stats: $ENABLE_NP_ACL_STATS"
+echo "Enable ACL Log Shipper: $ENABLE_ACL_LOG_SHIPPER"
 echo "Enable EIP and SNAT:  $ENABLE_EIP_SNAT"
 echo "Enable Mirror:        $ENABLE_MIRROR"
 echo "-------------------------------"
@@ -1516,6 +1527,9 @@ spec:
       - jsonPath: .spec.ipName
         name: IpName
         type: string
+      - jsonPath: .status.type
+        name: Type
+        type: string
       schema:
         openAPIV3Schema:
           type: object
@@ -1529,6 +1543,8 @@ spec:
                   type: string
                 v4Ip:
                   type: string
+                type:
+                  type: string
                 vpc:
                   type: string
                 conditions:
@@ -1561,6 +1577,13 @@ spec:
                   type: string
                 v4Ip:
                   type: string
+                type:
+                  type: string
+                  enum:
+                    - distributed
+                    - centralized
+                stateless:
+                  type: boolean
 ---
 apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
@@ -1608,6 +1631,12 @@ spec:
                   type: string
                 v4IpCidr:
                   type: string
+                v4Eips:
+                  type: array
+                  items:
+                    type: string
+                externalPortRange:
+                  type: string
                 vpc:
                   type: string
                 conditions:
@@ -1640,6 +1669,16 @@ spec:
                   type: string
                 v4IpCidr:
                   type: string
+                ovnEips:
+                  type: array
+                  items:
+                    type: string
+                ovnEipSelector:
+                  type: object
+                  additionalProperties:
+                    type: string
+                externalPortRange:
+                  type: string
 ---
 apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
@@ -1832,6 +1871,21 @@ spec:
                         type: string
                     type: object
                   type: array
+                interConnection:
+                  properties:
+                    transitSwitch:
+                      type: string
+                    transitSwitchCIDR:
+                      type: string
+                    advertiseCIDRs:
+                      items:
+                        type: string
+                      type: array
+                    acceptCIDRs:
+                      items:
+                        type: string
+                      type: array
+                  type: object
               type: object
             status:
               properties:
@@ -1876,6 +1930,42 @@ spec:
                   items:
                     type: string
                   type: array
+                interConnectionTransitSwitch:
+                  type: string
+                learnedRoutes:
+                  items:
+                    properties:
+                      cidr:
+                        type: string
+                      nextHopIP:
+                        type: string
+                      routeTable:
+                        type: string
+                      availabilityZone:
+                        type: string
+                      reason:
+                        type: string
+                    type: object
+                  type: array
+                advertisedRoutes:
+                  items:
+                    type: string
+                  type: array
+                rejectedRoutes:
+                  items:
+                    properties:
+                      cidr:
+                        type: string
+                      nextHopIP:
+                        type: string
+                      routeTable:
+                        type: string
+                      availabilityZone:
+                        type: string
+                      reason:
+                        type: string
+                    type: object
+                  type: array
                 tcpLoadBalancer:
                   type: string
                 tcpSessionLoadBalancer:
@@ -2283,16 +2373,26 @@ spec:
                   type: boolean
                 disableInterConnection:
                   type: boolean
+                interConnectionAZs:
+                  type: array
+                  items:
+                    type: string
                 enableDHCP:
                   type: boolean
                 dhcpV4Options:
                   type: string
                 dhcpV6Options:
                   type: string
+                dhcpOptions:
+                  type: string
                 enableIPv6RA:
                   type: boolean
                 ipv6RAConfigs:
                   type: string
+                ipv6AddressMode:
+                  type: string
+                  enum:
+                    - slaac
                 acls:
                   type: array
                   items:
@@ -2317,6 +2417,8 @@ spec:
                           - allow
                           - drop
                           - reject
+                      stateless:
+                        type: boolean
                 natOutgoingPolicyRules:
                   type: array
                   items:
@@ -2373,6 +2475,9 @@ spec:
       - name: IPs
         type: string
         jsonPath: .spec.ips
+      - name: Priority
+        type: integer
+        jsonPath: .spec.priority
       - name: V4Used
         type: number
         jsonPath: .status.v4UsingIPs
@@ -2404,7 +2509,6 @@ spec:
                     type: string
                 ips:
                   type: array
-                  minItems: 1
                   x-kubernetes-list-type: set
                   items:
                     type: string
@@ -2414,9 +2518,78 @@ spec:
                       - format: cidr
                       - pattern: ^(?:(?:[01]?\d{1,2}|2[0-4]\d|25[0-5])\.){3}(?:[01]?\d{1,2}|2[0-4]\d|25[0-5])\.\.(?:(?:[01]?\d{1,2}|2[0-4]\d|25[0-5])\.){3}(?:[01]?\d{1,2}|2[0-4]\d|25[0-5])$
                       - pattern: ^((([0-9A-Fa-f]{1,4}:){7}([0-9A-Fa-f]{1,4}|:))|(([0-9A-Fa-f]{1,4}:){6}(:[0-9A-Fa-f]{1,4}|:))|(([0-9A-Fa-f]{1,4}:){5}(((:[0-9A-Fa-f]{1,4}){1,2})|:))|(([0-9A-Fa-f]{1,4}:){4}(((:[0-9A-Fa-f]{1,4}){1,3})|:))|(([0-9A-Fa-f]{1,4}:){3}(((:[0-9A-Fa-f]{1,4}){1,4})|:))|(([0-9A-Fa-f]{1,4}:){2}(((:[0-9A-Fa-f]{1,4}){1,5})|:))|(([0-9A-Fa-f]{1,4}:){1}(((:[0-9A-Fa-f]{1,4}){1,6})|:))|(:(((:[0-9A-Fa-f]{1,4}){1,7})|:)))\.\.((([0-9A-Fa-f]{1,4}:){7}([0-9A-Fa-f]{1,4}|:))|(([0-9A-Fa-f]{1,4}:){6}(:[0-9A-Fa-f]{1,4}|:))|(([0-9A-Fa-f]{1,4}:){5}(((:[0-9A-Fa-f]{1,4}){1,2})|:))|(([0-9A-Fa-f]{1,4}:){4}(((:[0-9A-Fa-f]{1,4}){1,3})|:))|(([0-9A-Fa-f]{1,4}:){3}(((:[0-9A-Fa-f]{1,4}){1,4})|:))|(([0-9A-Fa-f]{1,4}:){2}(((:[0-9A-Fa-f]{1,4}){1,5})|:))|(([0-9A-Fa-f]{1,4}:){1}(((:[0-9A-Fa-f]{1,4}){1,6})|:))|(:(((:[0-9A-Fa-f]{1,4}){1,7})|:)))$
+                podSelector:
+                  type: object
+                  properties:
+                    matchLabels:
+                      type: object
+                      additionalProperties:
+                        type: string
+                    matchExpressions:
+                      type: array
+                      items:
+                        type: object
+                        properties:
+                          key:
+                            type: string
+                          operator:
+                            type: string
+                            enum:
+                              - In
+                              - NotIn
+                              - Exists
+                              - DoesNotExist
+                          values:
+                            type: array
+                            items:
+                              type: string
+                        required:
+                          - key
+                          - operator
+                owners:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      kind:
+                        type: string
+                        enum:
+                          - Deployment
+                          - StatefulSet
+                      namespace:
+                        type: string
+                      name:
+                        type: string
+                    required:
+                      - kind
+                      - namespace
+                      - name
+                priority:
+                  type: integer
+                cidrBlocks:
+                  type: array
+                  x-kubernetes-list-type: set
+                  items:
+                    type: string
+                    format: cidr
+                excludeIPs:
+                  type: array
+                  x-kubernetes-list-type: set
+                  items:
+                    type: string
+                autoAllocate:
+                  type: object
+                  properties:
+                    ipv4PrefixLength:
+                      type: integer
+                      minimum: 0
+                      maximum: 32
+                    ipv6PrefixLength:
+                      type: integer
+                      minimum: 0
+                      maximum: 128
               required:
                 - subnet
-                - ips
             status:
               type: object
               properties:
@@ -2661,12 +2834,37 @@ spec:
                         type: string
                       remoteSecurityGroup:
                         type: string
+                      remoteAddressGroup:
+                        type: string
                       portRangeMin:
                         type: integer
                       portRangeMax:
                         type: integer
+                      portRanges:
+                        type: array
+                        items:
+                          type: object
+                          properties:
+                            min:
+                              type: integer
+                            max:
+                              type: integer
+                      icmpType:
+                        type: integer
+                      icmpCode:
+                        type: integer
                       policy:
                         type: string
+                      log:
+                        type: boolean
+                      logSeverity:
+                        type: string
+                        enum:
+                          - alert
+                          - warning
+                          - notice
+                          - info
+                          - debug
                 egressRules:
                   type: array
                   items:
@@ -2684,14 +2882,41 @@ spec:
                         type: string
                       remoteSecurityGroup:
                         type: string
+                      remoteAddressGroup:
+                        type: string
                       portRangeMin:
                         type: integer
                       portRangeMax:
                         type: integer
+                      portRanges:
+                        type: array
+                        items:
+                          type: object
+                          properties:
+                            min:
+                              type: integer
+                            max:
+                              type: integer
+                      icmpType:
+                        type: integer
+                      icmpCode:
+                        type: integer
                       policy:
                         type: string
+                      log:
+                        type: boolean
+                      logSeverity:
+                        type: string
+                        enum:
+                          - alert
+                          - warning
+                          - notice
+                          - info
+                          - debug
                 allowSameGroupTraffic:
                   type: boolean
+                stateless:
+                  type: boolean
             status:
               type: object
               properties:
@@ -2699,6 +2924,8 @@ spec:
                   type: string
                 allowSameGroupTraffic:
                   type: boolean
+                stateless:
+                  type: boolean
                 ingressMd5:
                   type: string
                 egressMd5:
@@ -2714,6 +2941,502 @@ spec:
 ---
 apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
+metadata:
+  name: address-groups.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: address-groups
+    singular: address-group
+    shortNames:
+      - ag
+    kind: AddressGroup
+    listKind: AddressGroupList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      additionalPrinterColumns:
+      - jsonPath: .status.ready
+        name: Ready
+        type: boolean
+      - jsonPath: .status.error
+        name: Error
+        type: string
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                addresses:
+                  type: array
+                  items:
+                    type: string
+            status:
+              type: object
+              properties:
+                v4AddressSet:
+                  type: string
+                v6AddressSet:
+                  type: string
+                ready:
+                  type: boolean
+                error:
+                  type: string
+      subresources:
+        status: {}
+  conversion:
+    strategy: None
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: interconnections.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: interconnections
+    singular: interconnection
+    shortNames:
+      - ic
+    kind: InterConnection
+    listKind: InterConnectionList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      additionalPrinterColumns:
+      - jsonPath: .spec.enable
+        name: Enable
+        type: boolean
+      - jsonPath: .spec.azName
+        name: AZ
+        type: string
+      - jsonPath: .spec.autoRoute
+        name: AutoRoute
+        type: boolean
+      - jsonPath: .status.conditions[?(@.type=="TransitSwitchPortsReady")].status
+        name: Ready
+        type: string
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                enable:
+                  type: boolean
+                azName:
+                  type: string
+                icDBHosts:
+                  type: array
+                  items:
+                    type: string
+                icNbPort:
+                  type: integer
+                  minimum: 1
+                  maximum: 65535
+                icSbPort:
+                  type: integer
+                  minimum: 1
+                  maximum: 65535
+                gatewayNodes:
+                  type: array
+                  items:
+                    type: string
+                autoRoute:
+                  type: boolean
+              required:
+                - enable
+            status:
+              type: object
+              properties:
+                transitSwitches:
+                  type: array
+                  items:
+                    type: string
+                conditions:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      type:
+                        type: string
+                      status:
+                        type: string
+                      reason:
+                        type: string
+                      message:
+                        type: string
+                      lastUpdateTime:
+                        type: string
+                      lastTransitionTime:
+                        type: string
+      subresources:
+        status: {}
+  conversion:
+    strategy: None
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: vpc-route-tables.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: vpc-route-tables
+    singular: vpc-route-table
+    shortNames:
+      - rtb
+    kind: VpcRouteTable
+    listKind: VpcRouteTableList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      additionalPrinterColumns:
+      - jsonPath: .spec.vpc
+        name: Vpc
+        type: string
+      - jsonPath: .status.ready
+        name: Ready
+        type: boolean
+      - jsonPath: .status.subnets
+        name: Subnets
+        type: string
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                vpc:
+                  type: string
+                routes:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      policy:
+                        type: string
+                        enum:
+                          - policySrc
+                          - policyDst
+                      cidr:
+                        type: string
+                      nextHopIPs:
+                        type: array
+                        items:
+                          type: string
+                      enableBfd:
+                        type: boolean
+                    required:
+                      - cidr
+                      - nextHopIPs
+              required:
+                - vpc
+            status:
+              type: object
+              properties:
+                ready:
+                  type: boolean
+                error:
+                  type: string
+                subnets:
+                  type: array
+                  items:
+                    type: string
+                routes:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      policy:
+                        type: string
+                      cidr:
+                        type: string
+                      nextHopIP:
+                        type: string
+                      bfdId:
+                        type: string
+      subresources:
+        status: {}
+  conversion:
+    strategy: None
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: dhcp-options.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: dhcp-options
+    singular: dhcp-options
+    shortNames:
+      - dhcpo
+    kind: DHCPOptions
+    listKind: DHCPOptionsList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      additionalPrinterColumns:
+      - jsonPath: .spec.leaseTime
+        name: LeaseTime
+        type: integer
+      - jsonPath: .spec.mtu
+        name: MTU
+        type: integer
+      - jsonPath: .spec.bootfile
+        name: Bootfile
+        type: string
+      - jsonPath: .metadata.creationTimestamp
+        name: Age
+        type: date
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                routers:
+                  type: array
+                  items:
+                    type: string
+                dnsServers:
+                  type: array
+                  items:
+                    type: string
+                domainSearch:
+                  type: array
+                  items:
+                    type: string
+                ntpServers:
+                  type: array
+                  items:
+                    type: string
+                mtu:
+                  type: integer
+                  format: int32
+                  minimum: 68
+                  maximum: 65535
+                leaseTime:
+                  type: integer
+                  format: int32
+                  minimum: 0
+                classlessStaticRoutes:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      destination:
+                        type: string
+                      nextHop:
+                        type: string
+                    required:
+                      - destination
+                      - nextHop
+                bootfile:
+                  type: string
+                ipxeBootfile:
+                  type: string
+                nextServer:
+                  type: string
+            status:
+              type: object
+              properties:
+                subnets:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      name:
+                        type: string
+                      dhcpV4OptionsUUID:
+                        type: string
+                      dhcpV6OptionsUUID:
+                        type: string
+      subresources:
+        status: {}
+  conversion:
+    strategy: None
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: boot-servers.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: boot-servers
+    singular: boot-server
+    shortNames:
+      - bootsvr
+    kind: BootServer
+    listKind: BootServerList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      additionalPrinterColumns:
+      - jsonPath: .spec.subnet
+        name: Subnet
+        type: string
+      - jsonPath: .spec.ip
+        name: IP
+        type: string
+      - jsonPath: .status.active
+        name: Active
+        type: boolean
+      - jsonPath: .metadata.creationTimestamp
+        name: Age
+        type: date
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                subnet:
+                  type: string
+                ip:
+                  type: string
+                image:
+                  type: string
+                configMap:
+                  type: string
+                persistentVolumeClaim:
+                  type: string
+                httpPort:
+                  type: integer
+                  format: int32
+                  minimum: 1
+                  maximum: 65535
+              required:
+                - subnet
+                - ip
+            status:
+              type: object
+              properties:
+                active:
+                  type: boolean
+                message:
+                  type: string
+      subresources:
+        status: {}
+  conversion:
+    strategy: None
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: ip-handoffs.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: ip-handoffs
+    singular: ip-handoff
+    shortNames:
+      - iho
+    kind: IPHandoff
+    listKind: IPHandoffList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      additionalPrinterColumns:
+      - jsonPath: .status.phase
+        name: Phase
+        type: string
+      - jsonPath: .spec.sourcePod
+        name: Source
+        type: string
+      - jsonPath: .spec.targetPod
+        name: Target
+        type: string
+      - jsonPath: .status.ipAddress
+        name: IP
+        type: string
+      - jsonPath: .metadata.creationTimestamp
+        name: Age
+        type: date
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                namespace:
+                  type: string
+                sourcePod:
+                  type: string
+                targetPod:
+                  type: string
+                timeoutSeconds:
+                  type: integer
+                  format: int32
+                  minimum: 0
+              required:
+                - namespace
+                - sourcePod
+                - targetPod
+              x-kubernetes-validations:
+                - rule: "self.sourcePod != self.targetPod"
+                  message: "sourcePod and targetPod must be different."
+            status:
+              type: object
+              properties:
+                phase:
+                  type: string
+                ipAddress:
+                  type: string
+                macAddress:
+                  type: string
+                sourceNode:
+                  type: string
+                targetNode:
+                  type: string
+                startTime:
+                  type: string
+                  format: date-time
+                conditions:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      type:
+                        type: string
+                      status:
+                        type: string
+                      reason:
+                        type: string
+                      message:
+                        type: string
+                      lastUpdateTime:
+                        type: string
+                      lastTransitionTime:
+                        type: string
+      subresources:
+        status: {}
+  conversion:
+    strategy: None
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
 metadata:
   name: qos-policies.kubeovn.io
 spec:
@@ -2909,6 +3632,18 @@ rules:
       - provider-networks/status
       - security-groups
       - security-groups/status
+      - address-groups
+      - address-groups/status
+      - interconnections
+      - interconnections/status
+      - vpc-route-tables
+      - vpc-route-tables/status
+      - dhcp-options
+      - dhcp-options/status
+      - boot-servers
+      - boot-servers/status
+      - ip-handoffs
+      - ip-handoffs/status
       - iptables-eips
       - iptables-fip-rules
       - iptables-dnat-rules
@@ -2975,6 +3710,35 @@ rules:
       - get
       - list
       - watch
+  - apiGroups:
+      - policy.networking.k8s.io
+    resources:
+      - adminnetworkpolicies
+      - baselineadminnetworkpolicies
+    verbs:
+      - get
+      - list
+      - watch
+  - apiGroups:
+      - policy.networking.k8s.io
+    resources:
+      - adminnetworkpolicies/status
+      - baselineadminnetworkpolicies/status
+    verbs:
+      - patch
+  - apiGroups:
+      - networking.k8s.io
+    resources:
+      - networkpolicies
+    verbs:
+      - patch
+  - apiGroups:
+      - ""
+    resources:
+      - configmaps
+    verbs:
+      - create
+      - update
   - apiGroups:
       - apps
     resources:
@@ -3013,6 +3777,7 @@ rules:
     verbs:
       - get
       - list
+      - watch
       - create
       - delete
       - update
@@ -3104,6 +3869,12 @@ rules:
       - get
       - list
       - watch
+  - apiGroups:
+      - "kubeovn.io"
+    resources:
+      - provider-networks/status
+    verbs:
+      - update
   - apiGroups:
       - ""
       - "kubeovn.io"
@@ -3125,7 +3896,9 @@ rules:
       - ips
     verbs:
       - get
+      - list
       - update
+      - watch
   - apiGroups:
       - ""
     resources:
@@ -4030,6 +4803,8 @@ metadata:
   namespace: kube-system
 data:
   enable-vpc-nat-gw: "$ENABLE_NAT_GW"
+  nat-gw-backend: "$NAT_GW_BACKEND"
+  enable-nat-gw-metrics: "$ENABLE_NAT_GW_METRICS"
 ---
 kind: Deployment
 apiVersion: apps/v1
@@ -4103,6 +4878,8 @@ spec:
           - --pod-nic-type=$POD_NIC_TYPE
           - --enable-lb=$ENABLE_LB
           - --enable-np=$ENABLE_NP
+          - --enable-anp=$ENABLE_ANP
+          - --enable-np-acl-stats=$ENABLE_NP_ACL_STATS
           - --enable-eip-snat=$ENABLE_EIP_SNAT
           - --enable-external-vpc=$ENABLE_EXTERNAL_VPC
           - --logtostderr=false
@@ -4274,6 +5051,9 @@ spec:
           - --kubelet-dir=$KUBELET_DIR
           - --enable-tproxy=$ENABLE_TPROXY
           - --ovs-vsctl-concurrency=$OVS_VSCTL_CONCURRENCY
+          - --enable-np-acl-stats=$ENABLE_NP_ACL_STATS
+          - --enable-acl-log-shipper=$ENABLE_ACL_LOG_SHIPPER
+          - --acl-log-output=$ACL_LOG_OUTPUT
           - --secure-serving=${SECURE_SERVING}
         securityContext:
           runAsUser: 0
@@ -26,6 +26,15 @@ function check_inited() {
     fi
 }
 
+function init_nat_acct() {
+    # every forwarded packet of a nat rule is counted by the accounting rule of the nat rule,
+    # the chain is created on demand, so that nat gw pods initialized by older versions get it too
+    $iptables_cmd -t mangle -N NAT_ACCT 2>/dev/null || return 0
+    # traffic between internal subnets is not translated
+    $iptables_cmd -t mangle -A NAT_ACCT -i eth0 -o eth0 -j RETURN
+    $iptables_cmd -t mangle -A FORWARD -j NAT_ACCT
+}
+
 function init() {
     # run once is enough
     $iptables_save_cmd | grep DNAT_FILTER && exit 0
@@ -34,6 +43,8 @@ function init() {
     $iptables_cmd -t nat -N DNAT_FILTER
     ip link set net1 up
     ip link set dev net1 arp off
+    # count bytes and packets of each connection for the nat metrics
+    sysctl -w net.netfilter.nf_conntrack_acct=1
 
     # add static chain
     $iptables_cmd -t nat -N SNAT_FILTER
@@ -50,6 +61,8 @@ function init() {
     $iptables_cmd -t nat -A SNAT_FILTER -j EXCLUSIVE_SNAT
     $iptables_cmd -t nat -A SNAT_FILTER -j SHARED_SNAT
 
+    init_nat_acct
+
     for rule in $@
     do
         arr=(${rule//,/ })
@@ -123,10 +136,41 @@ function bms_subnet_route_del() {
     done
 }
 
+function nft_apply() {
+    # the whole ruleset is read from stdin and applied in a single transaction
+    nft -f -
+    ret=$?
+    if [ $ret -ne 0 ]; then
+        >&2 echo "failed to apply nftables ruleset"
+        exit $ret
+    fi
+}
+
 function get_iptables_version() {
   exec_cmd "$iptables_cmd --version"
 }
 
+function get_nat_stats() {
+    backend=$1
+    # sections are separated by "#### <name>" lines, which never appear in the output of the commands
+    echo "#### conntrack"
+    conntrack -L 2>/dev/null
+    echo "#### conntrack-stats"
+    conntrack -S 2>/dev/null
+    if [ "$backend" == "nftables" ]; then
+        echo "#### nftables"
+        nft list table ip kube_ovn_nat 2>/dev/null
+    else
+        echo "#### iptables"
+        $iptables_save_cmd -c -t nat
+        $iptables_save_cmd -c -t mangle
+    fi
+    # the packets dropped by the eip qos
+    echo "#### tc"
+    tc -p -s filter show dev net1 ingress 2>/dev/null
+    tc -p -s filter show dev net1 parent 1:0 2>/dev/null
+}
+
 function add_vpc_internal_route() {
     # make sure inited
     check_inited
@@ -236,6 +280,9 @@ function add_floating_ip() {
         $iptables_save_cmd | grep EXCLUSIVE_DNAT | grep -w "\-d $eip/32" | grep destination && exit 0
         exec_cmd "$iptables_cmd -t nat -A EXCLUSIVE_DNAT -d $eip -j DNAT --to-destination $internalIp"
         exec_cmd "$iptables_cmd -t nat -A EXCLUSIVE_SNAT -s $internalIp -j SNAT --to-source $eip"
+        init_nat_acct
+        exec_cmd "$iptables_cmd -t mangle -A NAT_ACCT -m conntrack --ctorigdst $eip -j RETURN"
+        exec_cmd "$iptables_cmd -t mangle -A NAT_ACCT -m conntrack --ctorigsrc $internalIp -j RETURN"
     done
 }
 
@@ -252,6 +299,8 @@ function del_floating_ip() {
         if [ "$?" -eq 0 ];then
             exec_cmd "$iptables_cmd -t nat -D EXCLUSIVE_DNAT -d $eip -j DNAT --to-destination $internalIp"
             exec_cmd "$iptables_cmd -t nat -D EXCLUSIVE_SNAT -s $internalIp -j SNAT --to-source $eip"
+            $iptables_cmd -t mangle -D NAT_ACCT -m conntrack --ctorigdst $eip -j RETURN 2>/dev/null || true
+            $iptables_cmd -t mangle -D NAT_ACCT -m conntrack --ctorigsrc $internalIp -j RETURN 2>/dev/null || true
             conntrack -D -d $eip 2>/dev/nul || true
         fi
     done
@@ -270,6 +319,8 @@ function add_snat() {
         # check if already exist
         $iptables_save_cmd | grep SHARED_SNAT | grep "\-s $internalCIDR" | grep "source $eip" && exit 0
         exec_cmd "$iptables_cmd -t nat -A SHARED_SNAT -o net1 -s $internalCIDR -j SNAT --to-source $eip $randomFullyOption"
+        init_nat_acct
+        exec_cmd "$iptables_cmd -t mangle -A NAT_ACCT -m conntrack --ctorigsrc $internalCIDR -j RETURN"
     done
 }
 function del_snat() {
@@ -286,6 +337,7 @@ function del_snat() {
         if [ "$?" -eq 0 ];then
           ruleMatch=$(echo $ruleMatch | sed 's/-A //')
           exec_cmd "$iptables_cmd -t nat -D $ruleMatch"
+          $iptables_cmd -t mangle -D NAT_ACCT -m conntrack --ctorigsrc $internalCIDR -j RETURN 2>/dev/null || true
         fi
     done
 }
@@ -302,9 +354,16 @@ function add_dnat() {
         protocol=${arr[2]}
         internalIp=${arr[3]}
         internalPort=${arr[4]}
+        # an empty internal port keeps the destination port of a port range
+        destination=$internalIp
+        if [ -n "$internalPort" ]; then
+            destination=$internalIp:$internalPort
+        fi
         # check if already exist
-        $iptables_save_cmd | grep SHARED_DNAT | grep -w "\-d $eip/32" | grep "p $protocol" | grep -w "dport $dport"| grep -w "destination $internalIp:$internalPort" && exit 0
-        exec_cmd "$iptables_cmd -t nat -A SHARED_DNAT -p $protocol -d $eip --dport $dport -j DNAT --to-destination $internalIp:$internalPort"
+        $iptables_save_cmd | grep SHARED_DNAT | grep -w "\-d $eip/32" | grep "p $protocol" | grep -w "dport $dport"| grep -E "destination $destination( |$)" && continue
+        exec_cmd "$iptables_cmd -t nat -A SHARED_DNAT -p $protocol -d $eip --dport $dport -j DNAT --to-destination $destination"
+        init_nat_acct
+        exec_cmd "$iptables_cmd -t mangle -A NAT_ACCT -p $protocol -m conntrack --ctorigdst $eip --ctorigdstport $dport -j RETURN"
     done
 }
 
@@ -320,10 +379,15 @@ function del_dnat() {
         protocol=${arr[2]}
         internalIp=${arr[3]}
         internalPort=${arr[4]}
+        destination=$internalIp
+        if [ -n "$internalPort" ]; then
+            destination=$internalIp:$internalPort
+        fi
         # check if already exist
-        $iptables_save_cmd | grep SHARED_DNAT | grep -w "\-d $eip/32" | grep "p $protocol" | grep -w "dport $dport"| grep -w "destination $internalIp:$internalPort"
+        $iptables_save_cmd | grep SHARED_DNAT | grep -w "\-d $eip/32" | grep "p $protocol" | grep -w "dport $dport"| grep -E "destination $destination( |$)"
         if [ "$?" -eq 0 ];then
-          exec_cmd "$iptables_cmd -t nat -D SHARED_DNAT -p $protocol -d $eip --dport $dport -j DNAT --to-destination $internalIp:$internalPort"
+          exec_cmd "$iptables_cmd -t nat -D SHARED_DNAT -p $protocol -d $eip --dport $dport -j DNAT --to-destination $destination"
+          $iptables_cmd -t mangle -D NAT_ACCT -p $protocol -m conntrack --ctorigdst $eip --ctorigdstport $dport -j RETURN 2>/dev/null || true
         fi
     done
 }
@@ -567,6 +631,13 @@ case $opt in
         echo "floating-ip-del $rules"
         del_floating_ip $rules
         ;;
+ nft-apply)
+        echo "nft-apply"
+        nft_apply
+        ;;
+ get-nat-stats)
+        get_nat_stats $rules
+        ;;
  get-iptables-version)
         echo "get-iptables-version $rules"
         get_iptables_version $rules
@@ -612,7 +683,7 @@ case $opt in
         bms_subnet_route_del $rules
         ;;
  *)
-        echo "Usage: $0 [init|subnet-route-add|subnet-route-del|eip-add|eip-del|floating-ip-add|floating-ip-del|dnat-add|dnat-del|snat-add|snat-del] ..."
+        echo "Usage: $0 [init|subnet-route-add|subnet-route-del|eip-add|eip-del|floating-ip-add|floating-ip-del|dnat-add|dnat-del|snat-add|snat-del|nft-apply|get-nat-stats] ..."
         exit 1
         ;;
 esac
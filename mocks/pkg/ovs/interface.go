@@ -20,6 +20,7 @@ import (
 	ovsdb "github.com/ovn-org/libovsdb/ovsdb"
 	gomock "go.uber.org/mock/gomock"
 	v10 "k8s.io/api/networking/v1"
+	v1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 )
 
 // MockNBGlobal is a mock of NBGlobal interface.
@@ -516,6 +517,21 @@ func (mr *MockBFDMockRecorder) DeleteBFD(lrpName, dstIP any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBFD", reflect.TypeOf((*MockBFD)(nil).DeleteBFD), lrpName, dstIP)
 }
 
+// ListBFD mocks base method.
+func (m *MockBFD) ListBFD(lrpName, dstIP string) ([]ovnnb.BFD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBFD", lrpName, dstIP)
+	ret0, _ := ret[0].([]ovnnb.BFD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBFD indicates an expected call of ListBFD.
+func (mr *MockBFDMockRecorder) ListBFD(lrpName, dstIP any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBFD", reflect.TypeOf((*MockBFD)(nil).ListBFD), lrpName, dstIP)
+}
+
 // MockLogicalSwitch is a mock of LogicalSwitch interface.
 type MockLogicalSwitch struct {
 	ctrl     *gomock.Controller
@@ -1584,6 +1600,35 @@ func (mr *MockACLMockRecorder) DeleteAclsOps(parentName, parentType, direction,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAclsOps", reflect.TypeOf((*MockACL)(nil).DeleteAclsOps), parentName, parentType, direction, externalIDs)
 }
 
+// ListAcls mocks base method.
+func (m *MockACL) ListAcls(direction string, externalIDs map[string]string) ([]ovnnb.ACL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAcls", direction, externalIDs)
+	ret0, _ := ret[0].([]ovnnb.ACL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAcls indicates an expected call of ListAcls.
+func (mr *MockACLMockRecorder) ListAcls(direction, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAcls", reflect.TypeOf((*MockACL)(nil).ListAcls), direction, externalIDs)
+}
+
+// MigrateACLTier mocks base method.
+func (m *MockACL) MigrateACLTier() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateACLTier")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MigrateACLTier indicates an expected call of MigrateACLTier.
+func (mr *MockACLMockRecorder) MigrateACLTier() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateACLTier", reflect.TypeOf((*MockACL)(nil).MigrateACLTier))
+}
+
 // SGLostACL mocks base method.
 func (m *MockACL) SGLostACL(sg *v1.SecurityGroup) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1627,6 +1672,21 @@ func (mr *MockACLMockRecorder) SetLogicalSwitchPrivate(lsName, cidrBlock, nodeSw
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogicalSwitchPrivate", reflect.TypeOf((*MockACL)(nil).SetLogicalSwitchPrivate), lsName, cidrBlock, nodeSwitchCIDR, allowSubnets)
 }
 
+// UpdateAnpRuleACLOps mocks base method.
+func (m *MockACL) UpdateAnpRuleACLOps(pgName, asName, protocol, aclName string, priority, tier int, aclAction ovnnb.ACLAction, rulePorts []v1alpha1.AdminNetworkPolicyPort, isIngress, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnpRuleACLOps", pgName, asName, protocol, aclName, priority, tier, aclAction, rulePorts, isIngress, logEnable, namedPortMap)
+	ret0, _ := ret[0].([]ovsdb.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAnpRuleACLOps indicates an expected call of UpdateAnpRuleACLOps.
+func (mr *MockACLMockRecorder) UpdateAnpRuleACLOps(pgName, asName, protocol, aclName, priority, tier, aclAction, rulePorts, isIngress, logEnable, namedPortMap any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnpRuleACLOps", reflect.TypeOf((*MockACL)(nil).UpdateAnpRuleACLOps), pgName, asName, protocol, aclName, priority, tier, aclAction, rulePorts, isIngress, logEnable, namedPortMap)
+}
+
 // UpdateEgressACLOps mocks base method.
 func (m *MockACL) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, aclName string, npp []v10.NetworkPolicyPort, logEnable bool, logACLActions []ovnnb.ACLAction, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
 	m.ctrl.T.Helper()
@@ -1826,6 +1886,20 @@ func (mr *MockLogicalRouterStaticRouteMockRecorder) AddLogicalRouterStaticRoute(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterStaticRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).AddLogicalRouterStaticRoute), varargs...)
 }
 
+// AddLogicalRouterStaticRouteWithExternalIDs mocks base method.
+func (m *MockLogicalRouterStaticRoute) AddLogicalRouterStaticRouteWithExternalIDs(lrName, routeTable, policy, ipPrefix, nexthop string, externalIDs map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLogicalRouterStaticRouteWithExternalIDs", lrName, routeTable, policy, ipPrefix, nexthop, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLogicalRouterStaticRouteWithExternalIDs indicates an expected call of AddLogicalRouterStaticRouteWithExternalIDs.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) AddLogicalRouterStaticRouteWithExternalIDs(lrName, routeTable, policy, ipPrefix, nexthop, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterStaticRouteWithExternalIDs", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).AddLogicalRouterStaticRouteWithExternalIDs), lrName, routeTable, policy, ipPrefix, nexthop, externalIDs)
+}
+
 // ClearLogicalRouterStaticRoute mocks base method.
 func (m *MockLogicalRouterStaticRoute) ClearLogicalRouterStaticRoute(lrName string) error {
 	m.ctrl.T.Helper()
@@ -2162,31 +2236,31 @@ func (mr *MockNATMockRecorder) NatExists(lrName, natType, externalIP, logicalIP
 }
 
 // UpdateDnatAndSnat mocks base method.
-func (m *MockNAT) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType string) error {
+func (m *MockNAT) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType string, stateless bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDnatAndSnat", lrName, externalIP, logicalIP, lspName, externalMac, gatewayType)
+	ret := m.ctrl.Call(m, "UpdateDnatAndSnat", lrName, externalIP, logicalIP, lspName, externalMac, gatewayType, stateless)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateDnatAndSnat indicates an expected call of UpdateDnatAndSnat.
-func (mr *MockNATMockRecorder) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType any) *gomock.Call {
+func (mr *MockNATMockRecorder) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType, stateless any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDnatAndSnat", reflect.TypeOf((*MockNAT)(nil).UpdateDnatAndSnat), lrName, externalIP, logicalIP, lspName, externalMac, gatewayType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDnatAndSnat", reflect.TypeOf((*MockNAT)(nil).UpdateDnatAndSnat), lrName, externalIP, logicalIP, lspName, externalMac, gatewayType, stateless)
 }
 
 // UpdateSnat mocks base method.
-func (m *MockNAT) UpdateSnat(lrName, externalIP, logicalIP string) error {
+func (m *MockNAT) UpdateSnat(lrName, externalIP, logicalIP, externalPortRange string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSnat", lrName, externalIP, logicalIP)
+	ret := m.ctrl.Call(m, "UpdateSnat", lrName, externalIP, logicalIP, externalPortRange)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateSnat indicates an expected call of UpdateSnat.
-func (mr *MockNATMockRecorder) UpdateSnat(lrName, externalIP, logicalIP any) *gomock.Call {
+func (mr *MockNATMockRecorder) UpdateSnat(lrName, externalIP, logicalIP, externalPortRange any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSnat", reflect.TypeOf((*MockNAT)(nil).UpdateSnat), lrName, externalIP, logicalIP)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSnat", reflect.TypeOf((*MockNAT)(nil).UpdateSnat), lrName, externalIP, logicalIP, externalPortRange)
 }
 
 // MockDHCPOptions is a mock of DHCPOptions interface.
@@ -2244,6 +2318,20 @@ func (mr *MockDHCPOptionsMockRecorder) DeleteDHCPOptionsByUUIDs(uuidList ...any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDHCPOptionsByUUIDs", reflect.TypeOf((*MockDHCPOptions)(nil).DeleteDHCPOptionsByUUIDs), uuidList...)
 }
 
+// DeleteLogicalSwitchPortDHCPOptions mocks base method.
+func (m *MockDHCPOptions) DeleteLogicalSwitchPortDHCPOptions(lspName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLogicalSwitchPortDHCPOptions", lspName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLogicalSwitchPortDHCPOptions indicates an expected call of DeleteLogicalSwitchPortDHCPOptions.
+func (mr *MockDHCPOptionsMockRecorder) DeleteLogicalSwitchPortDHCPOptions(lspName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogicalSwitchPortDHCPOptions", reflect.TypeOf((*MockDHCPOptions)(nil).DeleteLogicalSwitchPortDHCPOptions), lspName)
+}
+
 // ListDHCPOptions mocks base method.
 func (m *MockDHCPOptions) ListDHCPOptions(needVendorFilter bool, externalIDs map[string]string) ([]ovnnb.DHCPOptions, error) {
 	m.ctrl.T.Helper()
@@ -2260,18 +2348,33 @@ func (mr *MockDHCPOptionsMockRecorder) ListDHCPOptions(needVendorFilter, externa
 }
 
 // UpdateDHCPOptions mocks base method.
-func (m *MockDHCPOptions) UpdateDHCPOptions(subnet *v1.Subnet, mtu int) (*ovs.DHCPOptionsUUIDs, error) {
+func (m *MockDHCPOptions) UpdateDHCPOptions(subnet *v1.Subnet, mtu int, spec *v1.DHCPOptionsSpec) (*ovs.DHCPOptionsUUIDs, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDHCPOptions", subnet, mtu)
+	ret := m.ctrl.Call(m, "UpdateDHCPOptions", subnet, mtu, spec)
 	ret0, _ := ret[0].(*ovs.DHCPOptionsUUIDs)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateDHCPOptions indicates an expected call of UpdateDHCPOptions.
-func (mr *MockDHCPOptionsMockRecorder) UpdateDHCPOptions(subnet, mtu any) *gomock.Call {
+func (mr *MockDHCPOptionsMockRecorder) UpdateDHCPOptions(subnet, mtu, spec any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDHCPOptions", reflect.TypeOf((*MockDHCPOptions)(nil).UpdateDHCPOptions), subnet, mtu)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDHCPOptions", reflect.TypeOf((*MockDHCPOptions)(nil).UpdateDHCPOptions), subnet, mtu, spec)
+}
+
+// UpdateLogicalSwitchPortDHCPv4Options mocks base method.
+func (m *MockDHCPOptions) UpdateLogicalSwitchPortDHCPv4Options(lsName, lspName string, options map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLogicalSwitchPortDHCPv4Options", lsName, lspName, options)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLogicalSwitchPortDHCPv4Options indicates an expected call of UpdateLogicalSwitchPortDHCPv4Options.
+func (mr *MockDHCPOptionsMockRecorder) UpdateLogicalSwitchPortDHCPv4Options(lsName, lspName, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogicalSwitchPortDHCPv4Options", reflect.TypeOf((*MockDHCPOptions)(nil).UpdateLogicalSwitchPortDHCPv4Options), lsName, lspName, options)
 }
 
 // MockNbClient is a mock of NbClient interface.
@@ -2344,6 +2447,20 @@ func (mr *MockNbClientMockRecorder) AddLogicalRouterStaticRoute(lrName, routeTab
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterStaticRoute", reflect.TypeOf((*MockNbClient)(nil).AddLogicalRouterStaticRoute), varargs...)
 }
 
+// AddLogicalRouterStaticRouteWithExternalIDs mocks base method.
+func (m *MockNbClient) AddLogicalRouterStaticRouteWithExternalIDs(lrName, routeTable, policy, ipPrefix, nexthop string, externalIDs map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLogicalRouterStaticRouteWithExternalIDs", lrName, routeTable, policy, ipPrefix, nexthop, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLogicalRouterStaticRouteWithExternalIDs indicates an expected call of AddLogicalRouterStaticRouteWithExternalIDs.
+func (mr *MockNbClientMockRecorder) AddLogicalRouterStaticRouteWithExternalIDs(lrName, routeTable, policy, ipPrefix, nexthop, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterStaticRouteWithExternalIDs", reflect.TypeOf((*MockNbClient)(nil).AddLogicalRouterStaticRouteWithExternalIDs), lrName, routeTable, policy, ipPrefix, nexthop, externalIDs)
+}
+
 // AddNat mocks base method.
 func (m *MockNbClient) AddNat(lrName, natType, externalIP, logicalIP, logicalMac, port string, options map[string]string) error {
 	m.ctrl.T.Helper()
@@ -3047,6 +3164,20 @@ func (mr *MockNbClientMockRecorder) DeleteLogicalSwitchPort(lspName any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogicalSwitchPort", reflect.TypeOf((*MockNbClient)(nil).DeleteLogicalSwitchPort), lspName)
 }
 
+// DeleteLogicalSwitchPortDHCPOptions mocks base method.
+func (m *MockNbClient) DeleteLogicalSwitchPortDHCPOptions(lspName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLogicalSwitchPortDHCPOptions", lspName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLogicalSwitchPortDHCPOptions indicates an expected call of DeleteLogicalSwitchPortDHCPOptions.
+func (mr *MockNbClientMockRecorder) DeleteLogicalSwitchPortDHCPOptions(lspName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogicalSwitchPortDHCPOptions", reflect.TypeOf((*MockNbClient)(nil).DeleteLogicalSwitchPortDHCPOptions), lspName)
+}
+
 // DeleteLogicalSwitchPorts mocks base method.
 func (m *MockNbClient) DeleteLogicalSwitchPorts(externalIDs map[string]string, filter func(*ovnnb.LogicalSwitchPort) bool) error {
 	m.ctrl.T.Helper()
@@ -3311,6 +3442,35 @@ func (mr *MockNbClientMockRecorder) GetPortGroup(pgName, ignoreNotFound any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortGroup", reflect.TypeOf((*MockNbClient)(nil).GetPortGroup), pgName, ignoreNotFound)
 }
 
+// ListAcls mocks base method.
+func (m *MockNbClient) ListAcls(direction string, externalIDs map[string]string) ([]ovnnb.ACL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAcls", direction, externalIDs)
+	ret0, _ := ret[0].([]ovnnb.ACL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAcls indicates an expected call of ListAcls.
+func (mr *MockNbClientMockRecorder) ListAcls(direction, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAcls", reflect.TypeOf((*MockNbClient)(nil).ListAcls), direction, externalIDs)
+}
+
+// MigrateACLTier mocks base method.
+func (m *MockNbClient) MigrateACLTier() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateACLTier")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MigrateACLTier indicates an expected call of MigrateACLTier.
+func (mr *MockNbClientMockRecorder) MigrateACLTier() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateACLTier", reflect.TypeOf((*MockNbClient)(nil).MigrateACLTier))
+}
+
 // ListAddressSets mocks base method.
 func (m *MockNbClient) ListAddressSets(externalIDs map[string]string) ([]ovnnb.AddressSet, error) {
 	m.ctrl.T.Helper()
@@ -3326,6 +3486,21 @@ func (mr *MockNbClientMockRecorder) ListAddressSets(externalIDs any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAddressSets", reflect.TypeOf((*MockNbClient)(nil).ListAddressSets), externalIDs)
 }
 
+// ListBFD mocks base method.
+func (m *MockNbClient) ListBFD(lrpName, dstIP string) ([]ovnnb.BFD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBFD", lrpName, dstIP)
+	ret0, _ := ret[0].([]ovnnb.BFD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBFD indicates an expected call of ListBFD.
+func (mr *MockNbClientMockRecorder) ListBFD(lrpName, dstIP any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBFD", reflect.TypeOf((*MockNbClient)(nil).ListBFD), lrpName, dstIP)
+}
+
 // ListDHCPOptions mocks base method.
 func (m *MockNbClient) ListDHCPOptions(needVendorFilter bool, externalIDs map[string]string) ([]ovnnb.DHCPOptions, error) {
 	m.ctrl.T.Helper()
@@ -4164,33 +4339,48 @@ func (mr *MockNbClientMockRecorder) Transact(method, operations any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transact", reflect.TypeOf((*MockNbClient)(nil).Transact), method, operations)
 }
 
+// UpdateAnpRuleACLOps mocks base method.
+func (m *MockNbClient) UpdateAnpRuleACLOps(pgName, asName, protocol, aclName string, priority, tier int, aclAction ovnnb.ACLAction, rulePorts []v1alpha1.AdminNetworkPolicyPort, isIngress, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnpRuleACLOps", pgName, asName, protocol, aclName, priority, tier, aclAction, rulePorts, isIngress, logEnable, namedPortMap)
+	ret0, _ := ret[0].([]ovsdb.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAnpRuleACLOps indicates an expected call of UpdateAnpRuleACLOps.
+func (mr *MockNbClientMockRecorder) UpdateAnpRuleACLOps(pgName, asName, protocol, aclName, priority, tier, aclAction, rulePorts, isIngress, logEnable, namedPortMap any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnpRuleACLOps", reflect.TypeOf((*MockNbClient)(nil).UpdateAnpRuleACLOps), pgName, asName, protocol, aclName, priority, tier, aclAction, rulePorts, isIngress, logEnable, namedPortMap)
+}
+
 // UpdateDHCPOptions mocks base method.
-func (m *MockNbClient) UpdateDHCPOptions(subnet *v1.Subnet, mtu int) (*ovs.DHCPOptionsUUIDs, error) {
+func (m *MockNbClient) UpdateDHCPOptions(subnet *v1.Subnet, mtu int, spec *v1.DHCPOptionsSpec) (*ovs.DHCPOptionsUUIDs, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDHCPOptions", subnet, mtu)
+	ret := m.ctrl.Call(m, "UpdateDHCPOptions", subnet, mtu, spec)
 	ret0, _ := ret[0].(*ovs.DHCPOptionsUUIDs)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateDHCPOptions indicates an expected call of UpdateDHCPOptions.
-func (mr *MockNbClientMockRecorder) UpdateDHCPOptions(subnet, mtu any) *gomock.Call {
+func (mr *MockNbClientMockRecorder) UpdateDHCPOptions(subnet, mtu, spec any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDHCPOptions", reflect.TypeOf((*MockNbClient)(nil).UpdateDHCPOptions), subnet, mtu)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDHCPOptions", reflect.TypeOf((*MockNbClient)(nil).UpdateDHCPOptions), subnet, mtu, spec)
 }
 
 // UpdateDnatAndSnat mocks base method.
-func (m *MockNbClient) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType string) error {
+func (m *MockNbClient) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType string, stateless bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDnatAndSnat", lrName, externalIP, logicalIP, lspName, externalMac, gatewayType)
+	ret := m.ctrl.Call(m, "UpdateDnatAndSnat", lrName, externalIP, logicalIP, lspName, externalMac, gatewayType, stateless)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateDnatAndSnat indicates an expected call of UpdateDnatAndSnat.
-func (mr *MockNbClientMockRecorder) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType any) *gomock.Call {
+func (mr *MockNbClientMockRecorder) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType, stateless any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDnatAndSnat", reflect.TypeOf((*MockNbClient)(nil).UpdateDnatAndSnat), lrName, externalIP, logicalIP, lspName, externalMac, gatewayType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDnatAndSnat", reflect.TypeOf((*MockNbClient)(nil).UpdateDnatAndSnat), lrName, externalIP, logicalIP, lspName, externalMac, gatewayType, stateless)
 }
 
 // UpdateEgressACLOps mocks base method.
@@ -4265,6 +4455,21 @@ func (mr *MockNbClientMockRecorder) UpdateLogicalSwitchACL(lsName, subnetAcls an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogicalSwitchACL", reflect.TypeOf((*MockNbClient)(nil).UpdateLogicalSwitchACL), lsName, subnetAcls)
 }
 
+// UpdateLogicalSwitchPortDHCPv4Options mocks base method.
+func (m *MockNbClient) UpdateLogicalSwitchPortDHCPv4Options(lsName, lspName string, options map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLogicalSwitchPortDHCPv4Options", lsName, lspName, options)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLogicalSwitchPortDHCPv4Options indicates an expected call of UpdateLogicalSwitchPortDHCPv4Options.
+func (mr *MockNbClientMockRecorder) UpdateLogicalSwitchPortDHCPv4Options(lsName, lspName, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogicalSwitchPortDHCPv4Options", reflect.TypeOf((*MockNbClient)(nil).UpdateLogicalSwitchPortDHCPv4Options), lsName, lspName, options)
+}
+
 // UpdateNbGlobal mocks base method.
 func (m *MockNbClient) UpdateNbGlobal(nbGlobal *ovnnb.NBGlobal, fields ...any) error {
 	m.ctrl.T.Helper()
@@ -4299,17 +4504,17 @@ func (mr *MockNbClientMockRecorder) UpdateSgACL(sg, direction any) *gomock.Call
 }
 
 // UpdateSnat mocks base method.
-func (m *MockNbClient) UpdateSnat(lrName, externalIP, logicalIP string) error {
+func (m *MockNbClient) UpdateSnat(lrName, externalIP, logicalIP, externalPortRange string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSnat", lrName, externalIP, logicalIP)
+	ret := m.ctrl.Call(m, "UpdateSnat", lrName, externalIP, logicalIP, externalPortRange)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateSnat indicates an expected call of UpdateSnat.
-func (mr *MockNbClientMockRecorder) UpdateSnat(lrName, externalIP, logicalIP any) *gomock.Call {
+func (mr *MockNbClientMockRecorder) UpdateSnat(lrName, externalIP, logicalIP, externalPortRange any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSnat", reflect.TypeOf((*MockNbClient)(nil).UpdateSnat), lrName, externalIP, logicalIP)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSnat", reflect.TypeOf((*MockNbClient)(nil).UpdateSnat), lrName, externalIP, logicalIP, externalPortRange)
 }
 
 // MockSbClient is a mock of SbClient interface.
@@ -4437,6 +4642,21 @@ func (mr *MockSbClientMockRecorder) GetKubeOvnChassisses() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubeOvnChassisses", reflect.TypeOf((*MockSbClient)(nil).GetKubeOvnChassisses))
 }
 
+// GetLogicalFlowCookies mocks base method.
+func (m *MockSbClient) GetLogicalFlowCookies(uuids []string) (map[string][]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogicalFlowCookies", uuids)
+	ret0, _ := ret[0].(map[string][]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogicalFlowCookies indicates an expected call of GetLogicalFlowCookies.
+func (mr *MockSbClientMockRecorder) GetLogicalFlowCookies(uuids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogicalFlowCookies", reflect.TypeOf((*MockSbClient)(nil).GetLogicalFlowCookies), uuids)
+}
+
 // ListChassis mocks base method.
 func (m *MockSbClient) ListChassis() (*[]ovnsb.Chassis, error) {
 	m.ctrl.T.Helper()
@@ -4708,3 +4928,41 @@ func (mr *MockChassisMockRecorder) UpdateChassisTag(chassisName, nodeName any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChassisTag", reflect.TypeOf((*MockChassis)(nil).UpdateChassisTag), chassisName, nodeName)
 }
+
+// MockLogicalFlow is a mock of LogicalFlow interface.
+type MockLogicalFlow struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogicalFlowMockRecorder
+}
+
+// MockLogicalFlowMockRecorder is the mock recorder for MockLogicalFlow.
+type MockLogicalFlowMockRecorder struct {
+	mock *MockLogicalFlow
+}
+
+// NewMockLogicalFlow creates a new mock instance.
+func NewMockLogicalFlow(ctrl *gomock.Controller) *MockLogicalFlow {
+	mock := &MockLogicalFlow{ctrl: ctrl}
+	mock.recorder = &MockLogicalFlowMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogicalFlow) EXPECT() *MockLogicalFlowMockRecorder {
+	return m.recorder
+}
+
+// GetLogicalFlowCookies mocks base method.
+func (m *MockLogicalFlow) GetLogicalFlowCookies(uuids []string) (map[string][]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogicalFlowCookies", uuids)
+	ret0, _ := ret[0].(map[string][]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogicalFlowCookies indicates an expected call of GetLogicalFlowCookies.
+func (mr *MockLogicalFlowMockRecorder) GetLogicalFlowCookies(uuids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogicalFlowCookies", reflect.TypeOf((*MockLogicalFlow)(nil).GetLogicalFlowCookies), uuids)
+}
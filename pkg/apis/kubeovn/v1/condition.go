@@ -459,3 +459,133 @@ func (s *ProviderNetworkStatus) RemoveNodeConditions(node string) bool {
 	}
 	return changed
 }
+
+func (s *InterConnectionStatus) addCondition(ctype ConditionType, status corev1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+	s.Conditions = append(s.Conditions, InterConnectionCondition{
+		Type:               ctype,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setConditionValue updates or creates a new condition
+func (s *InterConnectionStatus) setConditionValue(ctype ConditionType, status corev1.ConditionStatus, reason, message string) {
+	var c *InterConnectionCondition
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == ctype {
+			c = &s.Conditions[i]
+		}
+	}
+	if c == nil {
+		s.addCondition(ctype, status, reason, message)
+	} else {
+		if c.Status == status && c.Reason == reason && c.Message == message {
+			return
+		}
+		now := metav1.Now()
+		c.LastUpdateTime = now
+		if c.Status != status {
+			c.LastTransitionTime = now
+		}
+		c.Status = status
+		c.Reason = reason
+		c.Message = message
+	}
+}
+
+// GetCondition get existing condition
+func (s *InterConnectionStatus) GetCondition(ctype ConditionType) *InterConnectionCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == ctype {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition updates or creates a new condition
+func (s *InterConnectionStatus) SetCondition(ctype ConditionType, reason, message string) {
+	s.setConditionValue(ctype, corev1.ConditionTrue, reason, message)
+}
+
+// ClearCondition updates or creates a new condition
+func (s *InterConnectionStatus) ClearCondition(ctype ConditionType, reason, message string) {
+	s.setConditionValue(ctype, corev1.ConditionFalse, reason, message)
+}
+
+// IsConditionTrue - if condition is true
+func (s *InterConnectionStatus) IsConditionTrue(ctype ConditionType) bool {
+	if c := s.GetCondition(ctype); c != nil {
+		return c.Status == corev1.ConditionTrue
+	}
+	return false
+}
+
+func (s *IPHandoffStatus) addCondition(ctype ConditionType, status corev1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+	s.Conditions = append(s.Conditions, IPHandoffCondition{
+		Type:               ctype,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setConditionValue updates or creates a new condition
+func (s *IPHandoffStatus) setConditionValue(ctype ConditionType, status corev1.ConditionStatus, reason, message string) {
+	var c *IPHandoffCondition
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == ctype {
+			c = &s.Conditions[i]
+		}
+	}
+	if c == nil {
+		s.addCondition(ctype, status, reason, message)
+	} else {
+		if c.Status == status && c.Reason == reason && c.Message == message {
+			return
+		}
+		now := metav1.Now()
+		c.LastUpdateTime = now
+		if c.Status != status {
+			c.LastTransitionTime = now
+		}
+		c.Status = status
+		c.Reason = reason
+		c.Message = message
+	}
+}
+
+// GetCondition get existing condition
+func (s *IPHandoffStatus) GetCondition(ctype ConditionType) *IPHandoffCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == ctype {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition updates or creates a new condition
+func (s *IPHandoffStatus) SetCondition(ctype ConditionType, reason, message string) {
+	s.setConditionValue(ctype, corev1.ConditionTrue, reason, message)
+}
+
+// ClearCondition updates or creates a new condition
+func (s *IPHandoffStatus) ClearCondition(ctype ConditionType, reason, message string) {
+	s.setConditionValue(ctype, corev1.ConditionFalse, reason, message)
+}
+
+// IsConditionTrue - if condition is true
+func (s *IPHandoffStatus) IsConditionTrue(ctype ConditionType) bool {
+	if c := s.GetCondition(ctype); c != nil {
+		return c.Status == corev1.ConditionTrue
+	}
+	return false
+}
@@ -65,6 +65,18 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&OvnDnatRuleList{},
 		&SecurityGroup{},
 		&SecurityGroupList{},
+		&AddressGroup{},
+		&AddressGroupList{},
+		&InterConnection{},
+		&InterConnectionList{},
+		&VpcRouteTable{},
+		&VpcRouteTableList{},
+		&IPHandoff{},
+		&IPHandoffList{},
+		&DHCPOptions{},
+		&DHCPOptionsList{},
+		&BootServer{},
+		&BootServerList{},
 		&SwitchLBRule{},
 		&SwitchLBRuleList{},
 		&VpcDns{},
@@ -48,6 +48,36 @@ func (sgs *SecurityGroupStatus) Bytes() ([]byte, error) {
 	return []byte(newStr), nil
 }
 
+func (ags *AddressGroupStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(ags)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
+func (ics *InterConnectionStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(ics)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
+func (rtbs *VpcRouteTableStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(rtbs)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
 func (vipst *VipStatus) Bytes() ([]byte, error) {
 	bytes, err := json.Marshal(vipst)
 	if err != nil {
@@ -157,3 +187,33 @@ func (vns *VpcNatStatus) Bytes() ([]byte, error) {
 	klog.V(5).Info("status body", newStr)
 	return []byte(newStr), nil
 }
+
+func (hs *IPHandoffStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(hs)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
+func (ds *DHCPOptionsStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(ds)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
+func (bss *BootServerStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(bss)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
@@ -19,11 +19,14 @@ const (
 	GWCentralizedType = "centralized"
 )
 
+const IPv6AddressModeSLAAC = "slaac"
+
 type SgRemoteType string
 
 const (
-	SgRemoteTypeAddress SgRemoteType = "address"
-	SgRemoteTypeSg      SgRemoteType = "securityGroup"
+	SgRemoteTypeAddress      SgRemoteType = "address"
+	SgRemoteTypeSg           SgRemoteType = "securityGroup"
+	SgRemoteTypeAddressGroup SgRemoteType = "addressGroup"
 )
 
 type SgProtocol string
@@ -70,6 +73,10 @@ const (
 	Validated = "Validated"
 	// Error => last recorded error
 	Error = "Error"
+	// NearExhaustion => the addresses are estimated to be exhausted soon at the current allocation rate
+	NearExhaustion = "NearExhaustion"
+	// GatewayReachable => the underlay gateways are reachable from the node
+	GatewayReachable = "GatewayReachable"
 
 	ReasonInit = "Init"
 )
@@ -151,13 +158,23 @@ type SubnetSpec struct {
 	LogicalGateway         bool `json:"logicalGateway,omitempty"`
 	DisableGatewayCheck    bool `json:"disableGatewayCheck,omitempty"`
 	DisableInterConnection bool `json:"disableInterConnection,omitempty"`
+	// InterConnectionAZs limits the availability zones learning the CIDR of the subnet, all of them learn it if empty.
+	// It takes effect on the subnets of custom vpcs connected to transit switches of OVN-IC.
+	// +optional
+	InterConnectionAZs []string `json:"interConnectionAZs,omitempty"`
 
 	EnableDHCP    bool   `json:"enableDHCP,omitempty"`
 	DHCPv4Options string `json:"dhcpV4Options,omitempty"`
 	DHCPv6Options string `json:"dhcpV6Options,omitempty"`
+	// DHCPOptions is the name of the DHCPOptions used by the subnet, which can't be set together with dhcpV4Options or dhcpV6Options
+	// +optional
+	DHCPOptions string `json:"dhcpOptions,omitempty"`
 
 	EnableIPv6RA  bool   `json:"enableIPv6RA,omitempty"`
 	IPv6RAConfigs string `json:"ipv6RAConfigs,omitempty"`
+	// IPv6AddressMode makes the pods get their ipv6 addresses from the upstream router via slaac,
+	// the addresses are learned and recorded rather than allocated by kube-ovn
+	IPv6AddressMode string `json:"ipv6AddressMode,omitempty"`
 
 	Acls []ACL `json:"acls,omitempty"`
 
@@ -177,6 +194,9 @@ type ACL struct {
 	Priority  int    `json:"priority,omitempty"`
 	Match     string `json:"match,omitempty"`
 	Action    string `json:"action,omitempty"`
+	// Stateless bypasses conntrack: an allow acl is created with action allow-stateless
+	// and paired with an acl allowing the reply traffic in the reverse direction
+	Stateless bool `json:"stateless,omitempty"`
 }
 
 type NatOutgoingPolicyRule struct {
@@ -270,6 +290,40 @@ type IPPoolSpec struct {
 	Subnet     string   `json:"subnet,omitempty"`
 	Namespaces []string `json:"namespaces,omitempty"`
 	IPs        []string `json:"ips,omitempty"`
+	// CIDRBlocks are the CIDR blocks of the ippool, which are merged with IPs
+	// +optional
+	CIDRBlocks []string `json:"cidrBlocks,omitempty"`
+	// ExcludeIPs are the addresses excluded from IPs and CIDRBlocks
+	// +optional
+	ExcludeIPs []string `json:"excludeIPs,omitempty"`
+	// AutoAllocate makes the ippool a template carving a CIDR block for each namespace
+	// when a pod of the namespace allocates address from the subnet for the first time
+	// +optional
+	AutoAllocate *IPPoolAutoAllocate `json:"autoAllocate,omitempty"`
+
+	// PodSelector selects the pods which allocate addresses from the ippool without the ip_pool annotation
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// Owners selects the pods owned by the workloads, which allocate addresses from the ippool without the ip_pool annotation
+	// +optional
+	Owners []IPPoolOwner `json:"owners,omitempty"`
+	// Priority decides the ippool used by a pod selected by several ippools, the ippool with higher priority is preferred
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// IPPoolAutoAllocate carves the CIDR blocks from CIDRBlocks of the ippool, or the subnet CIDR if CIDRBlocks is empty.
+// The CIDR block of a namespace is an ippool owned by the template and is released when the namespace is deleted.
+type IPPoolAutoAllocate struct {
+	IPv4PrefixLength int32 `json:"ipv4PrefixLength,omitempty"`
+	IPv6PrefixLength int32 `json:"ipv6PrefixLength,omitempty"`
+}
+
+type IPPoolOwner struct {
+	// Kind is the kind of the workload, Deployment or StatefulSet
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
 }
 
 // IPPoolCondition describes the state of an object at a certain point.
@@ -423,6 +477,8 @@ type VpcSpec struct {
 	EnableExternal       bool           `json:"enableExternal,omitempty"`
 	ExtraExternalSubnets []string       `json:"extraExternalSubnets,omitempty"`
 	EnableBfd            bool           `json:"enableBfd,omitempty"`
+
+	InterConnection *VpcInterConnection `json:"interConnection,omitempty"`
 }
 
 type VpcPeering struct {
@@ -430,6 +486,35 @@ type VpcPeering struct {
 	LocalConnectIP string `json:"localConnectIP,omitempty"`
 }
 
+// VpcInterConnection connects the vpc router to a transit switch of OVN-IC and filters the routes exchanged.
+// The routes of custom vpcs are advertised and learned per vpc router by kube-ovn-ic.
+// The default vpc is connected to the transit switches created by ovn-ic-db and only the route filters take effect.
+type VpcInterConnection struct {
+	// TransitSwitch is the name of the transit switch in the OVN-IC northbound database
+	// +optional
+	TransitSwitch string `json:"transitSwitch,omitempty"`
+	// TransitSwitchCIDR is the CIDR of the transit switch, used when the transit switch does not exist yet
+	// +optional
+	TransitSwitchCIDR string `json:"transitSwitchCIDR,omitempty"`
+	// AdvertiseCIDRs limits the routes advertised to the transit switch,
+	// all routes of the vpc are advertised if it is empty
+	// +optional
+	AdvertiseCIDRs []string `json:"advertiseCIDRs,omitempty"`
+	// AcceptCIDRs limits the routes learned from other availability zones,
+	// all routes are learned if it is empty
+	// +optional
+	AcceptCIDRs []string `json:"acceptCIDRs,omitempty"`
+}
+
+type VpcLearnedRoute struct {
+	CIDR             string `json:"cidr"`
+	NextHopIP        string `json:"nextHopIP"`
+	RouteTable       string `json:"routeTable,omitempty"`
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+	// Reason is the reason why the route is rejected
+	Reason string `json:"reason,omitempty"`
+}
+
 type RoutePolicy string
 
 const (
@@ -485,6 +570,11 @@ type VpcStatus struct {
 	EnableExternal          bool     `json:"enableExternal"`
 	ExtraExternalSubnets    []string `json:"extraExternalSubnets"`
 	EnableBfd               bool     `json:"enableBfd"`
+
+	InterConnectionTransitSwitch string             `json:"interConnectionTransitSwitch"`
+	LearnedRoutes                []*VpcLearnedRoute `json:"learnedRoutes"`
+	AdvertisedRoutes             []string           `json:"advertisedRoutes"`
+	RejectedRoutes               []*VpcLearnedRoute `json:"rejectedRoutes"`
 }
 
 // VpcCondition describes the state of an object at a certain point.
@@ -752,10 +842,10 @@ type IptablesDnatRule struct {
 }
 type IptablesDnatRuleSpec struct {
 	EIP          string `json:"eip"`
-	ExternalPort string `json:"externalPort"`
+	ExternalPort string `json:"externalPort"` // port, port range or list of them, eg: 80,443,30000-30999
 	Protocol     string `json:"protocol,omitempty"`
 	InternalIP   string `json:"internalIp"`
-	InternalPort string `json:"internalPort"`
+	InternalPort string `json:"internalPort"` // same layout as externalPort, mapped to it port by port
 }
 
 // IptablesDnatRuleCondition describes the state of an object at a certain point.
@@ -817,11 +907,15 @@ type SecurityGroupSpec struct {
 	IngressRules          []*SgRule `json:"ingressRules,omitempty"`
 	EgressRules           []*SgRule `json:"egressRules,omitempty"`
 	AllowSameGroupTraffic bool      `json:"allowSameGroupTraffic,omitempty"`
+	// Stateless makes the allow rules bypass conntrack, each of them is paired with a rule
+	// allowing the reply traffic in the reverse direction
+	Stateless bool `json:"stateless,omitempty"`
 }
 
 type SecurityGroupStatus struct {
 	PortGroup              string `json:"portGroup"`
 	AllowSameGroupTraffic  bool   `json:"allowSameGroupTraffic"`
+	Stateless              bool   `json:"stateless"`
 	IngressMd5             string `json:"ingressMd5"`
 	EgressMd5              string `json:"egressMd5"`
 	IngressLastSyncSuccess bool   `json:"ingressLastSyncSuccess"`
@@ -835,9 +929,25 @@ type SgRule struct {
 	RemoteType          SgRemoteType `json:"remoteType"`
 	RemoteAddress       string       `json:"remoteAddress,omitempty"`
 	RemoteSecurityGroup string       `json:"remoteSecurityGroup,omitempty"`
+	RemoteAddressGroup  string       `json:"remoteAddressGroup,omitempty"`
 	PortRangeMin        int          `json:"portRangeMin,omitempty"`
 	PortRangeMax        int          `json:"portRangeMax,omitempty"`
-	Policy              SgPolicy     `json:"policy"`
+	// PortRanges allows multiple port ranges in one rule, mutually exclusive with PortRangeMin and PortRangeMax
+	PortRanges []SgPortRange `json:"portRanges,omitempty"`
+	// ICMPType and ICMPCode match the icmp type and code, all icmp packets are matched if not set
+	ICMPType *int     `json:"icmpType,omitempty"`
+	ICMPCode *int     `json:"icmpCode,omitempty"`
+	Policy   SgPolicy `json:"policy"`
+	// Log enables logging of the packets hitting the rule, the acl is named as sg/<security group>/<direction>/<rule index>
+	Log bool `json:"log,omitempty"`
+	// LogSeverity is one of alert, warning, notice, info and debug, defaults to info
+	LogSeverity string `json:"logSeverity,omitempty"`
+}
+
+type SgPortRange struct {
+	Min int `json:"min"`
+	// Max defaults to Min, which means a single port
+	Max int `json:"max,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -849,6 +959,346 @@ type SecurityGroupList struct {
 	Items []SecurityGroup `json:"items"`
 }
 
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=address-groups
+
+// AddressGroup is a named set of ip addresses and cidrs backed by ovn address sets,
+// which can be referenced by security group rules
+type AddressGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AddressGroupSpec   `json:"spec"`
+	Status AddressGroupStatus `json:"status,omitempty"`
+}
+
+type AddressGroupSpec struct {
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+type AddressGroupStatus struct {
+	V4AddressSet string `json:"v4AddressSet"`
+	V6AddressSet string `json:"v6AddressSet"`
+	Ready        bool   `json:"ready"`
+	Error        string `json:"error"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AddressGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AddressGroup `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+
+// InterConnection is the configuration of OVN-IC, only one InterConnection is allowed in a cluster.
+// The ovn-ic-config ConfigMap is still used if there is no InterConnection.
+type InterConnection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InterConnectionSpec   `json:"spec"`
+	Status InterConnectionStatus `json:"status,omitempty"`
+}
+
+type InterConnectionSpec struct {
+	Enable bool   `json:"enable"`
+	AzName string `json:"azName,omitempty"`
+	// ICDBHosts are the ip addresses or hostnames of the OVN-IC databases
+	ICDBHosts []string `json:"icDBHosts,omitempty"`
+	ICNbPort  int32    `json:"icNbPort,omitempty"`
+	ICSbPort  int32    `json:"icSbPort,omitempty"`
+	// GatewayNodes are the nodes running as the gateways of the transit switches
+	GatewayNodes []string `json:"gatewayNodes,omitempty"`
+	AutoRoute    bool     `json:"autoRoute,omitempty"`
+}
+
+// Condition types of InterConnection
+const (
+	ICDBReachable            ConditionType = "ICDBReachable"
+	GatewayChassisRegistered ConditionType = "GatewayChassisRegistered"
+	TransitSwitchPortsReady  ConditionType = "TransitSwitchPortsReady"
+)
+
+// InterConnectionCondition describes the state of an object at a certain point.
+// +k8s:deepcopy-gen=true
+type InterConnectionCondition Condition
+
+type InterConnectionStatus struct {
+	TransitSwitches []string `json:"transitSwitches"`
+
+	// Conditions represents the latest state of the object
+	// +optional
+	Conditions []InterConnectionCondition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type InterConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []InterConnection `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=vpc-route-tables
+
+// VpcRouteTable is a route table of a vpc logical router, subnets use it by setting spec.routeTable to its name
+type VpcRouteTable struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VpcRouteTableSpec   `json:"spec"`
+	Status VpcRouteTableStatus `json:"status,omitempty"`
+}
+
+type VpcRouteTableSpec struct {
+	Vpc    string                `json:"vpc"`
+	Routes []*VpcRouteTableRoute `json:"routes,omitempty"`
+}
+
+type VpcRouteTableRoute struct {
+	Policy RoutePolicy `json:"policy,omitempty"`
+	CIDR   string      `json:"cidr"`
+	// NextHopIPs are the next hops of the route, traffic is balanced among them with ECMP if there are more than one
+	NextHopIPs []string `json:"nextHopIPs"`
+	// EnableBfd monitors the next hops with BFD and removes the unreachable ones from the route
+	// +optional
+	EnableBfd bool `json:"enableBfd,omitempty"`
+}
+
+type VpcRouteTableRouteStatus struct {
+	Policy    RoutePolicy `json:"policy,omitempty"`
+	CIDR      string      `json:"cidr"`
+	NextHopIP string      `json:"nextHopIP"`
+	BfdID     string      `json:"bfdId,omitempty"`
+}
+
+type VpcRouteTableStatus struct {
+	Ready bool   `json:"ready"`
+	Error string `json:"error,omitempty"`
+	// Subnets are the subnets using the route table
+	Subnets []string `json:"subnets"`
+	// Routes are the routes programmed in the logical router
+	Routes []*VpcRouteTableRouteStatus `json:"routes"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VpcRouteTableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VpcRouteTable `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=ip-handoffs
+
+// IPHandoff hands off the address and the logical switch port of a pod to another pod on a different node,
+// which lets sandboxes other than KubeVirt VMs keep their address during live migration.
+// The target pod must be annotated with ovn.kubernetes.io/ip_handoff set to the name of the IPHandoff.
+type IPHandoff struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IPHandoffSpec   `json:"spec"`
+	Status IPHandoffStatus `json:"status,omitempty"`
+}
+
+type IPHandoffSpec struct {
+	Namespace string `json:"namespace"`
+	SourcePod string `json:"sourcePod"`
+	TargetPod string `json:"targetPod"`
+	// TimeoutSeconds is the time to wait for the target pod to be ready before rolling back, default 300
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+type IPHandoffPhase string
+
+const (
+	IPHandoffPhasePending      IPHandoffPhase = "Pending"
+	IPHandoffPhaseTransferring IPHandoffPhase = "Transferring"
+	IPHandoffPhaseSucceeded    IPHandoffPhase = "Succeeded"
+	IPHandoffPhaseRolledBack   IPHandoffPhase = "RolledBack"
+	IPHandoffPhaseFailed       IPHandoffPhase = "Failed"
+)
+
+// Condition types of IPHandoff
+const (
+	PortMigrating ConditionType = "PortMigrating"
+	Transferred   ConditionType = "Transferred"
+)
+
+// IPHandoffCondition describes the state of an object at a certain point.
+// +k8s:deepcopy-gen=true
+type IPHandoffCondition Condition
+
+type IPHandoffStatus struct {
+	Phase      IPHandoffPhase `json:"phase,omitempty"`
+	IPAddress  string         `json:"ipAddress,omitempty"`
+	MacAddress string         `json:"macAddress,omitempty"`
+	SourceNode string         `json:"sourceNode,omitempty"`
+	TargetNode string         `json:"targetNode,omitempty"`
+	// StartTime is the time when the transfer started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Conditions represents the latest state of the object
+	// +optional
+	Conditions []IPHandoffCondition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type IPHandoffList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []IPHandoff `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=dhcp-options
+
+// DHCPOptions holds typed DHCP options shared by the subnets referencing it by name.
+// The OVN DHCP_Options rows of each subnet are rendered from the spec together with the subnet cidr and gateway.
+type DHCPOptions struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DHCPOptionsSpec   `json:"spec"`
+	Status DHCPOptionsStatus `json:"status,omitempty"`
+}
+
+type DHCPOptionsSpec struct {
+	// Routers are the ipv4 routers offered to the clients, default to the subnet gateway
+	// +optional
+	Routers []string `json:"routers,omitempty"`
+	// DNSServers are the ipv4 and ipv6 dns servers offered to the clients
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+	// DomainSearch is the domain search list, only the first domain is offered to the dhcpv6 clients
+	// +optional
+	DomainSearch []string `json:"domainSearch,omitempty"`
+	// NTPServers are the ipv4 ntp servers offered to the clients
+	// +optional
+	NTPServers []string `json:"ntpServers,omitempty"`
+	// MTU is the interface mtu offered to the clients, default to the subnet mtu
+	// +optional
+	MTU int32 `json:"mtu,omitempty"`
+	// LeaseTime is the ipv4 lease time in seconds, default 3600
+	// +optional
+	LeaseTime int32 `json:"leaseTime,omitempty"`
+	// ClasslessStaticRoutes are the ipv4 routes offered with option 121
+	// +optional
+	ClasslessStaticRoutes []DHCPStaticRoute `json:"classlessStaticRoutes,omitempty"`
+	// Bootfile is the boot file name offered to the PXE clients
+	// +optional
+	Bootfile string `json:"bootfile,omitempty"`
+	// IPXEBootfile is the boot file name offered instead of Bootfile to the clients with the iPXE user class,
+	// which is usually an iPXE script chainloaded by the iPXE firmware loaded with Bootfile
+	// +optional
+	IPXEBootfile string `json:"ipxeBootfile,omitempty"`
+	// NextServer is the ipv4 address of the TFTP server from which the boot file is loaded
+	// +optional
+	NextServer string `json:"nextServer,omitempty"`
+}
+
+type DHCPStaticRoute struct {
+	Destination string `json:"destination"`
+	NextHop     string `json:"nextHop"`
+}
+
+// DHCPOptionsSubnet records the OVN DHCP_Options rows rendered for a subnet
+type DHCPOptionsSubnet struct {
+	Name              string `json:"name"`
+	DHCPv4OptionsUUID string `json:"dhcpV4OptionsUUID,omitempty"`
+	DHCPv6OptionsUUID string `json:"dhcpV6OptionsUUID,omitempty"`
+}
+
+type DHCPOptionsStatus struct {
+	// Subnets are the subnets using the DHCPOptions
+	// +optional
+	Subnets []DHCPOptionsSubnet `json:"subnets,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DHCPOptionsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []DHCPOptions `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=boot-servers
+
+// BootServer runs a TFTP and HTTP responder attached to a subnet to serve network boot files.
+// The address of the responder is meant to be offered as the next server by the DHCP options of the subnet or VM.
+type BootServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BootServerSpec   `json:"spec"`
+	Status BootServerStatus `json:"status,omitempty"`
+}
+
+type BootServerSpec struct {
+	// Subnet is the subnet the responder is attached to, subnets of custom vpcs are supported
+	Subnet string `json:"subnet"`
+	// IP is the static ipv4 address of the responder in the subnet
+	IP string `json:"ip"`
+	// Image provides dnsmasq for the TFTP server and busybox httpd for the HTTP server,
+	// default to the image of the boot-server-config ConfigMap
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ConfigMap in the kube-ovn namespace holding the boot files, which are served from the root directory
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+	// PersistentVolumeClaim in the kube-ovn namespace holding the boot files, which is mutually exclusive with ConfigMap
+	// +optional
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	// HTTPPort is the port of the HTTP server, default 80
+	// +optional
+	HTTPPort int32 `json:"httpPort,omitempty"`
+}
+
+type BootServerStatus struct {
+	// Active is true when the deployment of the boot server is available
+	Active bool `json:"active"`
+	// Message is the reason why the responder is not active, which is cleared by the status patch when empty
+	// +optional
+	Message string `json:"message"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type BootServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []BootServer `json:"items"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +genclient:nonNamespaced
@@ -1069,6 +1519,11 @@ type OvnFipSpec struct {
 	IPName string `json:"ipName"` // vip, ip crd name
 	Vpc    string `json:"vpc"`
 	V4Ip   string `json:"v4Ip"`
+	// Type is distributed or centralized, distributed fip is handled on the chassis hosting the pod,
+	// fip bound to a pod ip is distributed by default
+	Type string `json:"type,omitempty"`
+	// Stateless makes the distributed fip bypass conntrack
+	Stateless bool `json:"stateless,omitempty"`
 }
 
 // OvnFipCondition describes the state of an object at a certain point.
@@ -1081,6 +1536,7 @@ type OvnFipStatus struct {
 	Vpc   string `json:"vpc" patchStrategy:"merge"`
 	V4Eip string `json:"v4Eip" patchStrategy:"merge"`
 	V4Ip  string `json:"v4Ip" patchStrategy:"merge"`
+	Type  string `json:"type,omitempty" patchStrategy:"merge"`
 	Ready bool   `json:"ready" patchStrategy:"merge"`
 
 	// Conditions represents the latest state of the object
@@ -1118,6 +1574,13 @@ type OvnSnatRuleSpec struct {
 	IPName    string `json:"ipName"`
 	Vpc       string `json:"vpc"`
 	V4IpCidr  string `json:"v4IpCidr"` // subnet cidr or pod ip address
+
+	// OvnEips and the eips selected by OvnEipSelector share the snat together with OvnEip,
+	// the internal cidr is split into parts and each part is translated to one of the eips
+	OvnEips        []string          `json:"ovnEips,omitempty"`
+	OvnEipSelector map[string]string `json:"ovnEipSelector,omitempty"`
+	// ExternalPortRange limits the source ports used by the snat, eg: 1024-65535
+	ExternalPortRange string `json:"externalPortRange,omitempty"`
 }
 
 // OvnSnatRuleCondition describes the state of an object at a certain point.
@@ -1127,10 +1590,12 @@ type OvnSnatRuleCondition Condition
 type OvnSnatRuleStatus struct {
 	// +optional
 	// +patchStrategy=merge
-	Vpc      string `json:"vpc" patchStrategy:"merge"`
-	V4Eip    string `json:"v4Eip" patchStrategy:"merge"`
-	V4IpCidr string `json:"v4IpCidr" patchStrategy:"merge"`
-	Ready    bool   `json:"ready" patchStrategy:"merge"`
+	Vpc               string   `json:"vpc" patchStrategy:"merge"`
+	V4Eip             string   `json:"v4Eip" patchStrategy:"merge"`
+	V4Eips            []string `json:"v4Eips,omitempty" patchStrategy:"merge"`
+	V4IpCidr          string   `json:"v4IpCidr" patchStrategy:"merge"`
+	ExternalPortRange string   `json:"externalPortRange,omitempty" patchStrategy:"merge"`
+	Ready             bool     `json:"ready" patchStrategy:"merge"`
 
 	// Conditions represents the latest state of the object
 	// +optional
@@ -1163,10 +1628,10 @@ type OvnDnatRule struct {
 
 type OvnDnatRuleSpec struct {
 	OvnEip       string `json:"ovnEip"`
-	IPType       string `json:"ipType"` // vip, ip
-	IPName       string `json:"ipName"` // vip, ip crd name
-	InternalPort string `json:"internalPort"`
-	ExternalPort string `json:"externalPort"`
+	IPType       string `json:"ipType"`       // vip, ip
+	IPName       string `json:"ipName"`       // vip, ip crd name
+	InternalPort string `json:"internalPort"` // same layout as externalPort, mapped to it port by port
+	ExternalPort string `json:"externalPort"` // port, port range or list of them, eg: 80,443,30000-30999
 	Protocol     string `json:"protocol,omitempty"`
 	Vpc          string `json:"vpc"`
 	V4Ip         string `json:"v4Ip"`
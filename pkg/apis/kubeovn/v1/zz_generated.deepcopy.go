@@ -23,6 +23,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -42,6 +43,197 @@ func (in *ACL) DeepCopy() *ACL {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressGroup) DeepCopyInto(out *AddressGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressGroup.
+func (in *AddressGroup) DeepCopy() *AddressGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AddressGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressGroupList) DeepCopyInto(out *AddressGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AddressGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressGroupList.
+func (in *AddressGroupList) DeepCopy() *AddressGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AddressGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressGroupSpec) DeepCopyInto(out *AddressGroupSpec) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressGroupSpec.
+func (in *AddressGroupSpec) DeepCopy() *AddressGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddressGroupStatus) DeepCopyInto(out *AddressGroupStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressGroupStatus.
+func (in *AddressGroupStatus) DeepCopy() *AddressGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddressGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootServer) DeepCopyInto(out *BootServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootServer.
+func (in *BootServer) DeepCopy() *BootServer {
+	if in == nil {
+		return nil
+	}
+	out := new(BootServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BootServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootServerList) DeepCopyInto(out *BootServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BootServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootServerList.
+func (in *BootServerList) DeepCopy() *BootServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(BootServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BootServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootServerSpec) DeepCopyInto(out *BootServerSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootServerSpec.
+func (in *BootServerSpec) DeepCopy() *BootServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BootServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootServerStatus) DeepCopyInto(out *BootServerStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootServerStatus.
+func (in *BootServerStatus) DeepCopy() *BootServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BootServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -50,64 +242,341 @@ func (in *Condition) DeepCopyInto(out *Condition) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
-func (in *Condition) DeepCopy() *Condition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomInterface) DeepCopyInto(out *CustomInterface) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomInterface.
+func (in *CustomInterface) DeepCopy() *CustomInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptions) DeepCopyInto(out *DHCPOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptions.
+func (in *DHCPOptions) DeepCopy() *DHCPOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DHCPOptions) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptionsList) DeepCopyInto(out *DHCPOptionsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DHCPOptions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionsList.
+func (in *DHCPOptionsList) DeepCopy() *DHCPOptionsList {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptionsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DHCPOptionsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptionsSpec) DeepCopyInto(out *DHCPOptionsSpec) {
+	*out = *in
+	if in.Routers != nil {
+		in, out := &in.Routers, &out.Routers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DomainSearch != nil {
+		in, out := &in.DomainSearch, &out.DomainSearch
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClasslessStaticRoutes != nil {
+		in, out := &in.ClasslessStaticRoutes, &out.ClasslessStaticRoutes
+		*out = make([]DHCPStaticRoute, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionsSpec.
+func (in *DHCPOptionsSpec) DeepCopy() *DHCPOptionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptionsStatus) DeepCopyInto(out *DHCPOptionsStatus) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]DHCPOptionsSubnet, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionsStatus.
+func (in *DHCPOptionsStatus) DeepCopy() *DHCPOptionsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptionsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptionsSubnet) DeepCopyInto(out *DHCPOptionsSubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionsSubnet.
+func (in *DHCPOptionsSubnet) DeepCopy() *DHCPOptionsSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptionsSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPStaticRoute) DeepCopyInto(out *DHCPStaticRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPStaticRoute.
+func (in *DHCPStaticRoute) DeepCopy() *DHCPStaticRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPStaticRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IP) DeepCopyInto(out *IP) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IP.
+func (in *IP) DeepCopy() *IP {
+	if in == nil {
+		return nil
+	}
+	out := new(IP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPHandoff) DeepCopyInto(out *IPHandoff) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPHandoff.
+func (in *IPHandoff) DeepCopy() *IPHandoff {
 	if in == nil {
 		return nil
 	}
-	out := new(Condition)
+	out := new(IPHandoff)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPHandoff) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomInterface) DeepCopyInto(out *CustomInterface) {
+func (in *IPHandoffCondition) DeepCopyInto(out *IPHandoffCondition) {
 	*out = *in
-	if in.Nodes != nil {
-		in, out := &in.Nodes, &out.Nodes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomInterface.
-func (in *CustomInterface) DeepCopy() *CustomInterface {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPHandoffCondition.
+func (in *IPHandoffCondition) DeepCopy() *IPHandoffCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomInterface)
+	out := new(IPHandoffCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IP) DeepCopyInto(out *IP) {
+func (in *IPHandoffList) DeepCopyInto(out *IPHandoffList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IPHandoff, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IP.
-func (in *IP) DeepCopy() *IP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPHandoffList.
+func (in *IPHandoffList) DeepCopy() *IPHandoffList {
 	if in == nil {
 		return nil
 	}
-	out := new(IP)
+	out := new(IPHandoffList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IP) DeepCopyObject() runtime.Object {
+func (in *IPHandoffList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPHandoffSpec) DeepCopyInto(out *IPHandoffSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPHandoffSpec.
+func (in *IPHandoffSpec) DeepCopy() *IPHandoffSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPHandoffSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPHandoffStatus) DeepCopyInto(out *IPHandoffStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]IPHandoffCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPHandoffStatus.
+func (in *IPHandoffStatus) DeepCopy() *IPHandoffStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IPHandoffStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPList) DeepCopyInto(out *IPList) {
 	*out = *in
@@ -169,6 +638,22 @@ func (in *IPPool) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolAutoAllocate) DeepCopyInto(out *IPPoolAutoAllocate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolAutoAllocate.
+func (in *IPPoolAutoAllocate) DeepCopy() *IPPoolAutoAllocate {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolAutoAllocate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPPoolCondition) DeepCopyInto(out *IPPoolCondition) {
 	*out = *in
@@ -194,7 +679,211 @@ func (in *IPPoolList) DeepCopyInto(out *IPPoolList) {
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IPPool, len(*in))
+		*out = make([]IPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolList.
+func (in *IPPoolList) DeepCopy() *IPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolOwner) DeepCopyInto(out *IPPoolOwner) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolOwner.
+func (in *IPPoolOwner) DeepCopy() *IPPoolOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CIDRBlocks != nil {
+		in, out := &in.CIDRBlocks, &out.CIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeIPs != nil {
+		in, out := &in.ExcludeIPs, &out.ExcludeIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoAllocate != nil {
+		in, out := &in.AutoAllocate, &out.AutoAllocate
+		*out = new(IPPoolAutoAllocate)
+		**out = **in
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]IPPoolOwner, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolSpec.
+func (in *IPPoolSpec) DeepCopy() *IPPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolStatus) DeepCopyInto(out *IPPoolStatus) {
+	*out = *in
+	in.V4AvailableIPs.DeepCopyInto(&out.V4AvailableIPs)
+	in.V4UsingIPs.DeepCopyInto(&out.V4UsingIPs)
+	in.V6AvailableIPs.DeepCopyInto(&out.V6AvailableIPs)
+	in.V6UsingIPs.DeepCopyInto(&out.V6UsingIPs)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]IPPoolCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolStatus.
+func (in *IPPoolStatus) DeepCopy() *IPPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPSpec) DeepCopyInto(out *IPSpec) {
+	*out = *in
+	if in.AttachSubnets != nil {
+		in, out := &in.AttachSubnets, &out.AttachSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AttachIPs != nil {
+		in, out := &in.AttachIPs, &out.AttachIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AttachMacs != nil {
+		in, out := &in.AttachMacs, &out.AttachMacs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPSpec.
+func (in *IPSpec) DeepCopy() *IPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterConnection) DeepCopyInto(out *InterConnection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterConnection.
+func (in *InterConnection) DeepCopy() *InterConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(InterConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterConnection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterConnectionCondition) DeepCopyInto(out *InterConnectionCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterConnectionCondition.
+func (in *InterConnectionCondition) DeepCopy() *InterConnectionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(InterConnectionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterConnectionList) DeepCopyInto(out *InterConnectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InterConnection, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -202,18 +891,18 @@ func (in *IPPoolList) DeepCopyInto(out *IPPoolList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolList.
-func (in *IPPoolList) DeepCopy() *IPPoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterConnectionList.
+func (in *InterConnectionList) DeepCopy() *InterConnectionList {
 	if in == nil {
 		return nil
 	}
-	out := new(IPPoolList)
+	out := new(InterConnectionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IPPoolList) DeepCopyObject() runtime.Object {
+func (in *InterConnectionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -221,41 +910,42 @@ func (in *IPPoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
+func (in *InterConnectionSpec) DeepCopyInto(out *InterConnectionSpec) {
 	*out = *in
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
+	if in.ICDBHosts != nil {
+		in, out := &in.ICDBHosts, &out.ICDBHosts
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.IPs != nil {
-		in, out := &in.IPs, &out.IPs
+	if in.GatewayNodes != nil {
+		in, out := &in.GatewayNodes, &out.GatewayNodes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolSpec.
-func (in *IPPoolSpec) DeepCopy() *IPPoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterConnectionSpec.
+func (in *InterConnectionSpec) DeepCopy() *InterConnectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IPPoolSpec)
+	out := new(InterConnectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPPoolStatus) DeepCopyInto(out *IPPoolStatus) {
+func (in *InterConnectionStatus) DeepCopyInto(out *InterConnectionStatus) {
 	*out = *in
-	in.V4AvailableIPs.DeepCopyInto(&out.V4AvailableIPs)
-	in.V4UsingIPs.DeepCopyInto(&out.V4UsingIPs)
-	in.V6AvailableIPs.DeepCopyInto(&out.V6AvailableIPs)
-	in.V6UsingIPs.DeepCopyInto(&out.V6UsingIPs)
+	if in.TransitSwitches != nil {
+		in, out := &in.TransitSwitches, &out.TransitSwitches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]IPPoolCondition, len(*in))
+		*out = make([]InterConnectionCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -263,43 +953,12 @@ func (in *IPPoolStatus) DeepCopyInto(out *IPPoolStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolStatus.
-func (in *IPPoolStatus) DeepCopy() *IPPoolStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(IPPoolStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPSpec) DeepCopyInto(out *IPSpec) {
-	*out = *in
-	if in.AttachSubnets != nil {
-		in, out := &in.AttachSubnets, &out.AttachSubnets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AttachIPs != nil {
-		in, out := &in.AttachIPs, &out.AttachIPs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AttachMacs != nil {
-		in, out := &in.AttachMacs, &out.AttachMacs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPSpec.
-func (in *IPSpec) DeepCopy() *IPSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterConnectionStatus.
+func (in *InterConnectionStatus) DeepCopy() *InterConnectionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IPSpec)
+	out := new(InterConnectionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1185,7 +1844,7 @@ func (in *OvnSnatRule) DeepCopyInto(out *OvnSnatRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -1262,6 +1921,18 @@ func (in *OvnSnatRuleList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OvnSnatRuleSpec) DeepCopyInto(out *OvnSnatRuleSpec) {
 	*out = *in
+	if in.OvnEips != nil {
+		in, out := &in.OvnEips, &out.OvnEips
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OvnEipSelector != nil {
+		in, out := &in.OvnEipSelector, &out.OvnEipSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -1278,6 +1949,11 @@ func (in *OvnSnatRuleSpec) DeepCopy() *OvnSnatRuleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OvnSnatRuleStatus) DeepCopyInto(out *OvnSnatRuleStatus) {
 	*out = *in
+	if in.V4Eips != nil {
+		in, out := &in.V4Eips, &out.V4Eips
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]OvnSnatRuleCondition, len(*in))
@@ -1711,7 +2387,7 @@ func (in *SecurityGroupSpec) DeepCopyInto(out *SecurityGroupSpec) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(SgRule)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
@@ -1722,7 +2398,7 @@ func (in *SecurityGroupSpec) DeepCopyInto(out *SecurityGroupSpec) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(SgRule)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
@@ -1755,9 +2431,40 @@ func (in *SecurityGroupStatus) DeepCopy() *SecurityGroupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SgPortRange) DeepCopyInto(out *SgPortRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SgPortRange.
+func (in *SgPortRange) DeepCopy() *SgPortRange {
+	if in == nil {
+		return nil
+	}
+	out := new(SgPortRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SgRule) DeepCopyInto(out *SgRule) {
 	*out = *in
+	if in.PortRanges != nil {
+		in, out := &in.PortRanges, &out.PortRanges
+		*out = make([]SgPortRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.ICMPType != nil {
+		in, out := &in.ICMPType, &out.ICMPType
+		*out = new(int)
+		**out = **in
+	}
+	if in.ICMPCode != nil {
+		in, out := &in.ICMPCode, &out.ICMPCode
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -1905,6 +2612,11 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.InterConnectionAZs != nil {
+		in, out := &in.InterConnectionAZs, &out.InterConnectionAZs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Acls != nil {
 		in, out := &in.Acls, &out.Acls
 		*out = make([]ACL, len(*in))
@@ -2597,6 +3309,48 @@ func (in *VpcDnsList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcInterConnection) DeepCopyInto(out *VpcInterConnection) {
+	*out = *in
+	if in.AdvertiseCIDRs != nil {
+		in, out := &in.AdvertiseCIDRs, &out.AdvertiseCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AcceptCIDRs != nil {
+		in, out := &in.AcceptCIDRs, &out.AcceptCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcInterConnection.
+func (in *VpcInterConnection) DeepCopy() *VpcInterConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcInterConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcLearnedRoute) DeepCopyInto(out *VpcLearnedRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcLearnedRoute.
+func (in *VpcLearnedRoute) DeepCopy() *VpcLearnedRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcLearnedRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcList) DeepCopyInto(out *VpcList) {
 	*out = *in
@@ -2868,6 +3622,163 @@ func (in *VpcPeering) DeepCopy() *VpcPeering {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcRouteTable) DeepCopyInto(out *VpcRouteTable) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcRouteTable.
+func (in *VpcRouteTable) DeepCopy() *VpcRouteTable {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcRouteTable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcRouteTable) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcRouteTableList) DeepCopyInto(out *VpcRouteTableList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VpcRouteTable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcRouteTableList.
+func (in *VpcRouteTableList) DeepCopy() *VpcRouteTableList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcRouteTableList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcRouteTableList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcRouteTableRoute) DeepCopyInto(out *VpcRouteTableRoute) {
+	*out = *in
+	if in.NextHopIPs != nil {
+		in, out := &in.NextHopIPs, &out.NextHopIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcRouteTableRoute.
+func (in *VpcRouteTableRoute) DeepCopy() *VpcRouteTableRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcRouteTableRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcRouteTableRouteStatus) DeepCopyInto(out *VpcRouteTableRouteStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcRouteTableRouteStatus.
+func (in *VpcRouteTableRouteStatus) DeepCopy() *VpcRouteTableRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcRouteTableRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcRouteTableSpec) DeepCopyInto(out *VpcRouteTableSpec) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]*VpcRouteTableRoute, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(VpcRouteTableRoute)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcRouteTableSpec.
+func (in *VpcRouteTableSpec) DeepCopy() *VpcRouteTableSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcRouteTableSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcRouteTableStatus) DeepCopyInto(out *VpcRouteTableStatus) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]*VpcRouteTableRouteStatus, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(VpcRouteTableRouteStatus)
+				**out = **in
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcRouteTableStatus.
+func (in *VpcRouteTableStatus) DeepCopy() *VpcRouteTableStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcRouteTableStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcSpec) DeepCopyInto(out *VpcSpec) {
 	*out = *in
@@ -2914,6 +3825,11 @@ func (in *VpcSpec) DeepCopyInto(out *VpcSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.InterConnection != nil {
+		in, out := &in.InterConnection, &out.InterConnection
+		*out = new(VpcInterConnection)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2952,6 +3868,33 @@ func (in *VpcStatus) DeepCopyInto(out *VpcStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LearnedRoutes != nil {
+		in, out := &in.LearnedRoutes, &out.LearnedRoutes
+		*out = make([]*VpcLearnedRoute, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(VpcLearnedRoute)
+				**out = **in
+			}
+		}
+	}
+	if in.AdvertisedRoutes != nil {
+		in, out := &in.AdvertisedRoutes, &out.AdvertisedRoutes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RejectedRoutes != nil {
+		in, out := &in.RejectedRoutes, &out.RejectedRoutes
+		*out = make([]*VpcLearnedRoute, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(VpcLearnedRoute)
+				**out = **in
+			}
+		}
+	}
 	return
 }
 
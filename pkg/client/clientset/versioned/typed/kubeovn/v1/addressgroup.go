@@ -0,0 +1,184 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	scheme "github.com/kubeovn/kube-ovn/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// AddressGroupsGetter has a method to return a AddressGroupInterface.
+// A group's client should implement this interface.
+type AddressGroupsGetter interface {
+	AddressGroups() AddressGroupInterface
+}
+
+// AddressGroupInterface has methods to work with AddressGroup resources.
+type AddressGroupInterface interface {
+	Create(ctx context.Context, addressGroup *v1.AddressGroup, opts metav1.CreateOptions) (*v1.AddressGroup, error)
+	Update(ctx context.Context, addressGroup *v1.AddressGroup, opts metav1.UpdateOptions) (*v1.AddressGroup, error)
+	UpdateStatus(ctx context.Context, addressGroup *v1.AddressGroup, opts metav1.UpdateOptions) (*v1.AddressGroup, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.AddressGroup, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.AddressGroupList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.AddressGroup, err error)
+	AddressGroupExpansion
+}
+
+// addressGroups implements AddressGroupInterface
+type addressGroups struct {
+	client rest.Interface
+}
+
+// newAddressGroups returns a AddressGroups
+func newAddressGroups(c *KubeovnV1Client) *addressGroups {
+	return &addressGroups{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the addressGroup, and returns the corresponding addressGroup object, and an error if there is any.
+func (c *addressGroups) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.AddressGroup, err error) {
+	result = &v1.AddressGroup{}
+	err = c.client.Get().
+		Resource("address-groups").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of AddressGroups that match those selectors.
+func (c *addressGroups) List(ctx context.Context, opts metav1.ListOptions) (result *v1.AddressGroupList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.AddressGroupList{}
+	err = c.client.Get().
+		Resource("address-groups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested addressGroups.
+func (c *addressGroups) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("address-groups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a addressGroup and creates it.  Returns the server's representation of the addressGroup, and an error, if there is any.
+func (c *addressGroups) Create(ctx context.Context, addressGroup *v1.AddressGroup, opts metav1.CreateOptions) (result *v1.AddressGroup, err error) {
+	result = &v1.AddressGroup{}
+	err = c.client.Post().
+		Resource("address-groups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(addressGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a addressGroup and updates it. Returns the server's representation of the addressGroup, and an error, if there is any.
+func (c *addressGroups) Update(ctx context.Context, addressGroup *v1.AddressGroup, opts metav1.UpdateOptions) (result *v1.AddressGroup, err error) {
+	result = &v1.AddressGroup{}
+	err = c.client.Put().
+		Resource("address-groups").
+		Name(addressGroup.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(addressGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *addressGroups) UpdateStatus(ctx context.Context, addressGroup *v1.AddressGroup, opts metav1.UpdateOptions) (result *v1.AddressGroup, err error) {
+	result = &v1.AddressGroup{}
+	err = c.client.Put().
+		Resource("address-groups").
+		Name(addressGroup.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(addressGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the addressGroup and deletes it. Returns an error if one occurs.
+func (c *addressGroups) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("address-groups").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *addressGroups) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("address-groups").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched addressGroup.
+func (c *addressGroups) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.AddressGroup, err error) {
+	result = &v1.AddressGroup{}
+	err = c.client.Patch(pt).
+		Resource("address-groups").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,184 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	scheme "github.com/kubeovn/kube-ovn/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// BootServersGetter has a method to return a BootServerInterface.
+// A group's client should implement this interface.
+type BootServersGetter interface {
+	BootServers() BootServerInterface
+}
+
+// BootServerInterface has methods to work with BootServer resources.
+type BootServerInterface interface {
+	Create(ctx context.Context, bootServer *v1.BootServer, opts metav1.CreateOptions) (*v1.BootServer, error)
+	Update(ctx context.Context, bootServer *v1.BootServer, opts metav1.UpdateOptions) (*v1.BootServer, error)
+	UpdateStatus(ctx context.Context, bootServer *v1.BootServer, opts metav1.UpdateOptions) (*v1.BootServer, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.BootServer, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.BootServerList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.BootServer, err error)
+	BootServerExpansion
+}
+
+// bootServers implements BootServerInterface
+type bootServers struct {
+	client rest.Interface
+}
+
+// newBootServers returns a BootServers
+func newBootServers(c *KubeovnV1Client) *bootServers {
+	return &bootServers{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the bootServer, and returns the corresponding bootServer object, and an error if there is any.
+func (c *bootServers) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.BootServer, err error) {
+	result = &v1.BootServer{}
+	err = c.client.Get().
+		Resource("boot-servers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of BootServers that match those selectors.
+func (c *bootServers) List(ctx context.Context, opts metav1.ListOptions) (result *v1.BootServerList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.BootServerList{}
+	err = c.client.Get().
+		Resource("boot-servers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested bootServers.
+func (c *bootServers) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("boot-servers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a bootServer and creates it.  Returns the server's representation of the bootServer, and an error, if there is any.
+func (c *bootServers) Create(ctx context.Context, bootServer *v1.BootServer, opts metav1.CreateOptions) (result *v1.BootServer, err error) {
+	result = &v1.BootServer{}
+	err = c.client.Post().
+		Resource("boot-servers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(bootServer).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a bootServer and updates it. Returns the server's representation of the bootServer, and an error, if there is any.
+func (c *bootServers) Update(ctx context.Context, bootServer *v1.BootServer, opts metav1.UpdateOptions) (result *v1.BootServer, err error) {
+	result = &v1.BootServer{}
+	err = c.client.Put().
+		Resource("boot-servers").
+		Name(bootServer.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(bootServer).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *bootServers) UpdateStatus(ctx context.Context, bootServer *v1.BootServer, opts metav1.UpdateOptions) (result *v1.BootServer, err error) {
+	result = &v1.BootServer{}
+	err = c.client.Put().
+		Resource("boot-servers").
+		Name(bootServer.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(bootServer).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the bootServer and deletes it. Returns an error if one occurs.
+func (c *bootServers) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("boot-servers").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *bootServers) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("boot-servers").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched bootServer.
+func (c *bootServers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.BootServer, err error) {
+	result = &v1.BootServer{}
+	err = c.client.Patch(pt).
+		Resource("boot-servers").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
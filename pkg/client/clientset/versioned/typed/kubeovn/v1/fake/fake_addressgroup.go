@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeAddressGroups implements AddressGroupInterface
+type FakeAddressGroups struct {
+	Fake *FakeKubeovnV1
+}
+
+var addressgroupsResource = v1.SchemeGroupVersion.WithResource("address-groups")
+
+var addressgroupsKind = v1.SchemeGroupVersion.WithKind("AddressGroup")
+
+// Get takes name of the addressGroup, and returns the corresponding addressGroup object, and an error if there is any.
+func (c *FakeAddressGroups) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.AddressGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(addressgroupsResource, name), &v1.AddressGroup{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.AddressGroup), err
+}
+
+// List takes label and field selectors, and returns the list of AddressGroups that match those selectors.
+func (c *FakeAddressGroups) List(ctx context.Context, opts metav1.ListOptions) (result *v1.AddressGroupList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(addressgroupsResource, addressgroupsKind, opts), &v1.AddressGroupList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.AddressGroupList{ListMeta: obj.(*v1.AddressGroupList).ListMeta}
+	for _, item := range obj.(*v1.AddressGroupList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested addressGroups.
+func (c *FakeAddressGroups) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(addressgroupsResource, opts))
+}
+
+// Create takes the representation of a addressGroup and creates it.  Returns the server's representation of the addressGroup, and an error, if there is any.
+func (c *FakeAddressGroups) Create(ctx context.Context, addressGroup *v1.AddressGroup, opts metav1.CreateOptions) (result *v1.AddressGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(addressgroupsResource, addressGroup), &v1.AddressGroup{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.AddressGroup), err
+}
+
+// Update takes the representation of a addressGroup and updates it. Returns the server's representation of the addressGroup, and an error, if there is any.
+func (c *FakeAddressGroups) Update(ctx context.Context, addressGroup *v1.AddressGroup, opts metav1.UpdateOptions) (result *v1.AddressGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(addressgroupsResource, addressGroup), &v1.AddressGroup{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.AddressGroup), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeAddressGroups) UpdateStatus(ctx context.Context, addressGroup *v1.AddressGroup, opts metav1.UpdateOptions) (*v1.AddressGroup, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(addressgroupsResource, "status", addressGroup), &v1.AddressGroup{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.AddressGroup), err
+}
+
+// Delete takes name of the addressGroup and deletes it. Returns an error if one occurs.
+func (c *FakeAddressGroups) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(addressgroupsResource, name, opts), &v1.AddressGroup{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeAddressGroups) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(addressgroupsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.AddressGroupList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched addressGroup.
+func (c *FakeAddressGroups) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.AddressGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(addressgroupsResource, name, pt, data, subresources...), &v1.AddressGroup{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.AddressGroup), err
+}
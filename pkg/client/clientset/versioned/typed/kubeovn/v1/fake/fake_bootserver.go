@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeBootServers implements BootServerInterface
+type FakeBootServers struct {
+	Fake *FakeKubeovnV1
+}
+
+var bootserversResource = v1.SchemeGroupVersion.WithResource("boot-servers")
+
+var bootserversKind = v1.SchemeGroupVersion.WithKind("BootServer")
+
+// Get takes name of the bootServer, and returns the corresponding bootServer object, and an error if there is any.
+func (c *FakeBootServers) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.BootServer, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(bootserversResource, name), &v1.BootServer{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.BootServer), err
+}
+
+// List takes label and field selectors, and returns the list of BootServers that match those selectors.
+func (c *FakeBootServers) List(ctx context.Context, opts metav1.ListOptions) (result *v1.BootServerList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(bootserversResource, bootserversKind, opts), &v1.BootServerList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.BootServerList{ListMeta: obj.(*v1.BootServerList).ListMeta}
+	for _, item := range obj.(*v1.BootServerList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested bootServers.
+func (c *FakeBootServers) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(bootserversResource, opts))
+}
+
+// Create takes the representation of a bootServer and creates it.  Returns the server's representation of the bootServer, and an error, if there is any.
+func (c *FakeBootServers) Create(ctx context.Context, bootServer *v1.BootServer, opts metav1.CreateOptions) (result *v1.BootServer, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(bootserversResource, bootServer), &v1.BootServer{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.BootServer), err
+}
+
+// Update takes the representation of a bootServer and updates it. Returns the server's representation of the bootServer, and an error, if there is any.
+func (c *FakeBootServers) Update(ctx context.Context, bootServer *v1.BootServer, opts metav1.UpdateOptions) (result *v1.BootServer, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(bootserversResource, bootServer), &v1.BootServer{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.BootServer), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeBootServers) UpdateStatus(ctx context.Context, bootServer *v1.BootServer, opts metav1.UpdateOptions) (*v1.BootServer, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(bootserversResource, "status", bootServer), &v1.BootServer{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.BootServer), err
+}
+
+// Delete takes name of the bootServer and deletes it. Returns an error if one occurs.
+func (c *FakeBootServers) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(bootserversResource, name, opts), &v1.BootServer{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeBootServers) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(bootserversResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.BootServerList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched bootServer.
+func (c *FakeBootServers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.BootServer, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(bootserversResource, name, pt, data, subresources...), &v1.BootServer{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.BootServer), err
+}
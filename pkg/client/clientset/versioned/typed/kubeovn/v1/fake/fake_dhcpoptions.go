@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDHCPOptions implements DHCPOptionsInterface
+type FakeDHCPOptions struct {
+	Fake *FakeKubeovnV1
+}
+
+var dhcpoptionsResource = v1.SchemeGroupVersion.WithResource("dhcp-options")
+
+var dhcpoptionsKind = v1.SchemeGroupVersion.WithKind("DHCPOptions")
+
+// Get takes name of the dHCPOptions, and returns the corresponding dHCPOptions object, and an error if there is any.
+func (c *FakeDHCPOptions) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.DHCPOptions, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(dhcpoptionsResource, name), &v1.DHCPOptions{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.DHCPOptions), err
+}
+
+// List takes label and field selectors, and returns the list of DHCPOptions that match those selectors.
+func (c *FakeDHCPOptions) List(ctx context.Context, opts metav1.ListOptions) (result *v1.DHCPOptionsList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(dhcpoptionsResource, dhcpoptionsKind, opts), &v1.DHCPOptionsList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.DHCPOptionsList{ListMeta: obj.(*v1.DHCPOptionsList).ListMeta}
+	for _, item := range obj.(*v1.DHCPOptionsList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested dHCPOptions.
+func (c *FakeDHCPOptions) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(dhcpoptionsResource, opts))
+}
+
+// Create takes the representation of a dHCPOptions and creates it.  Returns the server's representation of the dHCPOptions, and an error, if there is any.
+func (c *FakeDHCPOptions) Create(ctx context.Context, dHCPOptions *v1.DHCPOptions, opts metav1.CreateOptions) (result *v1.DHCPOptions, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(dhcpoptionsResource, dHCPOptions), &v1.DHCPOptions{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.DHCPOptions), err
+}
+
+// Update takes the representation of a dHCPOptions and updates it. Returns the server's representation of the dHCPOptions, and an error, if there is any.
+func (c *FakeDHCPOptions) Update(ctx context.Context, dHCPOptions *v1.DHCPOptions, opts metav1.UpdateOptions) (result *v1.DHCPOptions, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(dhcpoptionsResource, dHCPOptions), &v1.DHCPOptions{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.DHCPOptions), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeDHCPOptions) UpdateStatus(ctx context.Context, dHCPOptions *v1.DHCPOptions, opts metav1.UpdateOptions) (*v1.DHCPOptions, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(dhcpoptionsResource, "status", dHCPOptions), &v1.DHCPOptions{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.DHCPOptions), err
+}
+
+// Delete takes name of the dHCPOptions and deletes it. Returns an error if one occurs.
+func (c *FakeDHCPOptions) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(dhcpoptionsResource, name, opts), &v1.DHCPOptions{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDHCPOptions) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(dhcpoptionsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.DHCPOptionsList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched dHCPOptions.
+func (c *FakeDHCPOptions) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.DHCPOptions, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(dhcpoptionsResource, name, pt, data, subresources...), &v1.DHCPOptions{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.DHCPOptions), err
+}
@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeInterConnections implements InterConnectionInterface
+type FakeInterConnections struct {
+	Fake *FakeKubeovnV1
+}
+
+var interconnectionsResource = v1.SchemeGroupVersion.WithResource("interconnections")
+
+var interconnectionsKind = v1.SchemeGroupVersion.WithKind("InterConnection")
+
+// Get takes name of the interConnection, and returns the corresponding interConnection object, and an error if there is any.
+func (c *FakeInterConnections) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.InterConnection, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(interconnectionsResource, name), &v1.InterConnection{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.InterConnection), err
+}
+
+// List takes label and field selectors, and returns the list of InterConnections that match those selectors.
+func (c *FakeInterConnections) List(ctx context.Context, opts metav1.ListOptions) (result *v1.InterConnectionList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(interconnectionsResource, interconnectionsKind, opts), &v1.InterConnectionList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.InterConnectionList{ListMeta: obj.(*v1.InterConnectionList).ListMeta}
+	for _, item := range obj.(*v1.InterConnectionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested interConnections.
+func (c *FakeInterConnections) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(interconnectionsResource, opts))
+}
+
+// Create takes the representation of a interConnection and creates it.  Returns the server's representation of the interConnection, and an error, if there is any.
+func (c *FakeInterConnections) Create(ctx context.Context, interConnection *v1.InterConnection, opts metav1.CreateOptions) (result *v1.InterConnection, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(interconnectionsResource, interConnection), &v1.InterConnection{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.InterConnection), err
+}
+
+// Update takes the representation of a interConnection and updates it. Returns the server's representation of the interConnection, and an error, if there is any.
+func (c *FakeInterConnections) Update(ctx context.Context, interConnection *v1.InterConnection, opts metav1.UpdateOptions) (result *v1.InterConnection, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(interconnectionsResource, interConnection), &v1.InterConnection{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.InterConnection), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeInterConnections) UpdateStatus(ctx context.Context, interConnection *v1.InterConnection, opts metav1.UpdateOptions) (*v1.InterConnection, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(interconnectionsResource, "status", interConnection), &v1.InterConnection{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.InterConnection), err
+}
+
+// Delete takes name of the interConnection and deletes it. Returns an error if one occurs.
+func (c *FakeInterConnections) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(interconnectionsResource, name, opts), &v1.InterConnection{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeInterConnections) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(interconnectionsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.InterConnectionList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched interConnection.
+func (c *FakeInterConnections) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.InterConnection, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(interconnectionsResource, name, pt, data, subresources...), &v1.InterConnection{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.InterConnection), err
+}
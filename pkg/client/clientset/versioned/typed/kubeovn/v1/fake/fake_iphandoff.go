@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeIPHandoffs implements IPHandoffInterface
+type FakeIPHandoffs struct {
+	Fake *FakeKubeovnV1
+}
+
+var iphandoffsResource = v1.SchemeGroupVersion.WithResource("ip-handoffs")
+
+var iphandoffsKind = v1.SchemeGroupVersion.WithKind("IPHandoff")
+
+// Get takes name of the iPHandoff, and returns the corresponding iPHandoff object, and an error if there is any.
+func (c *FakeIPHandoffs) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.IPHandoff, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(iphandoffsResource, name), &v1.IPHandoff{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.IPHandoff), err
+}
+
+// List takes label and field selectors, and returns the list of IPHandoffs that match those selectors.
+func (c *FakeIPHandoffs) List(ctx context.Context, opts metav1.ListOptions) (result *v1.IPHandoffList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(iphandoffsResource, iphandoffsKind, opts), &v1.IPHandoffList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.IPHandoffList{ListMeta: obj.(*v1.IPHandoffList).ListMeta}
+	for _, item := range obj.(*v1.IPHandoffList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested iPHandoffs.
+func (c *FakeIPHandoffs) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(iphandoffsResource, opts))
+}
+
+// Create takes the representation of a iPHandoff and creates it.  Returns the server's representation of the iPHandoff, and an error, if there is any.
+func (c *FakeIPHandoffs) Create(ctx context.Context, iPHandoff *v1.IPHandoff, opts metav1.CreateOptions) (result *v1.IPHandoff, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(iphandoffsResource, iPHandoff), &v1.IPHandoff{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.IPHandoff), err
+}
+
+// Update takes the representation of a iPHandoff and updates it. Returns the server's representation of the iPHandoff, and an error, if there is any.
+func (c *FakeIPHandoffs) Update(ctx context.Context, iPHandoff *v1.IPHandoff, opts metav1.UpdateOptions) (result *v1.IPHandoff, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(iphandoffsResource, iPHandoff), &v1.IPHandoff{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.IPHandoff), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeIPHandoffs) UpdateStatus(ctx context.Context, iPHandoff *v1.IPHandoff, opts metav1.UpdateOptions) (*v1.IPHandoff, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(iphandoffsResource, "status", iPHandoff), &v1.IPHandoff{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.IPHandoff), err
+}
+
+// Delete takes name of the iPHandoff and deletes it. Returns an error if one occurs.
+func (c *FakeIPHandoffs) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(iphandoffsResource, name, opts), &v1.IPHandoff{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeIPHandoffs) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(iphandoffsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.IPHandoffList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched iPHandoff.
+func (c *FakeIPHandoffs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.IPHandoff, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(iphandoffsResource, name, pt, data, subresources...), &v1.IPHandoff{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.IPHandoff), err
+}
@@ -28,14 +28,34 @@ type FakeKubeovnV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeKubeovnV1) AddressGroups() v1.AddressGroupInterface {
+	return &FakeAddressGroups{c}
+}
+
+func (c *FakeKubeovnV1) BootServers() v1.BootServerInterface {
+	return &FakeBootServers{c}
+}
+
+func (c *FakeKubeovnV1) DHCPOptions() v1.DHCPOptionsInterface {
+	return &FakeDHCPOptions{c}
+}
+
 func (c *FakeKubeovnV1) IPs() v1.IPInterface {
 	return &FakeIPs{c}
 }
 
+func (c *FakeKubeovnV1) IPHandoffs() v1.IPHandoffInterface {
+	return &FakeIPHandoffs{c}
+}
+
 func (c *FakeKubeovnV1) IPPools() v1.IPPoolInterface {
 	return &FakeIPPools{c}
 }
 
+func (c *FakeKubeovnV1) InterConnections() v1.InterConnectionInterface {
+	return &FakeInterConnections{c}
+}
+
 func (c *FakeKubeovnV1) IptablesDnatRules() v1.IptablesDnatRuleInterface {
 	return &FakeIptablesDnatRules{c}
 }
@@ -116,6 +136,10 @@ func (c *FakeKubeovnV1) VpcNatGatewayIpips() v1.VpcNatGatewayIpipInterface {
 	return &FakeVpcNatGatewayIpips{c}
 }
 
+func (c *FakeKubeovnV1) VpcRouteTables() v1.VpcRouteTableInterface {
+	return &FakeVpcRouteTables{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKubeovnV1) RESTClient() rest.Interface {
@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVpcRouteTables implements VpcRouteTableInterface
+type FakeVpcRouteTables struct {
+	Fake *FakeKubeovnV1
+}
+
+var vpcroutetablesResource = v1.SchemeGroupVersion.WithResource("vpc-route-tables")
+
+var vpcroutetablesKind = v1.SchemeGroupVersion.WithKind("VpcRouteTable")
+
+// Get takes name of the vpcRouteTable, and returns the corresponding vpcRouteTable object, and an error if there is any.
+func (c *FakeVpcRouteTables) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.VpcRouteTable, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(vpcroutetablesResource, name), &v1.VpcRouteTable{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.VpcRouteTable), err
+}
+
+// List takes label and field selectors, and returns the list of VpcRouteTables that match those selectors.
+func (c *FakeVpcRouteTables) List(ctx context.Context, opts metav1.ListOptions) (result *v1.VpcRouteTableList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(vpcroutetablesResource, vpcroutetablesKind, opts), &v1.VpcRouteTableList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.VpcRouteTableList{ListMeta: obj.(*v1.VpcRouteTableList).ListMeta}
+	for _, item := range obj.(*v1.VpcRouteTableList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vpcRouteTables.
+func (c *FakeVpcRouteTables) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(vpcroutetablesResource, opts))
+}
+
+// Create takes the representation of a vpcRouteTable and creates it.  Returns the server's representation of the vpcRouteTable, and an error, if there is any.
+func (c *FakeVpcRouteTables) Create(ctx context.Context, vpcRouteTable *v1.VpcRouteTable, opts metav1.CreateOptions) (result *v1.VpcRouteTable, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(vpcroutetablesResource, vpcRouteTable), &v1.VpcRouteTable{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.VpcRouteTable), err
+}
+
+// Update takes the representation of a vpcRouteTable and updates it. Returns the server's representation of the vpcRouteTable, and an error, if there is any.
+func (c *FakeVpcRouteTables) Update(ctx context.Context, vpcRouteTable *v1.VpcRouteTable, opts metav1.UpdateOptions) (result *v1.VpcRouteTable, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(vpcroutetablesResource, vpcRouteTable), &v1.VpcRouteTable{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.VpcRouteTable), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVpcRouteTables) UpdateStatus(ctx context.Context, vpcRouteTable *v1.VpcRouteTable, opts metav1.UpdateOptions) (*v1.VpcRouteTable, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(vpcroutetablesResource, "status", vpcRouteTable), &v1.VpcRouteTable{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.VpcRouteTable), err
+}
+
+// Delete takes name of the vpcRouteTable and deletes it. Returns an error if one occurs.
+func (c *FakeVpcRouteTables) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(vpcroutetablesResource, name, opts), &v1.VpcRouteTable{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVpcRouteTables) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(vpcroutetablesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.VpcRouteTableList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vpcRouteTable.
+func (c *FakeVpcRouteTables) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.VpcRouteTable, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(vpcroutetablesResource, name, pt, data, subresources...), &v1.VpcRouteTable{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.VpcRouteTable), err
+}
@@ -18,10 +18,20 @@ limitations under the License.
 
 package v1
 
+type AddressGroupExpansion interface{}
+
+type BootServerExpansion interface{}
+
+type DHCPOptionsExpansion interface{}
+
 type IPExpansion interface{}
 
+type IPHandoffExpansion interface{}
+
 type IPPoolExpansion interface{}
 
+type InterConnectionExpansion interface{}
+
 type IptablesDnatRuleExpansion interface{}
 
 type IptablesEIPExpansion interface{}
@@ -61,3 +71,5 @@ type VpcDnsExpansion interface{}
 type VpcNatGatewayExpansion interface{}
 
 type VpcNatGatewayIpipExpansion interface{}
+
+type VpcRouteTableExpansion interface{}
@@ -28,8 +28,13 @@ import (
 
 type KubeovnV1Interface interface {
 	RESTClient() rest.Interface
+	AddressGroupsGetter
+	BootServersGetter
+	DHCPOptionsGetter
 	IPsGetter
+	IPHandoffsGetter
 	IPPoolsGetter
+	InterConnectionsGetter
 	IptablesDnatRulesGetter
 	IptablesEIPsGetter
 	IptablesFIPRulesGetter
@@ -50,6 +55,7 @@ type KubeovnV1Interface interface {
 	VpcDnsesGetter
 	VpcNatGatewaysGetter
 	VpcNatGatewayIpipsGetter
+	VpcRouteTablesGetter
 }
 
 // KubeovnV1Client is used to interact with features provided by the kubeovn.io group.
@@ -57,14 +63,34 @@ type KubeovnV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *KubeovnV1Client) AddressGroups() AddressGroupInterface {
+	return newAddressGroups(c)
+}
+
+func (c *KubeovnV1Client) BootServers() BootServerInterface {
+	return newBootServers(c)
+}
+
+func (c *KubeovnV1Client) DHCPOptions() DHCPOptionsInterface {
+	return newDHCPOptions(c)
+}
+
 func (c *KubeovnV1Client) IPs() IPInterface {
 	return newIPs(c)
 }
 
+func (c *KubeovnV1Client) IPHandoffs() IPHandoffInterface {
+	return newIPHandoffs(c)
+}
+
 func (c *KubeovnV1Client) IPPools() IPPoolInterface {
 	return newIPPools(c)
 }
 
+func (c *KubeovnV1Client) InterConnections() InterConnectionInterface {
+	return newInterConnections(c)
+}
+
 func (c *KubeovnV1Client) IptablesDnatRules() IptablesDnatRuleInterface {
 	return newIptablesDnatRules(c)
 }
@@ -145,6 +171,10 @@ func (c *KubeovnV1Client) VpcNatGatewayIpips() VpcNatGatewayIpipInterface {
 	return newVpcNatGatewayIpips(c)
 }
 
+func (c *KubeovnV1Client) VpcRouteTables() VpcRouteTableInterface {
+	return newVpcRouteTables(c)
+}
+
 // NewForConfig creates a new KubeovnV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -53,10 +53,20 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=kubeovn.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("address-groups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().AddressGroups().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("boot-servers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().BootServers().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("dhcp-options"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().DHCPOptions().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("ips"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().IPs().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("ip-handoffs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().IPHandoffs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("ippools"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().IPPools().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("interconnections"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().InterConnections().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("iptables-dnat-rules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().IptablesDnatRules().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("iptables-eips"):
@@ -97,6 +107,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcNatGateways().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("vpcnatgatewayipips"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcNatGatewayIpips().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vpc-route-tables"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcRouteTables().Informer()}, nil
 
 	}
 
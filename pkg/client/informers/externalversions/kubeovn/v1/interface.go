@@ -24,10 +24,20 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// AddressGroups returns a AddressGroupInformer.
+	AddressGroups() AddressGroupInformer
+	// BootServers returns a BootServerInformer.
+	BootServers() BootServerInformer
+	// DHCPOptions returns a DHCPOptionsInformer.
+	DHCPOptions() DHCPOptionsInformer
 	// IPs returns a IPInformer.
 	IPs() IPInformer
+	// IPHandoffs returns a IPHandoffInformer.
+	IPHandoffs() IPHandoffInformer
 	// IPPools returns a IPPoolInformer.
 	IPPools() IPPoolInformer
+	// InterConnections returns a InterConnectionInformer.
+	InterConnections() InterConnectionInformer
 	// IptablesDnatRules returns a IptablesDnatRuleInformer.
 	IptablesDnatRules() IptablesDnatRuleInformer
 	// IptablesEIPs returns a IptablesEIPInformer.
@@ -68,6 +78,8 @@ type Interface interface {
 	VpcNatGateways() VpcNatGatewayInformer
 	// VpcNatGatewayIpips returns a VpcNatGatewayIpipInformer.
 	VpcNatGatewayIpips() VpcNatGatewayIpipInformer
+	// VpcRouteTables returns a VpcRouteTableInformer.
+	VpcRouteTables() VpcRouteTableInformer
 }
 
 type version struct {
@@ -81,16 +93,41 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AddressGroups returns a AddressGroupInformer.
+func (v *version) AddressGroups() AddressGroupInformer {
+	return &addressGroupInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// BootServers returns a BootServerInformer.
+func (v *version) BootServers() BootServerInformer {
+	return &bootServerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// DHCPOptions returns a DHCPOptionsInformer.
+func (v *version) DHCPOptions() DHCPOptionsInformer {
+	return &dHCPOptionsInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IPs returns a IPInformer.
 func (v *version) IPs() IPInformer {
 	return &iPInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// IPHandoffs returns a IPHandoffInformer.
+func (v *version) IPHandoffs() IPHandoffInformer {
+	return &iPHandoffInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IPPools returns a IPPoolInformer.
 func (v *version) IPPools() IPPoolInformer {
 	return &iPPoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// InterConnections returns a InterConnectionInformer.
+func (v *version) InterConnections() InterConnectionInformer {
+	return &interConnectionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IptablesDnatRules returns a IptablesDnatRuleInformer.
 func (v *version) IptablesDnatRules() IptablesDnatRuleInformer {
 	return &iptablesDnatRuleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -190,3 +227,8 @@ func (v *version) VpcNatGateways() VpcNatGatewayInformer {
 func (v *version) VpcNatGatewayIpips() VpcNatGatewayIpipInformer {
 	return &vpcNatGatewayIpipInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// VpcRouteTables returns a VpcRouteTableInformer.
+func (v *version) VpcRouteTables() VpcRouteTableInformer {
+	return &vpcRouteTableInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -18,14 +18,34 @@ limitations under the License.
 
 package v1
 
+// AddressGroupListerExpansion allows custom methods to be added to
+// AddressGroupLister.
+type AddressGroupListerExpansion interface{}
+
+// BootServerListerExpansion allows custom methods to be added to
+// BootServerLister.
+type BootServerListerExpansion interface{}
+
+// DHCPOptionsListerExpansion allows custom methods to be added to
+// DHCPOptionsLister.
+type DHCPOptionsListerExpansion interface{}
+
 // IPListerExpansion allows custom methods to be added to
 // IPLister.
 type IPListerExpansion interface{}
 
+// IPHandoffListerExpansion allows custom methods to be added to
+// IPHandoffLister.
+type IPHandoffListerExpansion interface{}
+
 // IPPoolListerExpansion allows custom methods to be added to
 // IPPoolLister.
 type IPPoolListerExpansion interface{}
 
+// InterConnectionListerExpansion allows custom methods to be added to
+// InterConnectionLister.
+type InterConnectionListerExpansion interface{}
+
 // IptablesDnatRuleListerExpansion allows custom methods to be added to
 // IptablesDnatRuleLister.
 type IptablesDnatRuleListerExpansion interface{}
@@ -105,3 +125,7 @@ type VpcNatGatewayListerExpansion interface{}
 // VpcNatGatewayIpipListerExpansion allows custom methods to be added to
 // VpcNatGatewayIpipLister.
 type VpcNatGatewayIpipListerExpansion interface{}
+
+// VpcRouteTableListerExpansion allows custom methods to be added to
+// VpcRouteTableLister.
+type VpcRouteTableListerExpansion interface{}
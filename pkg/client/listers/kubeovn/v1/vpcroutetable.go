@@ -0,0 +1,68 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VpcRouteTableLister helps list VpcRouteTables.
+// All objects returned here must be treated as read-only.
+type VpcRouteTableLister interface {
+	// List lists all VpcRouteTables in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.VpcRouteTable, err error)
+	// Get retrieves the VpcRouteTable from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.VpcRouteTable, error)
+	VpcRouteTableListerExpansion
+}
+
+// vpcRouteTableLister implements the VpcRouteTableLister interface.
+type vpcRouteTableLister struct {
+	indexer cache.Indexer
+}
+
+// NewVpcRouteTableLister returns a new VpcRouteTableLister.
+func NewVpcRouteTableLister(indexer cache.Indexer) VpcRouteTableLister {
+	return &vpcRouteTableLister{indexer: indexer}
+}
+
+// List lists all VpcRouteTables in the indexer.
+func (s *vpcRouteTableLister) List(selector labels.Selector) (ret []*v1.VpcRouteTable, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.VpcRouteTable))
+	})
+	return ret, err
+}
+
+// Get retrieves the VpcRouteTable from the index for a given name.
+func (s *vpcRouteTableLister) Get(name string) (*v1.VpcRouteTable, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("vpcroutetable"), name)
+	}
+	return obj.(*v1.VpcRouteTable), nil
+}
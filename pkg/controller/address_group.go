@@ -0,0 +1,244 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func (c *Controller) enqueueAddAddressGroup(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add address group %s", key)
+	c.addOrUpdateAddressGroupQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateAddressGroup(oldObj, newObj interface{}) {
+	oldAg := oldObj.(*kubeovnv1.AddressGroup)
+	newAg := newObj.(*kubeovnv1.AddressGroup)
+	if !reflect.DeepEqual(oldAg.Spec, newAg.Spec) {
+		var key string
+		var err error
+		if key, err = cache.MetaNamespaceKeyFunc(newObj); err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+		klog.V(3).Infof("enqueue update address group %s", key)
+		c.addOrUpdateAddressGroupQueue.Add(key)
+	}
+}
+
+func (c *Controller) enqueueDeleteAddressGroup(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue delete address group %s", key)
+	c.delAddressGroupQueue.Add(key)
+}
+
+func (c *Controller) runAddAddressGroupWorker() {
+	for c.processNextAddOrUpdateAddressGroupWorkItem() {
+	}
+}
+
+func (c *Controller) runDelAddressGroupWorker() {
+	for c.processNextDeleteAddressGroupWorkItem() {
+	}
+}
+
+func (c *Controller) processNextAddOrUpdateAddressGroupWorkItem() bool {
+	obj, shutdown := c.addOrUpdateAddressGroupQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.addOrUpdateAddressGroupQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.addOrUpdateAddressGroupQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleAddOrUpdateAddressGroup(key); err != nil {
+			c.addOrUpdateAddressGroupQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		c.addOrUpdateAddressGroupQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) processNextDeleteAddressGroupWorkItem() bool {
+	obj, shutdown := c.delAddressGroupQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.delAddressGroupQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.delAddressGroupQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleDeleteAddressGroup(key); err != nil {
+			c.delAddressGroupQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		c.delAddressGroupQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+// splitAddressGroupAddresses validates the addresses of an address group and splits them by ip family
+func splitAddressGroupAddresses(addresses []string) (v4, v6 []string, err error) {
+	for _, address := range addresses {
+		if strings.Contains(address, "/") {
+			if _, _, err = net.ParseCIDR(address); err != nil {
+				return nil, nil, fmt.Errorf("invalid CIDR '%s'", address)
+			}
+		} else if net.ParseIP(address) == nil {
+			return nil, nil, fmt.Errorf("invalid ip address '%s'", address)
+		}
+
+		if util.CheckProtocol(address) == kubeovnv1.ProtocolIPv4 {
+			v4 = append(v4, address)
+		} else {
+			v6 = append(v6, address)
+		}
+	}
+	return v4, v6, nil
+}
+
+func (c *Controller) handleAddOrUpdateAddressGroup(key string) error {
+	cachedAg, err := c.addressGroupsLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	ag := cachedAg.DeepCopy()
+	klog.Infof("handle add/update address group %s", key)
+
+	v4AsName := ovs.GetAddressGroupAsName(ag.Name, kubeovnv1.ProtocolIPv4)
+	v6AsName := ovs.GetAddressGroupAsName(ag.Name, kubeovnv1.ProtocolIPv6)
+	ag.Status.V4AddressSet = v4AsName
+	ag.Status.V6AddressSet = v6AsName
+
+	v4, v6, err := splitAddressGroupAddresses(ag.Spec.Addresses)
+	if err != nil {
+		klog.Errorf("failed to validate address group %s: %v", key, err)
+		ag.Status.Ready = false
+		ag.Status.Error = err.Error()
+		c.patchAddressGroupStatus(ag)
+		// no need to retry until the spec is corrected
+		return nil
+	}
+
+	externalIDs := map[string]string{addressGroupKey: ag.Name}
+	for asName, addresses := range map[string][]string{v4AsName: v4, v6AsName: v6} {
+		if err = c.OVNNbClient.CreateAddressSet(asName, externalIDs); err != nil {
+			klog.Errorf("create address set %s for address group %s: %v", asName, key, err)
+			break
+		}
+		if err = c.OVNNbClient.AddressSetUpdateAddress(asName, addresses...); err != nil {
+			klog.Errorf("set addresses of address set %s for address group %s: %v", asName, key, err)
+			break
+		}
+	}
+	if err != nil {
+		ag.Status.Ready = false
+		ag.Status.Error = err.Error()
+		c.patchAddressGroupStatus(ag)
+		return err
+	}
+
+	ag.Status.Ready = true
+	ag.Status.Error = ""
+	c.patchAddressGroupStatus(ag)
+	c.enqueueAddressGroupReferrers(key)
+	return nil
+}
+
+func (c *Controller) handleDeleteAddressGroup(key string) error {
+	klog.Infof("handle delete address group %s", key)
+	if err := c.OVNNbClient.DeleteAddressSets(map[string]string{addressGroupKey: key}); err != nil {
+		klog.Errorf("delete address sets of address group %s: %v", key, err)
+		return err
+	}
+	c.enqueueAddressGroupReferrers(key)
+	return nil
+}
+
+// enqueueAddressGroupReferrers enqueues the security groups whose rules reference the address group,
+// so that the rules failed to be validated before the address group is created get reconciled
+func (c *Controller) enqueueAddressGroupReferrers(agName string) {
+	sgs, err := c.sgsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list security groups: %v", err)
+		return
+	}
+	for _, sg := range sgs {
+		if sgReferencesAddressGroup(sg.Spec.IngressRules, agName) || sgReferencesAddressGroup(sg.Spec.EgressRules, agName) {
+			klog.V(3).Infof("enqueue update securityGroup %s referencing address group %s", sg.Name, agName)
+			c.addOrUpdateSgQueue.Add(sg.Name)
+		}
+	}
+}
+
+func sgReferencesAddressGroup(rules []*kubeovnv1.SgRule, agName string) bool {
+	for _, rule := range rules {
+		if rule.RemoteType == kubeovnv1.SgRemoteTypeAddressGroup && rule.RemoteAddressGroup == agName {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Controller) patchAddressGroupStatus(ag *kubeovnv1.AddressGroup) {
+	bytes, err := ag.Status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().AddressGroups().Patch(context.Background(), ag.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("patch address group %s status failed: %v", ag.Name, err)
+	}
+}
@@ -0,0 +1,804 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/scylladb/go-set/strset"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/network-policy-api/apis/v1alpha1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const (
+	anpConditionReady       = "Ready"
+	anpReasonSetupSucceeded = "SetupSucceeded"
+	anpReasonUnsupported    = "Unsupported"
+)
+
+// anpPolicy is the common representation of admin network policy and baseline admin network policy
+type anpPolicy struct {
+	name        string
+	isBanp      bool
+	priority    int32
+	annotations map[string]string
+	subject     v1alpha1.AdminNetworkPolicySubject
+	ingress     []anpRule
+	egress      []anpRule
+}
+
+type anpRule struct {
+	action string
+	ports  []v1alpha1.AdminNetworkPolicyPort
+	// ingress peers only select namespaces or pods
+	peers []v1alpha1.AdminNetworkPolicyEgressPeer
+}
+
+func newAnpRule(action string, peers []v1alpha1.AdminNetworkPolicyEgressPeer, ports *[]v1alpha1.AdminNetworkPolicyPort) anpRule {
+	rule := anpRule{action: action, peers: peers}
+	if ports != nil {
+		rule.ports = *ports
+	}
+	return rule
+}
+
+func anpIngressPeers(from []v1alpha1.AdminNetworkPolicyIngressPeer) []v1alpha1.AdminNetworkPolicyEgressPeer {
+	peers := make([]v1alpha1.AdminNetworkPolicyEgressPeer, 0, len(from))
+	for _, peer := range from {
+		peers = append(peers, v1alpha1.AdminNetworkPolicyEgressPeer{Namespaces: peer.Namespaces, Pods: peer.Pods})
+	}
+	return peers
+}
+
+func newAnpPolicy(anp *v1alpha1.AdminNetworkPolicy) *anpPolicy {
+	p := &anpPolicy{
+		name:        anp.Name,
+		priority:    anp.Spec.Priority,
+		annotations: anp.Annotations,
+		subject:     anp.Spec.Subject,
+	}
+	for _, rule := range anp.Spec.Ingress {
+		p.ingress = append(p.ingress, newAnpRule(string(rule.Action), anpIngressPeers(rule.From), rule.Ports))
+	}
+	for _, rule := range anp.Spec.Egress {
+		p.egress = append(p.egress, newAnpRule(string(rule.Action), rule.To, rule.Ports))
+	}
+	return p
+}
+
+func newBanpPolicy(banp *v1alpha1.BaselineAdminNetworkPolicy) *anpPolicy {
+	p := &anpPolicy{
+		name:        banp.Name,
+		isBanp:      true,
+		annotations: banp.Annotations,
+		subject:     banp.Spec.Subject,
+	}
+	for _, rule := range banp.Spec.Ingress {
+		p.ingress = append(p.ingress, newAnpRule(string(rule.Action), anpIngressPeers(rule.From), rule.Ports))
+	}
+	for _, rule := range banp.Spec.Egress {
+		p.egress = append(p.egress, newAnpRule(string(rule.Action), rule.To, rule.Ports))
+	}
+	return p
+}
+
+func (p *anpPolicy) kind() string {
+	if p.isBanp {
+		return banpKey
+	}
+	return anpKey
+}
+
+func (p *anpPolicy) key() string {
+	return fmt.Sprintf("%s/%s", p.kind(), p.name)
+}
+
+// pgName return the port group name of the policy, the underscore avoids conflicts with network policy port groups.
+// ovn acl doesn't support address_set name with '-', so replace '-' by '.' like network policy does
+func (p *anpPolicy) pgName() string {
+	return strings.ReplaceAll(fmt.Sprintf("%s_%s", p.kind(), p.name), "-", ".")
+}
+
+func (p *anpPolicy) asName(direction string, idx int, protocol string) string {
+	return fmt.Sprintf("%s.%s.%d.%s", p.pgName(), direction, idx, protocol)
+}
+
+func (p *anpPolicy) externalIDs() map[string]string {
+	return map[string]string{p.kind(): p.name}
+}
+
+func (p *anpPolicy) rules(isIngress bool) []anpRule {
+	if isIngress {
+		return p.ingress
+	}
+	return p.egress
+}
+
+// aclPriority return the acl priority of the idx-th rule. Admin network policies are placed above network policies
+// and ordered by their priorities, baseline admin network policy is placed between network policies and subnet acls
+func (p *anpPolicy) aclPriority(idx int) int {
+	if p.isBanp {
+		return util.BanpACLMaxPriority - idx
+	}
+	return util.AnpACLMaxPriority - int(p.priority)*util.AnpMaxRules - idx
+}
+
+// aclTier return the acl tier of the policy, acls of admin network policies are evaluated before all other acls
+// and acls of baseline admin network policies share the tier with network policies
+func (p *anpPolicy) aclTier() int {
+	if p.isBanp {
+		return util.DefaultACLTier
+	}
+	return util.AnpACLTier
+}
+
+func (p *anpPolicy) validate() error {
+	if !p.isBanp && p.priority > util.AnpMaxPriority {
+		return fmt.Errorf("priority %d is larger than the max supported priority %d", p.priority, util.AnpMaxPriority)
+	}
+	if len(p.ingress) > util.AnpMaxRules || len(p.egress) > util.AnpMaxRules {
+		return fmt.Errorf("the number of ingress or egress rules is larger than %d", util.AnpMaxRules)
+	}
+	return nil
+}
+
+// aclAction return the acl action of the rule, pass acls hand the traffic over to the tier of network policies
+func (r anpRule) aclAction() ovnnb.ACLAction {
+	switch r.action {
+	case string(v1alpha1.AdminNetworkPolicyRuleActionDeny):
+		return ovnnb.ACLActionDrop
+	case string(v1alpha1.AdminNetworkPolicyRuleActionPass):
+		return ovnnb.ACLActionPass
+	default:
+		return ovnnb.ACLActionAllowRelated
+	}
+}
+
+func (p *anpPolicy) hasNodePeer() bool {
+	for _, rule := range p.egress {
+		for _, peer := range rule.peers {
+			if peer.Nodes != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *anpPolicy) selectsNamespace(ns *corev1.Namespace) bool {
+	if nsSelector, _ := anpSubjectSelectors(p.subject); nsSelector != nil && isLabelSelectorMatch(nsSelector, ns.Labels) {
+		return true
+	}
+	for _, rule := range append(slices.Clone(p.ingress), p.egress...) {
+		for _, peer := range rule.peers {
+			if peer.Namespaces != nil && isLabelSelectorMatch(peer.Namespaces, ns.Labels) {
+				return true
+			}
+			if peer.Pods != nil && isLabelSelectorMatch(&peer.Pods.NamespaceSelector, ns.Labels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *anpPolicy) selectsPod(pod *corev1.Pod, ns *corev1.Namespace) bool {
+	if nsSelector, podSelector := anpSubjectSelectors(p.subject); nsSelector != nil &&
+		isLabelSelectorMatch(nsSelector, ns.Labels) && isLabelSelectorMatch(podSelector, pod.Labels) {
+		return true
+	}
+	for _, rule := range append(slices.Clone(p.ingress), p.egress...) {
+		for _, peer := range rule.peers {
+			if peer.Namespaces != nil && isLabelSelectorMatch(peer.Namespaces, ns.Labels) {
+				return true
+			}
+			if peer.Pods != nil && isLabelSelectorMatch(&peer.Pods.NamespaceSelector, ns.Labels) &&
+				isLabelSelectorMatch(&peer.Pods.PodSelector, pod.Labels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func anpSubjectSelectors(subject v1alpha1.AdminNetworkPolicySubject) (*metav1.LabelSelector, *metav1.LabelSelector) {
+	if subject.Pods != nil {
+		return &subject.Pods.NamespaceSelector, &subject.Pods.PodSelector
+	}
+	return subject.Namespaces, &metav1.LabelSelector{}
+}
+
+func isLabelSelectorMatch(selector *metav1.LabelSelector, lbs map[string]string) bool {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		klog.Errorf("failed to create label selector from %v: %v", selector, err)
+		return false
+	}
+	return sel.Matches(labels.Set(lbs))
+}
+
+func (c *Controller) enqueueAddAnp(obj interface{}) {
+	anp := obj.(*v1alpha1.AdminNetworkPolicy)
+	klog.V(3).Infof("enqueue add anp %s", anp.Name)
+	c.updateAnpQueue.Add(anp.Name)
+}
+
+func (c *Controller) enqueueDeleteAnp(obj interface{}) {
+	var anp *v1alpha1.AdminNetworkPolicy
+	switch t := obj.(type) {
+	case *v1alpha1.AdminNetworkPolicy:
+		anp = t
+	case cache.DeletedFinalStateUnknown:
+		a, ok := t.Obj.(*v1alpha1.AdminNetworkPolicy)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		anp = a
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+	klog.V(3).Infof("enqueue delete anp %s", anp.Name)
+	c.deleteAnpQueue.Add(anp.Name)
+}
+
+func (c *Controller) enqueueUpdateAnp(oldObj, newObj interface{}) {
+	oldAnp := oldObj.(*v1alpha1.AdminNetworkPolicy)
+	newAnp := newObj.(*v1alpha1.AdminNetworkPolicy)
+	if reflect.DeepEqual(oldAnp.Spec, newAnp.Spec) && reflect.DeepEqual(oldAnp.Annotations, newAnp.Annotations) {
+		return
+	}
+
+	klog.V(3).Infof("enqueue update anp %s", newAnp.Name)
+	c.updateAnpQueue.Add(newAnp.Name)
+}
+
+func (c *Controller) enqueueAddBanp(obj interface{}) {
+	banp := obj.(*v1alpha1.BaselineAdminNetworkPolicy)
+	klog.V(3).Infof("enqueue add banp %s", banp.Name)
+	c.updateBanpQueue.Add(banp.Name)
+}
+
+func (c *Controller) enqueueDeleteBanp(obj interface{}) {
+	var banp *v1alpha1.BaselineAdminNetworkPolicy
+	switch t := obj.(type) {
+	case *v1alpha1.BaselineAdminNetworkPolicy:
+		banp = t
+	case cache.DeletedFinalStateUnknown:
+		b, ok := t.Obj.(*v1alpha1.BaselineAdminNetworkPolicy)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		banp = b
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+	klog.V(3).Infof("enqueue delete banp %s", banp.Name)
+	c.deleteBanpQueue.Add(banp.Name)
+}
+
+func (c *Controller) enqueueUpdateBanp(oldObj, newObj interface{}) {
+	oldBanp := oldObj.(*v1alpha1.BaselineAdminNetworkPolicy)
+	newBanp := newObj.(*v1alpha1.BaselineAdminNetworkPolicy)
+	if !reflect.DeepEqual(oldBanp.Spec, newBanp.Spec) || !reflect.DeepEqual(oldBanp.Annotations, newBanp.Annotations) {
+		klog.V(3).Infof("enqueue update banp %s", newBanp.Name)
+		c.updateBanpQueue.Add(newBanp.Name)
+	}
+}
+
+func (c *Controller) listAnpPolicies() []*anpPolicy {
+	anps, err := c.anpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list admin network policies: %v", err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+	banps, err := c.banpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list baseline admin network policies: %v", err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	policies := make([]*anpPolicy, 0, len(anps)+len(banps))
+	for _, anp := range anps {
+		policies = append(policies, newAnpPolicy(anp))
+	}
+	for _, banp := range banps {
+		policies = append(policies, newBanpPolicy(banp))
+	}
+	return policies
+}
+
+func (c *Controller) enqueueAnpPolicy(p *anpPolicy) {
+	klog.V(3).Infof("enqueue update %s", p.key())
+	if p.isBanp {
+		c.updateBanpQueue.Add(p.name)
+	} else {
+		c.updateAnpQueue.Add(p.name)
+	}
+}
+
+// enqueueAnpsByPods enqueue the admin network policies and baseline admin network policies selecting any of the pods
+func (c *Controller) enqueueAnpsByPods(pods ...*corev1.Pod) {
+	policies := c.listAnpPolicies()
+	for _, pod := range pods {
+		ns, err := c.namespacesLister.Get(pod.Namespace)
+		if err != nil {
+			klog.Errorf("failed to get namespace %s: %v", pod.Namespace, err)
+			utilruntime.HandleError(err)
+			continue
+		}
+		for _, p := range policies {
+			if p.selectsPod(pod, ns) {
+				c.enqueueAnpPolicy(p)
+			}
+		}
+	}
+}
+
+// enqueueAnpsByNamespaces enqueue the admin network policies and baseline admin network policies selecting any of the namespaces
+func (c *Controller) enqueueAnpsByNamespaces(nss ...*corev1.Namespace) {
+	policies := c.listAnpPolicies()
+	for _, ns := range nss {
+		for _, p := range policies {
+			if p.selectsNamespace(ns) {
+				c.enqueueAnpPolicy(p)
+			}
+		}
+	}
+}
+
+// enqueueAnpsByNodes enqueue the admin network policies and baseline admin network policies with node peers
+func (c *Controller) enqueueAnpsByNodes() {
+	for _, p := range c.listAnpPolicies() {
+		if p.hasNodePeer() {
+			c.enqueueAnpPolicy(p)
+		}
+	}
+}
+
+func (c *Controller) runUpdateAnpWorker() {
+	for c.processNextAnpWorkItem(c.updateAnpQueue, c.handleUpdateAnp) {
+	}
+}
+
+func (c *Controller) runDeleteAnpWorker() {
+	for c.processNextAnpWorkItem(c.deleteAnpQueue, c.handleDeleteAnp) {
+	}
+}
+
+func (c *Controller) runUpdateBanpWorker() {
+	for c.processNextAnpWorkItem(c.updateBanpQueue, c.handleUpdateBanp) {
+	}
+}
+
+func (c *Controller) runDeleteBanpWorker() {
+	for c.processNextAnpWorkItem(c.deleteBanpQueue, c.handleDeleteBanp) {
+	}
+}
+
+func (c *Controller) processNextAnpWorkItem(queue workqueue.RateLimitingInterface, handler func(key string) error) bool {
+	obj, shutdown := queue.Get()
+
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer queue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			queue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := handler(key); err != nil {
+			queue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		queue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) handleUpdateAnp(key string) error {
+	c.anpKeyMutex.LockKey(anpKey + "/" + key)
+	defer func() { _ = c.anpKeyMutex.UnlockKey(anpKey + "/" + key) }()
+	klog.Infof("handle add/update admin network policy %s", key)
+
+	anp, err := c.anpsLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to get admin network policy %s: %v", key, err)
+		return err
+	}
+
+	p := newAnpPolicy(anp)
+	if validateErr := p.validate(); validateErr != nil {
+		// invalid policy is not retried, its acls are removed and the error is reported by the status
+		klog.Errorf("unsupported admin network policy %s: %v", key, validateErr)
+		if err = c.deleteAnpPolicy(p); err != nil {
+			return err
+		}
+		return c.patchAnpStatusCondition(anp, anpReadyCondition(anp.Generation, validateErr))
+	}
+
+	if err = c.syncAnpPolicy(p); err != nil {
+		return err
+	}
+	return c.patchAnpStatusCondition(anp, anpReadyCondition(anp.Generation, nil))
+}
+
+func (c *Controller) handleDeleteAnp(key string) error {
+	c.anpKeyMutex.LockKey(anpKey + "/" + key)
+	defer func() { _ = c.anpKeyMutex.UnlockKey(anpKey + "/" + key) }()
+	klog.Infof("handle delete admin network policy %s", key)
+
+	if _, err := c.anpsLister.Get(key); err == nil {
+		// the policy has been recreated
+		return nil
+	}
+	return c.deleteAnpPolicy(&anpPolicy{name: key})
+}
+
+func (c *Controller) handleUpdateBanp(key string) error {
+	c.anpKeyMutex.LockKey(banpKey + "/" + key)
+	defer func() { _ = c.anpKeyMutex.UnlockKey(banpKey + "/" + key) }()
+	klog.Infof("handle add/update baseline admin network policy %s", key)
+
+	banp, err := c.banpsLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to get baseline admin network policy %s: %v", key, err)
+		return err
+	}
+
+	p := newBanpPolicy(banp)
+	if validateErr := p.validate(); validateErr != nil {
+		klog.Errorf("unsupported baseline admin network policy %s: %v", key, validateErr)
+		if err = c.deleteAnpPolicy(p); err != nil {
+			return err
+		}
+		return c.patchBanpStatusCondition(banp, anpReadyCondition(banp.Generation, validateErr))
+	}
+
+	if err = c.syncAnpPolicy(p); err != nil {
+		return err
+	}
+	return c.patchBanpStatusCondition(banp, anpReadyCondition(banp.Generation, nil))
+}
+
+func (c *Controller) handleDeleteBanp(key string) error {
+	c.anpKeyMutex.LockKey(banpKey + "/" + key)
+	defer func() { _ = c.anpKeyMutex.UnlockKey(banpKey + "/" + key) }()
+	klog.Infof("handle delete baseline admin network policy %s", key)
+
+	if _, err := c.banpsLister.Get(key); err == nil {
+		return nil
+	}
+	return c.deleteAnpPolicy(&anpPolicy{name: key, isBanp: true})
+}
+
+func (c *Controller) deleteAnpPolicy(p *anpPolicy) error {
+	// acls are removed together with the port group
+	if err := c.OVNNbClient.DeletePortGroup(p.pgName()); err != nil {
+		klog.Errorf("delete port group of %s: %v", p.key(), err)
+		return err
+	}
+	if err := c.OVNNbClient.DeleteAddressSets(p.externalIDs()); err != nil {
+		klog.Errorf("delete address sets of %s: %v", p.key(), err)
+		return err
+	}
+	return nil
+}
+
+// anpReadyCondition return the Ready condition of an admin network policy or baseline admin network policy
+func anpReadyCondition(generation int64, err error) metav1.Condition {
+	if err != nil {
+		return metav1.Condition{
+			Type:               anpConditionReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             anpReasonUnsupported,
+			Message:            err.Error(),
+		}
+	}
+	return metav1.Condition{
+		Type:               anpConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             anpReasonSetupSucceeded,
+		Message:            "acls of the policy have been created",
+	}
+}
+
+func (c *Controller) patchAnpStatusCondition(anp *v1alpha1.AdminNetworkPolicy, condition metav1.Condition) error {
+	status := anp.Status.DeepCopy()
+	if !apimeta.SetStatusCondition(&status.Conditions, condition) {
+		return nil
+	}
+
+	bytes, err := json.Marshal(map[string]v1alpha1.AdminNetworkPolicyStatus{"status": *status})
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.AnpFactoryClient.PolicyV1alpha1().AdminNetworkPolicies().Patch(context.Background(), anp.Name,
+		types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("failed to patch status of admin network policy %s: %v", anp.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) patchBanpStatusCondition(banp *v1alpha1.BaselineAdminNetworkPolicy, condition metav1.Condition) error {
+	status := banp.Status.DeepCopy()
+	if !apimeta.SetStatusCondition(&status.Conditions, condition) {
+		return nil
+	}
+
+	bytes, err := json.Marshal(map[string]v1alpha1.BaselineAdminNetworkPolicyStatus{"status": *status})
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.AnpFactoryClient.PolicyV1alpha1().BaselineAdminNetworkPolicies().Patch(context.Background(), banp.Name,
+		types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("failed to patch status of baseline admin network policy %s: %v", banp.Name, err)
+		return err
+	}
+	return nil
+}
+
+func anpRuleDirection(isIngress bool) (string, string) {
+	if isIngress {
+		return "ingress", ovnnb.ACLDirectionToLport
+	}
+	return "egress", ovnnb.ACLDirectionFromLport
+}
+
+func (c *Controller) syncAnpPolicy(p *anpPolicy) error {
+	pgName := p.pgName()
+	if err := c.OVNNbClient.CreatePortGroup(pgName, p.externalIDs()); err != nil {
+		klog.Errorf("create port group for %s: %v", p.key(), err)
+		return err
+	}
+
+	ports, err := c.fetchAnpSubjectPorts(p.subject)
+	if err != nil {
+		klog.Errorf("fetch ports belongs to %s: %v", p.key(), err)
+		return err
+	}
+	if err = c.OVNNbClient.PortGroupSetPorts(pgName, ports); err != nil {
+		klog.Errorf("failed to set ports of port group %s to %v: %v", pgName, ports, err)
+		return err
+	}
+
+	logEnable := p.annotations[util.NetworkPolicyLogAnnotation] == "true"
+	logActions := []string{ovnnb.ACLActionDrop}
+	if p.annotations[util.ACLActionsLogAnnotation] != "" {
+		logActions = strings.Split(p.annotations[util.ACLActionsLogAnnotation], ",")
+	}
+
+	asNames := strset.New()
+	for _, isIngress := range []bool{true, false} {
+		direction, aclDirection := anpRuleDirection(isIngress)
+		// put clear acl and update acl in a single transaction to imitate update acl
+		ops, err := c.OVNNbClient.DeleteAclsOps(pgName, portGroupKey, aclDirection, nil)
+		if err != nil {
+			klog.Errorf("generate operations that clear %s %s acls: %v", p.key(), direction, err)
+			return err
+		}
+
+		for idx, rule := range p.rules(isIngress) {
+			namedPortMap, err := c.fetchAnpNamedPorts(p, rule, isIngress)
+			if err != nil {
+				klog.Errorf("failed to fetch named ports of %s: %v", p.key(), err)
+				return err
+			}
+
+			for _, protocol := range []string{kubeovnv1.ProtocolIPv4, kubeovnv1.ProtocolIPv6} {
+				asName := p.asName(direction, idx, protocol)
+				addresses, err := c.fetchAnpPeerAddresses(rule.peers, protocol)
+				if err != nil {
+					klog.Errorf("failed to fetch peer addresses of %s: %v", p.key(), err)
+					return err
+				}
+				if err = c.OVNNbClient.CreateAddressSet(asName, p.externalIDs()); err != nil {
+					klog.Errorf("create address set %s for %s: %v", asName, p.key(), err)
+					return err
+				}
+				if err = c.OVNNbClient.AddressSetUpdateAddress(asName, addresses...); err != nil {
+					klog.Errorf("set peer addresses to address set %s: %v", asName, err)
+					return err
+				}
+				asNames.Add(asName)
+
+				action := rule.aclAction()
+				aclName := fmt.Sprintf("%s/%s/%s/%d", p.key(), direction, protocol, idx)
+				ruleOps, err := c.OVNNbClient.UpdateAnpRuleACLOps(pgName, asName, protocol, aclName, p.aclPriority(idx), p.aclTier(), action, rule.ports, isIngress,
+					logEnable && slices.Contains(logActions, action), namedPortMap)
+				if err != nil {
+					klog.Errorf("generate operations that add %s acls to %s: %v", direction, p.key(), err)
+					return err
+				}
+				ops = append(ops, ruleOps...)
+			}
+		}
+
+		if err = c.OVNNbClient.Transact("add-"+direction+"-acls", ops); err != nil {
+			klog.Errorf("failed to add %s acls for %s: %v", direction, p.key(), err)
+			return err
+		}
+	}
+
+	// delete address sets of removed rules
+	addressSets, err := c.OVNNbClient.ListAddressSets(p.externalIDs())
+	if err != nil {
+		klog.Errorf("failed to list address sets of %s: %v", p.key(), err)
+		return err
+	}
+	for _, as := range addressSets {
+		if asNames.Has(as.Name) {
+			continue
+		}
+		if err = c.OVNNbClient.DeleteAddressSet(as.Name); err != nil {
+			klog.Errorf("failed to delete address set %s: %v", as.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Controller) fetchAnpNamespaces(selector *metav1.LabelSelector) ([]string, error) {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("error creating label selector, %v", err)
+	}
+	nss, err := c.namespacesLister.List(sel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ns, %v", err)
+	}
+
+	names := make([]string, 0, len(nss))
+	for _, ns := range nss {
+		names = append(names, ns.Name)
+	}
+	return names, nil
+}
+
+func (c *Controller) fetchAnpSubjectPorts(subject v1alpha1.AdminNetworkPolicySubject) ([]string, error) {
+	nsSelector, podSelector := anpSubjectSelectors(subject)
+	if nsSelector == nil {
+		return nil, nil
+	}
+
+	namespaces, err := c.fetchAnpNamespaces(nsSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []string
+	for _, ns := range namespaces {
+		nsPorts, _, err := c.fetchSelectedPorts(ns, podSelector)
+		if err != nil {
+			return nil, err
+		}
+		ports = append(ports, nsPorts...)
+	}
+	return ports, nil
+}
+
+func (c *Controller) fetchAnpPeerAddresses(peers []v1alpha1.AdminNetworkPolicyEgressPeer, protocol string) ([]string, error) {
+	var addresses []string
+	for _, peer := range peers {
+		if peer.Namespaces != nil || peer.Pods != nil {
+			npp := netv1.NetworkPolicyPeer{NamespaceSelector: peer.Namespaces}
+			if peer.Pods != nil {
+				npp = netv1.NetworkPolicyPeer{NamespaceSelector: &peer.Pods.NamespaceSelector, PodSelector: &peer.Pods.PodSelector}
+			}
+			selected, _, err := c.fetchPolicySelectedAddresses("", protocol, npp)
+			if err != nil {
+				return nil, err
+			}
+			addresses = append(addresses, selected...)
+		}
+
+		if peer.Nodes != nil {
+			sel, err := metav1.LabelSelectorAsSelector(peer.Nodes)
+			if err != nil {
+				return nil, fmt.Errorf("error creating label selector, %v", err)
+			}
+			nodes, err := c.nodesLister.List(sel)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list nodes, %v", err)
+			}
+			for _, node := range nodes {
+				nodeIPv4, nodeIPv6 := util.GetNodeInternalIP(*node)
+				if protocol == kubeovnv1.ProtocolIPv4 && nodeIPv4 != "" {
+					addresses = append(addresses, nodeIPv4)
+				} else if protocol == kubeovnv1.ProtocolIPv6 && nodeIPv6 != "" {
+					addresses = append(addresses, nodeIPv6)
+				}
+			}
+		}
+
+		for _, cidr := range peer.Networks {
+			if util.CheckProtocol(string(cidr)) == protocol {
+				addresses = append(addresses, string(cidr))
+			}
+		}
+	}
+	return addresses, nil
+}
+
+// fetchAnpNamedPorts return the named ports of the destination pods of the rule,
+// which are the subject pods for ingress rules and the peer pods for egress rules
+func (c *Controller) fetchAnpNamedPorts(p *anpPolicy, rule anpRule, isIngress bool) (map[string]*util.NamedPortInfo, error) {
+	if !slices.ContainsFunc(rule.ports, func(port v1alpha1.AdminNetworkPolicyPort) bool { return port.NamedPort != nil }) {
+		return nil, nil
+	}
+
+	var nsSelectors []*metav1.LabelSelector
+	if isIngress {
+		if nsSelector, _ := anpSubjectSelectors(p.subject); nsSelector != nil {
+			nsSelectors = append(nsSelectors, nsSelector)
+		}
+	} else {
+		for _, peer := range rule.peers {
+			if peer.Namespaces != nil {
+				nsSelectors = append(nsSelectors, peer.Namespaces)
+			} else if peer.Pods != nil {
+				nsSelectors = append(nsSelectors, &peer.Pods.NamespaceSelector)
+			}
+		}
+	}
+
+	// a named port is resolved to the first port number found if it is defined differently across namespaces
+	namedPortMap := make(map[string]*util.NamedPortInfo)
+	for _, nsSelector := range nsSelectors {
+		namespaces, err := c.fetchAnpNamespaces(nsSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range namespaces {
+			for name, info := range c.namedPort.GetNamedPortByNs(ns) {
+				if _, ok := namedPortMap[name]; !ok {
+					namedPortMap[name] = info
+				}
+			}
+		}
+	}
+	return namedPortMap, nil
+}
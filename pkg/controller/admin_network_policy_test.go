@@ -0,0 +1,211 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/network-policy-api/apis/v1alpha1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_anpPolicy(t *testing.T) {
+	anp := &v1alpha1.AdminNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-guard"},
+		Spec: v1alpha1.AdminNetworkPolicySpec{
+			Priority: 10,
+			Subject: v1alpha1.AdminNetworkPolicySubject{
+				Namespaces: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+			},
+			Ingress: []v1alpha1.AdminNetworkPolicyIngressRule{{
+				Action: v1alpha1.AdminNetworkPolicyRuleActionPass,
+				From: []v1alpha1.AdminNetworkPolicyIngressPeer{{
+					Pods: &v1alpha1.NamespacedPod{
+						NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}},
+						PodSelector:       metav1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}},
+					},
+				}},
+			}},
+		},
+	}
+
+	p := newAnpPolicy(anp)
+	require.Equal(t, "anp_cluster.guard", p.pgName())
+	require.Equal(t, "anp_cluster.guard.ingress.0.IPv4", p.asName("ingress", 0, "IPv4"))
+	require.Equal(t, map[string]string{anpKey: "cluster-guard"}, p.externalIDs())
+	require.Equal(t, 29000, p.aclPriority(0))
+	require.Equal(t, 28999, p.aclPriority(1))
+	require.Equal(t, util.AnpACLTier, p.aclTier())
+	require.Equal(t, ovnnb.ACLActionPass, p.ingress[0].aclAction())
+	require.NoError(t, p.validate())
+
+	tenantNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{"tenant": "a"}}}
+	monitoringNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Labels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}}}
+	otherNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+	prometheus := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "prometheus", Namespace: "monitoring", Labels: map[string]string{"app": "prometheus"}}}
+	grafana := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "grafana", Namespace: "monitoring", Labels: map[string]string{"app": "grafana"}}}
+
+	require.True(t, p.selectsNamespace(tenantNs))
+	require.True(t, p.selectsNamespace(monitoringNs))
+	require.False(t, p.selectsNamespace(otherNs))
+	require.True(t, p.selectsPod(prometheus, monitoringNs))
+	require.False(t, p.selectsPod(grafana, monitoringNs))
+
+	anp.Spec.Priority = 100
+	require.Error(t, newAnpPolicy(anp).validate())
+
+	banp := newBanpPolicy(&v1alpha1.BaselineAdminNetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	require.Equal(t, "banp_default", banp.pgName())
+	require.Equal(t, 1900, banp.aclPriority(0))
+	require.Equal(t, util.DefaultACLTier, banp.aclTier())
+}
+
+func Test_handleUpdateAnp(t *testing.T) {
+	t.Parallel()
+
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{"tenant": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Labels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}}},
+	}
+	subject := v1alpha1.AdminNetworkPolicySubject{
+		Namespaces: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+	}
+	port := []v1alpha1.AdminNetworkPolicyPort{{PortNumber: &v1alpha1.Port{Protocol: corev1.ProtocolTCP, Port: 53}}}
+
+	t.Run("acls of pass, deny and allow rules", func(t *testing.T) {
+		t.Parallel()
+
+		anp := &v1alpha1.AdminNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Generation: 2},
+			Spec: v1alpha1.AdminNetworkPolicySpec{
+				Priority: 5,
+				Subject:  subject,
+				Ingress: []v1alpha1.AdminNetworkPolicyIngressRule{{
+					Action: v1alpha1.AdminNetworkPolicyRuleActionAllow,
+					From:   []v1alpha1.AdminNetworkPolicyIngressPeer{{Namespaces: &metav1.LabelSelector{MatchLabels: namespaces[1].Labels}}},
+				}},
+				Egress: []v1alpha1.AdminNetworkPolicyEgressRule{{
+					Action: v1alpha1.AdminNetworkPolicyRuleActionPass,
+					To:     []v1alpha1.AdminNetworkPolicyEgressPeer{{Networks: []v1alpha1.CIDR{"10.0.0.0/8", "fd00::/8"}}},
+					Ports:  &port,
+				}, {
+					Action: v1alpha1.AdminNetworkPolicyRuleActionDeny,
+					To:     []v1alpha1.AdminNetworkPolicyEgressPeer{{Networks: []v1alpha1.CIDR{"0.0.0.0/0"}}},
+				}},
+			},
+		}
+		fakeController := newFakeController(t, namespaces[0], namespaces[1], anp)
+		ctrl, mockOvnClient := fakeController.fakeController, fakeController.mockOvnClient
+
+		pgName := "anp_tenant.a"
+		externalIDs := map[string]string{anpKey: anp.Name}
+		mockOvnClient.EXPECT().CreatePortGroup(pgName, externalIDs).Return(nil)
+		mockOvnClient.EXPECT().PortGroupSetPorts(pgName, gomock.Any()).Return(nil)
+		mockOvnClient.EXPECT().DeleteAclsOps(pgName, portGroupKey, ovnnb.ACLDirectionToLport, nil).Return(nil, nil)
+		mockOvnClient.EXPECT().DeleteAclsOps(pgName, portGroupKey, ovnnb.ACLDirectionFromLport, nil).Return(nil, nil)
+		mockOvnClient.EXPECT().CreateAddressSet(gomock.Any(), externalIDs).Return(nil).Times(6)
+		mockOvnClient.EXPECT().AddressSetUpdateAddress(pgName + ".ingress.0.IPv4").Return(nil)
+		mockOvnClient.EXPECT().AddressSetUpdateAddress(pgName + ".ingress.0.IPv6").Return(nil)
+		mockOvnClient.EXPECT().AddressSetUpdateAddress(pgName+".egress.0.IPv4", "10.0.0.0/8").Return(nil)
+		mockOvnClient.EXPECT().AddressSetUpdateAddress(pgName+".egress.0.IPv6", "fd00::/8").Return(nil)
+		mockOvnClient.EXPECT().AddressSetUpdateAddress(pgName+".egress.1.IPv4", "0.0.0.0/0").Return(nil)
+		mockOvnClient.EXPECT().AddressSetUpdateAddress(pgName + ".egress.1.IPv6").Return(nil)
+
+		for _, protocol := range []string{kubeovnv1.ProtocolIPv4, kubeovnv1.ProtocolIPv6} {
+			mockOvnClient.EXPECT().UpdateAnpRuleACLOps(pgName, pgName+".ingress.0."+protocol, protocol, "anp/tenant-a/ingress/"+protocol+"/0",
+				29500, util.AnpACLTier, ovnnb.ACLActionAllowRelated, gomock.Nil(), true, false, gomock.Nil()).Return(nil, nil)
+			mockOvnClient.EXPECT().UpdateAnpRuleACLOps(pgName, pgName+".egress.0."+protocol, protocol, "anp/tenant-a/egress/"+protocol+"/0",
+				29500, util.AnpACLTier, ovnnb.ACLActionPass, port, false, false, gomock.Nil()).Return(nil, nil)
+			mockOvnClient.EXPECT().UpdateAnpRuleACLOps(pgName, pgName+".egress.1."+protocol, protocol, "anp/tenant-a/egress/"+protocol+"/1",
+				29499, util.AnpACLTier, ovnnb.ACLActionDrop, gomock.Nil(), false, false, gomock.Nil()).Return(nil, nil)
+		}
+		mockOvnClient.EXPECT().Transact("add-ingress-acls", gomock.Any()).Return(nil)
+		mockOvnClient.EXPECT().Transact("add-egress-acls", gomock.Any()).Return(nil)
+		mockOvnClient.EXPECT().ListAddressSets(externalIDs).Return([]ovnnb.AddressSet{{Name: pgName + ".egress.2.IPv4"}}, nil)
+		mockOvnClient.EXPECT().DeleteAddressSet(pgName + ".egress.2.IPv4").Return(nil)
+
+		require.NoError(t, ctrl.handleUpdateAnp(anp.Name))
+
+		anp, err := ctrl.config.AnpFactoryClient.PolicyV1alpha1().AdminNetworkPolicies().Get(context.Background(), anp.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		cond := apimeta.FindStatusCondition(anp.Status.Conditions, anpConditionReady)
+		require.NotNil(t, cond)
+		require.Equal(t, metav1.ConditionTrue, cond.Status)
+		require.Equal(t, int64(2), cond.ObservedGeneration)
+	})
+
+	t.Run("unsupported priority", func(t *testing.T) {
+		t.Parallel()
+
+		anp := &v1alpha1.AdminNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "low-priority"},
+			Spec:       v1alpha1.AdminNetworkPolicySpec{Priority: 500, Subject: subject},
+		}
+		fakeController := newFakeController(t, namespaces[0], namespaces[1], anp)
+		ctrl, mockOvnClient := fakeController.fakeController, fakeController.mockOvnClient
+
+		mockOvnClient.EXPECT().DeletePortGroup("anp_low.priority").Return(nil)
+		mockOvnClient.EXPECT().DeleteAddressSets(map[string]string{anpKey: anp.Name}).Return(nil)
+
+		require.NoError(t, ctrl.handleUpdateAnp(anp.Name))
+
+		anp, err := ctrl.config.AnpFactoryClient.PolicyV1alpha1().AdminNetworkPolicies().Get(context.Background(), anp.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		cond := apimeta.FindStatusCondition(anp.Status.Conditions, anpConditionReady)
+		require.NotNil(t, cond)
+		require.Equal(t, metav1.ConditionFalse, cond.Status)
+		require.Equal(t, anpReasonUnsupported, cond.Reason)
+		require.Contains(t, cond.Message, "priority 500")
+	})
+}
+
+func Test_enqueueDeleteAnp(t *testing.T) {
+	t.Parallel()
+
+	ctrl := newFakeController(t).fakeController
+	anp := &v1alpha1.AdminNetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "anp"}}
+	banp := &v1alpha1.BaselineAdminNetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+
+	ctrl.enqueueDeleteAnp(anp)
+	ctrl.enqueueDeleteAnp(cache.DeletedFinalStateUnknown{Key: "tombstone", Obj: &v1alpha1.AdminNetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "tombstone"}}})
+	ctrl.enqueueDeleteAnp(cache.DeletedFinalStateUnknown{Key: "default", Obj: banp})
+	require.Equal(t, 2, ctrl.deleteAnpQueue.Len())
+
+	ctrl.enqueueDeleteBanp(banp)
+	ctrl.enqueueDeleteBanp(cache.DeletedFinalStateUnknown{Key: "anp", Obj: anp})
+	require.Equal(t, 1, ctrl.deleteBanpQueue.Len())
+	item, _ := ctrl.deleteBanpQueue.Get()
+	require.Equal(t, "default", item)
+}
+
+func Test_enqueueAnpsByPods(t *testing.T) {
+	t.Parallel()
+
+	ctrl := newFakeController(t,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{"tenant": "a"}}},
+		&v1alpha1.AdminNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"},
+			Spec: v1alpha1.AdminNetworkPolicySpec{
+				Priority: 5,
+				Subject:  v1alpha1.AdminNetworkPolicySubject{Namespaces: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}}},
+			},
+		},
+	).fakeController
+
+	// the pod in the namespace not found does not stop the following pods from being handled
+	ctrl.enqueueAnpsByPods(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "not-found"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "a"}},
+	)
+	require.Equal(t, 1, ctrl.updateAnpQueue.Len())
+	item, _ := ctrl.updateAnpQueue.Get()
+	require.Equal(t, "tenant-a", item)
+}
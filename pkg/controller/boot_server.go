@@ -0,0 +1,354 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const (
+	bootServerTFTPContainerName = "tftp"
+	bootServerHTTPContainerName = "http"
+	bootServerVolumeName        = "boot-files"
+	bootServerRootDir           = "/srv/boot"
+	bootServerDefaultHTTPPort   = 80
+)
+
+func genBootServerDpName(name string) string {
+	return fmt.Sprintf("boot-server-%s", name)
+}
+
+func (c *Controller) enqueueAddBootServer(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add boot server %s", key)
+	c.addOrUpdateBootServerQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateBootServer(oldObj, newObj interface{}) {
+	oldBootServer := oldObj.(*kubeovnv1.BootServer)
+	newBootServer := newObj.(*kubeovnv1.BootServer)
+	if reflect.DeepEqual(oldBootServer.Spec, newBootServer.Spec) {
+		return
+	}
+
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(newObj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue update boot server %s", key)
+	c.addOrUpdateBootServerQueue.Add(key)
+}
+
+func (c *Controller) enqueueDelBootServer(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue delete boot server %s", key)
+	c.delBootServerQueue.Add(key)
+}
+
+// enqueueBootServerOfDeployment enqueues the boot server running the deployment to update its status
+func (c *Controller) enqueueBootServerOfDeployment(obj interface{}) {
+	var dp *appsv1.Deployment
+	switch t := obj.(type) {
+	case *appsv1.Deployment:
+		dp = t
+	case cache.DeletedFinalStateUnknown:
+		d, ok := t.Obj.(*appsv1.Deployment)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		dp = d
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+	if name := dp.Labels[util.BootServerNameLabel]; name != "" {
+		klog.V(3).Infof("enqueue boot server %s of deployment %s", name, dp.Name)
+		c.addOrUpdateBootServerQueue.Add(name)
+	}
+}
+
+func (c *Controller) enqueueUpdateBootServerDeployment(oldObj, newObj interface{}) {
+	oldDp := oldObj.(*appsv1.Deployment)
+	newDp := newObj.(*appsv1.Deployment)
+	if oldDp.Generation == newDp.Generation && reflect.DeepEqual(oldDp.Status, newDp.Status) {
+		return
+	}
+	c.enqueueBootServerOfDeployment(newObj)
+}
+
+func (c *Controller) runAddOrUpdateBootServerWorker() {
+	for c.processNextWorkItem("addOrUpdateBootServer", c.addOrUpdateBootServerQueue, c.handleAddOrUpdateBootServer) {
+	}
+}
+
+func (c *Controller) runDelBootServerWorker() {
+	for c.processNextWorkItem("delBootServer", c.delBootServerQueue, c.handleDelBootServer) {
+	}
+}
+
+func (c *Controller) handleAddOrUpdateBootServer(key string) error {
+	klog.Infof("handle add/update boot server %s", key)
+	cachedBootServer, err := c.bootServersLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+
+	bootServer := cachedBootServer.DeepCopy()
+	if err = c.createOrUpdateBootServerDep(bootServer); err != nil {
+		bootServer.Status.Active = false
+		bootServer.Status.Message = err.Error()
+	} else {
+		// the status is updated again by the events of the deployment
+		bootServer.Status.Active, bootServer.Status.Message = c.bootServerDeploymentAvailable(bootServer)
+	}
+	if !reflect.DeepEqual(cachedBootServer.Status, bootServer.Status) {
+		if patchErr := c.patchBootServerStatus(bootServer); patchErr != nil && err == nil {
+			return patchErr
+		}
+	}
+	return err
+}
+
+// bootServerDeploymentAvailable returns whether the deployment of the boot server is available,
+// and the reason if it is not
+func (c *Controller) bootServerDeploymentAvailable(bootServer *kubeovnv1.BootServer) (bool, string) {
+	name := genBootServerDpName(bootServer.Name)
+	dp, err := c.bootServerDeploymentsLister.Deployments(c.config.PodNamespace).Get(name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get deployment %s: %v", name, err)
+		}
+		return false, fmt.Sprintf("deployment %s is not created", name)
+	}
+	if dp.Status.ObservedGeneration < dp.Generation {
+		return false, fmt.Sprintf("deployment %s is being rolled out", name)
+	}
+	for _, condition := range dp.Status.Conditions {
+		if condition.Type != appsv1.DeploymentAvailable {
+			continue
+		}
+		if condition.Status == corev1.ConditionTrue && dp.Status.AvailableReplicas != 0 {
+			return true, ""
+		}
+		return false, fmt.Sprintf("deployment %s is not available: %s", name, condition.Message)
+	}
+	return false, fmt.Sprintf("deployment %s is not available", name)
+}
+
+func (c *Controller) patchBootServerStatus(bootServer *kubeovnv1.BootServer) error {
+	bytes, err := bootServer.Status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().BootServers().Patch(context.Background(), bootServer.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("failed to patch status of boot server %s: %v", bootServer.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) handleDelBootServer(key string) error {
+	klog.Infof("handle delete boot server %s", key)
+	name := genBootServerDpName(key)
+	if err := c.config.KubeClient.AppsV1().Deployments(c.config.PodNamespace).Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to delete boot server deployment %s: %v", name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) createOrUpdateBootServerDep(bootServer *kubeovnv1.BootServer) error {
+	subnet, err := c.subnetsLister.Get(bootServer.Spec.Subnet)
+	if err != nil {
+		err = fmt.Errorf("failed to get subnet %s: %w", bootServer.Spec.Subnet, err)
+		klog.Error(err)
+		return err
+	}
+	if util.CheckProtocol(bootServer.Spec.IP) != kubeovnv1.ProtocolIPv4 {
+		err = fmt.Errorf("ip %q of boot server %s is not a valid ipv4 address", bootServer.Spec.IP, bootServer.Name)
+		klog.Error(err)
+		return err
+	}
+	if !util.CIDRContainIP(subnet.Spec.CIDRBlock, bootServer.Spec.IP) {
+		err = fmt.Errorf("ip %s of boot server %s is not in the range of subnet %s", bootServer.Spec.IP, bootServer.Name, subnet.Name)
+		klog.Error(err)
+		return err
+	}
+	if bootServer.Spec.ConfigMap != "" && bootServer.Spec.PersistentVolumeClaim != "" {
+		err = fmt.Errorf("configMap and persistentVolumeClaim of boot server %s are mutually exclusive", bootServer.Name)
+		klog.Error(err)
+		return err
+	}
+
+	image := bootServer.Spec.Image
+	if image == "" {
+		cm, err := c.configMapsLister.ConfigMaps(c.config.PodNamespace).Get(util.BootServerConfig)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get configmap %s: %v", util.BootServerConfig, err)
+			return err
+		}
+		if cm != nil {
+			image = cm.Data["image"]
+		}
+		if image == "" {
+			err = fmt.Errorf("image of boot server %s is not set in the spec or configmap %s", bootServer.Name, util.BootServerConfig)
+			klog.Error(err)
+			return err
+		}
+	}
+
+	newDp := genBootServerDeployment(bootServer, image)
+	oldDp, err := c.config.KubeClient.AppsV1().Deployments(c.config.PodNamespace).Get(context.Background(), newDp.Name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get deployment %s: %v", newDp.Name, err)
+			return err
+		}
+		if _, err = c.config.KubeClient.AppsV1().Deployments(c.config.PodNamespace).Create(context.Background(), newDp, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create deployment %s: %v", newDp.Name, err)
+			return err
+		}
+		return nil
+	}
+
+	newDp.ResourceVersion = oldDp.ResourceVersion
+	if _, err = c.config.KubeClient.AppsV1().Deployments(c.config.PodNamespace).Update(context.Background(), newDp, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("failed to update deployment %s: %v", newDp.Name, err)
+		return err
+	}
+	return nil
+}
+
+// genBootServerDeployment generates the deployment running dnsmasq as the TFTP server and busybox httpd
+// as the HTTP server, both serving the boot files from the same directory on the static ip of the boot server
+func genBootServerDeployment(bootServer *kubeovnv1.BootServer, image string) *appsv1.Deployment {
+	httpPort := bootServer.Spec.HTTPPort
+	if httpPort == 0 {
+		httpPort = bootServerDefaultHTTPPort
+	}
+
+	volume := corev1.Volume{Name: bootServerVolumeName}
+	switch {
+	case bootServer.Spec.ConfigMap != "":
+		volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: bootServer.Spec.ConfigMap},
+		}
+	case bootServer.Spec.PersistentVolumeClaim != "":
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: bootServer.Spec.PersistentVolumeClaim,
+			ReadOnly:  true,
+		}
+	default:
+		volume.EmptyDir = &corev1.EmptyDirVolumeSource{}
+	}
+	volumeMounts := []corev1.VolumeMount{{Name: bootServerVolumeName, MountPath: bootServerRootDir, ReadOnly: true}}
+
+	podLabels := map[string]string{util.BootServerNameLabel: bootServer.Name}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   genBootServerDpName(bootServer.Name),
+			Labels: podLabels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			// the static ip can only be used by one pod at a time
+			Replicas: ptr.To(int32(1)),
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels,
+					Annotations: map[string]string{
+						util.LogicalSwitchAnnotation: bootServer.Spec.Subnet,
+						util.IPAddressAnnotation:     bootServer.Spec.IP,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:            bootServerTFTPContainerName,
+						Image:           image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command: []string{
+							"dnsmasq", "--keep-in-foreground", "--log-facility=-", "--user=root",
+							"--port=0", "--enable-tftp", "--tftp-root=" + bootServerRootDir,
+						},
+						Ports:        []corev1.ContainerPort{{Name: "tftp", ContainerPort: 69, Protocol: corev1.ProtocolUDP}},
+						VolumeMounts: volumeMounts,
+					}, {
+						Name:            bootServerHTTPContainerName,
+						Image:           image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"httpd", "-f", "-v", "-p", strconv.Itoa(int(httpPort)), "-h", bootServerRootDir},
+						Ports:           []corev1.ContainerPort{{Name: "http", ContainerPort: httpPort, Protocol: corev1.ProtocolTCP}},
+						VolumeMounts:    volumeMounts,
+					}},
+					Volumes: []corev1.Volume{volume},
+				},
+			},
+		},
+	}
+}
+
+func (c *Controller) gcBootServer() error {
+	klog.Info("start to gc boot server")
+	bootServers, err := c.bootServersLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list boot servers: %v", err)
+		return err
+	}
+	names := make(map[string]bool, len(bootServers))
+	for _, bootServer := range bootServers {
+		names[genBootServerDpName(bootServer.Name)] = true
+	}
+
+	deps, err := c.config.KubeClient.AppsV1().Deployments(c.config.PodNamespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: util.BootServerNameLabel,
+	})
+	if err != nil {
+		klog.Errorf("failed to list boot server deployments: %v", err)
+		return err
+	}
+	for _, dep := range deps.Items {
+		if names[dep.Name] {
+			continue
+		}
+		klog.Infof("gc boot server deployment %s", dep.Name)
+		if err = c.config.KubeClient.AppsV1().Deployments(c.config.PodNamespace).Delete(context.Background(), dep.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to delete boot server deployment %s: %v", dep.Name, err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	kubeovnfake "github.com/kubeovn/kube-ovn/pkg/client/clientset/versioned/fake"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_genBootServerDeployment(t *testing.T) {
+	t.Parallel()
+
+	bootServer := &kubeovnv1.BootServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "pxe"},
+		Spec:       kubeovnv1.BootServerSpec{Subnet: "vpc1-subnet", IP: "10.0.1.10", ConfigMap: "pxe-files"},
+	}
+	dep := genBootServerDeployment(bootServer, "boot-server:v1")
+	require.Equal(t, "boot-server-pxe", dep.Name)
+	require.EqualValues(t, 1, *dep.Spec.Replicas)
+	require.Equal(t, "vpc1-subnet", dep.Spec.Template.Annotations[util.LogicalSwitchAnnotation])
+	require.Equal(t, "10.0.1.10", dep.Spec.Template.Annotations[util.IPAddressAnnotation])
+	require.Equal(t, "pxe", dep.Spec.Selector.MatchLabels[util.BootServerNameLabel])
+	require.Equal(t, "pxe-files", dep.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+
+	containers := dep.Spec.Template.Spec.Containers
+	require.Len(t, containers, 2)
+	require.Contains(t, containers[0].Command, "--tftp-root="+bootServerRootDir)
+	require.Equal(t, []string{"httpd", "-f", "-v", "-p", "80", "-h", bootServerRootDir}, containers[1].Command)
+
+	bootServer.Spec.ConfigMap = ""
+	bootServer.Spec.PersistentVolumeClaim = "pxe-pvc"
+	bootServer.Spec.HTTPPort = 8080
+	dep = genBootServerDeployment(bootServer, "boot-server:v1")
+	require.Equal(t, "pxe-pvc", dep.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	require.EqualValues(t, 8080, dep.Spec.Template.Spec.Containers[1].Ports[0].ContainerPort)
+}
+
+func newBootServerTestDeployment(generation, observedGeneration int64, available corev1.ConditionStatus, availableReplicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "boot-server-pxe",
+			Namespace:  "kube-system",
+			Generation: generation,
+			Labels:     map[string]string{util.BootServerNameLabel: "pxe"},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: observedGeneration,
+			AvailableReplicas:  availableReplicas,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentAvailable,
+				Status:  available,
+				Message: "Deployment does not have minimum availability.",
+			}},
+		},
+	}
+}
+
+func Test_bootServerDeploymentAvailable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		active     bool
+		message    string
+	}{
+		{"not created", nil, false, "deployment boot-server-pxe is not created"},
+		{"available", newBootServerTestDeployment(2, 2, corev1.ConditionTrue, 1), true, ""},
+		{"rolling out", newBootServerTestDeployment(2, 1, corev1.ConditionTrue, 1), false, "deployment boot-server-pxe is being rolled out"},
+		{"unavailable", newBootServerTestDeployment(1, 1, corev1.ConditionFalse, 0), false, "deployment boot-server-pxe is not available: Deployment does not have minimum availability."},
+		{"no condition", &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "boot-server-pxe", Namespace: "kube-system"}}, false, "deployment boot-server-pxe is not available"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if tt.deployment != nil {
+				require.NoError(t, indexer.Add(tt.deployment))
+			}
+			c := &Controller{
+				config:                      &Configuration{PodNamespace: "kube-system"},
+				bootServerDeploymentsLister: appslisters.NewDeploymentLister(indexer),
+			}
+
+			active, message := c.bootServerDeploymentAvailable(&kubeovnv1.BootServer{ObjectMeta: metav1.ObjectMeta{Name: "pxe"}})
+			require.Equal(t, tt.active, active)
+			require.Equal(t, tt.message, message)
+		})
+	}
+}
+
+func Test_enqueueUpdateBootServerDeployment(t *testing.T) {
+	t.Parallel()
+
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "")
+	defer queue.ShutDown()
+	c := &Controller{addOrUpdateBootServerQueue: queue}
+
+	dp := newBootServerTestDeployment(1, 1, corev1.ConditionFalse, 0)
+	c.enqueueUpdateBootServerDeployment(dp, dp.DeepCopy())
+	require.Zero(t, queue.Len())
+
+	c.enqueueUpdateBootServerDeployment(dp, newBootServerTestDeployment(1, 1, corev1.ConditionTrue, 1))
+	require.Equal(t, 1, queue.Len())
+	key, _ := queue.Get()
+	require.Equal(t, "pxe", key)
+	queue.Done(key)
+
+	// deployments not created for boot servers are ignored
+	c.enqueueBootServerOfDeployment(cache.DeletedFinalStateUnknown{Obj: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "other"}}})
+	require.Zero(t, queue.Len())
+}
+
+func Test_patchBootServerStatus(t *testing.T) {
+	t.Parallel()
+
+	bootServer := &kubeovnv1.BootServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "pxe"},
+		Spec:       kubeovnv1.BootServerSpec{Subnet: "vpc1-subnet", IP: "10.0.1.10"},
+		Status:     kubeovnv1.BootServerStatus{Message: "deployment boot-server-pxe is not available"},
+	}
+	// created with the typed client as the tracker guesses the resource name from the kind
+	client := kubeovnfake.NewSimpleClientset()
+	_, err := client.KubeovnV1().BootServers().Create(context.Background(), bootServer, metav1.CreateOptions{})
+	require.NoError(t, err)
+	c := &Controller{config: &Configuration{KubeOvnClient: client}}
+
+	bootServer = bootServer.DeepCopy()
+	bootServer.Spec.IP = "10.0.1.11"
+	bootServer.Status = kubeovnv1.BootServerStatus{Active: true}
+	require.NoError(t, c.patchBootServerStatus(bootServer))
+
+	got, err := client.KubeovnV1().BootServers().Get(context.Background(), "pxe", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, got.Status.Active)
+	require.Empty(t, got.Status.Message)
+	// only the status is patched
+	require.Equal(t, "10.0.1.10", got.Spec.IP)
+}
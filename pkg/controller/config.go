@@ -13,6 +13,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 	"kubevirt.io/client-go/kubecli"
+	anpclientset "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned"
 
 	clientset "github.com/kubeovn/kube-ovn/pkg/client/clientset/versioned"
 	"github.com/kubeovn/kube-ovn/pkg/util"
@@ -38,6 +39,7 @@ type Configuration struct {
 	// with no timeout
 	KubeFactoryClient    kubernetes.Interface
 	KubeOvnFactoryClient clientset.Interface
+	AnpFactoryClient     anpclientset.Interface
 
 	DefaultLogicalSwitch      string
 	DefaultCIDR               string
@@ -70,6 +72,7 @@ type Configuration struct {
 	EnablePprof     bool
 	SecureServing   bool
 	NodePgProbeTime int
+	FQDNDNSServer   string
 
 	NetworkType             string
 	DefaultProviderName     string
@@ -82,6 +85,8 @@ type Configuration struct {
 
 	EnableLb          bool
 	EnableNP          bool
+	EnableNPACLStats  bool
+	EnableANP         bool
 	EnableEipSnat     bool
 	EnableExternalVpc bool
 	EnableEcmp        bool
@@ -101,6 +106,9 @@ type Configuration struct {
 	BfdMinRx      int
 	BfdDetectMult int
 
+	SubnetExhaustionThreshold      int
+	SubnetExhaustionForecastWindow int
+
 	NodeLocalDNSIP string
 }
 
@@ -145,6 +153,7 @@ func ParseFlags() (*Configuration, error) {
 		argPprofPort       = pflag.Int32("pprof-port", 10660, "The port to get profiling data")
 		argSecureServing   = pflag.Bool("secure-serving", false, "Enable secure serving")
 		argNodePgProbeTime = pflag.Int("nodepg-probe-time", 1, "The probe interval for node port-group, the unit is minute")
+		argFQDNDNSServer   = pflag.String("fqdn-dns-server", "", "The dns server used to resolve domain names in network policy egress fqdn annotations, the first nameserver in /etc/resolv.conf is used if not set")
 
 		argNetworkType             = pflag.String("network-type", util.NetworkTypeGeneve, "The ovn network type")
 		argDefaultProviderName     = pflag.String("default-provider-name", "provider", "The vlan or vxlan type default provider interface name")
@@ -157,6 +166,8 @@ func ParseFlags() (*Configuration, error) {
 		argPodNicType              = pflag.String("pod-nic-type", "veth-pair", "The default pod network nic implementation type")
 		argEnableLb                = pflag.Bool("enable-lb", true, "Enable load balancer")
 		argEnableNP                = pflag.Bool("enable-np", true, "Enable network policy support")
+		argEnableNPACLStats        = pflag.Bool("enable-np-acl-stats", false, "Record the openflow cookies of network policy acls for kube-ovn-cni to export acl hit counters")
+		argEnableANP               = pflag.Bool("enable-anp", false, "Enable admin network policy and baseline admin network policy support")
 		argEnableEipSnat           = pflag.Bool("enable-eip-snat", true, "Enable EIP and SNAT")
 		argEnableExternalVpc       = pflag.Bool("enable-external-vpc", true, "Enable external vpc support")
 		argEnableEcmp              = pflag.Bool("enable-ecmp", false, "Enable ecmp route for centralized subnet")
@@ -176,6 +187,9 @@ func ParseFlags() (*Configuration, error) {
 		argBfdMinTx      = pflag.Int("bfd-min-tx", 100, "This is the minimum interval, in milliseconds, ovn would like to use when transmitting BFD Control packets")
 		argBfdMinRx      = pflag.Int("bfd-min-rx", 100, "This is the minimum interval, in milliseconds, between received BFD Control packets")
 		argBfdDetectMult = pflag.Int("detect-mult", 3, "The negotiated transmit interval, multiplied by this value, provides the Detection Time for the receiving system in Asynchronous mode.")
+
+		argSubnetExhaustionThreshold      = pflag.Int("subnet-exhaustion-threshold", 86400, "Mark the subnet as NearExhaustion when its addresses are estimated to be exhausted within the seconds, default 86400 seconds. If set to 0, the exhaustion forecast will be disabled")
+		argSubnetExhaustionForecastWindow = pflag.Int("subnet-exhaustion-forecast-window", 3600, "The window of the address usage samples to estimate the allocation and release rates, default 3600 seconds")
 	)
 
 	klogFlags := flag.NewFlagSet("klog", flag.ExitOnError)
@@ -240,6 +254,8 @@ func ParseFlags() (*Configuration, error) {
 		PodNicType:                     *argPodNicType,
 		EnableLb:                       *argEnableLb,
 		EnableNP:                       *argEnableNP,
+		EnableNPACLStats:               *argEnableNPACLStats,
+		EnableANP:                      *argEnableANP,
 		EnableEipSnat:                  *argEnableEipSnat,
 		EnableExternalVpc:              *argEnableExternalVpc,
 		ExternalGatewayConfigNS:        *argExternalGatewayConfigNS,
@@ -249,6 +265,7 @@ func ParseFlags() (*Configuration, error) {
 		EnableEcmp:                     *argEnableEcmp,
 		EnableKeepVMIP:                 *argKeepVMIP,
 		NodePgProbeTime:                *argNodePgProbeTime,
+		FQDNDNSServer:                  *argFQDNDNSServer,
 		GCInterval:                     *argGCInterval,
 		InspectInterval:                *argInspectInterval,
 		EnableLbSvc:                    *argEnableLbSvc,
@@ -256,6 +273,8 @@ func ParseFlags() (*Configuration, error) {
 		BfdMinTx:                       *argBfdMinTx,
 		BfdMinRx:                       *argBfdMinRx,
 		BfdDetectMult:                  *argBfdDetectMult,
+		SubnetExhaustionThreshold:      *argSubnetExhaustionThreshold,
+		SubnetExhaustionForecastWindow: *argSubnetExhaustionForecastWindow,
 		NodeLocalDNSIP:                 *argNodeLocalDNSIP,
 	}
 
@@ -389,6 +408,15 @@ func (config *Configuration) initKubeFactoryClient() error {
 	}
 	config.KubeOvnFactoryClient = kubeOvnClient
 
+	if config.EnableANP {
+		anpClient, err := anpclientset.NewForConfig(cfg)
+		if err != nil {
+			klog.Errorf("init admin network policy client failed %v", err)
+			return err
+		}
+		config.AnpFactoryClient = anpClient
+	}
+
 	cfg.ContentType = "application/vnd.kubernetes.protobuf"
 	cfg.AcceptContentTypes = "application/vnd.kubernetes.protobuf,application/json"
 	kubeClient, err := kubernetes.NewForConfig(cfg)
@@ -16,6 +16,7 @@ import (
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	appsv1 "k8s.io/client-go/listers/apps/v1"
 	v1 "k8s.io/client-go/listers/core/v1"
 	netv1 "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/cache"
@@ -23,6 +24,8 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/keymutex"
+	anpinformer "sigs.k8s.io/network-policy-api/pkg/client/informers/externalversions"
+	anplister "sigs.k8s.io/network-policy-api/pkg/client/listers/apis/v1alpha1"
 
 	kubeovninformer "github.com/kubeovn/kube-ovn/pkg/client/informers/externalversions"
 	kubeovnlister "github.com/kubeovn/kube-ovn/pkg/client/listers/kubeovn/v1"
@@ -38,7 +41,10 @@ const (
 	logicalRouterKey      = "lr"
 	portGroupKey          = "pg"
 	networkPolicyKey      = "np"
+	anpKey                = "anp"
+	banpKey               = "banp"
 	sgKey                 = "sg"
+	addressGroupKey       = "address_group"
 	associatedSgKeyPrefix = "associated_sg_"
 	sgsKey                = "security_groups"
 )
@@ -52,6 +58,7 @@ type Controller struct {
 	podSubnetMap *sync.Map
 	ipam         *ovnipam.IPAM
 	namedPort    *NamedPort
+	fqdnCache    *FQDNCache
 
 	OVNNbClient ovs.NbClient
 	OVNSbClient ovs.SbClient
@@ -75,6 +82,11 @@ type Controller struct {
 	updateVpcStatusQueue workqueue.RateLimitingInterface
 	vpcKeyMutex          keymutex.KeyMutex
 
+	vpcRouteTablesLister          kubeovnlister.VpcRouteTableLister
+	vpcRouteTableSynced           cache.InformerSynced
+	addOrUpdateVpcRouteTableQueue workqueue.RateLimitingInterface
+	delVpcRouteTableQueue         workqueue.RateLimitingInterface
+
 	vpcNatGatewayIpipLister kubeovnlister.VpcNatGatewayIpipLister
 	vpcNatGatewayIpipSynced cache.InformerSynced
 	vpcBmsConnectionLister  kubeovnlister.VpcBmsConnectionLister
@@ -95,6 +107,7 @@ type Controller struct {
 	updateVpcDnatQueue            workqueue.RateLimitingInterface
 	updateVpcSnatQueue            workqueue.RateLimitingInterface
 	updateVpcSubnetQueue          workqueue.RateLimitingInterface
+	syncVpcNatGwNftRulesQueue     workqueue.RateLimitingInterface
 	vpcNatGwKeyMutex              keymutex.KeyMutex
 
 	switchLBRuleLister      kubeovnlister.SwitchLBRuleLister
@@ -116,6 +129,18 @@ type Controller struct {
 	syncVirtualPortsQueue   workqueue.RateLimitingInterface
 	subnetKeyMutex          keymutex.KeyMutex
 
+	dhcpOptionsLister            kubeovnlister.DHCPOptionsLister
+	dhcpOptionsSynced            cache.InformerSynced
+	addOrUpdateDHCPOptionsQueue  workqueue.RateLimitingInterface
+	updateDHCPOptionsStatusQueue workqueue.RateLimitingInterface
+
+	bootServersLister           kubeovnlister.BootServerLister
+	bootServerSynced            cache.InformerSynced
+	bootServerDeploymentsLister appsv1.DeploymentLister
+	bootServerDeploymentsSynced cache.InformerSynced
+	addOrUpdateBootServerQueue  workqueue.RateLimitingInterface
+	delBootServerQueue          workqueue.RateLimitingInterface
+
 	ippoolLister            kubeovnlister.IPPoolLister
 	ippoolSynced            cache.InformerSynced
 	addOrUpdateIPPoolQueue  workqueue.RateLimitingInterface
@@ -129,6 +154,11 @@ type Controller struct {
 	updateIPQueue workqueue.RateLimitingInterface
 	delIPQueue    workqueue.RateLimitingInterface
 
+	ipHandoffsLister          kubeovnlister.IPHandoffLister
+	ipHandoffSynced           cache.InformerSynced
+	addOrUpdateIPHandoffQueue workqueue.RateLimitingInterface
+	delIPHandoffQueue         workqueue.RateLimitingInterface
+
 	virtualIpsLister     kubeovnlister.VipLister
 	virtualIpsSynced     cache.InformerSynced
 	addVirtualIPQueue    workqueue.RateLimitingInterface
@@ -225,6 +255,17 @@ type Controller struct {
 	deleteNpQueue workqueue.RateLimitingInterface
 	npKeyMutex    keymutex.KeyMutex
 
+	anpsLister         anplister.AdminNetworkPolicyLister
+	anpsSynced         cache.InformerSynced
+	updateAnpQueue     workqueue.RateLimitingInterface
+	deleteAnpQueue     workqueue.RateLimitingInterface
+	banpsLister        anplister.BaselineAdminNetworkPolicyLister
+	banpsSynced        cache.InformerSynced
+	updateBanpQueue    workqueue.RateLimitingInterface
+	deleteBanpQueue    workqueue.RateLimitingInterface
+	anpKeyMutex        keymutex.KeyMutex
+	anpInformerFactory anpinformer.SharedInformerFactory
+
 	sgsLister          kubeovnlister.SecurityGroupLister
 	sgSynced           cache.InformerSynced
 	addOrUpdateSgQueue workqueue.RateLimitingInterface
@@ -232,6 +273,11 @@ type Controller struct {
 	syncSgPortsQueue   workqueue.RateLimitingInterface
 	sgKeyMutex         keymutex.KeyMutex
 
+	addressGroupsLister          kubeovnlister.AddressGroupLister
+	addressGroupSynced           cache.InformerSynced
+	addOrUpdateAddressGroupQueue workqueue.RateLimitingInterface
+	delAddressGroupQueue         workqueue.RateLimitingInterface
+
 	qosPoliciesLister    kubeovnlister.QoSPolicyLister
 	qosPolicySynced      cache.InformerSynced
 	addQoSPolicyQueue    workqueue.RateLimitingInterface
@@ -242,8 +288,10 @@ type Controller struct {
 	configMapsSynced cache.InformerSynced
 
 	recorder               record.EventRecorder
+	ipUsageTracker         *ipUsageTracker
 	informerFactory        kubeinformers.SharedInformerFactory
 	cmInformerFactory      kubeinformers.SharedInformerFactory
+	deployInformerFactory  kubeinformers.SharedInformerFactory
 	kubeovnInformerFactory kubeovninformer.SharedInformerFactory
 }
 
@@ -267,6 +315,12 @@ func Run(ctx context.Context, config *Configuration) {
 		kubeinformers.WithTweakListOptions(func(listOption *metav1.ListOptions) {
 			listOption.AllowWatchBookmarks = true
 		}), kubeinformers.WithNamespace(config.PodNamespace))
+	// only the deployments of the boot servers are watched
+	deployInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(config.KubeFactoryClient, 0,
+		kubeinformers.WithTweakListOptions(func(listOption *metav1.ListOptions) {
+			listOption.AllowWatchBookmarks = true
+			listOption.LabelSelector = util.BootServerNameLabel
+		}), kubeinformers.WithNamespace(config.PodNamespace))
 	kubeovnInformerFactory := kubeovninformer.NewSharedInformerFactoryWithOptions(config.KubeOvnFactoryClient, 0,
 		kubeovninformer.WithTweakListOptions(func(listOption *metav1.ListOptions) {
 			listOption.AllowWatchBookmarks = true
@@ -276,10 +330,15 @@ func Run(ctx context.Context, config *Configuration) {
 	vpcBmsConnectionInformer := kubeovnInformerFactory.Kubeovn().V1().VpcBmsConnections()
 
 	vpcInformer := kubeovnInformerFactory.Kubeovn().V1().Vpcs()
+	vpcRouteTableInformer := kubeovnInformerFactory.Kubeovn().V1().VpcRouteTables()
 	vpcNatGatewayInformer := kubeovnInformerFactory.Kubeovn().V1().VpcNatGateways()
 	subnetInformer := kubeovnInformerFactory.Kubeovn().V1().Subnets()
+	dhcpOptionsInformer := kubeovnInformerFactory.Kubeovn().V1().DHCPOptions()
+	bootServerInformer := kubeovnInformerFactory.Kubeovn().V1().BootServers()
+	bootServerDeploymentInformer := deployInformerFactory.Apps().V1().Deployments()
 	ippoolInformer := kubeovnInformerFactory.Kubeovn().V1().IPPools()
 	ipInformer := kubeovnInformerFactory.Kubeovn().V1().IPs()
+	ipHandoffInformer := kubeovnInformerFactory.Kubeovn().V1().IPHandoffs()
 	virtualIPInformer := kubeovnInformerFactory.Kubeovn().V1().Vips()
 	iptablesEipInformer := kubeovnInformerFactory.Kubeovn().V1().IptablesEIPs()
 	iptablesFipInformer := kubeovnInformerFactory.Kubeovn().V1().IptablesFIPRules()
@@ -288,6 +347,7 @@ func Run(ctx context.Context, config *Configuration) {
 	vlanInformer := kubeovnInformerFactory.Kubeovn().V1().Vlans()
 	providerNetworkInformer := kubeovnInformerFactory.Kubeovn().V1().ProviderNetworks()
 	sgInformer := kubeovnInformerFactory.Kubeovn().V1().SecurityGroups()
+	addressGroupInformer := kubeovnInformerFactory.Kubeovn().V1().AddressGroups()
 	podInformer := informerFactory.Core().V1().Pods()
 	namespaceInformer := informerFactory.Core().V1().Namespaces()
 	nodeInformer := informerFactory.Core().V1().Nodes()
@@ -323,6 +383,11 @@ func Run(ctx context.Context, config *Configuration) {
 		updateVpcStatusQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateVpcStatus"),
 		vpcKeyMutex:          keymutex.NewHashed(numKeyLocks),
 
+		vpcRouteTablesLister:          vpcRouteTableInformer.Lister(),
+		vpcRouteTableSynced:           vpcRouteTableInformer.Informer().HasSynced,
+		addOrUpdateVpcRouteTableQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "AddOrUpdateVpcRouteTable"),
+		delVpcRouteTableQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteVpcRouteTable"),
+
 		vpcNatGatewayIpipLister:           vpcNatGatewayIpipInformer.Lister(),
 		vpcNatGatewayIpipSynced:           vpcNatGatewayInformer.Informer().HasSynced,
 		addOrUpdateVpcNatGatewayIpipQueue: workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "AddOrUpdateVpcNatGwIpip"),
@@ -343,6 +408,7 @@ func Run(ctx context.Context, config *Configuration) {
 		updateVpcDnatQueue:            workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "UpdateVpcDnat"),
 		updateVpcSnatQueue:            workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "UpdateVpcSnat"),
 		updateVpcSubnetQueue:          workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "UpdateVpcSubnet"),
+		syncVpcNatGwNftRulesQueue:     workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "SyncVpcNatGwNftRules"),
 		vpcNatGwKeyMutex:              keymutex.NewHashed(numKeyLocks),
 
 		subnetsLister:           subnetInformer.Lister(),
@@ -353,6 +419,18 @@ func Run(ctx context.Context, config *Configuration) {
 		syncVirtualPortsQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SyncVirtualPort"),
 		subnetKeyMutex:          keymutex.NewHashed(numKeyLocks),
 
+		dhcpOptionsLister:            dhcpOptionsInformer.Lister(),
+		dhcpOptionsSynced:            dhcpOptionsInformer.Informer().HasSynced,
+		addOrUpdateDHCPOptionsQueue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "AddOrUpdateDHCPOptions"),
+		updateDHCPOptionsStatusQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateDHCPOptionsStatus"),
+
+		bootServersLister:           bootServerInformer.Lister(),
+		bootServerSynced:            bootServerInformer.Informer().HasSynced,
+		bootServerDeploymentsLister: bootServerDeploymentInformer.Lister(),
+		bootServerDeploymentsSynced: bootServerDeploymentInformer.Informer().HasSynced,
+		addOrUpdateBootServerQueue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "AddOrUpdateBootServer"),
+		delBootServerQueue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteBootServer"),
+
 		ippoolLister:            ippoolInformer.Lister(),
 		ippoolSynced:            ippoolInformer.Informer().HasSynced,
 		addOrUpdateIPPoolQueue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "AddIPPool"),
@@ -366,6 +444,11 @@ func Run(ctx context.Context, config *Configuration) {
 		updateIPQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateIP"),
 		delIPQueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteIP"),
 
+		ipHandoffsLister:          ipHandoffInformer.Lister(),
+		ipHandoffSynced:           ipHandoffInformer.Informer().HasSynced,
+		addOrUpdateIPHandoffQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "AddOrUpdateIPHandoff"),
+		delIPHandoffQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteIPHandoff"),
+
 		virtualIpsLister:     virtualIPInformer.Lister(),
 		virtualIpsSynced:     virtualIPInformer.Informer().HasSynced,
 		addVirtualIPQueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "AddVirtualIp"),
@@ -457,6 +540,11 @@ func Run(ctx context.Context, config *Configuration) {
 		delSgQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteSg"),
 		syncSgPortsQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SyncSgPorts"),
 
+		addressGroupsLister:          addressGroupInformer.Lister(),
+		addressGroupSynced:           addressGroupInformer.Informer().HasSynced,
+		addOrUpdateAddressGroupQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateAddressGroup"),
+		delAddressGroupQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteAddressGroup"),
+
 		ovnEipsLister:     ovnEipInformer.Lister(),
 		ovnEipSynced:      ovnEipInformer.Informer().HasSynced,
 		addOvnEipQueue:    workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "AddOvnEip"),
@@ -483,10 +571,13 @@ func Run(ctx context.Context, config *Configuration) {
 		delOvnDnatRuleQueue:    workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "DeleteOvnDnatRule"),
 
 		recorder:               recorder,
+		ipUsageTracker:         newIPUsageTracker(time.Duration(config.SubnetExhaustionForecastWindow) * time.Second),
 		informerFactory:        informerFactory,
 		cmInformerFactory:      cmInformerFactory,
+		deployInformerFactory:  deployInformerFactory,
 		kubeovnInformerFactory: kubeovnInformerFactory,
 	}
+	controller.fqdnCache = NewFQDNCache(controller.resolveFQDN, controller.enqueueNpsByFQDN)
 
 	var err error
 	if controller.OVNNbClient, err = ovs.NewOvnNbClient(
@@ -525,13 +616,38 @@ func Run(ctx context.Context, config *Configuration) {
 		controller.npKeyMutex = keymutex.NewHashed(numKeyLocks)
 	}
 
+	var anpInformerFactory anpinformer.SharedInformerFactory
+	if config.EnableANP {
+		anpInformerFactory = anpinformer.NewSharedInformerFactoryWithOptions(config.AnpFactoryClient, 0,
+			anpinformer.WithTweakListOptions(func(listOption *metav1.ListOptions) {
+				listOption.AllowWatchBookmarks = true
+			}))
+		anpInformer := anpInformerFactory.Policy().V1alpha1().AdminNetworkPolicies()
+		banpInformer := anpInformerFactory.Policy().V1alpha1().BaselineAdminNetworkPolicies()
+
+		controller.anpInformerFactory = anpInformerFactory
+		controller.anpsLister = anpInformer.Lister()
+		controller.anpsSynced = anpInformer.Informer().HasSynced
+		controller.updateAnpQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateAnp")
+		controller.deleteAnpQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteAnp")
+		controller.banpsLister = banpInformer.Lister()
+		controller.banpsSynced = banpInformer.Informer().HasSynced
+		controller.updateBanpQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateBanp")
+		controller.deleteBanpQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteBanp")
+		controller.anpKeyMutex = keymutex.NewHashed(numKeyLocks)
+	}
+
 	defer controller.shutdown()
 	klog.Info("Starting OVN controller")
 
 	// Wait for the caches to be synced before starting workers
 	controller.informerFactory.Start(ctx.Done())
 	controller.cmInformerFactory.Start(ctx.Done())
+	controller.deployInformerFactory.Start(ctx.Done())
 	controller.kubeovnInformerFactory.Start(ctx.Done())
+	if controller.config.EnableANP {
+		controller.anpInformerFactory.Start(ctx.Done())
+	}
 
 	klog.Info("Waiting for informer caches to sync")
 	cacheSyncs := []cache.InformerSynced{
@@ -542,6 +658,8 @@ func Run(ctx context.Context, config *Configuration) {
 		controller.serviceSynced, controller.endpointsSynced, controller.configMapsSynced,
 		controller.ovnEipSynced, controller.ovnFipSynced, controller.ovnSnatRuleSynced,
 		controller.ovnDnatRuleSynced, controller.vpcNatGatewayIpipSynced, controller.vpcBmsConnectionSynced,
+		controller.addressGroupSynced, controller.vpcRouteTableSynced, controller.ipHandoffSynced,
+		controller.dhcpOptionsSynced, controller.bootServerSynced, controller.bootServerDeploymentsSynced,
 	}
 	if controller.config.EnableLb {
 		cacheSyncs = append(cacheSyncs, controller.switchLBRuleSynced, controller.vpcDNSSynced)
@@ -549,6 +667,9 @@ func Run(ctx context.Context, config *Configuration) {
 	if controller.config.EnableNP {
 		cacheSyncs = append(cacheSyncs, controller.npsSynced)
 	}
+	if controller.config.EnableANP {
+		cacheSyncs = append(cacheSyncs, controller.anpsSynced, controller.banpsSynced)
+	}
 	if !cache.WaitForCacheSync(ctx.Done(), cacheSyncs...) {
 		util.LogFatalAndExit(nil, "failed to wait for caches to sync")
 	}
@@ -600,6 +721,14 @@ func Run(ctx context.Context, config *Configuration) {
 		util.LogFatalAndExit(err, "failed to add vpc event handler")
 	}
 
+	if _, err = vpcRouteTableInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddVpcRouteTable,
+		UpdateFunc: controller.enqueueUpdateVpcRouteTable,
+		DeleteFunc: controller.enqueueDelVpcRouteTable,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add vpc route table event handler")
+	}
+
 	if _, err = vpcNatGatewayIpipInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.enqueueAddVpcNatGwIpip,
 		UpdateFunc: controller.enqueueUpdateVpcNatGwIpip,
@@ -632,6 +761,30 @@ func Run(ctx context.Context, config *Configuration) {
 		util.LogFatalAndExit(err, "failed to add subnet event handler")
 	}
 
+	if _, err = dhcpOptionsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddDHCPOptions,
+		UpdateFunc: controller.enqueueUpdateDHCPOptions,
+		DeleteFunc: controller.enqueueDelDHCPOptions,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add dhcp options event handler")
+	}
+
+	if _, err = bootServerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddBootServer,
+		UpdateFunc: controller.enqueueUpdateBootServer,
+		DeleteFunc: controller.enqueueDelBootServer,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add boot server event handler")
+	}
+
+	if _, err = bootServerDeploymentInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueBootServerOfDeployment,
+		UpdateFunc: controller.enqueueUpdateBootServerDeployment,
+		DeleteFunc: controller.enqueueBootServerOfDeployment,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add boot server deployment event handler")
+	}
+
 	if _, err = ippoolInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.enqueueAddIPPool,
 		UpdateFunc: controller.enqueueUpdateIPPool,
@@ -640,6 +793,14 @@ func Run(ctx context.Context, config *Configuration) {
 		util.LogFatalAndExit(err, "failed to add ippool event handler")
 	}
 
+	if _, err = ipHandoffInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddIPHandoff,
+		UpdateFunc: controller.enqueueUpdateIPHandoff,
+		DeleteFunc: controller.enqueueDelIPHandoff,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add ip handoff event handler")
+	}
+
 	if _, err = ipInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.enqueueAddIP,
 		UpdateFunc: controller.enqueueUpdateIP,
@@ -664,6 +825,14 @@ func Run(ctx context.Context, config *Configuration) {
 		util.LogFatalAndExit(err, "failed to add security group event handler")
 	}
 
+	if _, err = addressGroupInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddAddressGroup,
+		DeleteFunc: controller.enqueueDeleteAddressGroup,
+		UpdateFunc: controller.enqueueUpdateAddressGroup,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add address group event handler")
+	}
+
 	if _, err = virtualIPInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.enqueueAddVirtualIP,
 		UpdateFunc: controller.enqueueUpdateVirtualIP,
@@ -772,6 +941,24 @@ func Run(ctx context.Context, config *Configuration) {
 		}
 	}
 
+	if config.EnableANP {
+		if _, err = anpInformerFactory.Policy().V1alpha1().AdminNetworkPolicies().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.enqueueAddAnp,
+			UpdateFunc: controller.enqueueUpdateAnp,
+			DeleteFunc: controller.enqueueDeleteAnp,
+		}); err != nil {
+			util.LogFatalAndExit(err, "failed to add admin network policy event handler")
+		}
+
+		if _, err = anpInformerFactory.Policy().V1alpha1().BaselineAdminNetworkPolicies().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.enqueueAddBanp,
+			UpdateFunc: controller.enqueueUpdateBanp,
+			DeleteFunc: controller.enqueueDeleteBanp,
+		}); err != nil {
+			util.LogFatalAndExit(err, "failed to add baseline admin network policy event handler")
+		}
+	}
+
 	controller.Run(ctx)
 }
 
@@ -851,11 +1038,17 @@ func (c *Controller) shutdown() {
 	c.addOrUpdateSubnetQueue.ShutDown()
 	c.deleteSubnetQueue.ShutDown()
 	c.updateSubnetStatusQueue.ShutDown()
+	c.addOrUpdateDHCPOptionsQueue.ShutDown()
+	c.updateDHCPOptionsStatusQueue.ShutDown()
+	c.addOrUpdateBootServerQueue.ShutDown()
+	c.delBootServerQueue.ShutDown()
 	c.syncVirtualPortsQueue.ShutDown()
 
 	c.addOrUpdateIPPoolQueue.ShutDown()
 	c.updateIPPoolStatusQueue.ShutDown()
 	c.deleteIPPoolQueue.ShutDown()
+	c.addOrUpdateIPHandoffQueue.ShutDown()
+	c.delIPHandoffQueue.ShutDown()
 
 	c.addNodeQueue.ShutDown()
 	c.updateNodeQueue.ShutDown()
@@ -873,6 +1066,8 @@ func (c *Controller) shutdown() {
 	c.addOrUpdateVpcQueue.ShutDown()
 	c.updateVpcStatusQueue.ShutDown()
 	c.delVpcQueue.ShutDown()
+	c.addOrUpdateVpcRouteTableQueue.ShutDown()
+	c.delVpcRouteTableQueue.ShutDown()
 
 	c.addOrUpdateVpcNatGatewayIpipQueue.ShutDown()
 	c.delVpcNatGatewayIpipQueue.ShutDown()
@@ -887,6 +1082,7 @@ func (c *Controller) shutdown() {
 	c.updateVpcDnatQueue.ShutDown()
 	c.updateVpcSnatQueue.ShutDown()
 	c.updateVpcSubnetQueue.ShutDown()
+	c.syncVpcNatGwNftRulesQueue.ShutDown()
 
 	if c.config.EnableLb {
 		c.addSwitchLBRuleQueue.ShutDown()
@@ -947,9 +1143,17 @@ func (c *Controller) shutdown() {
 		c.updateNpQueue.ShutDown()
 		c.deleteNpQueue.ShutDown()
 	}
+	if c.config.EnableANP {
+		c.updateAnpQueue.ShutDown()
+		c.deleteAnpQueue.ShutDown()
+		c.updateBanpQueue.ShutDown()
+		c.deleteBanpQueue.ShutDown()
+	}
 	c.addOrUpdateSgQueue.ShutDown()
 	c.delSgQueue.ShutDown()
 	c.syncSgPortsQueue.ShutDown()
+	c.addOrUpdateAddressGroupQueue.ShutDown()
+	c.delAddressGroupQueue.ShutDown()
 }
 
 func (c *Controller) startWorkers(ctx context.Context) {
@@ -971,6 +1175,7 @@ func (c *Controller) startWorkers(ctx context.Context) {
 	go wait.Until(c.runUpdateVpcDnatWorker, time.Second, ctx.Done())
 	go wait.Until(c.runUpdateVpcSnatWorker, time.Second, ctx.Done())
 	go wait.Until(c.runUpdateVpcSubnetWorker, time.Second, ctx.Done())
+	go wait.Until(c.runSyncVpcNatGwNftRulesWorker, time.Second, ctx.Done())
 
 	// add default/join subnet and wait them ready
 	go wait.Until(c.runAddSubnetWorker, time.Second, ctx.Done())
@@ -990,6 +1195,8 @@ func (c *Controller) startWorkers(ctx context.Context) {
 	go wait.Until(c.runAddSgWorker, time.Second, ctx.Done())
 	go wait.Until(c.runDelSgWorker, time.Second, ctx.Done())
 	go wait.Until(c.runSyncSgPortsWorker, time.Second, ctx.Done())
+	go wait.Until(c.runAddAddressGroupWorker, time.Second, ctx.Done())
+	go wait.Until(c.runDelAddressGroupWorker, time.Second, ctx.Done())
 
 	// run node worker before handle any pods
 	for i := 0; i < c.config.WorkerNum; i++ {
@@ -1018,6 +1225,10 @@ func (c *Controller) startWorkers(ctx context.Context) {
 
 	go wait.Until(c.runDelVpcWorker, time.Second, ctx.Done())
 	go wait.Until(c.runUpdateVpcStatusWorker, time.Second, ctx.Done())
+	go wait.Until(c.runAddVpcRouteTableWorker, time.Second, ctx.Done())
+	go wait.Until(c.runDelVpcRouteTableWorker, time.Second, ctx.Done())
+	go wait.Until(c.runAddOrUpdateBootServerWorker, time.Second, ctx.Done())
+	go wait.Until(c.runDelBootServerWorker, time.Second, ctx.Done())
 
 	if c.config.EnableLb {
 		go wait.Until(c.runAddServiceWorker, time.Second, ctx.Done())
@@ -1043,6 +1254,8 @@ func (c *Controller) startWorkers(ctx context.Context) {
 		go wait.Until(c.runDeleteSubnetWorker, time.Second, ctx.Done())
 		go wait.Until(c.runDeleteIPPoolWorker, time.Second, ctx.Done())
 		go wait.Until(c.runUpdateSubnetStatusWorker, time.Second, ctx.Done())
+		go wait.Until(c.runAddOrUpdateDHCPOptionsWorker, time.Second, ctx.Done())
+		go wait.Until(c.runUpdateDHCPOptionsStatusWorker, time.Second, ctx.Done())
 		go wait.Until(c.runUpdateIPPoolStatusWorker, time.Second, ctx.Done())
 		go wait.Until(c.runSyncVirtualPortsWorker, time.Second, ctx.Done())
 
@@ -1054,6 +1267,17 @@ func (c *Controller) startWorkers(ctx context.Context) {
 		if c.config.EnableNP {
 			go wait.Until(c.runUpdateNpWorker, time.Second, ctx.Done())
 			go wait.Until(c.runDeleteNpWorker, time.Second, ctx.Done())
+			go wait.Until(c.syncNpEgressFQDNs, time.Second, ctx.Done())
+			if c.config.EnableNPACLStats {
+				go wait.Until(c.syncNpACLCookies, 30*time.Second, ctx.Done())
+			}
+		}
+
+		if c.config.EnableANP {
+			go wait.Until(c.runUpdateAnpWorker, time.Second, ctx.Done())
+			go wait.Until(c.runDeleteAnpWorker, time.Second, ctx.Done())
+			go wait.Until(c.runUpdateBanpWorker, time.Second, ctx.Done())
+			go wait.Until(c.runDeleteBanpWorker, time.Second, ctx.Done())
 		}
 
 		go wait.Until(c.runDelVlanWorker, time.Second, ctx.Done())
@@ -1094,6 +1318,10 @@ func (c *Controller) startWorkers(ctx context.Context) {
 
 	go wait.Until(c.resyncProviderNetworkStatus, 30*time.Second, ctx.Done())
 	go wait.Until(c.exportSubnetMetrics, 30*time.Second, ctx.Done())
+	if c.config.SubnetExhaustionThreshold > 0 {
+		go wait.Until(c.forecastIPExhaustion, 30*time.Second, ctx.Done())
+	}
+	go wait.Until(c.exportVpcNatGwMetrics, 30*time.Second, ctx.Done())
 	go wait.Until(c.CheckGatewayReady, 5*time.Second, ctx.Done())
 
 	go wait.Until(c.runAddOvnEipWorker, time.Second, ctx.Done())
@@ -1120,6 +1348,8 @@ func (c *Controller) startWorkers(ctx context.Context) {
 	go wait.Until(c.runAddIPWorker, time.Second, ctx.Done())
 	go wait.Until(c.runUpdateIPWorker, time.Second, ctx.Done())
 	go wait.Until(c.runDelIPWorker, time.Second, ctx.Done())
+	go wait.Until(c.runAddIPHandoffWorker, time.Second, ctx.Done())
+	go wait.Until(c.runDelIPHandoffWorker, time.Second, ctx.Done())
 
 	go wait.Until(c.runAddVirtualIPWorker, time.Second, ctx.Done())
 	go wait.Until(c.runUpdateVirtualIPWorker, time.Second, ctx.Done())
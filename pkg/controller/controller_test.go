@@ -1,26 +1,45 @@
 package controller
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
-
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/keymutex"
+	"sigs.k8s.io/network-policy-api/apis/v1alpha1"
+	anpfake "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned/fake"
+	anpinformerfactory "sigs.k8s.io/network-policy-api/pkg/client/informers/externalversions"
+	anpinformer "sigs.k8s.io/network-policy-api/pkg/client/informers/externalversions/apis/v1alpha1"
 
 	mockovs "github.com/kubeovn/kube-ovn/mocks/pkg/ovs"
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	kubeovnfake "github.com/kubeovn/kube-ovn/pkg/client/clientset/versioned/fake"
 	kubeovninformerfactory "github.com/kubeovn/kube-ovn/pkg/client/informers/externalversions"
 	kubeovninformer "github.com/kubeovn/kube-ovn/pkg/client/informers/externalversions/kubeovn/v1"
 )
 
 type fakeControllerInformers struct {
-	vpcInformer     kubeovninformer.VpcInformer
-	sbunetInformer  kubeovninformer.SubnetInformer
-	serviceInformer coreinformers.ServiceInformer
+	vpcInformer           kubeovninformer.VpcInformer
+	sbunetInformer        kubeovninformer.SubnetInformer
+	ipInformer            kubeovninformer.IPInformer
+	ovnEipInformer        kubeovninformer.OvnEipInformer
+	ovnSnatRuleInformer   kubeovninformer.OvnSnatRuleInformer
+	ipHandoffInformer     kubeovninformer.IPHandoffInformer
+	vpcRouteTableInformer kubeovninformer.VpcRouteTableInformer
+	serviceInformer       coreinformers.ServiceInformer
+	podInformer           coreinformers.PodInformer
+	namespaceInformer     coreinformers.NamespaceInformer
+	anpInformer           anpinformer.AdminNetworkPolicyInformer
+	banpInformer          anpinformer.BaselineAdminNetworkPolicyInformer
 }
 
 type fakeController struct {
@@ -31,35 +50,135 @@ type fakeController struct {
 
 func alwaysReady() bool { return true }
 
-func newFakeController(t *testing.T) *fakeController {
+// newFakeController returns a controller with fake clients and informers,
+// the objects are added to the informer stores and created by the fake clients
+func newFakeController(t *testing.T, objects ...runtime.Object) *fakeController {
 	/* fake kube client */
 	kubeClient := fake.NewSimpleClientset()
 	kubeInformerFactory := informers.NewSharedInformerFactory(kubeClient, 0)
 	serviceInformer := kubeInformerFactory.Core().V1().Services()
+	podInformer := kubeInformerFactory.Core().V1().Pods()
+	namespaceInformer := kubeInformerFactory.Core().V1().Namespaces()
 
 	/* fake kube ovn client */
 	kubeovnClient := kubeovnfake.NewSimpleClientset()
 	kubeovnInformerFactory := kubeovninformerfactory.NewSharedInformerFactory(kubeovnClient, 0)
 	vpcInformer := kubeovnInformerFactory.Kubeovn().V1().Vpcs()
 	sbunetInformer := kubeovnInformerFactory.Kubeovn().V1().Subnets()
+	ipInformer := kubeovnInformerFactory.Kubeovn().V1().IPs()
+	ovnEipInformer := kubeovnInformerFactory.Kubeovn().V1().OvnEips()
+	ovnSnatRuleInformer := kubeovnInformerFactory.Kubeovn().V1().OvnSnatRules()
+	ipHandoffInformer := kubeovnInformerFactory.Kubeovn().V1().IPHandoffs()
+	vpcRouteTableInformer := kubeovnInformerFactory.Kubeovn().V1().VpcRouteTables()
+
+	/* fake admin network policy client */
+	anpClient := anpfake.NewSimpleClientset()
+	anpInformerFactory := anpinformerfactory.NewSharedInformerFactory(anpClient, 0)
+	anpInformer := anpInformerFactory.Policy().V1alpha1().AdminNetworkPolicies()
+	banpInformer := anpInformerFactory.Policy().V1alpha1().BaselineAdminNetworkPolicies()
 
 	fakeInformers := &fakeControllerInformers{
-		vpcInformer:     vpcInformer,
-		sbunetInformer:  sbunetInformer,
-		serviceInformer: serviceInformer,
+		vpcInformer:           vpcInformer,
+		sbunetInformer:        sbunetInformer,
+		ipInformer:            ipInformer,
+		ovnEipInformer:        ovnEipInformer,
+		ovnSnatRuleInformer:   ovnSnatRuleInformer,
+		ipHandoffInformer:     ipHandoffInformer,
+		vpcRouteTableInformer: vpcRouteTableInformer,
+		serviceInformer:       serviceInformer,
+		podInformer:           podInformer,
+		namespaceInformer:     namespaceInformer,
+		anpInformer:           anpInformer,
+		banpInformer:          banpInformer,
 	}
 
 	/* ovn fake client */
 	mockOvnClient := mockovs.NewMockNbClient(gomock.NewController(t))
 
 	ctrl := &Controller{
-		servicesLister:        serviceInformer.Lister(),
-		vpcsLister:            vpcInformer.Lister(),
-		vpcSynced:             alwaysReady,
-		subnetsLister:         sbunetInformer.Lister(),
-		subnetSynced:          alwaysReady,
-		OVNNbClient:           mockOvnClient,
-		syncVirtualPortsQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		config: &Configuration{
+			KubeClient:       kubeClient,
+			KubeOvnClient:    kubeovnClient,
+			AnpFactoryClient: anpClient,
+			PodNamespace:     "kube-system",
+		},
+		servicesLister:                serviceInformer.Lister(),
+		podsLister:                    podInformer.Lister(),
+		namespacesLister:              namespaceInformer.Lister(),
+		vpcsLister:                    vpcInformer.Lister(),
+		vpcSynced:                     alwaysReady,
+		subnetsLister:                 sbunetInformer.Lister(),
+		subnetSynced:                  alwaysReady,
+		ipsLister:                     ipInformer.Lister(),
+		ovnEipsLister:                 ovnEipInformer.Lister(),
+		ovnSnatRulesLister:            ovnSnatRuleInformer.Lister(),
+		ipHandoffsLister:              ipHandoffInformer.Lister(),
+		vpcRouteTablesLister:          vpcRouteTableInformer.Lister(),
+		anpsLister:                    anpInformer.Lister(),
+		banpsLister:                   banpInformer.Lister(),
+		anpKeyMutex:                   keymutex.NewHashed(1),
+		vpcKeyMutex:                   keymutex.NewHashed(1),
+		OVNNbClient:                   mockOvnClient,
+		recorder:                      record.NewFakeRecorder(10),
+		syncVirtualPortsQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		resetOvnEipQueue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		addOvnSnatRuleQueue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		updateOvnSnatRuleQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		addOrUpdateIPHandoffQueue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		addOrUpdateVpcRouteTableQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		delVpcRouteTableQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		updateAnpQueue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		deleteAnpQueue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		updateBanpQueue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		deleteBanpQueue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+		syncVpcNatGwNftRulesQueue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ""),
+	}
+
+	// the objects are created by the typed clients, as the fake object trackers can not guess the hyphenated resource names
+	ctx := context.Background()
+	for _, obj := range objects {
+		var err error
+		switch o := obj.(type) {
+		case *corev1.Pod:
+			require.NoError(t, podInformer.Informer().GetStore().Add(o))
+			_, err = kubeClient.CoreV1().Pods(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+		case *corev1.Namespace:
+			require.NoError(t, namespaceInformer.Informer().GetStore().Add(o))
+			_, err = kubeClient.CoreV1().Namespaces().Create(ctx, o, metav1.CreateOptions{})
+		case *corev1.Service:
+			require.NoError(t, serviceInformer.Informer().GetStore().Add(o))
+			_, err = kubeClient.CoreV1().Services(o.Namespace).Create(ctx, o, metav1.CreateOptions{})
+		case *kubeovnv1.Vpc:
+			require.NoError(t, vpcInformer.Informer().GetStore().Add(o))
+			_, err = kubeovnClient.KubeovnV1().Vpcs().Create(ctx, o, metav1.CreateOptions{})
+		case *kubeovnv1.Subnet:
+			require.NoError(t, sbunetInformer.Informer().GetStore().Add(o))
+			_, err = kubeovnClient.KubeovnV1().Subnets().Create(ctx, o, metav1.CreateOptions{})
+		case *kubeovnv1.IP:
+			require.NoError(t, ipInformer.Informer().GetStore().Add(o))
+			_, err = kubeovnClient.KubeovnV1().IPs().Create(ctx, o, metav1.CreateOptions{})
+		case *kubeovnv1.OvnEip:
+			require.NoError(t, ovnEipInformer.Informer().GetStore().Add(o))
+			_, err = kubeovnClient.KubeovnV1().OvnEips().Create(ctx, o, metav1.CreateOptions{})
+		case *kubeovnv1.OvnSnatRule:
+			require.NoError(t, ovnSnatRuleInformer.Informer().GetStore().Add(o))
+			_, err = kubeovnClient.KubeovnV1().OvnSnatRules().Create(ctx, o, metav1.CreateOptions{})
+		case *kubeovnv1.IPHandoff:
+			require.NoError(t, ipHandoffInformer.Informer().GetStore().Add(o))
+			_, err = kubeovnClient.KubeovnV1().IPHandoffs().Create(ctx, o, metav1.CreateOptions{})
+		case *kubeovnv1.VpcRouteTable:
+			require.NoError(t, vpcRouteTableInformer.Informer().GetStore().Add(o))
+			_, err = kubeovnClient.KubeovnV1().VpcRouteTables().Create(ctx, o, metav1.CreateOptions{})
+		case *v1alpha1.AdminNetworkPolicy:
+			require.NoError(t, anpInformer.Informer().GetStore().Add(o))
+			_, err = anpClient.PolicyV1alpha1().AdminNetworkPolicies().Create(ctx, o, metav1.CreateOptions{})
+		case *v1alpha1.BaselineAdminNetworkPolicy:
+			require.NoError(t, banpInformer.Informer().GetStore().Add(o))
+			_, err = anpClient.PolicyV1alpha1().BaselineAdminNetworkPolicies().Create(ctx, o, metav1.CreateOptions{})
+		default:
+			t.Fatalf("unsupported object type %T", obj)
+		}
+		require.NoError(t, err)
 	}
 
 	return &fakeController{
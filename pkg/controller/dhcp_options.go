@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func (c *Controller) enqueueAddDHCPOptions(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add dhcp options %s", key)
+	c.addOrUpdateDHCPOptionsQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateDHCPOptions(oldObj, newObj interface{}) {
+	oldDHCPOptions := oldObj.(*kubeovnv1.DHCPOptions)
+	newDHCPOptions := newObj.(*kubeovnv1.DHCPOptions)
+	if reflect.DeepEqual(oldDHCPOptions.Spec, newDHCPOptions.Spec) {
+		return
+	}
+
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(newObj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue update dhcp options %s", key)
+	c.addOrUpdateDHCPOptionsQueue.Add(key)
+}
+
+func (c *Controller) enqueueDelDHCPOptions(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	// the subnets still referencing the deleted dhcp options fall back to the default options
+	klog.V(3).Infof("enqueue delete dhcp options %s", key)
+	c.addOrUpdateDHCPOptionsQueue.Add(key)
+}
+
+// enqueueDHCPOptionsOfSubnet enqueues the dhcp options used by the subnet to update the subnets in its status
+func (c *Controller) enqueueDHCPOptionsOfSubnet(subnet *kubeovnv1.Subnet) {
+	if subnet.Spec.DHCPOptions != "" {
+		c.updateDHCPOptionsStatusQueue.Add(subnet.Spec.DHCPOptions)
+	}
+}
+
+// enqueueDHCPOptionsOfUpdatedSubnet enqueues the dhcp options whose status is changed by the subnet update.
+// It's called by the event handler after the lister is updated, so the status is generated from the new uuids.
+func (c *Controller) enqueueDHCPOptionsOfUpdatedSubnet(oldSubnet, newSubnet *kubeovnv1.Subnet) {
+	if oldSubnet.Spec.DHCPOptions != newSubnet.Spec.DHCPOptions {
+		c.enqueueDHCPOptionsOfSubnet(oldSubnet)
+		c.enqueueDHCPOptionsOfSubnet(newSubnet)
+		return
+	}
+	if oldSubnet.Status.DHCPv4OptionsUUID != newSubnet.Status.DHCPv4OptionsUUID ||
+		oldSubnet.Status.DHCPv6OptionsUUID != newSubnet.Status.DHCPv6OptionsUUID {
+		c.enqueueDHCPOptionsOfSubnet(newSubnet)
+	}
+}
+
+func (c *Controller) runAddOrUpdateDHCPOptionsWorker() {
+	for c.processNextAddOrUpdateDHCPOptionsWorkItem() {
+	}
+}
+
+func (c *Controller) runUpdateDHCPOptionsStatusWorker() {
+	for c.processNextUpdateDHCPOptionsStatusWorkItem() {
+	}
+}
+
+func (c *Controller) processNextAddOrUpdateDHCPOptionsWorkItem() bool {
+	obj, shutdown := c.addOrUpdateDHCPOptionsQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.addOrUpdateDHCPOptionsQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.addOrUpdateDHCPOptionsQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleAddOrUpdateDHCPOptions(key); err != nil {
+			c.addOrUpdateDHCPOptionsQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		c.addOrUpdateDHCPOptionsQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) processNextUpdateDHCPOptionsStatusWorkItem() bool {
+	obj, shutdown := c.updateDHCPOptionsStatusQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.updateDHCPOptionsStatusQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.updateDHCPOptionsStatusQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleUpdateDHCPOptionsStatus(key); err != nil {
+			c.updateDHCPOptionsStatusQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		c.updateDHCPOptionsStatusQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+// handleAddOrUpdateDHCPOptions renders the dhcp options of the subnets referencing it again
+func (c *Controller) handleAddOrUpdateDHCPOptions(key string) error {
+	klog.Infof("handle add/update dhcp options %s", key)
+	subnets, err := c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list subnets: %v", err)
+		return err
+	}
+	for _, subnet := range subnets {
+		if subnet.Spec.DHCPOptions == key {
+			klog.V(3).Infof("enqueue update subnet %s using dhcp options %s", subnet.Name, key)
+			c.addOrUpdateSubnetQueue.Add(subnet.Name)
+		}
+	}
+	c.updateDHCPOptionsStatusQueue.Add(key)
+	return nil
+}
+
+func (c *Controller) handleUpdateDHCPOptionsStatus(key string) error {
+	cachedDHCPOptions, err := c.dhcpOptionsLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+
+	subnets, err := c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list subnets: %v", err)
+		return err
+	}
+	dhcpOptions := cachedDHCPOptions.DeepCopy()
+	dhcpOptions.Status.Subnets = nil
+	for _, subnet := range subnets {
+		if subnet.Spec.DHCPOptions != key {
+			continue
+		}
+		dhcpOptions.Status.Subnets = append(dhcpOptions.Status.Subnets, kubeovnv1.DHCPOptionsSubnet{
+			Name:              subnet.Name,
+			DHCPv4OptionsUUID: subnet.Status.DHCPv4OptionsUUID,
+			DHCPv6OptionsUUID: subnet.Status.DHCPv6OptionsUUID,
+		})
+	}
+	sort.Slice(dhcpOptions.Status.Subnets, func(i, j int) bool {
+		return dhcpOptions.Status.Subnets[i].Name < dhcpOptions.Status.Subnets[j].Name
+	})
+	if reflect.DeepEqual(cachedDHCPOptions.Status, dhcpOptions.Status) {
+		return nil
+	}
+
+	bytes, err := dhcpOptions.Status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().DHCPOptions().Patch(context.Background(), key, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("failed to patch status of dhcp options %s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+// getSubnetDHCPOptionsSpec returns the spec of the dhcp options used by the subnet,
+// or nil if the subnet doesn't reference any dhcp options or the dhcp options is not found
+func (c *Controller) getSubnetDHCPOptionsSpec(subnet *kubeovnv1.Subnet) (*kubeovnv1.DHCPOptionsSpec, error) {
+	if subnet.Spec.DHCPOptions == "" {
+		return nil, nil
+	}
+	dhcpOptions, err := c.dhcpOptionsLister.Get(subnet.Spec.DHCPOptions)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			klog.Warningf("dhcp options %s used by subnet %s not found, fall back to the default options", subnet.Spec.DHCPOptions, subnet.Name)
+			return nil, nil
+		}
+		klog.Errorf("failed to get dhcp options %s: %v", subnet.Spec.DHCPOptions, err)
+		return nil, err
+	}
+	return &dhcpOptions.Spec, nil
+}
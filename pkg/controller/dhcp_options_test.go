@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/util/workqueue"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func Test_enqueueDHCPOptionsOfUpdatedSubnet(t *testing.T) {
+	t.Parallel()
+
+	subnet := func(dhcpOptions, v4UUID, v6UUID string) *kubeovnv1.Subnet {
+		return &kubeovnv1.Subnet{
+			Spec:   kubeovnv1.SubnetSpec{DHCPOptions: dhcpOptions},
+			Status: kubeovnv1.SubnetStatus{DHCPv4OptionsUUID: v4UUID, DHCPv6OptionsUUID: v6UUID},
+		}
+	}
+	tests := []struct {
+		name      string
+		oldSubnet *kubeovnv1.Subnet
+		newSubnet *kubeovnv1.Subnet
+		want      []string
+	}{
+		{"unchanged", subnet("pxe", "uuid4", "uuid6"), subnet("pxe", "uuid4", "uuid6"), nil},
+		{"uuids recorded", subnet("pxe", "", ""), subnet("pxe", "uuid4", ""), []string{"pxe"}},
+		{"ipv6 uuid changed", subnet("pxe", "uuid4", "uuid6"), subnet("pxe", "uuid4", "uuid6-new"), []string{"pxe"}},
+		{"dhcp options changed", subnet("pxe", "uuid4", ""), subnet("ipxe", "uuid4", ""), []string{"pxe", "ipxe"}},
+		{"dhcp options removed", subnet("pxe", "uuid4", ""), subnet("", "uuid4", ""), []string{"pxe"}},
+		{"no dhcp options", subnet("", "", ""), subnet("", "uuid4", ""), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "")
+			defer queue.ShutDown()
+			c := &Controller{updateDHCPOptionsStatusQueue: queue}
+
+			c.enqueueDHCPOptionsOfUpdatedSubnet(tt.oldSubnet, tt.newSubnet)
+			var got []string
+			for queue.Len() != 0 {
+				key, _ := queue.Get()
+				got = append(got, key.(string))
+				queue.Done(key)
+			}
+			require.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
@@ -37,6 +37,8 @@ func (c *Controller) gc() error {
 		c.gcLogicalSwitchPort,
 		c.gcLoadBalancer,
 		c.gcPortGroup,
+		c.gcAdminNetworkPolicy,
+		c.gcAddressGroup,
 		c.gcRoutePolicy,
 		c.gcStaticRoute,
 		c.gcVpcNatGateway,
@@ -44,6 +46,7 @@ func (c *Controller) gc() error {
 		c.gcVip,
 		c.gcLbSvcPods,
 		c.gcVPCDNS,
+		c.gcBootServer,
 	}
 	for _, gcFunc := range gcFunctions {
 		if err := gcFunc(); err != nil {
@@ -168,6 +171,18 @@ func (c *Controller) gcLogicalSwitch() error {
 	for _, item := range dhcpOptions {
 		if len(item.ExternalIDs) == 0 || !subnetNames.Has(item.ExternalIDs["ls"]) {
 			uuidToDeleteList = append(uuidToDeleteList, item.UUID)
+			continue
+		}
+		// dhcp options overridden for a logical switch port, e.g. boot options of a vm
+		if lsp := item.ExternalIDs["lsp"]; lsp != "" {
+			exists, err := c.OVNNbClient.LogicalSwitchPortExists(lsp)
+			if err != nil {
+				klog.Errorf("failed to check existence of lsp %s: %v", lsp, err)
+				return err
+			}
+			if !exists {
+				uuidToDeleteList = append(uuidToDeleteList, item.UUID)
+			}
 		}
 	}
 	klog.Infof("gc dhcp options %v", uuidToDeleteList)
@@ -615,6 +630,85 @@ func (c *Controller) gcLoadBalancer() error {
 	return nil
 }
 
+func (c *Controller) gcAdminNetworkPolicy() error {
+	if !c.config.EnableANP {
+		return nil
+	}
+	klog.Info("start to gc admin network policy")
+
+	anps, err := c.anpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list admin network policy, %v", err)
+		return err
+	}
+	anpNames := strset.NewWithSize(len(anps))
+	for _, anp := range anps {
+		anpNames.Add(anp.Name)
+	}
+
+	banps, err := c.banpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list baseline admin network policy, %v", err)
+		return err
+	}
+	banpNames := strset.NewWithSize(len(banps))
+	for _, banp := range banps {
+		banpNames.Add(banp.Name)
+	}
+
+	pgs, err := c.OVNNbClient.ListPortGroups(map[string]string{anpKey: ""})
+	if err != nil {
+		klog.Errorf("list anp port group: %v", err)
+		return err
+	}
+	for _, pg := range pgs {
+		if name := pg.ExternalIDs[anpKey]; !anpNames.Has(name) {
+			klog.Infof("gc port group '%s' admin network policy '%s'", pg.Name, name)
+			c.deleteAnpQueue.Add(name)
+		}
+	}
+
+	pgs, err = c.OVNNbClient.ListPortGroups(map[string]string{banpKey: ""})
+	if err != nil {
+		klog.Errorf("list banp port group: %v", err)
+		return err
+	}
+	for _, pg := range pgs {
+		if name := pg.ExternalIDs[banpKey]; !banpNames.Has(name) {
+			klog.Infof("gc port group '%s' baseline admin network policy '%s'", pg.Name, name)
+			c.deleteBanpQueue.Add(name)
+		}
+	}
+	return nil
+}
+
+func (c *Controller) gcAddressGroup() error {
+	klog.Info("start to gc address group")
+
+	ags, err := c.addressGroupsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list address group, %v", err)
+		return err
+	}
+	agNames := strset.NewWithSize(len(ags))
+	for _, ag := range ags {
+		agNames.Add(ag.Name)
+	}
+
+	addressSets, err := c.OVNNbClient.ListAddressSets(map[string]string{addressGroupKey: ""})
+	if err != nil {
+		klog.Errorf("list address group address set: %v", err)
+		return err
+	}
+	for _, as := range addressSets {
+		if name := as.ExternalIDs[addressGroupKey]; !agNames.Has(name) {
+			klog.Infof("gc address set '%s' of address group '%s'", as.Name, name)
+			c.delAddressGroupQueue.Add(name)
+		}
+	}
+	return nil
+}
+
 func (c *Controller) gcPortGroup() error {
 	klog.Info("start to gc network policy")
 
@@ -752,8 +846,16 @@ func (c *Controller) gcStaticRoute() error {
 		klog.Errorf("failed to get default vpc, %v", err)
 		return err
 	}
+	rtbNames, err := c.vpcRouteTableNames(c.config.ClusterRouter)
+	if err != nil {
+		return err
+	}
 	var keepStaticRoute bool
 	for _, route := range routes {
+		if rtbNames.Has(route.RouteTable) {
+			// reconciled by the vpc route table
+			continue
+		}
 		keepStaticRoute = false
 		for _, item := range defaultVpc.Spec.StaticRoutes {
 			if route.IPPrefix == item.CIDR && route.Nexthop == item.NextHopIP && route.RouteTable == item.RouteTable {
@@ -24,6 +24,12 @@ import (
 func (c *Controller) InitOVN() error {
 	var err error
 
+	// acls created by previous versions have no tier
+	if err = c.OVNNbClient.MigrateACLTier(); err != nil {
+		klog.Errorf("migrate acl tier failed: %v", err)
+		return err
+	}
+
 	if err = c.initClusterRouter(); err != nil {
 		klog.Errorf("init cluster router failed: %v", err)
 		return err
@@ -304,6 +310,7 @@ func (c *Controller) InitIPAM() error {
 		if err := c.ipam.AddOrUpdateSubnet(subnet.Name, subnet.Spec.CIDRBlock, subnet.Spec.Gateway, subnet.Spec.ExcludeIps); err != nil {
 			klog.Errorf("failed to init subnet %s: %v", subnet.Name, err)
 		}
+		c.ipam.SetSubnetV6Learned(subnet.Name, subnet.Spec.IPv6AddressMode != "")
 
 		u2oInterconnName := fmt.Sprintf(util.U2OInterconnName, subnet.Spec.Vpc, subnet.Name)
 		u2oInterconnLrpName := fmt.Sprintf("%s-%s", subnet.Spec.Vpc, subnet.Name)
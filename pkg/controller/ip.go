@@ -60,6 +60,10 @@ func (c *Controller) enqueueUpdateIP(oldObj, newObj interface{}) {
 			c.updateSubnetStatusQueue.Add(as)
 		}
 	}
+	if oldIP.Spec.NodeName != newIP.Spec.NodeName || oldIP.Spec.MacAddress != newIP.Spec.MacAddress {
+		// distributed fip should follow the pod to the new chassis
+		c.enqueueUpdateOvnFipsByIP(newIP.Name)
+	}
 }
 
 func (c *Controller) enqueueDelIP(obj interface{}) {
@@ -0,0 +1,320 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+type ipUsageSample struct {
+	time  time.Time
+	using float64
+}
+
+// ipUsageTracker keeps the samples of the using addresses in a sliding window
+// to estimate the allocation and release rates of subnets and ippools
+type ipUsageTracker struct {
+	mutex   sync.Mutex
+	window  time.Duration
+	samples map[string][]ipUsageSample
+	// nearExhaustion records the subnets whose NearExhaustion events have been emitted
+	nearExhaustion map[string]bool
+	// exported records the metrics exported by the last forecast
+	exported ipExhaustionMetrics
+}
+
+// ipExhaustionMetrics are the values of the ip exhaustion gauges, keyed by the joined label values
+type ipExhaustionMetrics map[*prometheus.GaugeVec]map[string]float64
+
+func (m ipExhaustionMetrics) set(gauge *prometheus.GaugeVec, value float64, labelValues ...string) {
+	if m[gauge] == nil {
+		m[gauge] = make(map[string]float64)
+	}
+	m[gauge][strings.Join(labelValues, "\x00")] = value
+}
+
+// swapMetrics sets the new values before deleting the series which no longer exist,
+// so that the series never disappear from a scrape in the middle of an update
+func (t *ipUsageTracker) swapMetrics(metrics ipExhaustionMetrics) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for gauge, values := range metrics {
+		for labelValues, value := range values {
+			gauge.WithLabelValues(strings.Split(labelValues, "\x00")...).Set(value)
+		}
+	}
+	for gauge, values := range t.exported {
+		for labelValues := range values {
+			if _, ok := metrics[gauge][labelValues]; !ok {
+				gauge.DeleteLabelValues(strings.Split(labelValues, "\x00")...)
+			}
+		}
+	}
+	t.exported = metrics
+}
+
+func newIPUsageTracker(window time.Duration) *ipUsageTracker {
+	return &ipUsageTracker{
+		window:         window,
+		samples:        make(map[string][]ipUsageSample),
+		nearExhaustion: make(map[string]bool),
+	}
+}
+
+// record adds a sample and returns the allocated and released addresses per hour in the window
+func (t *ipUsageTracker) record(key string, now time.Time, using float64) (allocationRate, releaseRate float64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	samples := append(t.samples[key], ipUsageSample{time: now, using: using})
+	// keep the latest sample out of the window, so that the rates are calculated in the whole window
+	start := 0
+	for start < len(samples)-1 && now.Sub(samples[start+1].time) >= t.window {
+		start++
+	}
+	samples = samples[start:]
+	t.samples[key] = samples
+
+	duration := now.Sub(samples[0].time)
+	if duration <= 0 {
+		return 0, 0
+	}
+	var allocated, released float64
+	for i := 1; i < len(samples); i++ {
+		if delta := samples[i].using - samples[i-1].using; delta > 0 {
+			allocated += delta
+		} else {
+			released -= delta
+		}
+	}
+	return allocated / duration.Hours(), released / duration.Hours()
+}
+
+// retain drops the samples of the keys not in the set
+func (t *ipUsageTracker) retain(keys map[string]bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for key := range t.samples {
+		if !keys[key] {
+			delete(t.samples, key)
+		}
+	}
+	for key := range t.nearExhaustion {
+		if !keys[key] {
+			delete(t.nearExhaustion, key)
+		}
+	}
+}
+
+// setNearExhaustion records the state and returns whether it's changed
+func (t *ipUsageTracker) setNearExhaustion(key string, near bool) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.nearExhaustion[key] == near {
+		return false
+	}
+	t.nearExhaustion[key] = near
+	return true
+}
+
+// timeToExhaustion returns the estimated duration until the available addresses are exhausted,
+// false is returned if the addresses are not being consumed
+func timeToExhaustion(available, allocationRate, releaseRate float64) (time.Duration, bool) {
+	if available <= 0 {
+		return 0, true
+	}
+	consumption := allocationRate - releaseRate
+	if consumption <= 0 {
+		return 0, false
+	}
+	hours := available / consumption
+	if hours >= math.MaxInt64/float64(time.Hour) {
+		return 0, false
+	}
+	return time.Duration(hours * float64(time.Hour)), true
+}
+
+type ipUsage struct {
+	protocol  string
+	using     float64
+	available float64
+}
+
+func subnetIPUsages(subnet *kubeovnv1.Subnet) []ipUsage {
+	var usages []ipUsage
+	if subnet.Spec.Protocol != kubeovnv1.ProtocolIPv6 {
+		usages = append(usages, ipUsage{kubeovnv1.ProtocolIPv4, subnet.Status.V4UsingIPs, subnet.Status.V4AvailableIPs})
+	}
+	if subnet.Spec.Protocol != kubeovnv1.ProtocolIPv4 {
+		usages = append(usages, ipUsage{kubeovnv1.ProtocolIPv6, subnet.Status.V6UsingIPs, subnet.Status.V6AvailableIPs})
+	}
+	return usages
+}
+
+func ippoolIPUsages(ippool *kubeovnv1.IPPool, protocol string) []ipUsage {
+	var usages []ipUsage
+	if protocol != kubeovnv1.ProtocolIPv6 {
+		usages = append(usages, ipUsage{kubeovnv1.ProtocolIPv4, ippool.Status.V4UsingIPs.Float64(), ippool.Status.V4AvailableIPs.Float64()})
+	}
+	if protocol != kubeovnv1.ProtocolIPv4 {
+		usages = append(usages, ipUsage{kubeovnv1.ProtocolIPv6, ippool.Status.V6UsingIPs.Float64(), ippool.Status.V6AvailableIPs.Float64()})
+	}
+	return usages
+}
+
+// forecastIPExhaustion samples the address usages of subnets and ippools, exports the allocation and release rates
+// and the estimated time to exhaustion, and marks the subnets near exhaustion within the threshold
+func (c *Controller) forecastIPExhaustion() {
+	subnets, err := c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list subnets: %v", err)
+		return
+	}
+	ippools, err := c.ippoolLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list ippools: %v", err)
+		return
+	}
+
+	metrics := make(ipExhaustionMetrics)
+	now := time.Now()
+	threshold := time.Duration(c.config.SubnetExhaustionThreshold) * time.Second
+	keys := make(map[string]bool, len(subnets)+len(ippools))
+	protocols := make(map[string]string, len(subnets))
+	for _, subnet := range subnets {
+		if !subnet.Status.IsValidated() {
+			continue
+		}
+		protocols[subnet.Name] = subnet.Spec.Protocol
+		var exhaustion time.Duration
+		var consumed bool
+		for _, usage := range subnetIPUsages(subnet) {
+			key := fmt.Sprintf("subnet/%s/%s", subnet.Name, usage.protocol)
+			keys[key] = true
+			allocationRate, releaseRate := c.ipUsageTracker.record(key, now, usage.using)
+			metrics.set(metricSubnetIPAllocationRate, allocationRate, subnet.Name, usage.protocol)
+			metrics.set(metricSubnetIPReleaseRate, releaseRate, subnet.Name, usage.protocol)
+			if d, ok := timeToExhaustion(usage.available, allocationRate, releaseRate); ok && (!consumed || d < exhaustion) {
+				exhaustion, consumed = d, true
+			}
+		}
+		if consumed {
+			metrics.set(metricSubnetTimeToExhaustion, exhaustion.Seconds(), subnet.Name)
+		}
+
+		keys[subnet.Name] = true
+		if err = c.updateSubnetNearExhaustion(subnet, consumed && exhaustion < threshold, exhaustion); err != nil {
+			klog.Error(err)
+		}
+	}
+
+	for _, ippool := range ippools {
+		protocol, ok := protocols[ippool.Spec.Subnet]
+		if !ok || ippool.Spec.AutoAllocate != nil {
+			continue
+		}
+		var exhaustion time.Duration
+		var consumed bool
+		for _, usage := range ippoolIPUsages(ippool, protocol) {
+			key := fmt.Sprintf("ippool/%s/%s", ippool.Name, usage.protocol)
+			keys[key] = true
+			allocationRate, releaseRate := c.ipUsageTracker.record(key, now, usage.using)
+			metrics.set(metricIPPoolIPAllocationRate, allocationRate, ippool.Name, ippool.Spec.Subnet, usage.protocol)
+			metrics.set(metricIPPoolIPReleaseRate, releaseRate, ippool.Name, ippool.Spec.Subnet, usage.protocol)
+			if d, ok := timeToExhaustion(usage.available, allocationRate, releaseRate); ok && (!consumed || d < exhaustion) {
+				exhaustion, consumed = d, true
+			}
+		}
+		if consumed {
+			metrics.set(metricIPPoolTimeToExhaustion, exhaustion.Seconds(), ippool.Name, ippool.Spec.Subnet)
+		}
+	}
+
+	c.ipUsageTracker.swapMetrics(metrics)
+	c.ipUsageTracker.retain(keys)
+}
+
+// updateSubnetNearExhaustion updates the NearExhaustion condition of the subnet,
+// and emits an event when the threshold is crossed
+func (c *Controller) updateSubnetNearExhaustion(subnet *kubeovnv1.Subnet, near bool, exhaustion time.Duration) error {
+	if c.ipUsageTracker.setNearExhaustion(subnet.Name, near) {
+		if near {
+			c.recorder.Eventf(subnet, v1.EventTypeWarning, "NearExhaustion", "addresses of subnet %s are estimated to be exhausted in %s", subnet.Name, exhaustion.Round(time.Second))
+		} else if subnet.Status.IsConditionTrue(kubeovnv1.NearExhaustion) {
+			c.recorder.Eventf(subnet, v1.EventTypeNormal, "ExhaustionRecovered", "addresses of subnet %s are no longer near exhaustion", subnet.Name)
+		}
+	}
+
+	if subnet.Status.IsConditionTrue(kubeovnv1.NearExhaustion) == near {
+		return nil
+	}
+	if !near && subnet.Status.GetCondition(kubeovnv1.NearExhaustion) == nil {
+		return nil
+	}
+
+	return c.patchSubnetCondition(subnet.Name, kubeovnv1.NearExhaustion, func(status *kubeovnv1.SubnetStatus) {
+		if near {
+			status.SetCondition(kubeovnv1.NearExhaustion, "ExhaustionForecasted", fmt.Sprintf("estimated to be exhausted in %s at the current allocation rate", exhaustion.Round(time.Second)))
+		} else {
+			status.ClearCondition(kubeovnv1.NearExhaustion, "SufficientAddresses", "not estimated to be exhausted within the threshold")
+		}
+	})
+}
+
+// patchSubnetCondition patches a single condition of the subnet fetched from the apiserver with a json patch,
+// so that the other conditions updated by the subnet controller in the meantime are not overwritten
+func (c *Controller) patchSubnetCondition(name string, ctype kubeovnv1.ConditionType, update func(*kubeovnv1.SubnetStatus)) error {
+	subnet, err := c.config.KubeOvnClient.KubeovnV1().Subnets().Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("failed to get subnet %s: %v", name, err)
+		return err
+	}
+	status := subnet.Status.DeepCopy()
+	update(status)
+	condition := status.GetCondition(ctype)
+	if condition == nil || reflect.DeepEqual(condition, subnet.Status.GetCondition(ctype)) {
+		return nil
+	}
+
+	var ops []map[string]any
+	index := slices.IndexFunc(subnet.Status.Conditions, func(c kubeovnv1.SubnetCondition) bool { return c.Type == ctype })
+	switch {
+	case index >= 0:
+		// the test fails the patch if the condition has been moved by another update
+		path := fmt.Sprintf("/status/conditions/%d", index)
+		ops = append(ops,
+			map[string]any{"op": "test", "path": path + "/type", "value": ctype},
+			map[string]any{"op": "replace", "path": path, "value": condition},
+		)
+	case len(subnet.Status.Conditions) == 0:
+		ops = append(ops, map[string]any{"op": "add", "path": "/status/conditions", "value": []kubeovnv1.SubnetCondition{*condition}})
+	default:
+		ops = append(ops, map[string]any{"op": "add", "path": "/status/conditions/-", "value": condition})
+	}
+	bytes, err := json.Marshal(ops)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().Subnets().Patch(context.Background(), name, types.JSONPatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("failed to patch condition %s of subnet %s: %v", ctype, name, err)
+		return err
+	}
+	return nil
+}
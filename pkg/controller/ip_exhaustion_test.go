@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func Test_ipUsageTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := newIPUsageTracker(time.Hour)
+	now := time.Now()
+	allocationRate, releaseRate := tracker.record("subnet", now, 10)
+	require.Zero(t, allocationRate)
+	require.Zero(t, releaseRate)
+
+	tracker.record("subnet", now.Add(15*time.Minute), 30)
+	tracker.record("subnet", now.Add(30*time.Minute), 25)
+	allocationRate, releaseRate = tracker.record("subnet", now.Add(time.Hour), 40)
+	require.InDelta(t, 35, allocationRate, 1e-9)
+	require.InDelta(t, 5, releaseRate, 1e-9)
+
+	// the first sample is out of the window
+	allocationRate, releaseRate = tracker.record("subnet", now.Add(90*time.Minute), 40)
+	require.InDelta(t, 15, allocationRate, 1e-9)
+	require.Zero(t, releaseRate)
+
+	tracker.retain(map[string]bool{})
+	require.Empty(t, tracker.samples)
+}
+
+func Test_timeToExhaustion(t *testing.T) {
+	t.Parallel()
+
+	d, ok := timeToExhaustion(100, 30, 5)
+	require.True(t, ok)
+	require.Equal(t, 4*time.Hour, d)
+
+	_, ok = timeToExhaustion(100, 5, 5)
+	require.False(t, ok)
+
+	d, ok = timeToExhaustion(0, 0, 0)
+	require.True(t, ok)
+	require.Zero(t, d)
+}
+
+func Test_updateSubnetNearExhaustion(t *testing.T) {
+	t.Parallel()
+
+	subnet := &kubeovnv1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet"},
+		Status: kubeovnv1.SubnetStatus{
+			Conditions: []kubeovnv1.SubnetCondition{{Type: kubeovnv1.Ready, Status: corev1.ConditionTrue, Reason: "ResourceCreated"}},
+		},
+	}
+	fakeController := newFakeController(t, subnet)
+	ctrl := fakeController.fakeController
+	ctrl.ipUsageTracker = newIPUsageTracker(time.Hour)
+	client := ctrl.config.KubeOvnClient.KubeovnV1().Subnets()
+
+	// the subnet controller updates the conditions after the subnet is cached
+	latest, err := client.Get(context.Background(), subnet.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	latest.Status.SetCondition(kubeovnv1.Validated, "ValidatedSuccessfully", "")
+	_, err = client.UpdateStatus(context.Background(), latest, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, ctrl.updateSubnetNearExhaustion(subnet, true, time.Hour))
+	latest, err = client.Get(context.Background(), subnet.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, latest.Status.Conditions, 3)
+	require.True(t, latest.Status.IsConditionTrue(kubeovnv1.Ready))
+	require.True(t, latest.Status.IsConditionTrue(kubeovnv1.Validated))
+	require.True(t, latest.Status.IsConditionTrue(kubeovnv1.NearExhaustion))
+
+	require.NoError(t, ctrl.updateSubnetNearExhaustion(latest, false, 0))
+	latest, err = client.Get(context.Background(), subnet.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, latest.Status.Conditions, 3)
+	require.True(t, latest.Status.IsConditionTrue(kubeovnv1.Validated))
+	require.NotNil(t, latest.Status.GetCondition(kubeovnv1.NearExhaustion))
+	require.False(t, latest.Status.IsConditionTrue(kubeovnv1.NearExhaustion))
+}
+
+func Test_ipUsageTracker_swapMetrics(t *testing.T) {
+	t.Parallel()
+
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_time_to_exhaustion"}, []string{"subnet"})
+	tracker := newIPUsageTracker(time.Hour)
+
+	metrics := make(ipExhaustionMetrics)
+	metrics.set(gauge, 10, "subnet1")
+	metrics.set(gauge, 20, "subnet2")
+	tracker.swapMetrics(metrics)
+	require.Equal(t, 2, testutil.CollectAndCount(gauge))
+
+	metrics = make(ipExhaustionMetrics)
+	metrics.set(gauge, 30, "subnet2")
+	tracker.swapMetrics(metrics)
+	require.Equal(t, 1, testutil.CollectAndCount(gauge))
+	require.InDelta(t, 30, testutil.ToFloat64(gauge.WithLabelValues("subnet2")), 1e-9)
+}
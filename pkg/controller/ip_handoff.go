@@ -0,0 +1,495 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const defaultIPHandoffTimeoutSeconds = 300
+
+// the annotations copied from the source pod to the target pod for the default network
+var ipHandoffAnnotationTemplates = []string{
+	util.IPAddressAnnotationTemplate,
+	util.MacAddressAnnotationTemplate,
+	util.CidrAnnotationTemplate,
+	util.GatewayAnnotationTemplate,
+	util.LogicalSwitchAnnotationTemplate,
+	util.LogicalRouterAnnotationTemplate,
+	util.VlanIDAnnotationTemplate,
+	util.ProviderNetworkTemplate,
+	util.PodNicAnnotationTemplate,
+}
+
+func (c *Controller) enqueueAddIPHandoff(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add ip handoff %s", key)
+	c.addOrUpdateIPHandoffQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateIPHandoff(oldObj, newObj interface{}) {
+	oldHandoff := oldObj.(*kubeovnv1.IPHandoff)
+	newHandoff := newObj.(*kubeovnv1.IPHandoff)
+	if reflect.DeepEqual(oldHandoff.Spec, newHandoff.Spec) {
+		return
+	}
+
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(newObj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue update ip handoff %s", key)
+	c.addOrUpdateIPHandoffQueue.Add(key)
+}
+
+func (c *Controller) enqueueDelIPHandoff(obj interface{}) {
+	var handoff *kubeovnv1.IPHandoff
+	switch t := obj.(type) {
+	case *kubeovnv1.IPHandoff:
+		handoff = t
+	case cache.DeletedFinalStateUnknown:
+		h, ok := t.Obj.(*kubeovnv1.IPHandoff)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		handoff = h
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+	klog.V(3).Infof("enqueue delete ip handoff %s", handoff.Name)
+	c.delIPHandoffQueue.Add(handoff)
+}
+
+// enqueueIPHandoffOfPod enqueues the ip handoff targeting the pod
+func (c *Controller) enqueueIPHandoffOfPod(pod *v1.Pod) {
+	if name := pod.Annotations[util.IPHandoffAnnotation]; name != "" {
+		c.addOrUpdateIPHandoffQueue.Add(name)
+	}
+}
+
+func (c *Controller) runAddIPHandoffWorker() {
+	for c.processNextAddOrUpdateIPHandoffWorkItem() {
+	}
+}
+
+func (c *Controller) runDelIPHandoffWorker() {
+	for c.processNextDeleteIPHandoffWorkItem() {
+	}
+}
+
+func (c *Controller) processNextAddOrUpdateIPHandoffWorkItem() bool {
+	obj, shutdown := c.addOrUpdateIPHandoffQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.addOrUpdateIPHandoffQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.addOrUpdateIPHandoffQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleAddOrUpdateIPHandoff(key); err != nil {
+			c.addOrUpdateIPHandoffQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		c.addOrUpdateIPHandoffQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) processNextDeleteIPHandoffWorkItem() bool {
+	obj, shutdown := c.delIPHandoffQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.delIPHandoffQueue.Done(obj)
+		var handoff *kubeovnv1.IPHandoff
+		var ok bool
+		if handoff, ok = obj.(*kubeovnv1.IPHandoff); !ok {
+			c.delIPHandoffQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected ip handoff in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleDelIPHandoff(handoff); err != nil {
+			c.delIPHandoffQueue.AddRateLimited(obj)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", handoff.Name, err.Error())
+		}
+		c.delIPHandoffQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) handleAddOrUpdateIPHandoff(key string) error {
+	cachedHandoff, err := c.ipHandoffsLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	klog.Infof("handle add/update ip handoff %s", key)
+
+	handoff := cachedHandoff.DeepCopy()
+	switch handoff.Status.Phase {
+	case kubeovnv1.IPHandoffPhaseSucceeded, kubeovnv1.IPHandoffPhaseRolledBack, kubeovnv1.IPHandoffPhaseFailed:
+		return nil
+	case kubeovnv1.IPHandoffPhaseTransferring:
+		err = c.checkIPHandoff(handoff)
+	default:
+		err = c.startIPHandoff(handoff)
+	}
+	if patchErr := c.patchIPHandoffStatus(cachedHandoff, handoff); patchErr != nil {
+		return patchErr
+	}
+	return err
+}
+
+// startIPHandoff lets the logical switch port of the source pod be bound on both nodes,
+// and assigns the address of the source pod to the target pod
+func (c *Controller) startIPHandoff(handoff *kubeovnv1.IPHandoff) error {
+	handoff.Status.Phase = kubeovnv1.IPHandoffPhasePending
+	source, err := c.podsLister.Pods(handoff.Spec.Namespace).Get(handoff.Spec.SourcePod)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			failIPHandoff(handoff, "SourcePodNotFound", fmt.Sprintf("source pod %s/%s not found", handoff.Spec.Namespace, handoff.Spec.SourcePod))
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	if source.Annotations[util.AllocatedAnnotation] != "true" || source.Spec.NodeName == "" {
+		failIPHandoff(handoff, "SourcePodNotAllocated", fmt.Sprintf("source pod %s/%s has no address allocated", source.Namespace, source.Name))
+		return nil
+	}
+	if source.Annotations[util.IPHandedOffAnnotation] == "true" {
+		failIPHandoff(handoff, "AddressHandedOff", fmt.Sprintf("address of source pod %s/%s has been handed off", source.Namespace, source.Name))
+		return nil
+	}
+
+	target, err := c.podsLister.Pods(handoff.Spec.Namespace).Get(handoff.Spec.TargetPod)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			handoff.Status.ClearCondition(kubeovnv1.PortMigrating, "WaitingForTargetPod", "target pod is not created")
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	if target.Annotations[util.IPHandoffAnnotation] != handoff.Name {
+		failIPHandoff(handoff, "TargetPodNotAnnotated", fmt.Sprintf("target pod %s/%s is not annotated with %s=%s", target.Namespace, target.Name, util.IPHandoffAnnotation, handoff.Name))
+		return nil
+	}
+	if target.Spec.NodeName == "" {
+		handoff.Status.ClearCondition(kubeovnv1.PortMigrating, "WaitingForTargetPod", "target pod is not scheduled")
+		return nil
+	}
+	if target.Spec.NodeName == source.Spec.NodeName {
+		failIPHandoff(handoff, "SameNode", fmt.Sprintf("source and target pods are both on node %s", target.Spec.NodeName))
+		return nil
+	}
+	// only the address and the logical switch port of the default network are handed off
+	for _, pod := range []*v1.Pod{source, target} {
+		if providers := ipHandoffUnsupportedProviders(pod); len(providers) != 0 {
+			failIPHandoff(handoff, "UnsupportedNetwork", fmt.Sprintf("pod %s/%s is attached to networks other than the default network: %s", pod.Namespace, pod.Name, strings.Join(providers, ", ")))
+			return nil
+		}
+	}
+
+	owner := c.getNameByPod(source)
+	portName := ovs.PodNameToPortName(owner, source.Namespace, util.OvnProvider)
+	if err = c.OVNNbClient.SetLogicalSwitchPortMigrateOptions(portName, source.Spec.NodeName, target.Spec.NodeName); err != nil {
+		klog.Errorf("failed to set migrate options of lsp %s: %v", portName, err)
+		return err
+	}
+
+	// the target pod takes over the logical switch port of the source pod
+	patch := util.KVPatch{
+		util.IPHandoffPortAnnotation: owner,
+		util.AllocatedAnnotation:     "true",
+	}
+	for _, template := range ipHandoffAnnotationTemplates {
+		if value := source.Annotations[fmt.Sprintf(template, util.OvnProvider)]; value != "" {
+			patch[fmt.Sprintf(template, util.OvnProvider)] = value
+		}
+	}
+	if err = util.PatchAnnotations(c.config.KubeClient.CoreV1().Pods(target.Namespace), target.Name, patch); err != nil {
+		klog.Errorf("failed to patch annotations of pod %s/%s: %v", target.Namespace, target.Name, err)
+		return err
+	}
+
+	now := metav1.Now()
+	handoff.Status.Phase = kubeovnv1.IPHandoffPhaseTransferring
+	handoff.Status.IPAddress = source.Annotations[util.IPAddressAnnotation]
+	handoff.Status.MacAddress = source.Annotations[util.MacAddressAnnotation]
+	handoff.Status.SourceNode = source.Spec.NodeName
+	handoff.Status.TargetNode = target.Spec.NodeName
+	handoff.Status.StartTime = &now
+	handoff.Status.SetCondition(kubeovnv1.PortMigrating, "Transferring", fmt.Sprintf("lsp %s is requested on node %s and %s", portName, source.Spec.NodeName, target.Spec.NodeName))
+	c.recorder.Eventf(target, v1.EventTypeNormal, "IPHandoffStarted", "taking over address %s from pod %s", handoff.Status.IPAddress, source.Name)
+	c.addOrUpdateIPHandoffQueue.AddAfter(handoff.Name, ipHandoffTimeout(handoff))
+	return nil
+}
+
+// checkIPHandoff finalizes the handoff when the target pod is ready, or rolls it back on failure or timeout
+func (c *Controller) checkIPHandoff(handoff *kubeovnv1.IPHandoff) error {
+	target, err := c.podsLister.Pods(handoff.Spec.Namespace).Get(handoff.Spec.TargetPod)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		klog.Error(err)
+		return err
+	}
+	switch {
+	case target == nil || target.DeletionTimestamp != nil || target.Status.Phase == v1.PodFailed:
+		return c.rollbackIPHandoff(handoff, "TargetPodFailed", "target pod is deleted or failed")
+	case isPodReady(target):
+		return c.finalizeIPHandoff(handoff, target)
+	}
+
+	timeout := ipHandoffTimeout(handoff)
+	if elapsed := time.Since(handoff.Status.StartTime.Time); elapsed < timeout {
+		c.addOrUpdateIPHandoffQueue.AddAfter(handoff.Name, timeout-elapsed)
+		return nil
+	}
+	return c.rollbackIPHandoff(handoff, "Timeout", fmt.Sprintf("target pod is not ready in %s", timeout))
+}
+
+func (c *Controller) finalizeIPHandoff(handoff *kubeovnv1.IPHandoff, target *v1.Pod) error {
+	owner := c.getNameByPod(target)
+	portName := ovs.PodNameToPortName(owner, target.Namespace, util.OvnProvider)
+	if err := c.OVNNbClient.ResetLogicalSwitchPortMigrateOptions(portName, handoff.Status.SourceNode, handoff.Status.TargetNode, false); err != nil {
+		klog.Errorf("failed to reset migrate options of lsp %s: %v", portName, err)
+		return err
+	}
+	// move the ip CR to the target node
+	if err := c.createOrUpdateCrdIPs(owner, target.Annotations[util.IPAddressAnnotation], target.Annotations[util.MacAddressAnnotation],
+		target.Annotations[util.LogicalSwitchAnnotation], target.Namespace, handoff.Status.TargetNode, util.OvnProvider, getPodType(target)); err != nil {
+		klog.Errorf("failed to move ip CR %s to node %s: %v", portName, handoff.Status.TargetNode, err)
+		return err
+	}
+	if err := c.markIPHandedOff(handoff.Spec.Namespace, handoff.Spec.SourcePod); err != nil {
+		return err
+	}
+
+	handoff.Status.Phase = kubeovnv1.IPHandoffPhaseSucceeded
+	handoff.Status.ClearCondition(kubeovnv1.PortMigrating, "Finalized", fmt.Sprintf("lsp %s is requested on node %s", portName, handoff.Status.TargetNode))
+	handoff.Status.SetCondition(kubeovnv1.Transferred, "Succeeded", fmt.Sprintf("address %s is handed off to pod %s", handoff.Status.IPAddress, target.Name))
+	c.recorder.Eventf(target, v1.EventTypeNormal, "IPHandoffSucceeded", "took over address %s from pod %s", handoff.Status.IPAddress, handoff.Spec.SourcePod)
+	return nil
+}
+
+// rollbackIPHandoff binds the logical switch port back to the source node,
+// and deletes the target pod without releasing the address
+func (c *Controller) rollbackIPHandoff(handoff *kubeovnv1.IPHandoff, reason, message string) error {
+	portName := ovs.PodNameToPortName(c.ipHandoffPortOwner(handoff), handoff.Spec.Namespace, util.OvnProvider)
+	if err := c.OVNNbClient.ResetLogicalSwitchPortMigrateOptions(portName, handoff.Status.SourceNode, handoff.Status.TargetNode, true); err != nil {
+		klog.Errorf("failed to reset migrate options of lsp %s: %v", portName, err)
+		return err
+	}
+	if err := c.markIPHandedOff(handoff.Spec.Namespace, handoff.Spec.TargetPod); err != nil {
+		return err
+	}
+	if err := c.deleteIPHandoffTargetPod(handoff, message); err != nil {
+		return err
+	}
+
+	klog.Infof("roll back ip handoff %s: %s", handoff.Name, message)
+	handoff.Status.Phase = kubeovnv1.IPHandoffPhaseRolledBack
+	handoff.Status.ClearCondition(kubeovnv1.PortMigrating, reason, fmt.Sprintf("lsp %s is requested on node %s", portName, handoff.Status.SourceNode))
+	handoff.Status.ClearCondition(kubeovnv1.Transferred, reason, message)
+	return nil
+}
+
+// deleteIPHandoffTargetPod deletes the target pod of a rolled back ip handoff, which is annotated and configured
+// with the address of the source pod. The pod recreated by its owner allocates an address of its own.
+func (c *Controller) deleteIPHandoffTargetPod(handoff *kubeovnv1.IPHandoff, message string) error {
+	target, err := c.podsLister.Pods(handoff.Spec.Namespace).Get(handoff.Spec.TargetPod)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	if target.DeletionTimestamp != nil || target.Annotations[util.IPHandoffAnnotation] != handoff.Name {
+		return nil
+	}
+
+	c.recorder.Eventf(target, v1.EventTypeWarning, "IPHandoffRolledBack", "deleting the pod holding address %s of pod %s: %s", handoff.Status.IPAddress, handoff.Spec.SourcePod, message)
+	options := metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &target.UID}}
+	if err = c.config.KubeClient.CoreV1().Pods(target.Namespace).Delete(context.Background(), target.Name, options); err != nil && !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to delete pod %s/%s: %v", target.Namespace, target.Name, err)
+		return err
+	}
+	return nil
+}
+
+// isIPHandoffPending returns whether the address of the pod is going to be assigned by the ip handoff
+func (c *Controller) isIPHandoffPending(pod *v1.Pod) bool {
+	name := pod.Annotations[util.IPHandoffAnnotation]
+	if name == "" || pod.Annotations[util.AllocatedAnnotation] == "true" {
+		return false
+	}
+	handoff, err := c.ipHandoffsLister.Get(name)
+	if err != nil {
+		// the ip handoff may be created after the pod
+		return true
+	}
+	switch handoff.Status.Phase {
+	case kubeovnv1.IPHandoffPhaseSucceeded, kubeovnv1.IPHandoffPhaseRolledBack, kubeovnv1.IPHandoffPhaseFailed:
+		return false
+	}
+	return true
+}
+
+// ipHandoffPortOwnerOfPod returns the owner of the logical switch port taken over by the pod,
+// or an empty string if the pod is not the target of an ip handoff
+func ipHandoffPortOwnerOfPod(pod *v1.Pod) string {
+	if pod.Annotations[util.IPHandoffAnnotation] == "" {
+		return ""
+	}
+	return pod.Annotations[util.IPHandoffPortAnnotation]
+}
+
+// ipHandoffUnsupportedProviders returns the providers of the pod other than the default network,
+// whose addresses and logical switch ports can not be handed off
+func ipHandoffUnsupportedProviders(pod *v1.Pod) []string {
+	var providers []string
+	if pod.Annotations[util.AttachmentNetworkAnnotation] != "" {
+		providers = append(providers, util.AttachmentNetworkAnnotation)
+	}
+	if pod.Annotations[util.DefaultNetworkAnnotation] != "" {
+		providers = append(providers, util.DefaultNetworkAnnotation)
+	}
+	for key, value := range pod.Annotations {
+		if value == "true" && key != util.AllocatedAnnotation && strings.HasSuffix(key, util.AllocatedAnnotationSuffix) {
+			providers = append(providers, strings.TrimSuffix(key, util.AllocatedAnnotationSuffix))
+		}
+	}
+	slices.Sort(providers)
+	return providers
+}
+
+// ipHandoffPortOwner returns the name of the logical switch port owner of the ip handoff
+func (c *Controller) ipHandoffPortOwner(handoff *kubeovnv1.IPHandoff) string {
+	for _, name := range []string{handoff.Spec.TargetPod, handoff.Spec.SourcePod} {
+		if pod, err := c.podsLister.Pods(handoff.Spec.Namespace).Get(name); err == nil {
+			return c.getNameByPod(pod)
+		}
+	}
+	return handoff.Spec.SourcePod
+}
+
+// markIPHandedOff marks the pod not owning its address any more, so that the address is not released with the pod
+func (c *Controller) markIPHandedOff(namespace, name string) error {
+	patch := util.KVPatch{util.IPHandedOffAnnotation: "true"}
+	if err := util.PatchAnnotations(c.config.KubeClient.CoreV1().Pods(namespace), name, patch); err != nil && !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to patch annotations of pod %s/%s: %v", namespace, name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) handleDelIPHandoff(handoff *kubeovnv1.IPHandoff) error {
+	klog.Infof("handle delete ip handoff %s", handoff.Name)
+	if handoff.Status.Phase != kubeovnv1.IPHandoffPhaseTransferring {
+		return nil
+	}
+	return c.rollbackIPHandoff(handoff.DeepCopy(), "Deleted", "ip handoff is deleted during the transfer")
+}
+
+// isAddressHandedOff returns whether the address of the pod should be kept when the pod is deleted,
+// which is either handed off to another pod or still in transfer
+func (c *Controller) isAddressHandedOff(pod *v1.Pod) bool {
+	if pod.Annotations[util.IPHandedOffAnnotation] == "true" {
+		return true
+	}
+	name := pod.Annotations[util.IPHandoffAnnotation]
+	if name == "" || pod.Annotations[util.IPHandoffPortAnnotation] == "" {
+		return false
+	}
+	handoff, err := c.ipHandoffsLister.Get(name)
+	if err != nil || handoff.Status.Phase == kubeovnv1.IPHandoffPhaseSucceeded {
+		return false
+	}
+	// roll back the transfer
+	c.addOrUpdateIPHandoffQueue.Add(name)
+	return true
+}
+
+func failIPHandoff(handoff *kubeovnv1.IPHandoff, reason, message string) {
+	klog.Errorf("ip handoff %s failed: %s", handoff.Name, message)
+	handoff.Status.Phase = kubeovnv1.IPHandoffPhaseFailed
+	handoff.Status.ClearCondition(kubeovnv1.Transferred, reason, message)
+}
+
+func ipHandoffTimeout(handoff *kubeovnv1.IPHandoff) time.Duration {
+	if handoff.Spec.TimeoutSeconds > 0 {
+		return time.Duration(handoff.Spec.TimeoutSeconds) * time.Second
+	}
+	return defaultIPHandoffTimeoutSeconds * time.Second
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (c *Controller) patchIPHandoffStatus(cachedHandoff, handoff *kubeovnv1.IPHandoff) error {
+	if reflect.DeepEqual(cachedHandoff.Status, handoff.Status) {
+		return nil
+	}
+	bytes, err := handoff.Status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().IPHandoffs().Patch(context.Background(), handoff.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("patch ip handoff %s status failed: %v", handoff.Name, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_ipHandoffTimeout(t *testing.T) {
+	t.Parallel()
+
+	handoff := &kubeovnv1.IPHandoff{}
+	require.Equal(t, defaultIPHandoffTimeoutSeconds*time.Second, ipHandoffTimeout(handoff))
+	handoff.Spec.TimeoutSeconds = 30
+	require.Equal(t, 30*time.Second, ipHandoffTimeout(handoff))
+}
+
+func Test_getNameByPod_ipHandoff(t *testing.T) {
+	t.Parallel()
+
+	c := &Controller{config: &Configuration{}}
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "target", Annotations: map[string]string{}}}
+	require.Equal(t, "target", c.getNameByPod(pod))
+
+	// the address is not taken over yet
+	pod.Annotations[util.IPHandoffAnnotation] = "handoff"
+	require.Equal(t, "target", c.getNameByPod(pod))
+
+	// the virtualmachine annotation is not used to alias the pod
+	pod.Annotations[fmt.Sprintf(util.VMAnnotationTemplate, util.OvnProvider)] = "vm"
+	require.Equal(t, "target", c.getNameByPod(pod))
+
+	pod.Annotations[util.IPHandoffPortAnnotation] = "source"
+	require.Equal(t, "source", c.getNameByPod(pod))
+
+	// the port annotation is only used by the target pod of an ip handoff
+	delete(pod.Annotations, util.IPHandoffAnnotation)
+	require.Equal(t, "target", c.getNameByPod(pod))
+}
+
+func newIPHandoffTestPods() (*v1.Pod, *v1.Pod) {
+	source := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source",
+			Namespace: "default",
+			Annotations: map[string]string{
+				util.AllocatedAnnotation:     "true",
+				util.IPAddressAnnotation:     "10.16.0.10",
+				util.MacAddressAnnotation:    "00:00:00:11:22:33",
+				util.LogicalSwitchAnnotation: "ovn-default",
+			},
+		},
+		Spec: v1.PodSpec{NodeName: "node1"},
+	}
+	target := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "target",
+			Namespace:   "default",
+			UID:         "target-uid",
+			Annotations: map[string]string{util.IPHandoffAnnotation: "handoff"},
+		},
+		Spec: v1.PodSpec{NodeName: "node2"},
+	}
+	return source, target
+}
+
+func newIPHandoffTestHandoff() *kubeovnv1.IPHandoff {
+	return &kubeovnv1.IPHandoff{
+		ObjectMeta: metav1.ObjectMeta{Name: "handoff"},
+		Spec:       kubeovnv1.IPHandoffSpec{Namespace: "default", SourcePod: "source", TargetPod: "target"},
+	}
+}
+
+// takeOverAddress returns the target pod annotated by a started ip handoff
+func takeOverAddress(source, target *v1.Pod) *v1.Pod {
+	target = target.DeepCopy()
+	for k, v := range source.Annotations {
+		target.Annotations[k] = v
+	}
+	target.Annotations[util.IPHandoffPortAnnotation] = source.Name
+	return target
+}
+
+func transferringIPHandoff(startTime time.Time) *kubeovnv1.IPHandoff {
+	handoff := newIPHandoffTestHandoff()
+	start := metav1.NewTime(startTime)
+	handoff.Status.Phase = kubeovnv1.IPHandoffPhaseTransferring
+	handoff.Status.IPAddress = "10.16.0.10"
+	handoff.Status.SourceNode = "node1"
+	handoff.Status.TargetNode = "node2"
+	handoff.Status.StartTime = &start
+	return handoff
+}
+
+func Test_startIPHandoff(t *testing.T) {
+	t.Parallel()
+
+	portName := ovs.PodNameToPortName("source", "default", util.OvnProvider)
+
+	t.Run("start", func(t *testing.T) {
+		t.Parallel()
+		source, target := newIPHandoffTestPods()
+		handoff := newIPHandoffTestHandoff()
+		fakeController := newFakeController(t, handoff, source, target)
+		ctrl, nbClient := fakeController.fakeController, fakeController.mockOvnClient
+		nbClient.EXPECT().SetLogicalSwitchPortMigrateOptions(portName, "node1", "node2").Return(nil)
+
+		require.NoError(t, ctrl.startIPHandoff(handoff))
+		require.Equal(t, kubeovnv1.IPHandoffPhaseTransferring, handoff.Status.Phase)
+		require.Equal(t, "10.16.0.10", handoff.Status.IPAddress)
+		require.Equal(t, "node1", handoff.Status.SourceNode)
+		require.Equal(t, "node2", handoff.Status.TargetNode)
+		require.NotNil(t, handoff.Status.StartTime)
+		require.True(t, handoff.Status.IsConditionTrue(kubeovnv1.PortMigrating))
+
+		pod, err := ctrl.config.KubeClient.CoreV1().Pods("default").Get(context.Background(), "target", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "source", pod.Annotations[util.IPHandoffPortAnnotation])
+		require.Empty(t, pod.Annotations[fmt.Sprintf(util.VMAnnotationTemplate, util.OvnProvider)])
+		require.Equal(t, "10.16.0.10", pod.Annotations[util.IPAddressAnnotation])
+		require.Equal(t, "00:00:00:11:22:33", pod.Annotations[util.MacAddressAnnotation])
+		require.Equal(t, "true", pod.Annotations[util.AllocatedAnnotation])
+	})
+
+	t.Run("target pod not scheduled", func(t *testing.T) {
+		t.Parallel()
+		source, target := newIPHandoffTestPods()
+		target.Spec.NodeName = ""
+		handoff := newIPHandoffTestHandoff()
+		fakeController := newFakeController(t, handoff, source, target)
+		ctrl := fakeController.fakeController
+
+		require.NoError(t, ctrl.startIPHandoff(handoff))
+		require.Equal(t, kubeovnv1.IPHandoffPhasePending, handoff.Status.Phase)
+		require.Equal(t, "WaitingForTargetPod", handoff.Status.GetCondition(kubeovnv1.PortMigrating).Reason)
+	})
+
+	t.Run("source and target on the same node", func(t *testing.T) {
+		t.Parallel()
+		source, target := newIPHandoffTestPods()
+		target.Spec.NodeName = source.Spec.NodeName
+		handoff := newIPHandoffTestHandoff()
+		fakeController := newFakeController(t, handoff, source, target)
+		ctrl := fakeController.fakeController
+
+		require.NoError(t, ctrl.startIPHandoff(handoff))
+		require.Equal(t, kubeovnv1.IPHandoffPhaseFailed, handoff.Status.Phase)
+		require.Equal(t, "SameNode", handoff.Status.GetCondition(kubeovnv1.Transferred).Reason)
+	})
+
+	t.Run("source pod handed off", func(t *testing.T) {
+		t.Parallel()
+		source, target := newIPHandoffTestPods()
+		source.Annotations[util.IPHandedOffAnnotation] = "true"
+		handoff := newIPHandoffTestHandoff()
+		fakeController := newFakeController(t, handoff, source, target)
+		ctrl := fakeController.fakeController
+
+		require.NoError(t, ctrl.startIPHandoff(handoff))
+		require.Equal(t, kubeovnv1.IPHandoffPhaseFailed, handoff.Status.Phase)
+		require.Equal(t, "AddressHandedOff", handoff.Status.GetCondition(kubeovnv1.Transferred).Reason)
+	})
+
+	t.Run("source pod attached to other networks", func(t *testing.T) {
+		t.Parallel()
+		source, target := newIPHandoffTestPods()
+		source.Annotations[util.AttachmentNetworkAnnotation] = "default/attach"
+		source.Annotations[fmt.Sprintf(util.AllocatedAnnotationTemplate, "attach.default.ovn")] = "true"
+		handoff := newIPHandoffTestHandoff()
+		fakeController := newFakeController(t, handoff, source, target)
+		ctrl := fakeController.fakeController
+
+		require.NoError(t, ctrl.startIPHandoff(handoff))
+		require.Equal(t, kubeovnv1.IPHandoffPhaseFailed, handoff.Status.Phase)
+		condition := handoff.Status.GetCondition(kubeovnv1.Transferred)
+		require.Equal(t, "UnsupportedNetwork", condition.Reason)
+		require.Contains(t, condition.Message, "attach.default.ovn")
+	})
+
+	t.Run("target pod attached to other networks", func(t *testing.T) {
+		t.Parallel()
+		source, target := newIPHandoffTestPods()
+		target.Annotations[util.DefaultNetworkAnnotation] = "default/net"
+		handoff := newIPHandoffTestHandoff()
+		fakeController := newFakeController(t, handoff, source, target)
+		ctrl := fakeController.fakeController
+
+		require.NoError(t, ctrl.startIPHandoff(handoff))
+		require.Equal(t, kubeovnv1.IPHandoffPhaseFailed, handoff.Status.Phase)
+		require.Equal(t, "UnsupportedNetwork", handoff.Status.GetCondition(kubeovnv1.Transferred).Reason)
+	})
+}
+
+func Test_checkIPHandoff(t *testing.T) {
+	t.Parallel()
+
+	portName := ovs.PodNameToPortName("source", "default", util.OvnProvider)
+
+	t.Run("finalize when the target pod is ready", func(t *testing.T) {
+		t.Parallel()
+		source, target := newIPHandoffTestPods()
+		target = takeOverAddress(source, target)
+		target.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+		handoff := transferringIPHandoff(time.Now())
+		fakeController := newFakeController(t, handoff, source, target)
+		ctrl, nbClient := fakeController.fakeController, fakeController.mockOvnClient
+		nbClient.EXPECT().ResetLogicalSwitchPortMigrateOptions(portName, "node1", "node2", false).Return(nil)
+
+		require.NoError(t, ctrl.checkIPHandoff(handoff))
+		require.Equal(t, kubeovnv1.IPHandoffPhaseSucceeded, handoff.Status.Phase)
+		require.True(t, handoff.Status.IsConditionTrue(kubeovnv1.Transferred))
+		require.False(t, handoff.Status.IsConditionTrue(kubeovnv1.PortMigrating))
+
+		ip, err := ctrl.config.KubeOvnClient.KubeovnV1().IPs().Get(context.Background(), portName, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "node2", ip.Spec.NodeName)
+		require.Equal(t, "10.16.0.10", ip.Spec.IPAddress)
+
+		pod, err := ctrl.config.KubeClient.CoreV1().Pods("default").Get(context.Background(), "source", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "true", pod.Annotations[util.IPHandedOffAnnotation])
+	})
+
+	t.Run("wait for the target pod", func(t *testing.T) {
+		t.Parallel()
+		source, target := newIPHandoffTestPods()
+		target = takeOverAddress(source, target)
+		handoff := transferringIPHandoff(time.Now())
+		fakeController := newFakeController(t, handoff, source, target)
+		ctrl := fakeController.fakeController
+
+		require.NoError(t, ctrl.checkIPHandoff(handoff))
+		require.Equal(t, kubeovnv1.IPHandoffPhaseTransferring, handoff.Status.Phase)
+	})
+
+	t.Run("roll back on timeout", func(t *testing.T) {
+		t.Parallel()
+		source, target := newIPHandoffTestPods()
+		target = takeOverAddress(source, target)
+		handoff := transferringIPHandoff(time.Now().Add(-time.Hour))
+		fakeController := newFakeController(t, handoff, source, target)
+		ctrl, nbClient := fakeController.fakeController, fakeController.mockOvnClient
+		nbClient.EXPECT().ResetLogicalSwitchPortMigrateOptions(portName, "node1", "node2", true).Return(nil)
+
+		require.NoError(t, ctrl.checkIPHandoff(handoff))
+		require.Equal(t, kubeovnv1.IPHandoffPhaseRolledBack, handoff.Status.Phase)
+		require.Equal(t, "Timeout", handoff.Status.GetCondition(kubeovnv1.Transferred).Reason)
+		require.False(t, handoff.Status.IsConditionTrue(kubeovnv1.PortMigrating))
+
+		// the target pod holding the address of the source pod is deleted
+		_, err := ctrl.config.KubeClient.CoreV1().Pods("default").Get(context.Background(), "target", metav1.GetOptions{})
+		require.True(t, k8serrors.IsNotFound(err))
+		pod, err := ctrl.config.KubeClient.CoreV1().Pods("default").Get(context.Background(), "source", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Empty(t, pod.Annotations[util.IPHandedOffAnnotation])
+	})
+
+	t.Run("roll back when the target pod is deleted", func(t *testing.T) {
+		t.Parallel()
+		source, _ := newIPHandoffTestPods()
+		handoff := transferringIPHandoff(time.Now())
+		fakeController := newFakeController(t, handoff, source)
+		ctrl, nbClient := fakeController.fakeController, fakeController.mockOvnClient
+		nbClient.EXPECT().ResetLogicalSwitchPortMigrateOptions(portName, "node1", "node2", true).Return(nil)
+
+		require.NoError(t, ctrl.checkIPHandoff(handoff))
+		require.Equal(t, kubeovnv1.IPHandoffPhaseRolledBack, handoff.Status.Phase)
+		require.Equal(t, "TargetPodFailed", handoff.Status.GetCondition(kubeovnv1.Transferred).Reason)
+	})
+}
+
+func Test_isIPHandoffPending(t *testing.T) {
+	t.Parallel()
+
+	_, target := newIPHandoffTestPods()
+	handoff := newIPHandoffTestHandoff()
+	fakeController := newFakeController(t, handoff)
+	ctrl := fakeController.fakeController
+	require.True(t, ctrl.isIPHandoffPending(target))
+
+	handoff.Status.Phase = kubeovnv1.IPHandoffPhaseTransferring
+	require.True(t, ctrl.isIPHandoffPending(target))
+
+	// the pod recreated after the rollback allocates an address of its own
+	handoff.Status.Phase = kubeovnv1.IPHandoffPhaseRolledBack
+	require.False(t, ctrl.isIPHandoffPending(target))
+
+	target.Annotations[util.IPHandoffAnnotation] = "not-created"
+	require.True(t, ctrl.isIPHandoffPending(target))
+
+	target.Annotations[util.AllocatedAnnotation] = "true"
+	require.False(t, ctrl.isIPHandoffPending(target))
+	require.False(t, ctrl.isIPHandoffPending(&v1.Pod{}))
+}
@@ -4,17 +4,23 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
+	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ipam"
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
@@ -48,7 +54,10 @@ func (c *Controller) enqueueUpdateIPPool(oldObj, newObj interface{}) {
 	}
 
 	if !reflect.DeepEqual(oldIPPool.Spec.Namespaces, newIPPool.Spec.Namespaces) ||
-		!reflect.DeepEqual(oldIPPool.Spec.IPs, newIPPool.Spec.IPs) {
+		!reflect.DeepEqual(oldIPPool.Spec.IPs, newIPPool.Spec.IPs) ||
+		!reflect.DeepEqual(oldIPPool.Spec.CIDRBlocks, newIPPool.Spec.CIDRBlocks) ||
+		!reflect.DeepEqual(oldIPPool.Spec.ExcludeIPs, newIPPool.Spec.ExcludeIPs) ||
+		!reflect.DeepEqual(oldIPPool.Spec.AutoAllocate, newIPPool.Spec.AutoAllocate) {
 		klog.V(3).Infof("enqueue update ippool %s", key)
 		c.addOrUpdateIPPoolQueue.Add(key)
 	}
@@ -168,8 +177,39 @@ func (c *Controller) handleAddOrUpdateIPPool(key string) error {
 
 	ippool := cachedIPPool.DeepCopy()
 	ippool.Status.EnsureStandardConditions()
-	if err = c.ipam.AddOrUpdateIPPool(ippool.Spec.Subnet, ippool.Name, ippool.Spec.IPs); err != nil {
-		klog.Errorf("failed to add/update ippool %s with IPs %v in subnet %s: %v", ippool.Name, ippool.Spec.IPs, ippool.Spec.Subnet, err)
+	if ippool.Spec.AutoAllocate != nil {
+		// the template has no address itself, the CIDR blocks are carved when the namespaces allocate addresses
+		if err = c.patchIPPoolStatusCondition(ippool, "AutoAllocateTemplate", ""); err != nil {
+			klog.Error(err)
+			return err
+		}
+		return nil
+	}
+	if ns := ippool.Labels[util.IPPoolNamespaceLabel]; ns != "" && ippool.Labels[util.ParentIPPoolLabel] != "" {
+		if _, err = c.namespacesLister.Get(ns); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				klog.Errorf("failed to get namespace %s: %v", ns, err)
+				return err
+			}
+			klog.Infof("release ippool %s carved for deleted namespace %s", ippool.Name, ns)
+			if err = c.config.KubeOvnClient.KubeovnV1().IPPools().Delete(context.Background(), ippool.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				klog.Errorf("failed to delete ippool %s: %v", ippool.Name, err)
+				return err
+			}
+			return nil
+		}
+	}
+
+	ips, err := ipam.IPPoolAddresses(ippool.Spec.IPs, ippool.Spec.CIDRBlocks, ippool.Spec.ExcludeIPs)
+	if err != nil {
+		klog.Errorf("failed to get addresses of ippool %s: %v", ippool.Name, err)
+		if patchErr := c.patchIPPoolStatusCondition(ippool, "UpdateIPAMFailed", err.Error()); patchErr != nil {
+			klog.Error(patchErr)
+		}
+		return err
+	}
+	if err = c.ipam.AddOrUpdateIPPool(ippool.Spec.Subnet, ippool.Name, ips); err != nil {
+		klog.Errorf("failed to add/update ippool %s with IPs %v in subnet %s: %v", ippool.Name, ips, ippool.Spec.Subnet, err)
 		if patchErr := c.patchIPPoolStatusCondition(ippool, "UpdateIPAMFailed", err.Error()); patchErr != nil {
 			klog.Error(patchErr)
 		}
@@ -278,3 +318,191 @@ func (c Controller) patchIPPoolStatus(ippool *kubeovnv1.IPPool) error {
 
 	return nil
 }
+
+// getPodWorkload returns the kind and name of the Deployment or StatefulSet owning the pod
+func getPodWorkload(pod *corev1.Pod) (string, string) {
+	if isStsPod, stsName, _ := isStatefulSetPod(pod); isStsPod {
+		return util.IPPoolOwnerKindStatefulSet, stsName
+	}
+	hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "ReplicaSet" && strings.HasPrefix(owner.APIVersion, "apps/") &&
+			hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			return util.IPPoolOwnerKindDeployment, strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+	}
+	return "", ""
+}
+
+// getPodSelectedIPPool returns the ippool of the subnet selecting the pod by pod selector or owner,
+// the one with the highest priority is returned if the pod is selected by several ippools
+func (c *Controller) getPodSelectedIPPool(pod *corev1.Pod, subnet string) (string, error) {
+	ippools, err := c.ippoolLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list ippools: %v", err)
+		return "", err
+	}
+
+	ownerKind, ownerName := getPodWorkload(pod)
+	var selected *kubeovnv1.IPPool
+	for _, ippool := range ippools {
+		if ippool.Spec.Subnet != subnet || ippool.Spec.AutoAllocate != nil {
+			continue
+		}
+		ok, err := util.IPPoolSelectsPod(&ippool.Spec, pod.Namespace, pod.Labels, ownerKind, ownerName)
+		if err != nil {
+			klog.Errorf("invalid pod selector of ippool %s: %v", ippool.Name, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if selected == nil || ippool.Spec.Priority > selected.Spec.Priority ||
+			(ippool.Spec.Priority == selected.Spec.Priority && ippool.Name < selected.Name) {
+			selected = ippool
+		}
+	}
+	if selected == nil {
+		return "", nil
+	}
+	klog.Infof("pod %s/%s is selected by ippool %s", pod.Namespace, pod.Name, selected.Name)
+	return selected.Name, nil
+}
+
+// getNamespaceAutoAllocatedIPPool returns the ippool carved for the namespace by the auto allocate ippool of the subnet,
+// the CIDR blocks are carved when the namespace allocates address from the subnet for the first time
+func (c *Controller) getNamespaceAutoAllocatedIPPool(namespace string, subnet *kubeovnv1.Subnet) (string, error) {
+	ippools, err := c.ippoolLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list ippools: %v", err)
+		return "", err
+	}
+	sort.Slice(ippools, func(i, j int) bool { return ippools[i].Name < ippools[j].Name })
+	var template *kubeovnv1.IPPool
+	for _, ippool := range ippools {
+		if ippool.Spec.AutoAllocate != nil && ippool.Spec.Subnet == subnet.Name &&
+			(len(ippool.Spec.Namespaces) == 0 || slices.Contains(ippool.Spec.Namespaces, namespace)) {
+			template = ippool
+			break
+		}
+	}
+	if template == nil {
+		return "", nil
+	}
+
+	name := autoAllocatedIPPoolName(template.Name, namespace)
+	if _, err = c.ippoolLister.Get(name); err == nil {
+		return name, nil
+	} else if !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to get ippool %s: %v", name, err)
+		return "", err
+	}
+
+	// carve the CIDR blocks one by one in the subnet
+	lockKey := "auto-allocate/" + subnet.Name
+	c.ippoolKeyMutex.LockKey(lockKey)
+	defer func() { _ = c.ippoolKeyMutex.UnlockKey(lockKey) }()
+
+	// the ippool may be just created and not in the cache yet
+	if _, err = c.config.KubeOvnClient.KubeovnV1().IPPools().Get(context.Background(), name, metav1.GetOptions{}); err == nil {
+		return name, nil
+	} else if !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to get ippool %s: %v", name, err)
+		return "", err
+	}
+
+	parents := template.Spec.CIDRBlocks
+	if len(parents) == 0 {
+		parents = strings.Split(subnet.Spec.CIDRBlock, ",")
+	}
+	usedV4, usedV6 := c.ipam.CarvedOrUsedIPs(subnet.Name)
+	var blocks []string
+	for _, family := range []struct {
+		protocol     string
+		prefixLength int32
+		used         *ipam.IPRangeList
+	}{
+		{kubeovnv1.ProtocolIPv4, template.Spec.AutoAllocate.IPv4PrefixLength, usedV4},
+		{kubeovnv1.ProtocolIPv6, template.Spec.AutoAllocate.IPv6PrefixLength, usedV6},
+	} {
+		if family.prefixLength == 0 {
+			continue
+		}
+		var block string
+		for _, parent := range parents {
+			if util.CheckProtocol(parent) != family.protocol {
+				continue
+			}
+			if block, err = ipam.CarveCIDR(parent, int(family.prefixLength), family.used); err == nil {
+				break
+			}
+		}
+		if block == "" {
+			err = fmt.Errorf("failed to carve a /%d block for namespace %s from ippool %s: %v", family.prefixLength, namespace, template.Name, err)
+			klog.Error(err)
+			return "", err
+		}
+		blocks = append(blocks, block)
+	}
+
+	ips, err := ipam.IPPoolAddresses(nil, blocks, template.Spec.ExcludeIPs)
+	if err != nil {
+		klog.Error(err)
+		return "", err
+	}
+	// add the ippool to ipam before it's handled, so that the blocks are not carved again
+	// and the pod is able to allocate address from it
+	if err = c.ipam.AddOrUpdateIPPool(subnet.Name, name, ips); err != nil {
+		klog.Errorf("failed to add ippool %s to ipam: %v", name, err)
+		return "", err
+	}
+	ippool := &kubeovnv1.IPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				util.ParentIPPoolLabel:    template.Name,
+				util.IPPoolNamespaceLabel: namespace,
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(template, kubeovnv1.SchemeGroupVersion.WithKind("IPPool"))},
+		},
+		Spec: kubeovnv1.IPPoolSpec{
+			Subnet:     subnet.Name,
+			Namespaces: []string{namespace},
+			CIDRBlocks: blocks,
+			ExcludeIPs: template.Spec.ExcludeIPs,
+		},
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().IPPools().Create(context.Background(), ippool, metav1.CreateOptions{}); err != nil && !k8serrors.IsAlreadyExists(err) {
+		klog.Errorf("failed to create ippool %s: %v", name, err)
+		c.ipam.RemoveIPPool(subnet.Name, name)
+		return "", err
+	}
+	klog.Infof("carved CIDR blocks %v from ippool %s for namespace %s", blocks, template.Name, namespace)
+	return name, nil
+}
+
+// autoAllocatedIPPoolName returns the name of the ippool carved from the template for the namespace.
+// The hash of the template and the namespace is appended, so that "a-b" for namespace "c" and "a"
+// for namespace "b-c" get different names, and the name is truncated to fit the length limit.
+func autoAllocatedIPPoolName(template, namespace string) string {
+	suffix := "-" + util.Sha256Hash([]byte(template + "/" + namespace))[:10]
+	prefix := template + "-" + namespace
+	if len(prefix)+len(suffix) > validation.DNS1123SubdomainMaxLength {
+		prefix = strings.TrimRight(prefix[:validation.DNS1123SubdomainMaxLength-len(suffix)], "-.")
+	}
+	return prefix + suffix
+}
+
+// enqueueNamespaceAutoAllocatedIPPools enqueues the ippools carved for the namespace to release them
+func (c *Controller) enqueueNamespaceAutoAllocatedIPPools(namespace string) {
+	ippools, err := c.ippoolLister.List(labels.SelectorFromSet(labels.Set{util.IPPoolNamespaceLabel: namespace}))
+	if err != nil {
+		klog.Errorf("failed to list ippools of namespace %s: %v", namespace, err)
+		return
+	}
+	for _, ippool := range ippools {
+		if ippool.Labels[util.ParentIPPoolLabel] != "" {
+			c.addOrUpdateIPPoolQueue.Add(ippool.Name)
+		}
+	}
+}
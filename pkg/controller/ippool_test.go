@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func Test_autoAllocatedIPPoolName(t *testing.T) {
+	t.Parallel()
+
+	name := autoAllocatedIPPoolName("pool", "ns")
+	require.True(t, strings.HasPrefix(name, "pool-ns-"))
+	require.Equal(t, name, autoAllocatedIPPoolName("pool", "ns"))
+	require.Empty(t, validation.IsDNS1123Subdomain(name))
+
+	// the concatenations are the same, but the names are not
+	require.NotEqual(t, autoAllocatedIPPoolName("a-b", "c"), autoAllocatedIPPoolName("a", "b-c"))
+
+	long := autoAllocatedIPPoolName(strings.Repeat("a", 240)+".b", strings.Repeat("c", 63))
+	require.LessOrEqual(t, len(long), validation.DNS1123SubdomainMaxLength)
+	require.Empty(t, validation.IsDNS1123Subdomain(long))
+	require.NotEqual(t, long, autoAllocatedIPPoolName(strings.Repeat("a", 240)+".b", strings.Repeat("c", 62)))
+}
@@ -21,6 +21,9 @@ func (c *Controller) enqueueAddNamespace(obj interface{}) {
 			c.updateNpQueue.Add(np)
 		}
 	}
+	if c.config.EnableANP {
+		c.enqueueAnpsByNamespaces(obj.(*v1.Namespace))
+	}
 	var key string
 	var err error
 	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
@@ -36,6 +39,12 @@ func (c *Controller) enqueueDeleteNamespace(obj interface{}) {
 			c.updateNpQueue.Add(np)
 		}
 	}
+	if c.config.EnableANP {
+		c.enqueueAnpsByNamespaces(obj.(*v1.Namespace))
+	}
+	if ns, ok := obj.(*v1.Namespace); ok {
+		c.enqueueNamespaceAutoAllocatedIPPools(ns.Name)
+	}
 }
 
 func (c *Controller) enqueueUpdateNamespace(oldObj, newObj interface{}) {
@@ -52,6 +61,9 @@ func (c *Controller) enqueueUpdateNamespace(oldObj, newObj interface{}) {
 			c.updateNpQueue.Add(np)
 		}
 	}
+	if c.config.EnableANP && !reflect.DeepEqual(oldNs.Labels, newNs.Labels) {
+		c.enqueueAnpsByNamespaces(oldNs, newNs)
+	}
 
 	// in case annotations are removed by other controllers
 	if newNs.Annotations == nil || newNs.Annotations[util.LogicalSwitchAnnotation] == "" {
@@ -140,7 +152,8 @@ func (c *Controller) handleAddNamespace(key string) error {
 	}
 
 	for _, p := range ippools {
-		if util.ContainsString(p.Spec.Namespaces, key) {
+		// the ippools with selectors are chosen for the selected pods only
+		if p.Spec.AutoAllocate == nil && p.Spec.PodSelector == nil && len(p.Spec.Owners) == 0 && util.ContainsString(p.Spec.Namespaces, key) {
 			ippool = p.Name
 			break
 		}
@@ -66,6 +66,164 @@ var (
 			"ip",
 			"pod_name",
 		})
+
+	metricSubnetIPAllocationRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "subnet_ip_allocation_rate",
+			Help: "The num of ip addresses allocated per hour in subnet, estimated in the forecast window.",
+		},
+		[]string{
+			"subnet_name",
+			"protocol",
+		})
+
+	metricSubnetIPReleaseRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "subnet_ip_release_rate",
+			Help: "The num of ip addresses released per hour in subnet, estimated in the forecast window.",
+		},
+		[]string{
+			"subnet_name",
+			"protocol",
+		})
+
+	metricSubnetTimeToExhaustion = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "subnet_time_to_exhaustion_seconds",
+			Help: "The estimated seconds until the ip addresses of subnet are exhausted at the current allocation and release rates, only exported when the addresses are being consumed.",
+		},
+		[]string{
+			"subnet_name",
+		})
+
+	metricIPPoolIPAllocationRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ippool_ip_allocation_rate",
+			Help: "The num of ip addresses allocated per hour in ippool, estimated in the forecast window.",
+		},
+		[]string{
+			"ippool_name",
+			"subnet_name",
+			"protocol",
+		})
+
+	metricIPPoolIPReleaseRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ippool_ip_release_rate",
+			Help: "The num of ip addresses released per hour in ippool, estimated in the forecast window.",
+		},
+		[]string{
+			"ippool_name",
+			"subnet_name",
+			"protocol",
+		})
+
+	metricIPPoolTimeToExhaustion = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ippool_time_to_exhaustion_seconds",
+			Help: "The estimated seconds until the ip addresses of ippool are exhausted at the current allocation and release rates, only exported when the addresses are being consumed.",
+		},
+		[]string{
+			"ippool_name",
+			"subnet_name",
+		})
+
+	metricVpcNatGwEipConntrackEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vpc_nat_gw_eip_conntrack_entries",
+			Help: "The num of active conntrack entries of the iptables eip in vpc nat gateway.",
+		},
+		[]string{
+			"vpc_nat_gateway",
+			"eip",
+		})
+
+	metricVpcNatGwEipBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vpc_nat_gw_eip_bytes",
+			Help: "The bytes forwarded by the nat rules of the iptables eip in vpc nat gateway, read from the iptables or nftables counters.",
+		},
+		[]string{
+			"vpc_nat_gateway",
+			"eip",
+		})
+
+	metricVpcNatGwEipPackets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vpc_nat_gw_eip_packets",
+			Help: "The packets forwarded by the nat rules of the iptables eip in vpc nat gateway, read from the iptables or nftables counters.",
+		},
+		[]string{
+			"vpc_nat_gateway",
+			"eip",
+		})
+
+	metricVpcNatGwEipDrops = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vpc_nat_gw_eip_drops",
+			Help: "The num of packets of the iptables eip dropped by the eip qos in vpc nat gateway.",
+		},
+		[]string{
+			"vpc_nat_gateway",
+			"eip",
+		})
+
+	metricVpcNatGwRuleConntrackEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vpc_nat_gw_rule_conntrack_entries",
+			Help: "The num of active conntrack entries of the iptables fip, dnat or snat rule in vpc nat gateway.",
+		},
+		[]string{
+			"vpc_nat_gateway",
+			"eip",
+			"type",
+			"rule",
+		})
+
+	metricVpcNatGwRuleBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vpc_nat_gw_rule_bytes",
+			Help: "The bytes forwarded by the iptables fip, dnat or snat rule in vpc nat gateway, read from the iptables or nftables counters.",
+		},
+		[]string{
+			"vpc_nat_gateway",
+			"eip",
+			"type",
+			"rule",
+		})
+
+	metricVpcNatGwRulePackets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vpc_nat_gw_rule_packets",
+			Help: "The packets forwarded by the iptables fip, dnat or snat rule in vpc nat gateway, read from the iptables or nftables counters.",
+		},
+		[]string{
+			"vpc_nat_gateway",
+			"eip",
+			"type",
+			"rule",
+		})
+
+	metricVpcNatGwRuleConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vpc_nat_gw_rule_connections",
+			Help: "The num of connections translated by the iptables fip, dnat or snat rule in vpc nat gateway, read from the iptables or nftables counters.",
+		},
+		[]string{
+			"vpc_nat_gateway",
+			"eip",
+			"type",
+			"rule",
+		})
+
+	metricVpcNatGwConntrackDrops = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vpc_nat_gw_conntrack_drops",
+			Help: "The num of packets dropped by conntrack in vpc nat gateway, including drop, early_drop and insert_failed, which can not be attributed to an eip.",
+		},
+		[]string{
+			"vpc_nat_gateway",
+		})
 )
 
 func registerMetrics() {
@@ -74,4 +232,19 @@ func registerMetrics() {
 	metrics.Registry.MustRegister(metricCentralSubnetInfo)
 	metrics.Registry.MustRegister(metricSubnetIPAMInfo)
 	metrics.Registry.MustRegister(metricSubnetIPAssignedInfo)
+	metrics.Registry.MustRegister(metricSubnetIPAllocationRate)
+	metrics.Registry.MustRegister(metricSubnetIPReleaseRate)
+	metrics.Registry.MustRegister(metricSubnetTimeToExhaustion)
+	metrics.Registry.MustRegister(metricIPPoolIPAllocationRate)
+	metrics.Registry.MustRegister(metricIPPoolIPReleaseRate)
+	metrics.Registry.MustRegister(metricIPPoolTimeToExhaustion)
+	metrics.Registry.MustRegister(metricVpcNatGwEipConntrackEntries)
+	metrics.Registry.MustRegister(metricVpcNatGwEipBytes)
+	metrics.Registry.MustRegister(metricVpcNatGwEipPackets)
+	metrics.Registry.MustRegister(metricVpcNatGwEipDrops)
+	metrics.Registry.MustRegister(metricVpcNatGwRuleConntrackEntries)
+	metrics.Registry.MustRegister(metricVpcNatGwRuleBytes)
+	metrics.Registry.MustRegister(metricVpcNatGwRulePackets)
+	metrics.Registry.MustRegister(metricVpcNatGwRuleConnections)
+	metrics.Registry.MustRegister(metricVpcNatGwConntrackDrops)
 }
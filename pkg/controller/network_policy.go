@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 	"strconv"
@@ -51,7 +52,7 @@ func (c *Controller) enqueueUpdateNp(oldObj, newObj interface{}) {
 	oldNp := oldObj.(*netv1.NetworkPolicy)
 	newNp := newObj.(*netv1.NetworkPolicy)
 	if !reflect.DeepEqual(oldNp.Spec, newNp.Spec) ||
-		!reflect.DeepEqual(oldNp.Annotations, newNp.Annotations) {
+		!reflect.DeepEqual(npSyncAnnotations(oldNp), npSyncAnnotations(newNp)) {
 		var key string
 		var err error
 		if key, err = cache.MetaNamespaceKeyFunc(newObj); err != nil {
@@ -63,6 +64,14 @@ func (c *Controller) enqueueUpdateNp(oldObj, newObj interface{}) {
 	}
 }
 
+// npSyncAnnotations returns the annotations of the network policy except the ones written back by the controller,
+// so that updating the status does not trigger another sync
+func npSyncAnnotations(np *netv1.NetworkPolicy) map[string]string {
+	annotations := maps.Clone(np.Annotations)
+	delete(annotations, util.NetworkPolicyStatusAnnotation)
+	return annotations
+}
+
 func (c *Controller) runUpdateNpWorker() {
 	for c.processNextUpdateNpWorkItem() {
 	}
@@ -133,7 +142,7 @@ func (c *Controller) processNextDeleteNpWorkItem() bool {
 	return true
 }
 
-func (c *Controller) handleUpdateNp(key string) error {
+func (c *Controller) handleUpdateNp(key string) (err error) {
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
@@ -169,6 +178,9 @@ func (c *Controller) handleUpdateNp(key string) error {
 		logActions = []string{ovnnb.ACLActionDrop}
 	}
 
+	// egress to the domain names is allowed in addition to the egress rules
+	fqdns := getNpEgressFQDNs(np)
+
 	npName := np.Name
 	if nameArray := []rune(np.Name); !unicode.IsLetter(nameArray[0]) {
 		npName = "np" + np.Name
@@ -177,12 +189,16 @@ func (c *Controller) handleUpdateNp(key string) error {
 	// TODO: ovn acl doesn't support address_set name with '-', now we replace '-' by '.'.
 	// This may cause conflict if two np with name test-np and test.np. Maybe hash is a better solution,
 	// but we do not want to lost the readability now.
-	pgName := strings.ReplaceAll(fmt.Sprintf("%s.%s", npName, np.Namespace), "-", ".")
+	pgName := npPortGroupName(np.Namespace, np.Name)
 	ingressAllowAsNamePrefix := strings.ReplaceAll(fmt.Sprintf("%s.%s.ingress.allow", npName, np.Namespace), "-", ".")
 	ingressExceptAsNamePrefix := strings.ReplaceAll(fmt.Sprintf("%s.%s.ingress.except", npName, np.Namespace), "-", ".")
 	egressAllowAsNamePrefix := strings.ReplaceAll(fmt.Sprintf("%s.%s.egress.allow", npName, np.Namespace), "-", ".")
 	egressExceptAsNamePrefix := strings.ReplaceAll(fmt.Sprintf("%s.%s.egress.except", npName, np.Namespace), "-", ".")
 
+	defer func() {
+		c.updateNpStatus(np, pgName, err)
+	}()
+
 	if err = c.OVNNbClient.CreatePortGroup(pgName, map[string]string{networkPolicyKey: np.Namespace + "/" + npName}); err != nil {
 		klog.Errorf("create port group for np %s: %v", key, err)
 		return err
@@ -502,6 +518,44 @@ func (c *Controller) handleUpdateNp(key string) error {
 					return err
 				}
 
+				egressACLOps = append(egressACLOps, ops...)
+			}
+			if len(fqdns) != 0 {
+				egressAllowAsName := fmt.Sprintf("%s.%s.fqdn", egressAllowAsNamePrefix, protocol)
+				egressExceptAsName := fmt.Sprintf("%s.%s.fqdn", egressExceptAsNamePrefix, protocol)
+				aclName := fmt.Sprintf("np/%s.%s/egress/%s/fqdn", npName, np.Namespace, protocol)
+
+				var allows []string
+				for _, name := range fqdns {
+					allows = append(allows, c.fqdnCache.Addresses(name, protocol)...)
+				}
+				klog.Infof("UpdateNp Egress, fqdns %v resolved to %v, log %v", fqdns, allows, logEnable)
+
+				if err = c.OVNNbClient.CreateAddressSet(egressAllowAsName, map[string]string{
+					networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, npName, "egress"),
+				}); err != nil {
+					klog.Errorf("create address set %s for np %s: %v", egressAllowAsName, key, err)
+					return err
+				}
+
+				if err = c.OVNNbClient.AddressSetUpdateAddress(egressAllowAsName, allows...); err != nil {
+					klog.Errorf("set egress fqdn ips to address set %s: %v", egressAllowAsName, err)
+					return err
+				}
+
+				if err = c.OVNNbClient.CreateAddressSet(egressExceptAsName, map[string]string{
+					networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, npName, "egress"),
+				}); err != nil {
+					klog.Errorf("create address set %s for np %s: %v", egressExceptAsName, key, err)
+					return err
+				}
+
+				ops, err := c.OVNNbClient.UpdateEgressACLOps(pgName, egressAllowAsName, egressExceptAsName, protocol, aclName, nil, logEnable, logActions, namedPortMap)
+				if err != nil {
+					klog.Errorf("generate operations that add egress fqdn acls to np %s: %v", key, err)
+					return err
+				}
+
 				egressACLOps = append(egressACLOps, ops...)
 			}
 		}
@@ -533,6 +587,15 @@ func (c *Controller) handleUpdateNp(key string) error {
 			if idxStr == "all" {
 				continue
 			}
+			if idxStr == "fqdn" {
+				if len(fqdns) == 0 {
+					if err = c.OVNNbClient.DeleteAddressSet(as.Name); err != nil {
+						klog.Errorf("delete np %s address set: %v", key, err)
+						return err
+					}
+				}
+				continue
+			}
 
 			idx, _ := strconv.Atoi(idxStr)
 			if idx >= len(np.Spec.Egress) {
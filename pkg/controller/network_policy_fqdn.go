@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/scylladb/go-set/strset"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const (
+	// answers are cached for at least fqdnMinTTL to avoid flooding the dns server
+	fqdnMinTTL = 5 * time.Second
+	// a name is resolved again at least every fqdnMaxTTL
+	fqdnMaxTTL       = 5 * time.Minute
+	fqdnQueryTimeout = 3 * time.Second
+	// max number of names resolved at the same time
+	fqdnMaxConcurrentQueries = 16
+)
+
+type fqdnEntry struct {
+	// key is the address, value is its expiration time
+	addresses map[string]time.Time
+	// timer resolves the name again when the first address expires
+	timer *time.Timer
+}
+
+// FQDNCache keeps the resolved addresses of domain names referenced by network policies.
+// Each name is resolved by its own timer driven by the ttl of the answers, so slow names do not delay others.
+// An address is kept until its ttl expires, even if it is missing from the latest answer,
+// so that connections to rotated addresses are not broken.
+type FQDNCache struct {
+	mutex   sync.RWMutex
+	entries map[string]*fqdnEntry
+	queries chan struct{}
+
+	resolve func(name string) (map[string]uint32, error)
+	// onChange is called when the addresses of the name changed
+	onChange func(name string)
+}
+
+func NewFQDNCache(resolve func(name string) (map[string]uint32, error), onChange func(name string)) *FQDNCache {
+	return &FQDNCache{
+		entries:  map[string]*fqdnEntry{},
+		queries:  make(chan struct{}, fqdnMaxConcurrentQueries),
+		resolve:  resolve,
+		onChange: onChange,
+	}
+}
+
+// Addresses returns the unexpired addresses of the name in the protocol
+func (f *FQDNCache) Addresses(name, protocol string) []string {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	entry, ok := f.entries[name]
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	addresses := make([]string, 0, len(entry.addresses))
+	for address, expiration := range entry.addresses {
+		if expiration.After(now) && util.CheckProtocol(address) == protocol {
+			addresses = append(addresses, address)
+		}
+	}
+	slices.Sort(addresses)
+	return addresses
+}
+
+// SetNames starts resolving the new names, and stops resolving the names which are not referenced any more
+func (f *FQDNCache) SetNames(names *strset.Set) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for name, entry := range f.entries {
+		if !names.Has(name) {
+			entry.timer.Stop()
+			delete(f.entries, name)
+		}
+	}
+	names.Each(func(name string) bool {
+		if _, ok := f.entries[name]; !ok {
+			entry := &fqdnEntry{addresses: map[string]time.Time{}}
+			entry.timer = time.AfterFunc(0, func() { f.refresh(name, entry) })
+			f.entries[name] = entry
+		}
+		return true
+	})
+}
+
+// refresh resolves the name and schedules the next resolution
+func (f *FQDNCache) refresh(name string, entry *fqdnEntry) {
+	f.queries <- struct{}{}
+	answers, err := f.resolve(name)
+	<-f.queries
+	if err != nil {
+		klog.Errorf("failed to resolve %s: %v", name, err)
+	}
+
+	f.mutex.Lock()
+	if f.entries[name] != entry {
+		// the name is not referenced any more
+		f.mutex.Unlock()
+		return
+	}
+	before := len(entry.addresses)
+	modified, refreshAfter := entry.update(time.Now(), answers, err)
+	entry.timer.Reset(refreshAfter)
+	after := len(entry.addresses)
+	f.mutex.Unlock()
+
+	if modified {
+		klog.Infof("addresses of %s changed from %d to %d entries", name, before, after)
+		f.onChange(name)
+	}
+}
+
+// update merges the answers into the addresses, removes the expired addresses,
+// and returns whether the addresses changed and when the name should be resolved again
+func (e *fqdnEntry) update(now time.Time, answers map[string]uint32, err error) (bool, time.Duration) {
+	modified := false
+	for address, expiration := range e.addresses {
+		if !expiration.After(now) {
+			delete(e.addresses, address)
+			modified = true
+		}
+	}
+
+	refreshAfter := fqdnMaxTTL
+	if err != nil {
+		refreshAfter = fqdnMinTTL
+	}
+	for address, ttl := range answers {
+		if _, ok := e.addresses[address]; !ok {
+			modified = true
+		}
+		e.addresses[address] = now.Add(max(time.Duration(ttl)*time.Second, fqdnMinTTL))
+	}
+	// resolve again when any address expires
+	for _, expiration := range e.addresses {
+		refreshAfter = min(refreshAfter, expiration.Sub(now))
+	}
+	return modified, refreshAfter
+}
+
+// getNpEgressFQDNs returns the valid domain names in the egress fqdn annotation of the network policy,
+// invalid names are rejected by the webhook and ignored here
+func getNpEgressFQDNs(np *netv1.NetworkPolicy) []string {
+	names, err := util.ParseEgressFQDNs(np.Annotations[util.NetworkPolicyEgressFQDNsAnnotation])
+	if err != nil {
+		klog.Warningf("ignore domain names in network policy %s/%s: %v", np.Namespace, np.Name, err)
+	}
+	return names
+}
+
+func (c *Controller) resolveFQDN(name string) (map[string]uint32, error) {
+	return util.LookupFQDN(c.config.FQDNDNSServer, name, fqdnQueryTimeout)
+}
+
+// syncNpEgressFQDNs registers the domain names referenced by network policies to the fqdn cache
+func (c *Controller) syncNpEgressFQDNs() {
+	nps, err := c.npsLister.NetworkPolicies(corev1.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list network policies: %v", err)
+		return
+	}
+
+	names := strset.New()
+	for _, np := range nps {
+		names.Add(getNpEgressFQDNs(np)...)
+	}
+	c.fqdnCache.SetNames(names)
+}
+
+// enqueueNpsByFQDN enqueues the network policies referencing the domain name whose addresses changed
+func (c *Controller) enqueueNpsByFQDN(name string) {
+	nps, err := c.npsLister.NetworkPolicies(corev1.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list network policies: %v", err)
+		return
+	}
+	for _, np := range nps {
+		if slices.Contains(getNpEgressFQDNs(np), name) {
+			key := fmt.Sprintf("%s/%s", np.Namespace, np.Name)
+			klog.V(3).Infof("enqueue update network policy %s for fqdn %s", key, name)
+			c.updateNpQueue.Add(key)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/scylladb/go-set/strset"
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_getNpEgressFQDNs(t *testing.T) {
+	np := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+		Name:        "np",
+		Namespace:   "default",
+		Annotations: map[string]string{util.NetworkPolicyEgressFQDNsAnnotation: "api.github.com, API.github.com.,*.s3.amazonaws.com,,"},
+	}}
+	require.Equal(t, []string{"api.github.com"}, getNpEgressFQDNs(np))
+}
+
+func Test_fqdnEntryUpdate(t *testing.T) {
+	entry := &fqdnEntry{addresses: map[string]time.Time{}}
+
+	now := time.Now()
+	answers := map[string]uint32{"192.0.2.1": 60, "192.0.2.2": 300, "2001:db8::1": 300}
+	modified, refreshAfter := entry.update(now, answers, nil)
+	require.True(t, modified)
+	require.Equal(t, 60*time.Second, refreshAfter)
+
+	// the same answer
+	modified, refreshAfter = entry.update(now.Add(30*time.Second), answers, nil)
+	require.False(t, modified)
+	require.Equal(t, 60*time.Second, refreshAfter)
+
+	// addresses missing from the latest answer are kept until their ttl expires
+	modified, refreshAfter = entry.update(now.Add(60*time.Second), map[string]uint32{"192.0.2.3": 1}, nil)
+	require.True(t, modified)
+	require.Equal(t, fqdnMinTTL, refreshAfter)
+	require.Contains(t, entry.addresses, "192.0.2.2")
+	require.Contains(t, entry.addresses, "192.0.2.3")
+
+	// unexpired addresses are kept when the name fails to be resolved
+	modified, refreshAfter = entry.update(now.Add(100*time.Second), nil, errors.New("timeout"))
+	require.True(t, modified)
+	require.Equal(t, fqdnMinTTL, refreshAfter)
+	require.Len(t, entry.addresses, 2)
+	require.Contains(t, entry.addresses, "192.0.2.2")
+	require.NotContains(t, entry.addresses, "192.0.2.3")
+}
+
+func TestFQDNCache(t *testing.T) {
+	slowStarted := make(chan struct{})
+	releaseSlow := make(chan struct{})
+	changed := make(chan string, 2)
+	resolve := func(name string) (map[string]uint32, error) {
+		if name == "slow.example.com" {
+			close(slowStarted)
+			<-releaseSlow
+			return map[string]uint32{"2001:db8::1": 300}, nil
+		}
+		return map[string]uint32{"192.0.2.1": 300, "192.0.2.2": 300}, nil
+	}
+	cache := NewFQDNCache(resolve, func(name string) { changed <- name })
+
+	cache.SetNames(strset.New("slow.example.com", "api.example.com"))
+	<-slowStarted
+	// a slow name does not block the resolution of other names
+	require.Equal(t, "api.example.com", <-changed)
+	require.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, cache.Addresses("api.example.com", kubeovnv1.ProtocolIPv4))
+	require.Empty(t, cache.Addresses("slow.example.com", kubeovnv1.ProtocolIPv6))
+
+	close(releaseSlow)
+	require.Equal(t, "slow.example.com", <-changed)
+	require.Equal(t, []string{"2001:db8::1"}, cache.Addresses("slow.example.com", kubeovnv1.ProtocolIPv6))
+
+	cache.SetNames(strset.New("slow.example.com"))
+	require.Empty(t, cache.Addresses("api.example.com", kubeovnv1.ProtocolIPv4))
+	require.Equal(t, []string{"2001:db8::1"}, cache.Addresses("slow.example.com", kubeovnv1.ProtocolIPv6))
+	cache.SetNames(strset.New())
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+	"unicode"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// npStatus is the programming status of a network policy written to the status annotation
+type npStatus struct {
+	PortGroup          string `json:"portGroup"`
+	ACLCount           int    `json:"aclCount"`
+	LastSyncError      string `json:"lastSyncError,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime"`
+}
+
+// npPortGroupName returns the name of the port group holding the acls of a network policy
+func npPortGroupName(namespace, name string) string {
+	npName := name
+	if nameArray := []rune(name); !unicode.IsLetter(nameArray[0]) {
+		npName = "np" + name
+	}
+	return strings.ReplaceAll(fmt.Sprintf("%s.%s", npName, namespace), "-", ".")
+}
+
+// updateNpStatus writes the port group, acl count and sync error of the network policy back to its annotation,
+// the annotation is patched only when the status changes
+func (c *Controller) updateNpStatus(np *netv1.NetworkPolicy, pgName string, syncErr error) {
+	status := npStatus{PortGroup: pgName}
+	if syncErr != nil {
+		status.LastSyncError = syncErr.Error()
+	}
+	// the acls are listed from the local cache of the nb database
+	acls, err := c.OVNNbClient.ListAcls("", map[string]string{util.ACLParentKey: pgName})
+	if err != nil {
+		klog.Errorf("failed to list acls of port group %s: %v", pgName, err)
+		return
+	}
+	status.ACLCount = len(acls)
+
+	if value := np.Annotations[util.NetworkPolicyStatusAnnotation]; value != "" {
+		var current npStatus
+		if err = json.Unmarshal([]byte(value), &current); err == nil {
+			current.LastTransitionTime = ""
+			if current == status {
+				return
+			}
+		}
+	}
+
+	status.LastTransitionTime = time.Now().UTC().Format(time.RFC3339)
+	value, err := json.Marshal(status)
+	if err != nil {
+		klog.Errorf("failed to marshal status of network policy %s/%s: %v", np.Namespace, np.Name, err)
+		return
+	}
+	patch := util.KVPatch{util.NetworkPolicyStatusAnnotation: string(value)}
+	if err = util.PatchAnnotations(c.config.KubeClient.NetworkingV1().NetworkPolicies(np.Namespace), np.Name, patch); err != nil {
+		klog.Errorf("failed to update status of network policy %s/%s: %v", np.Namespace, np.Name, err)
+	}
+}
+
+// syncNpACLCookies records the openflow cookies of network policy acls in a configmap in the namespace of kube-ovn-controller,
+// so that kube-ovn-cni is able to export the hit counters of the acls from the flow statistics of br-int.
+// The logical flows are generated by ovn-northd asynchronously and may be recreated, so the cookies are synced periodically
+// and the configmap is written only when the cookies change.
+func (c *Controller) syncNpACLCookies() {
+	nps, err := c.npsLister.NetworkPolicies(corev1.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list network policies: %v", err)
+		return
+	}
+	policies := make(map[string]string, len(nps))
+	for _, np := range nps {
+		policies[npPortGroupName(np.Namespace, np.Name)] = np.Namespace + "/" + np.Name
+	}
+
+	acls, err := c.OVNNbClient.ListAcls("", map[string]string{util.NetworkPolicyRuleKey: ""})
+	if err != nil {
+		klog.Errorf("failed to list network policy acls: %v", err)
+		return
+	}
+	aclRules := make(map[string]string, len(acls))
+	uuids := make([]string, 0, len(acls))
+	for _, acl := range acls {
+		policy := policies[acl.ExternalIDs[util.ACLParentKey]]
+		if policy == "" {
+			continue
+		}
+		aclRules[acl.UUID] = policy + "/" + acl.ExternalIDs[util.NetworkPolicyRuleKey]
+		uuids = append(uuids, acl.UUID)
+	}
+	aclCookies, err := c.OVNSbClient.GetLogicalFlowCookies(uuids)
+	if err != nil {
+		klog.Errorf("failed to get logical flow cookies of network policy acls: %v", err)
+		return
+	}
+
+	// cookie -> namespace/policy/direction/index
+	data := make(map[string]string)
+	for uuid, cookies := range aclCookies {
+		for _, cookie := range cookies {
+			data[fmt.Sprintf("%#x", cookie)] = aclRules[uuid]
+		}
+	}
+
+	cm, err := c.configMapsLister.ConfigMaps(c.config.PodNamespace).Get(util.NetworkPolicyACLCookiesConfigMap)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get configmap %s: %v", util.NetworkPolicyACLCookiesConfigMap, err)
+			return
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: util.NetworkPolicyACLCookiesConfigMap},
+			Data:       data,
+		}
+		if _, err = c.config.KubeClient.CoreV1().ConfigMaps(c.config.PodNamespace).Create(context.Background(), cm, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create configmap %s: %v", util.NetworkPolicyACLCookiesConfigMap, err)
+		}
+		return
+	}
+	if maps.Equal(cm.Data, data) {
+		return
+	}
+
+	cm = cm.DeepCopy()
+	cm.Data = data
+	if _, err = c.config.KubeClient.CoreV1().ConfigMaps(c.config.PodNamespace).Update(context.Background(), cm, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("failed to update configmap %s: %v", util.NetworkPolicyACLCookiesConfigMap, err)
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	netlisterv1 "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+
+	mockovs "github.com/kubeovn/kube-ovn/mocks/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_npPortGroupName(t *testing.T) {
+	require.Equal(t, "allow.dns.kube.system", npPortGroupName("kube-system", "allow-dns"))
+	require.Equal(t, "np1st.default", npPortGroupName("default", "1st"))
+}
+
+func Test_npSyncAnnotations(t *testing.T) {
+	np := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+		Name:      "np",
+		Namespace: "default",
+		Annotations: map[string]string{
+			util.NetworkPolicyLogAnnotation:    "true",
+			util.NetworkPolicyStatusAnnotation: `{"portGroup":"np.default","aclCount":2}`,
+		},
+	}}
+	require.Equal(t, map[string]string{util.NetworkPolicyLogAnnotation: "true"}, npSyncAnnotations(np))
+	require.Len(t, np.Annotations, 2)
+}
+
+func Test_syncNpACLCookies(t *testing.T) {
+	t.Parallel()
+
+	np := &netv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "allow-dns", Namespace: "kube-system"}}
+	npIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, npIndexer.Add(np))
+	cmIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	mockCtrl := gomock.NewController(t)
+	nbClient := mockovs.NewMockNbClient(mockCtrl)
+	sbClient := mockovs.NewMockSbClient(mockCtrl)
+	kubeClient := fake.NewSimpleClientset()
+	ctrl := &Controller{
+		config:           &Configuration{KubeClient: kubeClient, PodNamespace: "kube-system"},
+		npsLister:        netlisterv1.NewNetworkPolicyLister(npIndexer),
+		configMapsLister: listerv1.NewConfigMapLister(cmIndexer),
+		OVNNbClient:      nbClient,
+		OVNSbClient:      sbClient,
+	}
+
+	acls := []ovnnb.ACL{
+		{UUID: "acl-1", ExternalIDs: map[string]string{util.ACLParentKey: "allow.dns.kube.system", util.NetworkPolicyRuleKey: "ingress/0"}},
+		{UUID: "acl-2", ExternalIDs: map[string]string{util.ACLParentKey: "allow.dns.kube.system", util.NetworkPolicyRuleKey: "egress/1"}},
+		{UUID: "acl-3", ExternalIDs: map[string]string{util.ACLParentKey: "deleted.default", util.NetworkPolicyRuleKey: "ingress/0"}},
+	}
+	nbClient.EXPECT().ListAcls("", map[string]string{util.NetworkPolicyRuleKey: ""}).Return(acls, nil).Times(2)
+	sbClient.EXPECT().GetLogicalFlowCookies([]string{"acl-1", "acl-2"}).Return(map[string][]uint64{
+		"acl-1": {0x1a2b},
+		"acl-2": {0x3c4d, 0x5e6f},
+	}, nil).Times(2)
+
+	expected := map[string]string{
+		"0x1a2b": "kube-system/allow-dns/ingress/0",
+		"0x3c4d": "kube-system/allow-dns/egress/1",
+		"0x5e6f": "kube-system/allow-dns/egress/1",
+	}
+	ctrl.syncNpACLCookies()
+	cm, err := kubeClient.CoreV1().ConfigMaps("kube-system").Get(context.Background(), util.NetworkPolicyACLCookiesConfigMap, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, expected, cm.Data)
+
+	// the configmap is not written again when the cookies do not change
+	require.NoError(t, cmIndexer.Add(cm))
+	kubeClient.ClearActions()
+	ctrl.syncNpACLCookies()
+	require.Empty(t, kubeClient.Actions())
+}
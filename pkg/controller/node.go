@@ -42,6 +42,10 @@ func (c *Controller) enqueueAddNode(obj interface{}) {
 	}
 	klog.V(3).Infof("enqueue add node %s", key)
 	c.addNodeQueue.Add(key)
+
+	if c.config.EnableANP {
+		c.enqueueAnpsByNodes()
+	}
 }
 
 func nodeReady(node *v1.Node) bool {
@@ -61,6 +65,11 @@ func (c *Controller) enqueueUpdateNode(oldObj, newObj interface{}) {
 	oldNode := oldObj.(*v1.Node)
 	newNode := newObj.(*v1.Node)
 
+	if c.config.EnableANP && (!reflect.DeepEqual(oldNode.Labels, newNode.Labels) ||
+		!reflect.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses)) {
+		c.enqueueAnpsByNodes()
+	}
+
 	if nodeReady(oldNode) != nodeReady(newNode) ||
 		!reflect.DeepEqual(oldNode.Annotations, newNode.Annotations) {
 		var key string
@@ -91,6 +100,10 @@ func (c *Controller) enqueueDeleteNode(obj interface{}) {
 	n := obj.(*v1.Node)
 	c.deletingNodeObjMap.Store(key, n)
 	c.deleteNodeQueue.Add(key)
+
+	if c.config.EnableANP {
+		c.enqueueAnpsByNodes()
+	}
 }
 
 func (c *Controller) runAddNodeWorker() {
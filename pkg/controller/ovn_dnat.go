@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 
 	"github.com/ovn-org/libovsdb/ovsdb"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -185,11 +186,9 @@ func (c *Controller) processNextDeleteOvnDnatRuleWorkItem() bool {
 	return true
 }
 
-func (c *Controller) isOvnDnatDuplicated(eipName, dnatName, externalPort string) error {
+func (c *Controller) isOvnDnatDuplicated(eipName, dnatName, protocol, externalPort string) error {
 	// check if eip:external port already used
-	dnats, err := c.ovnDnatRulesLister.List(labels.SelectorFromSet(labels.Set{
-		util.VpcDnatEPortLabel: externalPort,
-	}))
+	dnats, err := c.ovnDnatRulesLister.List(labels.Everything())
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			klog.Error(err)
@@ -198,8 +197,9 @@ func (c *Controller) isOvnDnatDuplicated(eipName, dnatName, externalPort string)
 	}
 	if len(dnats) != 0 {
 		for _, d := range dnats {
-			if d.Name != dnatName && d.Spec.OvnEip == eipName {
-				err = fmt.Errorf("failed to create dnat %s, duplicate, same eip %s, same external port '%s' is using by dnat %s", dnatName, eipName, externalPort, d.Name)
+			if d.Name != dnatName && d.Spec.OvnEip == eipName &&
+				util.IsDnatPortConflict(protocol, externalPort, d.Spec.Protocol, d.Spec.ExternalPort) {
+				err = fmt.Errorf("failed to create dnat %s, duplicate, same eip %s, external port '%s' overlaps with port '%s' of dnat %s", dnatName, eipName, externalPort, d.Spec.ExternalPort, d.Name)
 				return err
 			}
 		}
@@ -245,7 +245,7 @@ func (c *Controller) handleAddOvnDnatRule(key string) error {
 		klog.Error(err)
 		return err
 	}
-	if err := c.isOvnDnatDuplicated(eipName, key, cachedDnat.Spec.ExternalPort); err != nil {
+	if err := c.isOvnDnatDuplicated(eipName, key, cachedDnat.Spec.Protocol, cachedDnat.Spec.ExternalPort); err != nil {
 		klog.Errorf("failed to create dnat %s, %v", cachedDnat.Name, err)
 		return err
 	}
@@ -396,7 +396,7 @@ func (c *Controller) handleUpdateOvnDnatRule(key string) error {
 		klog.Error(err)
 		return err
 	}
-	if err := c.isOvnDnatDuplicated(eipName, key, cachedDnat.Spec.ExternalPort); err != nil {
+	if err := c.isOvnDnatDuplicated(eipName, key, cachedDnat.Spec.Protocol, cachedDnat.Spec.ExternalPort); err != nil {
 		klog.Errorf("failed to create dnat %s, %v", cachedDnat.Name, err)
 		return err
 	}
@@ -619,21 +619,29 @@ func (c *Controller) patchOvnDnatStatus(key, vpcName, v4Eip, podIP string, ready
 	return nil
 }
 
+// AddDnatRule adds one load balancer vip for each external port of the dnat,
+// since the vips of ovn load balancers do not support port ranges
 func (c *Controller) AddDnatRule(vpcName, dnatName, externalIP, internalIP, externalPort, internalPort, protocol string) error {
-	var (
-		externalEndpoint = net.JoinHostPort(externalIP, externalPort)
-		internalEndpoint = net.JoinHostPort(internalIP, internalPort)
-		err              error
-	)
+	external, internal, err := util.ParseOvnDnatPortRanges(externalPort, internalPort)
+	if err != nil {
+		klog.Errorf("failed to parse ports of dnat %s: %v", dnatName, err)
+		return err
+	}
 
 	if err = c.OVNNbClient.CreateLoadBalancer(dnatName, protocol, ""); err != nil {
 		klog.Errorf("create loadBalancer %s: %v", dnatName, err)
 		return err
 	}
 
-	if err = c.OVNNbClient.LoadBalancerAddVip(dnatName, externalEndpoint, internalEndpoint); err != nil {
-		klog.Errorf("add vip %s with backends %s to LB %s: %v", externalEndpoint, internalEndpoint, dnatName, err)
-		return err
+	for i, ext := range external {
+		for offset := 0; offset < ext.Size(); offset++ {
+			externalEndpoint := net.JoinHostPort(externalIP, strconv.Itoa(ext.Start+offset))
+			internalEndpoint := net.JoinHostPort(internalIP, strconv.Itoa(internal[i].Start+offset))
+			if err = c.OVNNbClient.LoadBalancerAddVip(dnatName, externalEndpoint, internalEndpoint); err != nil {
+				klog.Errorf("add vip %s with backends %s to LB %s: %v", externalEndpoint, internalEndpoint, dnatName, err)
+				return err
+			}
+		}
 	}
 
 	if err = c.OVNNbClient.LogicalRouterUpdateLoadBalancers(vpcName, ovsdb.MutateOperationInsert, dnatName); err != nil {
@@ -644,18 +652,23 @@ func (c *Controller) AddDnatRule(vpcName, dnatName, externalIP, internalIP, exte
 }
 
 func (c *Controller) DelDnatRule(vpcName, dnatName, externalIP, externalPort string) error {
-	var (
-		ignoreHealthCheck = true
-		externalEndpoint  string
-		err               error
-	)
-	externalEndpoint = net.JoinHostPort(externalIP, externalPort)
-
-	if err = c.OVNNbClient.LoadBalancerDeleteVip(dnatName, externalEndpoint, ignoreHealthCheck); err != nil {
-		klog.Errorf("delete loadBalancer vips %s: %v", externalEndpoint, err)
+	ignoreHealthCheck := true
+	external, err := util.ParsePortRanges(externalPort)
+	if err != nil {
+		klog.Errorf("failed to parse external port of dnat %s: %v", dnatName, err)
 		return err
 	}
 
+	for _, ext := range external {
+		for port := ext.Start; port <= ext.End; port++ {
+			externalEndpoint := net.JoinHostPort(externalIP, strconv.Itoa(port))
+			if err = c.OVNNbClient.LoadBalancerDeleteVip(dnatName, externalEndpoint, ignoreHealthCheck); err != nil {
+				klog.Errorf("delete loadBalancer vips %s: %v", externalEndpoint, err)
+				return err
+			}
+		}
+	}
+
 	if err = c.OVNNbClient.LogicalRouterUpdateLoadBalancers(vpcName, ovsdb.MutateOperationDelete, dnatName); err != nil {
 		klog.Errorf("failed to remove lb %s from vpc %s: %v", dnatName, vpcName, err)
 		return err
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -29,6 +31,7 @@ func (c *Controller) enqueueAddOvnEip(obj interface{}) {
 	}
 	klog.Infof("enqueue add ovn eip %s", key)
 	c.addOvnEipQueue.Add(key)
+	c.enqueueOvnSnatRulesOfEip(obj.(*kubeovnv1.OvnEip))
 }
 
 func (c *Controller) enqueueUpdateOvnEip(oldObj, newObj interface{}) {
@@ -46,9 +49,14 @@ func (c *Controller) enqueueUpdateOvnEip(oldObj, newObj interface{}) {
 		}
 		klog.Infof("enqueue del ovn eip %s", key)
 		c.delOvnEipQueue.Add(key)
+		c.enqueueOvnSnatRulesOfEip(newEip)
 		return
 	}
 	oldEip := oldObj.(*kubeovnv1.OvnEip)
+	if oldEip.Status.V4Ip != newEip.Status.V4Ip || !maps.Equal(oldEip.Labels, newEip.Labels) {
+		// the eip may become ready, or be selected or unselected by the eip selector of snats
+		c.enqueueOvnSnatRulesOfEip(newEip)
+	}
 	if oldEip.Spec.V4Ip != "" && oldEip.Spec.V4Ip != newEip.Spec.V4Ip ||
 		oldEip.Spec.MacAddress != "" && oldEip.Spec.MacAddress != newEip.Spec.MacAddress {
 		klog.Infof("not support change ip or mac for eip %s", key)
@@ -71,6 +79,9 @@ func (c *Controller) enqueueDelOvnEip(obj interface{}) {
 	}
 	klog.Infof("enqueue del ovn eip %s", key)
 	c.delOvnEipQueue.Add(key)
+	if eip, ok := obj.(*kubeovnv1.OvnEip); ok {
+		c.enqueueOvnSnatRulesOfEip(eip)
+	}
 }
 
 func (c *Controller) runAddOvnEipWorker() {
@@ -678,6 +689,16 @@ func (c *Controller) getOvnEipNat(eipV4IP string) (string, error) {
 		klog.Errorf("failed to get ovn snats, %v", err)
 		return "", err
 	}
+	if len(snats) == 0 {
+		// the eip may be shared by a snat which translates to multiple eips
+		if snats, err = c.ovnSnatRulesLister.List(labels.Everything()); err != nil {
+			klog.Errorf("failed to get ovn snats, %v", err)
+			return "", err
+		}
+		snats = slices.DeleteFunc(snats, func(snat *kubeovnv1.OvnSnatRule) bool {
+			return !slices.Contains(snat.Status.V4Eips, eipV4IP)
+		})
+	}
 	if len(snats) != 0 {
 		nats = append(nats, util.SnatUsingEip)
 	}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -55,7 +54,9 @@ func (c *Controller) enqueueUpdateOvnFip(oldObj, newObj interface{}) {
 		c.resetOvnEipQueue.Add(oldFip.Spec.OvnEip)
 	}
 	if oldFip.Spec.IPName != newFip.Spec.IPName ||
-		oldFip.Spec.IPType != newFip.Spec.IPType {
+		oldFip.Spec.IPType != newFip.Spec.IPType ||
+		oldFip.Spec.Type != newFip.Spec.Type ||
+		oldFip.Spec.Stateless != newFip.Spec.Stateless {
 		klog.Infof("enqueue update fip %s", key)
 		c.updateOvnFipQueue.Add(key)
 		return
@@ -292,9 +293,12 @@ func (c *Controller) handleAddOvnFip(key string) error {
 		return err
 	}
 	// ovn add fip
-	options := map[string]string{"staleless": strconv.FormatBool(c.ExternalGatewayType == kubeovnv1.GWDistributedType)}
-	if err = c.OVNNbClient.AddNat(vpcName, ovnnb.NATTypeDNATAndSNAT, cachedEip.Status.V4Ip,
-		internalV4Ip, mac, cachedFip.Spec.IPName, options); err != nil {
+	fipType, lspName, err := ovnFipTypeAndPort(cachedFip, mac)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if err = c.OVNNbClient.UpdateDnatAndSnat(vpcName, cachedEip.Status.V4Ip, internalV4Ip, lspName, mac, fipType, cachedFip.Spec.Stateless); err != nil {
 		klog.Errorf("failed to create v4 fip, %v", err)
 		return err
 	}
@@ -314,7 +318,7 @@ func (c *Controller) handleAddOvnFip(key string) error {
 		return err
 	}
 	if err = c.patchOvnFipStatus(key, vpcName, cachedEip.Status.V4Ip,
-		internalV4Ip, true); err != nil {
+		internalV4Ip, fipType, true); err != nil {
 		klog.Errorf("failed to patch status for fip %s, %v", key, err)
 		return err
 	}
@@ -420,6 +424,11 @@ func (c *Controller) handleUpdateOvnFip(key string) error {
 		klog.Errorf("failed to add finalizer for ovn eip, %v", err)
 		return err
 	}
+	fipType, lspName, err := ovnFipTypeAndPort(cachedFip, mac)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
 	fip := cachedFip.DeepCopy()
 	// fip change eip
 	if c.ovnFipChangeEip(fip, cachedEip) {
@@ -429,9 +438,7 @@ func (c *Controller) handleUpdateOvnFip(key string) error {
 			return err
 		}
 		// ovn add fip
-		options := map[string]string{"staleless": strconv.FormatBool(c.ExternalGatewayType == kubeovnv1.GWDistributedType)}
-		if err = c.OVNNbClient.AddNat(vpcName, ovnnb.NATTypeDNATAndSNAT, cachedEip.Status.V4Ip,
-			internalV4Ip, mac, cachedFip.Spec.IPName, options); err != nil {
+		if err = c.OVNNbClient.UpdateDnatAndSnat(vpcName, cachedEip.Status.V4Ip, internalV4Ip, lspName, mac, fipType, cachedFip.Spec.Stateless); err != nil {
 			klog.Errorf("failed to create fip, %v", err)
 			return err
 		}
@@ -444,12 +451,25 @@ func (c *Controller) handleUpdateOvnFip(key string) error {
 			return err
 		}
 		if err = c.patchOvnFipStatus(key, vpcName, cachedEip.Status.V4Ip,
-			internalV4Ip, true); err != nil {
+			internalV4Ip, fipType, true); err != nil {
 			klog.Errorf("failed to patch status for fip '%s', %v", key, err)
 			return err
 		}
 		return nil
 	}
+	if !fip.Status.Ready {
+		return nil
+	}
+	// fip change type or the pod moves to another chassis
+	if err = c.OVNNbClient.UpdateDnatAndSnat(vpcName, cachedEip.Status.V4Ip, internalV4Ip, lspName, mac, fipType, cachedFip.Spec.Stateless); err != nil {
+		klog.Errorf("failed to update fip %s, %v", key, err)
+		return err
+	}
+	if err = c.patchOvnFipStatus(key, vpcName, cachedEip.Status.V4Ip,
+		internalV4Ip, fipType, true); err != nil {
+		klog.Errorf("failed to patch status for fip '%s', %v", key, err)
+		return err
+	}
 	return nil
 }
 
@@ -518,7 +538,7 @@ func (c *Controller) patchOvnFipAnnotations(key, eipName string) error {
 	return nil
 }
 
-func (c *Controller) patchOvnFipStatus(key, vpcName, v4Eip, podIP string, ready bool) error {
+func (c *Controller) patchOvnFipStatus(key, vpcName, v4Eip, podIP, fipType string, ready bool) error {
 	oriFip, err := c.ovnFipsLister.Get(key)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -568,6 +588,10 @@ func (c *Controller) patchOvnFipStatus(key, vpcName, v4Eip, podIP string, ready
 		fip.Status.V4Ip = podIP
 		changed = true
 	}
+	if fipType != "" && fip.Status.Type != fipType {
+		fip.Status.Type = fipType
+		changed = true
+	}
 	if changed {
 		bytes, err := fip.Status.Bytes()
 		if err != nil {
@@ -583,6 +607,43 @@ func (c *Controller) patchOvnFipStatus(key, vpcName, v4Eip, podIP string, ready
 	return nil
 }
 
+// ovnFipTypeAndPort returns the type of the fip and the logical switch port hosting the fip,
+// distributed fip is handled on the chassis where the logical switch port resides
+func ovnFipTypeAndPort(fip *kubeovnv1.OvnFip, mac string) (string, string, error) {
+	fipType := fip.Spec.Type
+	if fipType == "" {
+		// keep the behavior of fip created by old versions
+		fipType = kubeovnv1.GWCentralizedType
+		if fip.Spec.IPType != util.Vip && fip.Spec.IPName != "" && mac != "" {
+			fipType = kubeovnv1.GWDistributedType
+		}
+	}
+	if fipType != kubeovnv1.GWDistributedType {
+		return fipType, "", nil
+	}
+	if fip.Spec.IPType == util.Vip || fip.Spec.IPName == "" || mac == "" {
+		err := fmt.Errorf("distributed fip %s should be bound to a pod ip", fip.Name)
+		klog.Error(err)
+		return "", "", err
+	}
+	// the ip crd has the same name as the logical switch port
+	return fipType, fip.Spec.IPName, nil
+}
+
+func (c *Controller) enqueueUpdateOvnFipsByIP(ipName string) {
+	fips, err := c.ovnFipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list ovn fips, %v", err)
+		return
+	}
+	for _, fip := range fips {
+		if fip.Spec.IPName == ipName && fip.Spec.IPType != util.Vip {
+			klog.Infof("enqueue update fip %s", fip.Name)
+			c.updateOvnFipQueue.Add(fip.Name)
+		}
+	}
+}
+
 func (c *Controller) ovnFipChangeEip(fip *kubeovnv1.OvnFip, eip *kubeovnv1.OvnEip) bool {
 	if fip.Status.V4Ip == "" || eip.Status.V4Ip == "" {
 		// eip created but not ready
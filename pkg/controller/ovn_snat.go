@@ -4,9 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math/big"
+	"net"
+	"slices"
+	"strings"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -50,7 +56,15 @@ func (c *Controller) enqueueUpdateOvnSnatRule(oldObj, newObj interface{}) {
 		// enqueue to reset eip to be clean
 		c.resetOvnEipQueue.Add(oldSnat.Spec.OvnEip)
 	}
+	for _, eipName := range oldSnat.Spec.OvnEips {
+		if eipName != newSnat.Spec.OvnEip && !slices.Contains(newSnat.Spec.OvnEips, eipName) {
+			c.resetOvnEipQueue.Add(eipName)
+		}
+	}
 	if oldSnat.Spec.OvnEip != newSnat.Spec.OvnEip ||
+		!slices.Equal(oldSnat.Spec.OvnEips, newSnat.Spec.OvnEips) ||
+		!maps.Equal(oldSnat.Spec.OvnEipSelector, newSnat.Spec.OvnEipSelector) ||
+		oldSnat.Spec.ExternalPortRange != newSnat.Spec.ExternalPortRange ||
 		oldSnat.Spec.VpcSubnet != newSnat.Spec.VpcSubnet ||
 		oldSnat.Spec.IPName != newSnat.Spec.IPName {
 		klog.Infof("enqueue update snat %s", key)
@@ -186,29 +200,13 @@ func (c *Controller) handleAddOvnSnatRule(key string) error {
 		return nil
 	}
 	klog.Infof("handle add ovn snat %s", key)
-	// check eip
-	eipName := cachedSnat.Spec.OvnEip
-	if eipName == "" {
-		err := fmt.Errorf("failed to create ovn snat rule, should set eip")
-		klog.Error(err)
-		return err
-	}
-	cachedEip, err := c.GetOvnEip(eipName)
+	// check eips
+	eips, err := c.getOvnSnatEips(cachedSnat)
 	if err != nil {
-		klog.Errorf("failed to get eip, %v", err)
-		return err
-	}
-	if cachedEip.Spec.Type == util.Lsp {
-		// eip is using by ecmp nexthop lsp, nat can not use
-		err = fmt.Errorf("ovn nat %s can not use type %s eip %s", key, util.Lsp, eipName)
-		klog.Error(err)
-		return err
-	}
-	if cachedEip.Status.V4Ip == "" {
-		err := fmt.Errorf("failed to create v4 snat %s, eip %s has no v4 ip", cachedSnat.Name, eipName)
-		klog.Error(err)
+		klog.Errorf("failed to get eips for ovn snat %s, %v", key, err)
 		return err
 	}
+	eipName, v4Eips := eips[0].Name, ovnSnatEipV4Ips(eips)
 	var v4IpCidr, vpcName string
 	if cachedSnat.Spec.Vpc != "" {
 		vpcName = cachedSnat.Spec.Vpc
@@ -252,12 +250,14 @@ func (c *Controller) handleAddOvnSnatRule(key string) error {
 	}
 
 	// create snat
-	if err = c.handleAddOvnEipFinalizer(cachedEip, util.ControllerName); err != nil {
-		klog.Errorf("failed to add finalizer for ovn eip, %v", err)
-		return err
+	for _, eip := range eips {
+		if err = c.handleAddOvnEipFinalizer(eip, util.ControllerName); err != nil {
+			klog.Errorf("failed to add finalizer for ovn eip, %v", err)
+			return err
+		}
 	}
 	// about conflicts: if multi vpc snat use the same eip, if only one gw node exist, it may should work
-	if err = c.OVNNbClient.AddNat(vpcName, ovnnb.NATTypeSNAT, cachedEip.Spec.V4Ip, v4IpCidr, "", "", nil); err != nil {
+	if err = c.addOvnSnatNats(vpcName, v4IpCidr, v4Eips, cachedSnat.Spec.ExternalPortRange); err != nil {
 		klog.Errorf("failed to create snat, %v", err)
 		return err
 	}
@@ -265,21 +265,25 @@ func (c *Controller) handleAddOvnSnatRule(key string) error {
 		klog.Errorf("failed to add finalizer for ovn snat %s, %v", cachedSnat.Name, err)
 		return err
 	}
-	if err = c.natLabelAndAnnoOvnEip(eipName, cachedSnat.Name, vpcName); err != nil {
-		klog.Errorf("failed to label snat '%s' in eip %s, %v", cachedSnat.Name, eipName, err)
-		return err
+	for _, eip := range eips {
+		if err = c.natLabelAndAnnoOvnEip(eip.Name, cachedSnat.Name, vpcName); err != nil {
+			klog.Errorf("failed to label snat '%s' in eip %s, %v", cachedSnat.Name, eip.Name, err)
+			return err
+		}
 	}
 	if err = c.patchOvnSnatAnnotation(key, eipName); err != nil {
 		klog.Errorf("failed to patch label for snat %s, %v", key, err)
 		return err
 	}
-	if err = c.patchOvnSnatStatus(key, vpcName, cachedEip.Spec.V4Ip, v4IpCidr, true); err != nil {
+	if err = c.patchOvnSnatStatus(key, vpcName, v4Eips, v4IpCidr, cachedSnat.Spec.ExternalPortRange, true); err != nil {
 		klog.Errorf("failed to update status for snat %s, %v", key, err)
 		return err
 	}
-	if err = c.patchOvnEipStatus(eipName, true); err != nil {
-		klog.Errorf("failed to patch status for eip %s, %v", key, err)
-		return err
+	for _, eip := range eips {
+		if err = c.patchOvnEipStatus(eip.Name, true); err != nil {
+			klog.Errorf("failed to patch status for eip %s, %v", eip.Name, err)
+			return err
+		}
 	}
 	return nil
 }
@@ -296,39 +300,21 @@ func (c *Controller) handleUpdateOvnSnatRule(key string) error {
 	// should delete
 	if !cachedSnat.DeletionTimestamp.IsZero() {
 		klog.Infof("ovn delete snat %s", key)
-		if cachedSnat.Status.Vpc != "" && cachedSnat.Status.V4Eip != "" && cachedSnat.Status.V4IpCidr != "" {
-			if err = c.OVNNbClient.DeleteNat(cachedSnat.Status.Vpc, ovnnb.NATTypeSNAT, cachedSnat.Status.V4Eip, cachedSnat.Status.V4IpCidr); err != nil {
-				klog.Errorf("failed to delete snat, %v", err)
-				return err
-			}
+		if err = c.deleteOvnSnatNats(cachedSnat); err != nil {
+			klog.Errorf("failed to delete snat, %v", err)
+			return err
 		}
-		c.resetOvnEipQueue.Add(cachedSnat.Spec.OvnEip)
+		c.resetOvnSnatEips(cachedSnat)
 		return nil
 	}
 	klog.Infof("handle update ovn snat %s", key)
-	// check eip
-	eipName := cachedSnat.Spec.OvnEip
-	if eipName == "" {
-		err := fmt.Errorf("failed to create ovn snat rule, should set eip")
-		klog.Error(err)
-		return err
-	}
-	cachedEip, err := c.GetOvnEip(eipName)
+	// check eips
+	eips, err := c.getOvnSnatEips(cachedSnat)
 	if err != nil {
-		klog.Errorf("failed to get eip, %v", err)
-		return err
-	}
-	if cachedEip.Spec.Type == util.Lsp {
-		// eip is using by ecmp nexthop lsp, nat can not use
-		err = fmt.Errorf("ovn nat %s can not use type %s eip %s", key, util.Lsp, eipName)
-		klog.Error(err)
-		return err
-	}
-	if cachedEip.Status.V4Ip == "" {
-		err := fmt.Errorf("failed to create v4 snat %s, eip %s has no v4 ip", cachedSnat.Name, eipName)
-		klog.Error(err)
+		klog.Errorf("failed to get eips for ovn snat %s, %v", key, err)
 		return err
 	}
+	eipName, v4Eips := eips[0].Name, ovnSnatEipV4Ips(eips)
 	var v4IpCidr, vpcName string
 	if cachedSnat.Spec.Vpc != "" {
 		vpcName = cachedSnat.Spec.Vpc
@@ -370,38 +356,57 @@ func (c *Controller) handleUpdateOvnSnatRule(key string) error {
 		klog.Error(err)
 		return err
 	}
-	if cachedEip.Spec.Type == util.Lsp {
-		// eip is using by ecmp nexthop lsp, nat can not use
-		err = fmt.Errorf("ovn nat %s can not use type %s eip %s", key, util.Lsp, eipName)
-		klog.Error(err)
-		return err
-	}
-	// snat change eip
-	if c.ovnSnatChangeEip(cachedSnat, cachedEip) {
-		klog.Infof("snat change ip, old ip %s, new ip %s", cachedEip.Status.V4Ip, cachedEip.Spec.V4Ip)
-		if err = c.OVNNbClient.DeleteNat(vpcName, ovnnb.NATTypeSNAT, cachedEip.Status.V4Ip, v4IpCidr); err != nil {
+	// snat change eips
+	if c.ovnSnatChangeEips(cachedSnat, v4Eips) || (cachedSnat.Status.V4IpCidr != "" && cachedSnat.Status.V4IpCidr != v4IpCidr) {
+		klog.Infof("snat change ip, old ips %v of %s, new ips %v of %s", ovnSnatStatusV4Eips(cachedSnat), cachedSnat.Status.V4IpCidr, v4Eips, v4IpCidr)
+		oldEipNames := c.getOvnSnatStatusEipNames(cachedSnat)
+		if err = c.deleteOvnSnatNats(cachedSnat); err != nil {
 			klog.Errorf("failed to delte snat, %v", err)
 			return err
 		}
-		// ovn add snat with new eip
-		if err = c.OVNNbClient.AddNat(vpcName, ovnnb.NATTypeSNAT, cachedEip.Spec.V4Ip, v4IpCidr, "", "", nil); err != nil {
+		// ovn add snat with new eips
+		if err = c.addOvnSnatNats(vpcName, v4IpCidr, v4Eips, cachedSnat.Spec.ExternalPortRange); err != nil {
 			klog.Errorf("failed to create snat, %v", err)
 			return err
 		}
-		if err = c.natLabelAndAnnoOvnEip(eipName, cachedSnat.Name, vpcName); err != nil {
-			klog.Errorf("failed to label snat '%s' in eip %s, %v", cachedSnat.Name, eipName, err)
-			return err
+		for _, eip := range eips {
+			if err = c.handleAddOvnEipFinalizer(eip, util.ControllerName); err != nil {
+				klog.Errorf("failed to add finalizer for ovn eip, %v", err)
+				return err
+			}
+			if err = c.natLabelAndAnnoOvnEip(eip.Name, cachedSnat.Name, vpcName); err != nil {
+				klog.Errorf("failed to label snat '%s' in eip %s, %v", cachedSnat.Name, eip.Name, err)
+				return err
+			}
 		}
 		if err = c.patchOvnSnatAnnotation(key, eipName); err != nil {
 			klog.Errorf("failed to patch label for snat %s, %v", key, err)
 			return err
 		}
-		if err = c.patchOvnSnatStatus(key, vpcName, cachedEip.Spec.V4Ip, v4IpCidr, true); err != nil {
+		if err = c.patchOvnSnatStatus(key, vpcName, v4Eips, v4IpCidr, cachedSnat.Spec.ExternalPortRange, true); err != nil {
 			klog.Errorf("failed to update status for snat %s, %v", key, err)
 			return err
 		}
+		// the eips no longer used by the snat are reset
+		for _, name := range oldEipNames {
+			if !slices.ContainsFunc(eips, func(eip *kubeovnv1.OvnEip) bool { return eip.Name == name }) {
+				c.resetOvnEipQueue.Add(name)
+			}
+		}
 		return nil
 	}
+	// snat change external port range only, the nats are updated in place
+	if cachedSnat.Status.ExternalPortRange != cachedSnat.Spec.ExternalPortRange {
+		klog.Infof("snat change external port range, old %q, new %q", cachedSnat.Status.ExternalPortRange, cachedSnat.Spec.ExternalPortRange)
+		if err = c.addOvnSnatNats(vpcName, v4IpCidr, v4Eips, cachedSnat.Spec.ExternalPortRange); err != nil {
+			klog.Errorf("failed to update snat, %v", err)
+			return err
+		}
+		if err = c.patchOvnSnatStatus(key, vpcName, v4Eips, v4IpCidr, cachedSnat.Spec.ExternalPortRange, true); err != nil {
+			klog.Errorf("failed to update status for snat %s, %v", key, err)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -416,24 +421,19 @@ func (c *Controller) handleDelOvnSnatRule(key string) error {
 		return err
 	}
 	// ovn delete snat
-	if cachedSnat.Status.Vpc != "" && cachedSnat.Status.V4Eip != "" && cachedSnat.Status.V4IpCidr != "" {
-		if err = c.OVNNbClient.DeleteNat(cachedSnat.Status.Vpc, ovnnb.NATTypeSNAT,
-			cachedSnat.Status.V4Eip, cachedSnat.Status.V4IpCidr); err != nil {
-			klog.Errorf("failed to delete snat %s, %v", key, err)
-			return err
-		}
+	if err = c.deleteOvnSnatNats(cachedSnat); err != nil {
+		klog.Errorf("failed to delete snat %s, %v", key, err)
+		return err
 	}
 	if err = c.handleDelOvnSnatFinalizer(cachedSnat, util.ControllerName); err != nil {
 		klog.Errorf("failed to remove finalizer for ovn snat %s, %v", cachedSnat.Name, err)
 		return err
 	}
-	if cachedSnat.Spec.OvnEip != "" {
-		c.resetOvnEipQueue.Add(cachedSnat.Spec.OvnEip)
-	}
+	c.resetOvnSnatEips(cachedSnat)
 	return nil
 }
 
-func (c *Controller) patchOvnSnatStatus(key, vpc, v4Eip, v4IpCidr string, ready bool) error {
+func (c *Controller) patchOvnSnatStatus(key, vpc string, v4Eips []string, v4IpCidr, externalPortRange string, ready bool) error {
 	oriSnat, err := c.ovnSnatRulesLister.Get(key)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -443,6 +443,10 @@ func (c *Controller) patchOvnSnatStatus(key, vpc, v4Eip, v4IpCidr string, ready
 		return err
 	}
 	snat := oriSnat.DeepCopy()
+	var v4Eip string
+	if len(v4Eips) != 0 {
+		v4Eip = v4Eips[0]
+	}
 	needUpdateLabel := false
 	var op string
 	if len(snat.Labels) == 0 {
@@ -479,10 +483,18 @@ func (c *Controller) patchOvnSnatStatus(key, vpc, v4Eip, v4IpCidr string, ready
 		snat.Status.V4Eip = v4Eip
 		changed = true
 	}
+	if len(v4Eips) != 0 && !slices.Equal(snat.Status.V4Eips, v4Eips) {
+		snat.Status.V4Eips = v4Eips
+		changed = true
+	}
 	if v4IpCidr != "" && snat.Status.V4IpCidr != v4IpCidr {
 		snat.Status.V4IpCidr = v4IpCidr
 		changed = true
 	}
+	if snat.Status.ExternalPortRange != externalPortRange {
+		snat.Status.ExternalPortRange = externalPortRange
+		changed = true
+	}
 	if changed {
 		bytes, err := snat.Status.Bytes()
 		if err != nil {
@@ -535,15 +547,200 @@ func (c *Controller) patchOvnSnatAnnotation(key, eipName string) error {
 	return nil
 }
 
-func (c *Controller) ovnSnatChangeEip(snat *kubeovnv1.OvnSnatRule, eip *kubeovnv1.OvnEip) bool {
-	if snat.Status.V4Eip == "" || eip.Spec.V4Ip == "" {
+func (c *Controller) ovnSnatChangeEips(snat *kubeovnv1.OvnSnatRule, v4Eips []string) bool {
+	if snat.Status.V4Eip == "" || slices.Contains(v4Eips, "") {
 		// eip created but not ready
 		return false
 	}
-	if snat.Status.V4Eip != eip.Spec.V4Ip {
-		return true
+	return !slices.Equal(ovnSnatStatusV4Eips(snat), v4Eips)
+}
+
+// getOvnSnatEips returns the eips used by the snat, the first one is the primary eip.
+// Spec ovnEip comes first, followed by spec ovnEips, which must be ready, and the ready eips
+// selected by spec ovnEipSelector sorted by name. The eips selected but not ready yet are skipped,
+// the snat is requeued when they are ready.
+func (c *Controller) getOvnSnatEips(snat *kubeovnv1.OvnSnatRule) ([]*kubeovnv1.OvnEip, error) {
+	names := make([]string, 0, 1+len(snat.Spec.OvnEips))
+	if snat.Spec.OvnEip != "" {
+		names = append(names, snat.Spec.OvnEip)
+	}
+	names = append(names, snat.Spec.OvnEips...)
+
+	eips := make([]*kubeovnv1.OvnEip, 0, len(names))
+	for _, name := range names {
+		if slices.ContainsFunc(eips, func(eip *kubeovnv1.OvnEip) bool { return eip.Name == name }) {
+			continue
+		}
+		cachedEip, err := c.GetOvnEip(name)
+		if err != nil {
+			klog.Errorf("failed to get eip, %v", err)
+			return nil, err
+		}
+		eips = append(eips, cachedEip)
+	}
+	if len(snat.Spec.OvnEipSelector) != 0 {
+		selected, err := c.ovnEipsLister.List(labels.SelectorFromSet(snat.Spec.OvnEipSelector))
+		if err != nil {
+			klog.Errorf("failed to list ovn eips by selector %v, %v", snat.Spec.OvnEipSelector, err)
+			return nil, err
+		}
+		slices.SortFunc(selected, func(a, b *kubeovnv1.OvnEip) int { return strings.Compare(a.Name, b.Name) })
+		for _, eip := range selected {
+			if eip.Status.V4Ip == "" || !eip.DeletionTimestamp.IsZero() {
+				klog.Infof("skip eip %s selected by ovn snat %s, it is not ready", eip.Name, snat.Name)
+				continue
+			}
+			if !slices.ContainsFunc(eips, func(e *kubeovnv1.OvnEip) bool { return e.Name == eip.Name }) {
+				eips = append(eips, eip)
+			}
+		}
 	}
-	return false
+	if len(eips) == 0 {
+		err := fmt.Errorf("failed to create ovn snat rule %s, should set eip", snat.Name)
+		klog.Error(err)
+		return nil, err
+	}
+
+	for _, eip := range eips {
+		if eip.Spec.Type == util.Lsp {
+			// eip is using by ecmp nexthop lsp, nat can not use
+			err := fmt.Errorf("ovn nat %s can not use type %s eip %s", snat.Name, util.Lsp, eip.Name)
+			klog.Error(err)
+			return nil, err
+		}
+	}
+	return eips, nil
+}
+
+// getOvnSnatStatusEipNames returns the names of the eips recorded in snat status and spec ovnEip(s),
+// the eips selected by label are found by the ips in status, as the selector may have changed
+func (c *Controller) getOvnSnatStatusEipNames(snat *kubeovnv1.OvnSnatRule) []string {
+	names := make([]string, 0, 1+len(snat.Spec.OvnEips))
+	if snat.Spec.OvnEip != "" {
+		names = append(names, snat.Spec.OvnEip)
+	}
+	names = append(names, snat.Spec.OvnEips...)
+	if v4Eips := ovnSnatStatusV4Eips(snat); len(v4Eips) != 0 {
+		eips, err := c.ovnEipsLister.List(labels.Everything())
+		if err != nil {
+			klog.Errorf("failed to list ovn eips, %v", err)
+			return names
+		}
+		for _, eip := range eips {
+			if eip.Status.V4Ip != "" && slices.Contains(v4Eips, eip.Status.V4Ip) && !slices.Contains(names, eip.Name) {
+				names = append(names, eip.Name)
+			}
+		}
+	}
+	return names
+}
+
+func (c *Controller) resetOvnSnatEips(snat *kubeovnv1.OvnSnatRule) {
+	for _, name := range c.getOvnSnatStatusEipNames(snat) {
+		c.resetOvnEipQueue.Add(name)
+	}
+}
+
+// enqueueOvnSnatRulesOfEip requeues the snat rules using the eip by name, by label or by the ip in status
+func (c *Controller) enqueueOvnSnatRulesOfEip(eip *kubeovnv1.OvnEip) {
+	snats, err := c.ovnSnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list ovn snats, %v", err)
+		return
+	}
+	for _, snat := range snats {
+		if !snat.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if snat.Spec.OvnEip != eip.Name && !slices.Contains(snat.Spec.OvnEips, eip.Name) &&
+			(len(snat.Spec.OvnEipSelector) == 0 || !labels.SelectorFromSet(snat.Spec.OvnEipSelector).Matches(labels.Set(eip.Labels))) &&
+			(eip.Status.V4Ip == "" || !slices.Contains(ovnSnatStatusV4Eips(snat), eip.Status.V4Ip)) {
+			continue
+		}
+		klog.Infof("enqueue ovn snat %s for the change of eip %s", snat.Name, eip.Name)
+		if snat.Status.Ready {
+			c.updateOvnSnatRuleQueue.Add(snat.Name)
+		} else {
+			c.addOvnSnatRuleQueue.Add(snat.Name)
+		}
+	}
+}
+
+func ovnSnatEipV4Ips(eips []*kubeovnv1.OvnEip) []string {
+	v4Eips := make([]string, 0, len(eips))
+	for _, eip := range eips {
+		v4Eips = append(v4Eips, eip.Status.V4Ip)
+	}
+	return v4Eips
+}
+
+// ovnSnatStatusV4Eips returns the eips in snat status, status v4Eips is empty for snat created by old versions
+func ovnSnatStatusV4Eips(snat *kubeovnv1.OvnSnatRule) []string {
+	if len(snat.Status.V4Eips) != 0 {
+		return snat.Status.V4Eips
+	}
+	if snat.Status.V4Eip != "" {
+		return []string{snat.Status.V4Eip}
+	}
+	return nil
+}
+
+// splitOvnSnatCidr splits the internal cidr into 2^n parts, 2^n is the minimum power of 2 not less than
+// the number of eips, the i-th part is translated to the (i % count)-th eip,
+// the cidr is not split if it has not enough host bits or it is a single ip address
+func splitOvnSnatCidr(v4IpCidr string, count int) []string {
+	_, ipNet, err := net.ParseCIDR(v4IpCidr)
+	if err != nil {
+		// single ip address
+		return []string{v4IpCidr}
+	}
+	ones, bits := ipNet.Mask.Size()
+	n := 0
+	for 1<<n < count && ones+n < bits {
+		n++
+	}
+	if n == 0 {
+		return []string{v4IpCidr}
+	}
+
+	cidrs := make([]string, 0, 1<<n)
+	base := new(big.Int).SetBytes(ipNet.IP)
+	step := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones-n))
+	for i := 0; i < 1<<n; i++ {
+		ip := new(big.Int).Add(base, new(big.Int).Mul(step, big.NewInt(int64(i))))
+		buf := make([]byte, len(ipNet.IP))
+		ip.FillBytes(buf)
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", net.IP(buf), ones+n))
+	}
+	return cidrs
+}
+
+// addOvnSnatNats distributes the internal cidr to the eips, all the eips share the external port range
+func (c *Controller) addOvnSnatNats(vpcName, v4IpCidr string, v4Eips []string, externalPortRange string) error {
+	cidrs := splitOvnSnatCidr(v4IpCidr, len(v4Eips))
+	for i, cidr := range cidrs {
+		if err := c.OVNNbClient.UpdateSnat(vpcName, v4Eips[i%len(v4Eips)], cidr, externalPortRange); err != nil {
+			klog.Errorf("failed to update snat %s for %s, %v", v4Eips[i%len(v4Eips)], cidr, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteOvnSnatNats deletes the ovn nats recorded in snat status
+func (c *Controller) deleteOvnSnatNats(snat *kubeovnv1.OvnSnatRule) error {
+	v4Eips := ovnSnatStatusV4Eips(snat)
+	if snat.Status.Vpc == "" || len(v4Eips) == 0 || snat.Status.V4IpCidr == "" {
+		return nil
+	}
+	cidrs := splitOvnSnatCidr(snat.Status.V4IpCidr, len(v4Eips))
+	for i, cidr := range cidrs {
+		if err := c.OVNNbClient.DeleteNat(snat.Status.Vpc, ovnnb.NATTypeSNAT, v4Eips[i%len(v4Eips)], cidr); err != nil {
+			klog.Errorf("failed to delete snat %s for %s, %v", v4Eips[i%len(v4Eips)], cidr, err)
+			return err
+		}
+	}
+	return nil
 }
 
 func (c *Controller) handleAddOvnSnatFinalizer(cachedSnat *kubeovnv1.OvnSnatRule, finalizer string) error {
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_splitOvnSnatCidr(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"10.0.0.0/24"}, splitOvnSnatCidr("10.0.0.0/24", 1))
+	require.Equal(t, []string{"10.0.0.0/25", "10.0.0.128/25"}, splitOvnSnatCidr("10.0.0.0/24", 2))
+	require.Equal(t, []string{"10.0.0.0/26", "10.0.0.64/26", "10.0.0.128/26", "10.0.0.192/26"}, splitOvnSnatCidr("10.0.0.0/24", 3))
+	// not enough host bits
+	require.Equal(t, []string{"10.0.0.0/32", "10.0.0.1/32"}, splitOvnSnatCidr("10.0.0.0/31", 4))
+	require.Equal(t, []string{"10.0.0.5"}, splitOvnSnatCidr("10.0.0.5", 2))
+}
+
+func newOvnSnatTestEip(name, v4Ip string, labels map[string]string) *kubeovnv1.OvnEip {
+	return &kubeovnv1.OvnEip{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       kubeovnv1.OvnEipSpec{V4Ip: v4Ip, Type: util.NAT},
+		Status:     kubeovnv1.OvnEipStatus{V4Ip: v4Ip},
+	}
+}
+
+func Test_getOvnSnatEips(t *testing.T) {
+	t.Parallel()
+
+	notReady := newOvnSnatTestEip("eip-not-ready", "172.18.0.14", map[string]string{"pool": "a"})
+	notReady.Status.V4Ip = ""
+	ctrl := newFakeController(t,
+		newOvnSnatTestEip("eip1", "172.18.0.10", nil),
+		newOvnSnatTestEip("eip2", "172.18.0.11", nil),
+		newOvnSnatTestEip("eip4", "172.18.0.13", map[string]string{"pool": "a"}),
+		newOvnSnatTestEip("eip3", "172.18.0.12", map[string]string{"pool": "a"}),
+		notReady,
+	).fakeController
+
+	t.Run("spec eips come first followed by the selected eips sorted by name", func(t *testing.T) {
+		snat := &kubeovnv1.OvnSnatRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "snat"},
+			Spec:       kubeovnv1.OvnSnatRuleSpec{OvnEip: "eip2", OvnEips: []string{"eip1", "eip2", "eip4"}, OvnEipSelector: map[string]string{"pool": "a"}},
+		}
+		selected, err := ctrl.getOvnSnatEips(snat)
+		require.NoError(t, err)
+		names := make([]string, 0, len(selected))
+		for _, eip := range selected {
+			names = append(names, eip.Name)
+		}
+		// the selected eip which is not ready is skipped
+		require.Equal(t, []string{"eip2", "eip1", "eip4", "eip3"}, names)
+		require.Equal(t, []string{"172.18.0.11", "172.18.0.10", "172.18.0.13", "172.18.0.12"}, ovnSnatEipV4Ips(selected))
+	})
+
+	t.Run("spec eip not ready", func(t *testing.T) {
+		snat := &kubeovnv1.OvnSnatRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "snat"},
+			Spec:       kubeovnv1.OvnSnatRuleSpec{OvnEips: []string{"eip1", "eip-not-ready"}},
+		}
+		_, err := ctrl.getOvnSnatEips(snat)
+		require.ErrorContains(t, err, "not ready")
+	})
+
+	t.Run("no eip selected", func(t *testing.T) {
+		snat := &kubeovnv1.OvnSnatRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "snat"},
+			Spec:       kubeovnv1.OvnSnatRuleSpec{OvnEipSelector: map[string]string{"pool": "b"}},
+		}
+		_, err := ctrl.getOvnSnatEips(snat)
+		require.ErrorContains(t, err, "should set eip")
+	})
+}
+
+func Test_handleUpdateOvnSnatRule(t *testing.T) {
+	t.Parallel()
+
+	t.Run("selected eips changed", func(t *testing.T) {
+		t.Parallel()
+
+		snat := &kubeovnv1.OvnSnatRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "snat"},
+			Spec:       kubeovnv1.OvnSnatRuleSpec{OvnEipSelector: map[string]string{"pool": "a"}, Vpc: "vpc1", V4IpCidr: "10.0.0.0/24"},
+			Status:     kubeovnv1.OvnSnatRuleStatus{Ready: true, Vpc: "vpc1", V4Eip: "172.18.0.10", V4Eips: []string{"172.18.0.10"}, V4IpCidr: "10.0.0.0/24"},
+		}
+		// eip1 is no longer selected and eip2 is selected
+		fakeController := newFakeController(t,
+			newOvnSnatTestEip("eip1", "172.18.0.10", map[string]string{"pool": "b"}),
+			newOvnSnatTestEip("eip2", "172.18.0.11", map[string]string{"pool": "a"}),
+			snat,
+		)
+		ctrl, mockOvnClient := fakeController.fakeController, fakeController.mockOvnClient
+		mockOvnClient.EXPECT().DeleteNat("vpc1", ovnnb.NATTypeSNAT, "172.18.0.10", "10.0.0.0/24").Return(nil)
+		mockOvnClient.EXPECT().UpdateSnat("vpc1", "172.18.0.11", "10.0.0.0/24", "").Return(nil)
+
+		require.NoError(t, ctrl.handleUpdateOvnSnatRule(snat.Name))
+
+		newSnat, err := ctrl.config.KubeOvnClient.KubeovnV1().OvnSnatRules().Get(context.Background(), snat.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"172.18.0.11"}, newSnat.Status.V4Eips)
+		require.Equal(t, "172.18.0.11", newSnat.Status.V4Eip)
+		// the eip recorded in status is reset even though it is no longer selected
+		require.Equal(t, 1, ctrl.resetOvnEipQueue.Len())
+		item, _ := ctrl.resetOvnEipQueue.Get()
+		require.Equal(t, "eip1", item)
+	})
+
+	t.Run("external port range changed", func(t *testing.T) {
+		t.Parallel()
+
+		snat := &kubeovnv1.OvnSnatRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "snat"},
+			Spec:       kubeovnv1.OvnSnatRuleSpec{OvnEips: []string{"eip1", "eip2"}, Vpc: "vpc1", V4IpCidr: "10.0.0.0/24", ExternalPortRange: "1024-65535"},
+			Status:     kubeovnv1.OvnSnatRuleStatus{Ready: true, Vpc: "vpc1", V4Eip: "172.18.0.10", V4Eips: []string{"172.18.0.10", "172.18.0.11"}, V4IpCidr: "10.0.0.0/24"},
+		}
+		fakeController := newFakeController(t,
+			newOvnSnatTestEip("eip1", "172.18.0.10", nil),
+			newOvnSnatTestEip("eip2", "172.18.0.11", nil),
+			snat,
+		)
+		ctrl, mockOvnClient := fakeController.fakeController, fakeController.mockOvnClient
+		mockOvnClient.EXPECT().UpdateSnat("vpc1", "172.18.0.10", "10.0.0.0/25", "1024-65535").Return(nil)
+		mockOvnClient.EXPECT().UpdateSnat("vpc1", "172.18.0.11", "10.0.0.128/25", "1024-65535").Return(nil)
+
+		require.NoError(t, ctrl.handleUpdateOvnSnatRule(snat.Name))
+
+		newSnat, err := ctrl.config.KubeOvnClient.KubeovnV1().OvnSnatRules().Get(context.Background(), snat.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "1024-65535", newSnat.Status.ExternalPortRange)
+		require.Zero(t, ctrl.resetOvnEipQueue.Len())
+	})
+}
+
+func Test_enqueueOvnSnatRulesOfEip(t *testing.T) {
+	t.Parallel()
+
+	ctrl := newFakeController(t,
+		&kubeovnv1.OvnSnatRule{ObjectMeta: metav1.ObjectMeta{Name: "by-name"}, Spec: kubeovnv1.OvnSnatRuleSpec{OvnEips: []string{"eip1"}}},
+		&kubeovnv1.OvnSnatRule{ObjectMeta: metav1.ObjectMeta{Name: "by-selector"}, Spec: kubeovnv1.OvnSnatRuleSpec{OvnEipSelector: map[string]string{"pool": "a"}}, Status: kubeovnv1.OvnSnatRuleStatus{Ready: true}},
+		&kubeovnv1.OvnSnatRule{ObjectMeta: metav1.ObjectMeta{Name: "by-status"}, Status: kubeovnv1.OvnSnatRuleStatus{Ready: true, V4Eips: []string{"172.18.0.10"}}},
+		&kubeovnv1.OvnSnatRule{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Spec: kubeovnv1.OvnSnatRuleSpec{OvnEip: "eip2", OvnEipSelector: map[string]string{"pool": "b"}}},
+	).fakeController
+
+	ctrl.enqueueOvnSnatRulesOfEip(newOvnSnatTestEip("eip1", "172.18.0.10", map[string]string{"pool": "a"}))
+	require.Equal(t, 1, ctrl.addOvnSnatRuleQueue.Len())
+	item, _ := ctrl.addOvnSnatRuleQueue.Get()
+	require.Equal(t, "by-name", item)
+	require.Equal(t, 2, ctrl.updateOvnSnatRuleQueue.Len())
+}
@@ -1,11 +1,14 @@
 package controller
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -179,15 +182,18 @@ func (c *Controller) enqueueAddPod(obj interface{}) {
 	}
 
 	p := obj.(*v1.Pod)
-	// TODO: we need to find a way to reduce duplicated np added to the queue
-	if c.config.EnableNP {
+	if c.config.EnableNP || c.config.EnableANP {
 		c.namedPort.AddNamedPortByPod(p)
-		if p.Status.PodIP != "" {
-			for _, np := range c.podMatchNetworkPolicies(p) {
-				c.updateNpQueue.Add(np)
-			}
+	}
+	// TODO: we need to find a way to reduce duplicated np added to the queue
+	if c.config.EnableNP && p.Status.PodIP != "" {
+		for _, np := range c.podMatchNetworkPolicies(p) {
+			c.updateNpQueue.Add(np)
 		}
 	}
+	if c.config.EnableANP && p.Status.PodIP != "" {
+		c.enqueueAnpsByPods(p)
+	}
 
 	if p.Spec.HostNetwork {
 		return
@@ -235,17 +241,23 @@ func (c *Controller) enqueueDeletePod(obj interface{}) {
 	}
 
 	p := obj.(*v1.Pod)
-	if c.config.EnableNP {
+	if c.config.EnableNP || c.config.EnableANP {
 		c.namedPort.DeleteNamedPortByPod(p)
+	}
+	if c.config.EnableNP {
 		for _, np := range c.podMatchNetworkPolicies(p) {
 			c.updateNpQueue.Add(np)
 		}
 	}
+	if c.config.EnableANP {
+		c.enqueueAnpsByPods(p)
+	}
 
 	if p.Spec.HostNetwork {
 		return
 	}
 
+	c.enqueueIPHandoffOfPod(p)
 	klog.Infof("enqueue delete pod %s", key)
 	c.deletingPodObjMap.Store(key, p)
 	c.deletePodQueue.Add(key)
@@ -263,6 +275,7 @@ func (c *Controller) enqueueUpdatePod(oldObj, newObj interface{}) {
 		utilruntime.HandleError(err)
 		return
 	}
+	c.enqueueIPHandoffOfPod(newPod)
 
 	podNets, err := c.getPodKubeovnNets(newPod)
 	if err != nil {
@@ -270,8 +283,10 @@ func (c *Controller) enqueueUpdatePod(oldObj, newObj interface{}) {
 		return
 	}
 
-	if c.config.EnableNP {
+	if c.config.EnableNP || c.config.EnableANP {
 		c.namedPort.AddNamedPortByPod(newPod)
+	}
+	if c.config.EnableNP {
 		newNp := c.podMatchNetworkPolicies(newPod)
 		if !reflect.DeepEqual(oldPod.Labels, newPod.Labels) {
 			oldNp := c.podMatchNetworkPolicies(oldPod)
@@ -293,6 +308,20 @@ func (c *Controller) enqueueUpdatePod(oldObj, newObj interface{}) {
 		}
 	}
 
+	if c.config.EnableANP {
+		if !reflect.DeepEqual(oldPod.Labels, newPod.Labels) {
+			c.enqueueAnpsByPods(oldPod, newPod)
+		} else {
+			for _, podNet := range podNets {
+				annotation := fmt.Sprintf(util.AllocatedAnnotationTemplate, podNet.ProviderName)
+				if oldPod.Annotations[annotation] != newPod.Annotations[annotation] {
+					c.enqueueAnpsByPods(newPod)
+					break
+				}
+			}
+		}
+	}
+
 	if newPod.Spec.HostNetwork {
 		return
 	}
@@ -355,7 +384,10 @@ func (c *Controller) enqueueUpdatePod(oldObj, newObj interface{}) {
 		newSg := newPod.Annotations[fmt.Sprintf(util.SecurityGroupAnnotationTemplate, podNet.ProviderName)]
 		oldVips := oldPod.Annotations[fmt.Sprintf(util.PortVipAnnotationTemplate, podNet.ProviderName)]
 		newVips := newPod.Annotations[fmt.Sprintf(util.PortVipAnnotationTemplate, podNet.ProviderName)]
-		if oldSecurity != newSecurity || oldSg != newSg || oldVips != newVips {
+		// the ipv6 address learned from the upstream router is added by kube-ovn-cni
+		learnedIPChanged := podNet.Subnet.Spec.IPv6AddressMode != "" &&
+			oldPod.Annotations[fmt.Sprintf(util.IPAddressAnnotationTemplate, podNet.ProviderName)] != newPod.Annotations[fmt.Sprintf(util.IPAddressAnnotationTemplate, podNet.ProviderName)]
+		if oldSecurity != newSecurity || oldSg != newSg || oldVips != newVips || learnedIPChanged {
 			c.updatePodSecurityQueue.Add(key)
 			break
 		}
@@ -531,10 +563,18 @@ func (c *Controller) handleAddOrUpdatePod(key string) (err error) {
 		c.recorder.Eventf(pod, v1.EventTypeWarning, "ValidatePodNetworkFailed", err.Error())
 		return err
 	}
+	if c.isIPHandoffPending(pod) {
+		// the address is assigned by the ip handoff
+		c.enqueueIPHandoffOfPod(pod)
+		return nil
+	}
 
 	podNets, err := c.getPodKubeovnNets(pod)
 	if err != nil {
 		klog.Errorf("failed to get pod nets %v", err)
+		if errors.Is(err, ipam.ErrNoAvailable) {
+			c.recorder.Eventf(pod, v1.EventTypeWarning, "SubnetExhausted", err.Error())
+		}
 		return err
 	}
 
@@ -596,7 +636,11 @@ func (c *Controller) reconcileAllocateSubnets(pod *v1.Pod, needAllocatePodNets [
 		// the subnet may changed when alloc static ip from the latter subnet after ns supports multi subnets
 		v4IP, v6IP, mac, subnet, err := c.acquireAddress(pod, podNet)
 		if err != nil {
-			c.recorder.Eventf(pod, v1.EventTypeWarning, "AcquireAddressFailed", err.Error())
+			if errors.Is(err, ipam.ErrNoAvailable) {
+				c.recorder.Eventf(pod, v1.EventTypeWarning, "SubnetExhausted", "no available address in subnet %s: %v", podNet.Subnet.Name, err)
+			} else {
+				c.recorder.Eventf(pod, v1.EventTypeWarning, "AcquireAddressFailed", err.Error())
+			}
 			klog.Error(err)
 			return nil, err
 		}
@@ -664,6 +708,24 @@ func (c *Controller) reconcileAllocateSubnets(pod *v1.Pod, needAllocatePodNets [
 				DHCPv4OptionsUUID: subnet.Status.DHCPv4OptionsUUID,
 				DHCPv6OptionsUUID: subnet.Status.DHCPv6OptionsUUID,
 			}
+			if podNet.Subnet.Spec.EnableDHCP {
+				bootOptions, err := podDHCPBootOptions(pod, podNet.ProviderName)
+				if err != nil {
+					c.recorder.Eventf(pod, v1.EventTypeWarning, "InvalidDHCPBootOptions", err.Error())
+					klog.Error(err)
+					return nil, err
+				}
+				if len(bootOptions) != 0 {
+					uuid, err := c.OVNNbClient.UpdateLogicalSwitchPortDHCPv4Options(subnet.Name, portName, bootOptions)
+					if err != nil {
+						klog.Errorf("failed to update dhcp boot options of lsp %s: %v", portName, err)
+						return nil, err
+					}
+					if uuid != "" {
+						dhcpOptions.DHCPv4OptionsUUID = uuid
+					}
+				}
+			}
 
 			securityGroupAnnotation := pod.Annotations[fmt.Sprintf(util.SecurityGroupAnnotationTemplate, podNet.ProviderName)]
 			securityGroups := strings.ReplaceAll(securityGroupAnnotation, " ", "")
@@ -673,6 +735,12 @@ func (c *Controller) reconcileAllocateSubnets(pod *v1.Pod, needAllocatePodNets [
 				klog.Errorf("%v", err)
 				return nil, err
 			}
+			if portSecurity && subnet.Spec.IPv6AddressMode != "" {
+				if err := c.OVNNbClient.SetLogicalSwitchPortSecurity(portSecurity, portName, mac, portSecurityIPs(subnet, mac, ipStr), vips); err != nil {
+					klog.Errorf("set logical switch port security: %v", err)
+					return nil, err
+				}
+			}
 
 			if isMigrate {
 				if migrated {
@@ -901,7 +969,7 @@ func (c *Controller) reconcileRouteSubnets(pod *v1.Pod, needRoutePodNets []*kube
 							klog.Errorf("failed to delete nat rules: %v", err)
 						}
 					} else if util.CheckProtocol(eip) == util.CheckProtocol(ipStr) {
-						if err = c.OVNNbClient.UpdateDnatAndSnat(c.config.ClusterRouter, eip, ipStr, fmt.Sprintf("%s.%s", podName, pod.Namespace), pod.Annotations[util.MacAddressAnnotation], c.ExternalGatewayType); err != nil {
+						if err = c.OVNNbClient.UpdateDnatAndSnat(c.config.ClusterRouter, eip, ipStr, fmt.Sprintf("%s.%s", podName, pod.Namespace), pod.Annotations[util.MacAddressAnnotation], c.ExternalGatewayType, true); err != nil {
 							klog.Errorf("failed to add nat rules, %v", err)
 							return err
 						}
@@ -911,7 +979,22 @@ func (c *Controller) reconcileRouteSubnets(pod *v1.Pod, needRoutePodNets []*kube
 							klog.Errorf("failed to delete nat rules: %v", err)
 						}
 					} else if util.CheckProtocol(eip) == util.CheckProtocol(ipStr) {
-						if err = c.OVNNbClient.UpdateSnat(c.config.ClusterRouter, eip, ipStr); err != nil {
+						// snat rules are identified by the external ip, remove the one with the previous eip
+						nats, err := c.OVNNbClient.ListNats(c.config.ClusterRouter, ovnnb.NATTypeSNAT, ipStr, nil)
+						if err != nil {
+							klog.Errorf("failed to list nat rules: %v", err)
+							return err
+						}
+						for _, nat := range nats {
+							if nat.ExternalIP == eip {
+								continue
+							}
+							if err = c.OVNNbClient.DeleteNat(c.config.ClusterRouter, ovnnb.NATTypeSNAT, nat.ExternalIP, ipStr); err != nil {
+								klog.Errorf("failed to delete nat rules: %v", err)
+								return err
+							}
+						}
+						if err = c.OVNNbClient.UpdateSnat(c.config.ClusterRouter, eip, ipStr, ""); err != nil {
 							klog.Errorf("failed to add nat rules, %v", err)
 							return err
 						}
@@ -953,6 +1036,10 @@ func (c *Controller) handleDeletePod(key string) error {
 		// Pod with same name exists, just return here
 		return nil
 	}
+	if c.isAddressHandedOff(pod) {
+		klog.Infof("address of pod %s is handed off, skip releasing it", key)
+		return nil
+	}
 
 	podKey := fmt.Sprintf("%s/%s", pod.Namespace, podName)
 
@@ -1062,6 +1149,10 @@ func (c *Controller) handleDeletePod(key string) error {
 				klog.Errorf("failed to delete lsp %s, %v", port.Name, err)
 				return err
 			}
+			if err := c.OVNNbClient.DeleteLogicalSwitchPortDHCPOptions(port.Name); err != nil {
+				klog.Errorf("failed to delete dhcp options of lsp %s, %v", port.Name, err)
+				return err
+			}
 		}
 		klog.Infof("release all ip address for deleting pod %s", podKey)
 		for _, podNet := range podNets {
@@ -1095,6 +1186,73 @@ func (c *Controller) deleteCrdIPs(podName, ns, providerName string) error {
 	return nil
 }
 
+// recordLearnedIPv6Address records the ipv6 address learned from the upstream router into ipam and the ip CR
+func (c *Controller) recordLearnedIPv6Address(pod *v1.Pod, podNet *kubeovnNet) error {
+	if podNet.Subnet.Spec.IPv6AddressMode == "" || pod.Annotations[fmt.Sprintf(util.AllocatedAnnotationTemplate, podNet.ProviderName)] != "true" {
+		return nil
+	}
+	ipStr := pod.Annotations[fmt.Sprintf(util.IPAddressAnnotationTemplate, podNet.ProviderName)]
+	if _, v6IP := util.SplitStringIP(ipStr); v6IP == "" {
+		return nil
+	}
+
+	podName := c.getNameByPod(pod)
+	key := fmt.Sprintf("%s/%s", pod.Namespace, podName)
+	portName := ovs.PodNameToPortName(podName, pod.Namespace, podNet.ProviderName)
+	mac := pod.Annotations[fmt.Sprintf(util.MacAddressAnnotationTemplate, podNet.ProviderName)]
+	if _, _, _, err := c.ipam.GetStaticAddress(key, portName, ipStr, &mac, podNet.Subnet.Name, true); err != nil {
+		klog.Errorf("failed to record learned address %s of pod %s: %v", ipStr, key, err)
+		c.recorder.Eventf(pod, v1.EventTypeWarning, "RecordLearnedAddressFailed", err.Error())
+		return err
+	}
+	if err := c.createOrUpdateCrdIPs(podName, ipStr, mac, podNet.Subnet.Name, pod.Namespace, pod.Spec.NodeName, podNet.ProviderName, getPodType(pod)); err != nil {
+		klog.Errorf("failed to create or update ip CR of pod %s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+// portSecurityIPs returns the addresses allowed by the port security. Until the ipv6 address is learned
+// from the upstream router, only the link local address generated from the mac address is allowed,
+// with which ovn also allows the unspecified address for DAD.
+func portSecurityIPs(subnet *kubeovnv1.Subnet, mac, ipStr string) string {
+	if subnet.Spec.IPv6AddressMode == "" {
+		return ipStr
+	}
+	v4IP, v6IP := util.SplitStringIP(ipStr)
+	if v6IP != "" {
+		return ipStr
+	}
+	linkLocal, err := util.IPv6LinkLocalAddress(mac)
+	if err != nil {
+		klog.Errorf("failed to generate ipv6 link local address from mac %s: %v", mac, err)
+	}
+	return strings.Trim(v4IP+","+linkLocal, ",")
+}
+
+// podDHCPBootOptions returns the network boot options set by the pod annotations,
+// which override the dhcpv4 options of the subnet for the logical switch port
+func podDHCPBootOptions(pod *v1.Pod, provider string) (map[string]string, error) {
+	nextServer := pod.Annotations[fmt.Sprintf(util.DHCPNextServerAnnotationTemplate, provider)]
+	bootfile := pod.Annotations[fmt.Sprintf(util.DHCPBootfileAnnotationTemplate, provider)]
+	ipxeBootfile := pod.Annotations[fmt.Sprintf(util.DHCPIPXEBootfileAnnotationTemplate, provider)]
+	if err := util.ValidateDHCPBootOptions(nextServer, bootfile, ipxeBootfile); err != nil {
+		return nil, fmt.Errorf("invalid dhcp boot options of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+
+	options := make(map[string]string, 3)
+	if nextServer != "" {
+		options["next_server"] = nextServer
+	}
+	if bootfile != "" {
+		options["bootfile_name"] = strconv.Quote(bootfile)
+	}
+	if ipxeBootfile != "" {
+		options["bootfile_name_alt"] = strconv.Quote(ipxeBootfile)
+	}
+	return options, nil
+}
+
 func (c *Controller) handleUpdatePodSecurity(key string) error {
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
@@ -1130,11 +1288,15 @@ func (c *Controller) handleUpdatePodSecurity(key string) error {
 			portSecurity = true
 		}
 
+		if err = c.recordLearnedIPv6Address(pod, podNet); err != nil {
+			return err
+		}
+
 		mac := pod.Annotations[fmt.Sprintf(util.MacAddressAnnotationTemplate, podNet.ProviderName)]
 		ipStr := pod.Annotations[fmt.Sprintf(util.IPAddressAnnotationTemplate, podNet.ProviderName)]
 		vips := pod.Annotations[fmt.Sprintf(util.PortVipAnnotationTemplate, podNet.ProviderName)]
 
-		if err = c.OVNNbClient.SetLogicalSwitchPortSecurity(portSecurity, ovs.PodNameToPortName(podName, namespace, podNet.ProviderName), mac, ipStr, vips); err != nil {
+		if err = c.OVNNbClient.SetLogicalSwitchPortSecurity(portSecurity, ovs.PodNameToPortName(podName, namespace, podNet.ProviderName), mac, portSecurityIPs(podNet.Subnet, mac, ipStr), vips); err != nil {
 			klog.Errorf("set logical switch port security: %v", err)
 			return err
 		}
@@ -1204,6 +1366,10 @@ func (c *Controller) syncKubeOvnNet(pod *v1.Pod, podNets []*kubeovnNet) (*v1.Pod
 			klog.Errorf("failed to delete lsp %s, %v", portNeedDel, err)
 			return nil, err
 		}
+		if err := c.OVNNbClient.DeleteLogicalSwitchPortDHCPOptions(portNeedDel); err != nil {
+			klog.Errorf("failed to delete dhcp options of lsp %s, %v", portNeedDel, err)
+			return nil, err
+		}
 		if err := c.config.KubeOvnClient.KubeovnV1().IPs().Delete(context.Background(), portNeedDel, metav1.DeleteOptions{}); err != nil {
 			if !k8serrors.IsNotFound(err) {
 				klog.Errorf("failed to delete ip %s, %v", portNeedDel, err)
@@ -1429,6 +1595,7 @@ func (c *Controller) getPodDefaultSubnet(pod *v1.Pod) (*kubeovnv1.Subnet, error)
 	}
 
 	subnetNames := ns.Annotations[util.LogicalSwitchAnnotation]
+	var subnets []*kubeovnv1.Subnet
 	for _, subnetName := range strings.Split(subnetNames, ",") {
 		if subnetName == "" {
 			err = fmt.Errorf("namespace %s default logical switch is not found", ns.Name)
@@ -1440,28 +1607,131 @@ func (c *Controller) getPodDefaultSubnet(pod *v1.Pod) (*kubeovnv1.Subnet, error)
 			klog.Errorf("failed to get subnet %s: %v", subnetName, err)
 			return nil, err
 		}
+		subnets = append(subnets, subnet)
+	}
 
-		switch subnet.Spec.Protocol {
-		case kubeovnv1.ProtocolDual:
-			if subnet.Status.V6AvailableIPs == 0 {
-				klog.Infof("there's no available ipv6 address in subnet %s, try next one", subnet.Name)
-				continue
-			}
-			fallthrough
-		case kubeovnv1.ProtocolIPv4:
-			if subnet.Status.V4AvailableIPs == 0 {
-				klog.Infof("there's no available ipv4 address in subnet %s, try next one", subnet.Name)
-				continue
+	policy := c.getNsSubnetAllocationPolicy(ns)
+	zone, err := c.getPodZoneByPolicy(pod, policy)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	if subnet := selectNamespaceSubnet(policy, subnets, zone); subnet != nil {
+		return subnet, nil
+	}
+	return nil, fmt.Errorf("%w: subnets %s of namespace %s are exhausted", ipam.ErrNoAvailable, subnetNames, ns.Name)
+}
+
+// getNsSubnetAllocationPolicy returns the subnet allocation policy of the namespace,
+// an unknown policy is reported by a warning event and the subnets are used in order
+func (c *Controller) getNsSubnetAllocationPolicy(ns *v1.Namespace) string {
+	policy := ns.Annotations[util.SubnetAllocationPolicyAnnotation]
+	switch policy {
+	case "", util.SubnetAllocationPolicyOrdered, util.SubnetAllocationPolicyBalanced, util.SubnetAllocationPolicyZoneAware:
+		return policy
+	}
+	klog.Warningf("unknown subnet allocation policy %q of namespace %s, use %s", policy, ns.Name, util.SubnetAllocationPolicyOrdered)
+	c.recorder.Eventf(ns, v1.EventTypeWarning, "InvalidSubnetAllocationPolicy", "unknown subnet allocation policy %q, the subnets are used in order", policy)
+	return util.SubnetAllocationPolicyOrdered
+}
+
+// getPodZoneByPolicy returns the topology zone of the pod if it's required by the subnet allocation policy
+func (c *Controller) getPodZoneByPolicy(pod *v1.Pod, policy string) (string, error) {
+	if policy != util.SubnetAllocationPolicyZoneAware {
+		return "", nil
+	}
+	return c.getPodZone(pod)
+}
+
+// selectNamespaceSubnet chooses a subnet with available addresses from the subnets bound to the namespace
+// by the allocation policy
+func selectNamespaceSubnet(policy string, subnets []*kubeovnv1.Subnet, zone string) *kubeovnv1.Subnet {
+	for _, subnet := range sortNamespaceSubnets(policy, subnets, zone) {
+		if subnetAvailableIPs(subnet) == 0 {
+			klog.Infof("there's no available address in subnet %s, try next one", subnet.Name)
+			continue
+		}
+		return subnet
+	}
+	return nil
+}
+
+// sortNamespaceSubnets returns the subnets bound to the namespace in the order preferred by the allocation policy,
+// the subnets are used in order if the policy is empty or unknown
+func sortNamespaceSubnets(policy string, subnets []*kubeovnv1.Subnet, zone string) []*kubeovnv1.Subnet {
+	switch policy {
+	case util.SubnetAllocationPolicyBalanced:
+		result := slices.Clone(subnets)
+		slices.SortStableFunc(result, func(a, b *kubeovnv1.Subnet) int {
+			return cmp.Compare(subnetAvailableIPs(b), subnetAvailableIPs(a))
+		})
+		return result
+	case util.SubnetAllocationPolicyZoneAware:
+		if zone == "" {
+			break
+		}
+		// spill over to the subnets not tied to any zone when the subnets of the zone are exhausted,
+		// the subnets of the other zones are never used
+		var zoned, unzoned []*kubeovnv1.Subnet
+		for _, subnet := range subnets {
+			switch subnet.Labels[v1.LabelTopologyZone] {
+			case zone:
+				zoned = append(zoned, subnet)
+			case "":
+				unzoned = append(unzoned, subnet)
 			}
-		case kubeovnv1.ProtocolIPv6:
-			if subnet.Status.V6AvailableIPs == 0 {
-				klog.Infof("there's no available ipv6 address in subnet %s, try next one", subnet.Name)
-				continue
+		}
+		return append(zoned, unzoned...)
+	}
+	return subnets
+}
+
+// subnetAvailableIPs returns the number of addresses can be allocated in the subnet,
+// which is the less one of the ipv4 and ipv6 available addresses for dual stack subnet
+func subnetAvailableIPs(subnet *kubeovnv1.Subnet) float64 {
+	switch subnet.Spec.Protocol {
+	case kubeovnv1.ProtocolDual:
+		return min(subnet.Status.V4AvailableIPs, subnet.Status.V6AvailableIPs)
+	case kubeovnv1.ProtocolIPv4:
+		return subnet.Status.V4AvailableIPs
+	case kubeovnv1.ProtocolIPv6:
+		return subnet.Status.V6AvailableIPs
+	}
+	return max(subnet.Status.V4AvailableIPs, subnet.Status.V6AvailableIPs)
+}
+
+// getPodZone returns the topology zone of the pod's node, or the zone required by the pod if it's not scheduled yet
+func (c *Controller) getPodZone(pod *v1.Pod) (string, error) {
+	if pod.Spec.NodeName != "" {
+		node, err := c.nodesLister.Get(pod.Spec.NodeName)
+		if err != nil {
+			klog.Errorf("failed to get node %s: %v", pod.Spec.NodeName, err)
+			return "", err
+		}
+		return node.Labels[v1.LabelTopologyZone], nil
+	}
+	if zone := pod.Spec.NodeSelector[v1.LabelTopologyZone]; zone != "" {
+		return zone, nil
+	}
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil ||
+		pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return "", nil
+	}
+	// only a single zone required by all the node selector terms is used
+	var zone string
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		var termZone string
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == v1.LabelTopologyZone && expr.Operator == v1.NodeSelectorOpIn && len(expr.Values) == 1 {
+				termZone = expr.Values[0]
 			}
 		}
-		return subnet, nil
+		if termZone == "" || (zone != "" && zone != termZone) {
+			return "", nil
+		}
+		zone = termZone
 	}
-	return nil, ipam.ErrNoAvailable
+	return zone, nil
 }
 
 func loadNetConf(bytes []byte) (*multustypes.DelegateNetConf, error) {
@@ -1660,6 +1930,20 @@ func (c *Controller) acquireAddress(pod *v1.Pod, podNet *kubeovnNet) (string, st
 	}
 
 	ippoolStr := pod.Annotations[fmt.Sprintf(util.IPPoolAnnotationTemplate, podNet.ProviderName)]
+	if ippoolStr == "" {
+		ippool, err := c.getPodSelectedIPPool(pod, podNet.Subnet.Name)
+		if err != nil {
+			return "", "", "", podNet.Subnet, err
+		}
+		ippoolStr = ippool
+	}
+	if ippoolStr == "" {
+		ippool, err := c.getNamespaceAutoAllocatedIPPool(pod.Namespace, podNet.Subnet)
+		if err != nil {
+			return "", "", "", podNet.Subnet, err
+		}
+		ippoolStr = ippool
+	}
 	if ippoolStr == "" {
 		ns, err := c.namespacesLister.Get(pod.Namespace)
 		if err != nil {
@@ -1704,9 +1988,12 @@ func (c *Controller) acquireAddress(pod *v1.Pod, podNet *kubeovnNet) (string, st
 	portName := ovs.PodNameToPortName(podName, pod.Namespace, podNet.ProviderName)
 
 	// The static ip can be assigned from any subnet after ns supports multi subnets
-	nsNets, _ := c.getNsAvailableSubnets(pod, podNet)
+	nsNets, err := c.getNsAvailableSubnets(pod, podNet)
+	if err != nil {
+		klog.Error(err)
+		return "", "", "", podNet.Subnet, err
+	}
 	var v4IP, v6IP, mac string
-	var err error
 
 	// Static allocate
 	if pod.Annotations[fmt.Sprintf(util.IPAddressAnnotationTemplate, podNet.ProviderName)] != "" {
@@ -1965,10 +2252,14 @@ func (c *Controller) getNameByPod(pod *v1.Pod) string {
 			return vmName
 		}
 	}
+	if owner := ipHandoffPortOwnerOfPod(pod); owner != "" {
+		return owner
+	}
 	return pod.Name
 }
 
 // When subnet's v4availableIPs is 0 but still there's available ip in exclude-ips, the static ip in exclude-ips can be allocated normal.
+// The other subnets of the namespace are sorted by the subnet allocation policy of the namespace.
 func (c *Controller) getNsAvailableSubnets(pod *v1.Pod, podNet *kubeovnNet) ([]*kubeovnNet, error) {
 	var result []*kubeovnNet
 	// keep the annotation subnet of the pod in first position
@@ -1980,9 +2271,10 @@ func (c *Controller) getNsAvailableSubnets(pod *v1.Pod, podNet *kubeovnNet) ([]*
 		return nil, err
 	}
 	if ns.Annotations == nil {
-		return nil, nil
+		return result, nil
 	}
 
+	var subnets []*kubeovnv1.Subnet
 	subnetNames := ns.Annotations[util.LogicalSwitchAnnotation]
 	for _, subnetName := range strings.Split(subnetNames, ",") {
 		if subnetName == "" || subnetName == podNet.Subnet.Name {
@@ -1993,7 +2285,19 @@ func (c *Controller) getNsAvailableSubnets(pod *v1.Pod, podNet *kubeovnNet) ([]*
 			klog.Errorf("failed to get subnet %v", err)
 			return nil, err
 		}
+		subnets = append(subnets, subnet)
+	}
+	if len(subnets) == 0 {
+		return result, nil
+	}
 
+	policy := c.getNsSubnetAllocationPolicy(ns)
+	zone, err := c.getPodZoneByPolicy(pod, policy)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	for _, subnet := range sortNamespaceSubnets(policy, subnets, zone) {
 		result = append(result, &kubeovnNet{
 			Type:         providerTypeOriginal,
 			ProviderName: subnet.Spec.Provider,
@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_selectNamespaceSubnet(t *testing.T) {
+	t.Parallel()
+
+	subnet := func(name, zone string, available float64) *kubeovnv1.Subnet {
+		s := &kubeovnv1.Subnet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kubeovnv1.SubnetSpec{Protocol: kubeovnv1.ProtocolIPv4},
+			Status:     kubeovnv1.SubnetStatus{V4AvailableIPs: available},
+		}
+		if zone != "" {
+			s.Labels = map[string]string{v1.LabelTopologyZone: zone}
+		}
+		return s
+	}
+	full := subnet("full", "", 0)
+	small := subnet("small", "", 10)
+	large := subnet("large", "", 100)
+	zoneA := subnet("zone-a", "a", 10)
+	fullZoneB := subnet("zone-b", "b", 0)
+
+	tests := []struct {
+		name    string
+		policy  string
+		subnets []*kubeovnv1.Subnet
+		zone    string
+		want    *kubeovnv1.Subnet
+	}{
+		{"ordered", "", []*kubeovnv1.Subnet{full, small, large}, "", small},
+		{"explicitly ordered", util.SubnetAllocationPolicyOrdered, []*kubeovnv1.Subnet{large, small}, "", large},
+		{"exhausted", "", []*kubeovnv1.Subnet{full}, "", nil},
+		{"balanced", util.SubnetAllocationPolicyBalanced, []*kubeovnv1.Subnet{full, small, large}, "", large},
+		{"zone", util.SubnetAllocationPolicyZoneAware, []*kubeovnv1.Subnet{small, zoneA}, "a", zoneA},
+		{"zone exhausted", util.SubnetAllocationPolicyZoneAware, []*kubeovnv1.Subnet{zoneA, fullZoneB, small}, "b", small},
+		{"zone exhausted without spill-over", util.SubnetAllocationPolicyZoneAware, []*kubeovnv1.Subnet{zoneA, fullZoneB}, "b", nil},
+		{"unknown zone", util.SubnetAllocationPolicyZoneAware, []*kubeovnv1.Subnet{zoneA, small}, "", zoneA},
+		{"unknown policy", "random", []*kubeovnv1.Subnet{small, large}, "", small},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, selectNamespaceSubnet(tt.policy, tt.subnets, tt.zone))
+		})
+	}
+}
+
+func Test_portSecurityIPs(t *testing.T) {
+	t.Parallel()
+
+	subnet := func(cidr, mode string) *kubeovnv1.Subnet {
+		return &kubeovnv1.Subnet{Spec: kubeovnv1.SubnetSpec{CIDRBlock: cidr, IPv6AddressMode: mode}}
+	}
+	tests := []struct {
+		name   string
+		subnet *kubeovnv1.Subnet
+		ip     string
+		want   string
+	}{
+		{"allocated", subnet("10.0.0.0/24,fd00::/64", ""), "10.0.0.2,fd00::2", "10.0.0.2,fd00::2"},
+		{"dual stack not learned", subnet("10.0.0.0/24,fd00::/64", kubeovnv1.IPv6AddressModeSLAAC), "10.0.0.2", "10.0.0.2,fe80::200:ff:fe00:1"},
+		{"ipv6 not learned", subnet("fd00::/64", kubeovnv1.IPv6AddressModeSLAAC), "", "fe80::200:ff:fe00:1"},
+		{"learned", subnet("10.0.0.0/24,fd00::/64", kubeovnv1.IPv6AddressModeSLAAC), "10.0.0.2,fd00::200:ff:fe00:1", "10.0.0.2,fd00::200:ff:fe00:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, portSecurityIPs(tt.subnet, "00:00:00:00:00:01", tt.ip))
+		})
+	}
+}
+
+func Test_podDHCPBootOptions(t *testing.T) {
+	t.Parallel()
+
+	pod := func(annotations map[string]string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: "default", Annotations: annotations}}
+	}
+	tests := []struct {
+		name    string
+		pod     *v1.Pod
+		want    map[string]string
+		wantErr bool
+	}{
+		{"no boot options", pod(nil), map[string]string{}, false},
+		{"boot options", pod(map[string]string{
+			"ovn.kubernetes.io/dhcp_next_server":   "10.0.0.10",
+			"ovn.kubernetes.io/dhcp_bootfile":      "undionly.kpxe",
+			"ovn.kubernetes.io/dhcp_ipxe_bootfile": "http://10.0.0.10/boot.ipxe",
+			"other.kubernetes.io/dhcp_next_server": "10.0.0.11",
+		}), map[string]string{
+			"next_server":       "10.0.0.10",
+			"bootfile_name":     `"undionly.kpxe"`,
+			"bootfile_name_alt": `"http://10.0.0.10/boot.ipxe"`,
+		}, false},
+		{"invalid next server", pod(map[string]string{"ovn.kubernetes.io/dhcp_next_server": "fd00::10"}), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := podDHCPBootOptions(tt.pod, util.OvnProvider)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, options)
+		})
+	}
+}
+
+func Test_getNsAvailableSubnets(t *testing.T) {
+	t.Parallel()
+
+	subnet := func(name, zone string, available float64) *kubeovnv1.Subnet {
+		s := &kubeovnv1.Subnet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kubeovnv1.SubnetSpec{Protocol: kubeovnv1.ProtocolIPv4, Provider: util.OvnProvider},
+			Status:     kubeovnv1.SubnetStatus{V4AvailableIPs: available},
+		}
+		if zone != "" {
+			s.Labels = map[string]string{v1.LabelTopologyZone: zone}
+		}
+		return s
+	}
+	small := subnet("small", "", 10)
+	large := subnet("large", "", 100)
+	zoneA := subnet("zone-a", "a", 10)
+	zoneB := subnet("zone-b", "b", 10)
+	subnetNames := func(nets []*kubeovnNet) []string {
+		names := make([]string, 0, len(nets))
+		for _, net := range nets {
+			names = append(names, net.Subnet.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name   string
+		policy string
+		want   []string
+		event  bool
+	}{
+		{"ordered", "", []string{"zone-b", "small", "large", "zone-a"}, false},
+		{"balanced", util.SubnetAllocationPolicyBalanced, []string{"zone-b", "large", "small", "zone-a"}, false},
+		{"zone aware", util.SubnetAllocationPolicyZoneAware, []string{"zone-b", "zone-a", "small", "large"}, false},
+		{"unknown policy", "random", []string{"zone-b", "small", "large", "zone-a"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ns := &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+					Annotations: map[string]string{
+						util.LogicalSwitchAnnotation:          "small,large,zone-a,zone-b",
+						util.SubnetAllocationPolicyAnnotation: tt.policy,
+					},
+				},
+			}
+			fakeController := newFakeController(t, ns, small, large, zoneA, zoneB)
+			ctrl := fakeController.fakeController
+
+			// the pod requires zone a, and the subnet annotated to the pod is kept in first position
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+				Spec:       v1.PodSpec{NodeSelector: map[string]string{v1.LabelTopologyZone: "a"}},
+			}
+			nets, err := ctrl.getNsAvailableSubnets(pod, &kubeovnNet{Type: providerTypeOriginal, ProviderName: util.OvnProvider, Subnet: zoneB})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, subnetNames(nets))
+
+			recorder := ctrl.recorder.(*record.FakeRecorder)
+			if tt.event {
+				require.Len(t, recorder.Events, 1)
+				require.Contains(t, <-recorder.Events, "InvalidSubnetAllocationPolicy")
+			} else {
+				require.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
@@ -318,6 +318,13 @@ func (c *Controller) handleAddOrUpdateSg(key string, force bool) error {
 			ingressNeedUpdate = true
 			egressNeedUpdate = true
 		}
+
+		// check stateless switch, the reply acls of stateless rules are created in the reverse direction
+		if sg.Status.Stateless != sg.Spec.Stateless || (sg.Spec.Stateless && (ingressNeedUpdate || egressNeedUpdate)) {
+			klog.Infof("both ingress && egress need update, sg:%s", sg.Name)
+			ingressNeedUpdate = true
+			egressNeedUpdate = true
+		}
 	}
 
 	// update sg rule
@@ -354,6 +361,7 @@ func (c *Controller) handleAddOrUpdateSg(key string, force bool) error {
 	// update status
 	sg.Status.PortGroup = ovs.GetSgPortGroupName(sg.Name)
 	sg.Status.AllowSameGroupTraffic = sg.Spec.AllowSameGroupTraffic
+	sg.Status.Stateless = sg.Spec.Stateless
 	c.patchSgStatus(sg)
 	c.syncSgPortsQueue.Add(key)
 	return nil
@@ -387,22 +395,98 @@ func (c *Controller) validateSgRule(sg *kubeovnv1.SecurityGroup) error {
 			if err != nil {
 				return fmt.Errorf("failed to get remote sg '%s', %v", rule.RemoteSecurityGroup, err)
 			}
+		case kubeovnv1.SgRemoteTypeAddressGroup:
+			_, err := c.addressGroupsLister.Get(rule.RemoteAddressGroup)
+			if err != nil {
+				return fmt.Errorf("failed to get remote address group '%s', %v", rule.RemoteAddressGroup, err)
+			}
 		default:
 			return fmt.Errorf("not support sgRemoteType '%s'", rule.RemoteType)
 		}
 
-		if rule.Protocol == kubeovnv1.ProtocolTCP || rule.Protocol == kubeovnv1.ProtocolUDP {
-			if rule.PortRangeMin < 1 || rule.PortRangeMin > 65535 || rule.PortRangeMax < 1 || rule.PortRangeMax > 65535 {
-				return fmt.Errorf("portRange is out of range")
-			}
-			if rule.PortRangeMin > rule.PortRangeMax {
-				return fmt.Errorf("portRange err, range Minimum value greater than maximum value")
+		if err := validateSgRulePorts(rule); err != nil {
+			return err
+		}
+		if err := validateSgRuleICMP(rule); err != nil {
+			return err
+		}
+		switch rule.LogSeverity {
+		case "", ovnnb.ACLSeverityAlert, ovnnb.ACLSeverityWarning, ovnnb.ACLSeverityNotice, ovnnb.ACLSeverityInfo, ovnnb.ACLSeverityDebug:
+		default:
+			return fmt.Errorf("logSeverity '%s' should be one of alert, warning, notice, info and debug", rule.LogSeverity)
+		}
+		if sg.Spec.Stateless {
+			if err := validateSgRuleStateless(rule); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
+// validateSgRuleStateless checks whether the reply traffic of an allow rule is representable without conntrack
+func validateSgRuleStateless(rule *kubeovnv1.SgRule) error {
+	if rule.Policy != kubeovnv1.PolicyAllow || rule.Protocol != kubeovnv1.ProtocolICMP || rule.ICMPType == nil {
+		return nil
+	}
+	// only the reply of echo request is known, other icmp messages are related to existing connections
+	if (rule.IPVersion == "ipv4" && *rule.ICMPType != 8) || (rule.IPVersion == "ipv6" && *rule.ICMPType != 128) {
+		return fmt.Errorf("icmpType '%d' is not supported by stateless security group, only echo request is allowed", *rule.ICMPType)
+	}
+	return nil
+}
+
+func validateSgRulePorts(rule *kubeovnv1.SgRule) error {
+	if rule.Protocol != kubeovnv1.ProtocolTCP && rule.Protocol != kubeovnv1.ProtocolUDP {
+		if len(rule.PortRanges) != 0 {
+			return fmt.Errorf("portRanges is only supported by tcp and udp")
+		}
+		return nil
+	}
+
+	if len(rule.PortRanges) == 0 {
+		if rule.PortRangeMin < 1 || rule.PortRangeMin > 65535 || rule.PortRangeMax < 1 || rule.PortRangeMax > 65535 {
+			return fmt.Errorf("portRange is out of range")
+		}
+		if rule.PortRangeMin > rule.PortRangeMax {
+			return fmt.Errorf("portRange err, range Minimum value greater than maximum value")
+		}
+		return nil
+	}
+
+	if rule.PortRangeMin != 0 || rule.PortRangeMax != 0 {
+		return fmt.Errorf("portRanges and portRangeMin/portRangeMax are mutually exclusive")
+	}
+	for _, r := range rule.PortRanges {
+		if r.Min < 1 || r.Min > 65535 || r.Max < 0 || r.Max > 65535 {
+			return fmt.Errorf("portRange %d-%d is out of range", r.Min, r.Max)
+		}
+		if r.Max != 0 && r.Min > r.Max {
+			return fmt.Errorf("portRange err, range Minimum value %d greater than maximum value %d", r.Min, r.Max)
+		}
+	}
+	return nil
+}
+
+func validateSgRuleICMP(rule *kubeovnv1.SgRule) error {
+	if rule.ICMPType == nil && rule.ICMPCode == nil {
+		return nil
+	}
+	if rule.Protocol != kubeovnv1.ProtocolICMP {
+		return fmt.Errorf("icmpType and icmpCode are only supported by icmp")
+	}
+	if rule.ICMPType == nil {
+		return fmt.Errorf("icmpCode requires icmpType")
+	}
+	if *rule.ICMPType < 0 || *rule.ICMPType > 255 {
+		return fmt.Errorf("icmpType '%d' is not in the range of 0 to 255", *rule.ICMPType)
+	}
+	if rule.ICMPCode != nil && (*rule.ICMPCode < 0 || *rule.ICMPCode > 255) {
+		return fmt.Errorf("icmpCode '%d' is not in the range of 0 to 255", *rule.ICMPCode)
+	}
+	return nil
+}
+
 func (c *Controller) patchSgStatus(sg *kubeovnv1.SecurityGroup) {
 	bytes, err := sg.Status.Bytes()
 	if err != nil {
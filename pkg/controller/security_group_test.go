@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	"github.com/kubeovn/kube-ovn/pkg/ovs"
 	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
 )
@@ -72,3 +73,38 @@ func Test_securityGroupALLNotExist(t *testing.T) {
 		require.True(t, exist)
 	})
 }
+
+func Test_validateSgRulePorts(t *testing.T) {
+	tcp := func(min, max int, ranges ...kubeovnv1.SgPortRange) *kubeovnv1.SgRule {
+		return &kubeovnv1.SgRule{Protocol: kubeovnv1.ProtocolTCP, PortRangeMin: min, PortRangeMax: max, PortRanges: ranges}
+	}
+
+	require.NoError(t, validateSgRulePorts(tcp(80, 80)))
+	require.NoError(t, validateSgRulePorts(tcp(0, 0, kubeovnv1.SgPortRange{Min: 80}, kubeovnv1.SgPortRange{Min: 8000, Max: 8080})))
+	require.Error(t, validateSgRulePorts(tcp(0, 0)))
+	require.Error(t, validateSgRulePorts(tcp(80, 80, kubeovnv1.SgPortRange{Min: 443})))
+	require.Error(t, validateSgRulePorts(tcp(0, 0, kubeovnv1.SgPortRange{Min: 8080, Max: 8000})))
+	require.Error(t, validateSgRulePorts(tcp(0, 0, kubeovnv1.SgPortRange{Min: 0, Max: 80})))
+	require.Error(t, validateSgRulePorts(&kubeovnv1.SgRule{Protocol: kubeovnv1.ProtocolICMP, PortRanges: []kubeovnv1.SgPortRange{{Min: 80}}}))
+}
+
+func Test_validateSgRuleICMP(t *testing.T) {
+	icmpType, icmpCode, invalid := 3, 4, 256
+
+	require.NoError(t, validateSgRuleICMP(&kubeovnv1.SgRule{Protocol: kubeovnv1.ProtocolICMP}))
+	require.NoError(t, validateSgRuleICMP(&kubeovnv1.SgRule{Protocol: kubeovnv1.ProtocolICMP, ICMPType: &icmpType, ICMPCode: &icmpCode}))
+	require.Error(t, validateSgRuleICMP(&kubeovnv1.SgRule{Protocol: kubeovnv1.ProtocolICMP, ICMPCode: &icmpCode}))
+	require.Error(t, validateSgRuleICMP(&kubeovnv1.SgRule{Protocol: kubeovnv1.ProtocolICMP, ICMPType: &invalid}))
+	require.Error(t, validateSgRuleICMP(&kubeovnv1.SgRule{Protocol: kubeovnv1.ProtocolTCP, ICMPType: &icmpType}))
+}
+
+func Test_validateSgRuleStateless(t *testing.T) {
+	echoRequest, echoRequestV6, unreachable := 8, 128, 3
+
+	require.NoError(t, validateSgRuleStateless(&kubeovnv1.SgRule{IPVersion: "ipv4", Protocol: kubeovnv1.ProtocolUDP, Policy: kubeovnv1.PolicyAllow}))
+	require.NoError(t, validateSgRuleStateless(&kubeovnv1.SgRule{IPVersion: "ipv4", Protocol: kubeovnv1.ProtocolICMP, Policy: kubeovnv1.PolicyAllow, ICMPType: &echoRequest}))
+	require.NoError(t, validateSgRuleStateless(&kubeovnv1.SgRule{IPVersion: "ipv6", Protocol: kubeovnv1.ProtocolICMP, Policy: kubeovnv1.PolicyAllow, ICMPType: &echoRequestV6}))
+	require.NoError(t, validateSgRuleStateless(&kubeovnv1.SgRule{IPVersion: "ipv4", Protocol: kubeovnv1.ProtocolICMP, Policy: kubeovnv1.PolicyDrop, ICMPType: &unreachable}))
+	require.Error(t, validateSgRuleStateless(&kubeovnv1.SgRule{IPVersion: "ipv4", Protocol: kubeovnv1.ProtocolICMP, Policy: kubeovnv1.PolicyAllow, ICMPType: &unreachable}))
+	require.Error(t, validateSgRuleStateless(&kubeovnv1.SgRule{IPVersion: "ipv6", Protocol: kubeovnv1.ProtocolICMP, Policy: kubeovnv1.PolicyAllow, ICMPType: &echoRequest}))
+}
@@ -39,6 +39,7 @@ func (c *Controller) enqueueAddSubnet(obj interface{}) {
 	}
 	klog.V(3).Infof("enqueue add subnet %s", key)
 	c.addOrUpdateSubnetQueue.Add(key)
+	c.enqueueVpcRouteTableOfSubnet(obj.(*kubeovnv1.Subnet))
 }
 
 func (c *Controller) enqueueDeleteSubnet(obj interface{}) {
@@ -53,6 +54,10 @@ func (c *Controller) enqueueDeleteSubnet(obj interface{}) {
 	}
 	klog.V(3).Infof("enqueue delete subnet %s", key)
 	c.deleteSubnetQueue.Add(obj)
+	if subnet, ok := obj.(*kubeovnv1.Subnet); ok {
+		c.enqueueVpcRouteTableOfSubnet(subnet)
+		c.enqueueDHCPOptionsOfSubnet(subnet)
+	}
 }
 
 func (c *Controller) enqueueUpdateSubnet(oldObj, newObj interface{}) {
@@ -70,6 +75,12 @@ func (c *Controller) enqueueUpdateSubnet(oldObj, newObj interface{}) {
 		return
 	}
 
+	if oldSubnet.Spec.RouteTable != newSubnet.Spec.RouteTable || oldSubnet.Spec.Vpc != newSubnet.Spec.Vpc {
+		c.enqueueVpcRouteTableOfSubnet(oldSubnet)
+		c.enqueueVpcRouteTableOfSubnet(newSubnet)
+	}
+	c.enqueueDHCPOptionsOfUpdatedSubnet(oldSubnet, newSubnet)
+
 	// Trigger network policy refresh only if they are enabled, otherwise the lister will be nil
 	if c.npsLister != nil {
 		if newSubnet.Spec.Gateway != oldSubnet.Spec.Gateway || newSubnet.Status.U2OInterconnectionIP != oldSubnet.Status.U2OInterconnectionIP {
@@ -126,8 +137,10 @@ func (c *Controller) enqueueUpdateSubnet(oldObj, newObj interface{}) {
 		oldSubnet.Spec.EnableDHCP != newSubnet.Spec.EnableDHCP ||
 		oldSubnet.Spec.DHCPv4Options != newSubnet.Spec.DHCPv4Options ||
 		oldSubnet.Spec.DHCPv6Options != newSubnet.Spec.DHCPv6Options ||
+		oldSubnet.Spec.DHCPOptions != newSubnet.Spec.DHCPOptions ||
 		oldSubnet.Spec.EnableIPv6RA != newSubnet.Spec.EnableIPv6RA ||
 		oldSubnet.Spec.IPv6RAConfigs != newSubnet.Spec.IPv6RAConfigs ||
+		oldSubnet.Spec.IPv6AddressMode != newSubnet.Spec.IPv6AddressMode ||
 		oldSubnet.Spec.Protocol != newSubnet.Spec.Protocol ||
 		(oldSubnet.Spec.EnableLb == nil && newSubnet.Spec.EnableLb != nil) ||
 		(oldSubnet.Spec.EnableLb != nil && newSubnet.Spec.EnableLb == nil) ||
@@ -666,7 +679,12 @@ func (c *Controller) updateSubnetDHCPOption(subnet *kubeovnv1.Subnet, needRouter
 		}
 	}
 
-	dhcpOptionsUUIDs, err := c.OVNNbClient.UpdateDHCPOptions(subnet, mtu)
+	dhcpOptionsSpec, err := c.getSubnetDHCPOptionsSpec(subnet)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	dhcpOptionsUUIDs, err := c.OVNNbClient.UpdateDHCPOptions(subnet, mtu, dhcpOptionsSpec)
 	if err != nil {
 		klog.Errorf("failed to update dhcp options for switch %s, %v", subnet.Name, err)
 		return err
@@ -742,6 +760,7 @@ func (c *Controller) handleAddOrUpdateSubnet(key string) error {
 	if err := c.ipam.AddOrUpdateSubnet(subnet.Name, subnet.Spec.CIDRBlock, subnet.Spec.Gateway, subnet.Spec.ExcludeIps); err != nil {
 		return err
 	}
+	c.ipam.SetSubnetV6Learned(subnet.Name, subnet.Spec.IPv6AddressMode != "")
 
 	// availableIPStr valued from ipam, so leave update subnet.status after ipam process
 	if subnet.Spec.Protocol == kubeovnv1.ProtocolDual {
@@ -412,6 +412,26 @@ func (c *Controller) handleAddOrUpdateVpc(key string) error {
 		}
 	}
 
+	// the routes of the route tables managed by VpcRouteTable are reconciled by the route table handler
+	rtbNames, err := c.vpcRouteTableNames(vpc.Name)
+	if err != nil {
+		return err
+	}
+	if rtbNames.Len() != 0 {
+		staticExistedRoutes = slices.DeleteFunc(staticExistedRoutes, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+			return rtbNames.Has(route.RouteTable)
+		})
+		targetRoutes := make([]*kubeovnv1.StaticRoute, 0, len(staticTargetRoutes))
+		for _, route := range staticTargetRoutes {
+			if rtbNames.Has(route.RouteTable) {
+				klog.Warningf("ignore static route %+v of vpc %s, route table %s is managed by vpc route table", route, vpc.Name, route.RouteTable)
+				continue
+			}
+			targetRoutes = append(targetRoutes, route)
+		}
+		staticTargetRoutes = targetRoutes
+	}
+
 	routeNeedDel, routeNeedAdd, err := diffStaticRoute(staticExistedRoutes, staticTargetRoutes)
 	if err != nil {
 		klog.Errorf("failed to diff vpc %s static route, %v", vpc.Name, err)
@@ -538,6 +558,7 @@ func (c *Controller) handleAddOrUpdateVpc(key string) error {
 			c.addOrUpdateSubnetQueue.Add(subnet.Name)
 		}
 	}
+	c.enqueueVpcRouteTablesOfVpc(key)
 	if vpc.Name != util.DefaultVpc {
 		if cachedVpc.Spec.EnableExternal {
 			if !cachedVpc.Status.EnableExternal {
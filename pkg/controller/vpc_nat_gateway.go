@@ -29,6 +29,9 @@ var (
 	vpcNatEnabled   = "unknown"
 	VpcNatCmVersion = ""
 	natGwCreatedAT  = ""
+	vpcNatGwBackend = util.NatGwBackendIptables
+
+	vpcNatGwMetricsEnabled = false
 )
 
 const (
@@ -78,6 +81,16 @@ func (c *Controller) resyncVpcNatGwConfig() {
 	if vpcNatEnabled == "true" && VpcNatCmVersion == cm.ResourceVersion {
 		return
 	}
+	switch backend := cm.Data["nat-gw-backend"]; backend {
+	case "", util.NatGwBackendIptables:
+		vpcNatGwBackend = util.NatGwBackendIptables
+	case util.NatGwBackendNftables:
+		vpcNatGwBackend = util.NatGwBackendNftables
+	default:
+		klog.Errorf("unsupported nat gw backend %q, use %s instead", backend, util.NatGwBackendIptables)
+		vpcNatGwBackend = util.NatGwBackendIptables
+	}
+	vpcNatGwMetricsEnabled = cm.Data["enable-nat-gw-metrics"] == "true"
 	gws, err := c.vpcNatGatewayLister.List(labels.Everything())
 	if err != nil {
 		klog.Errorf("failed to get vpc nat gateway, %v", err)
@@ -410,7 +423,8 @@ func (c *Controller) handleInitVpcNatGw(key string) error {
 	c.updateVpcSubnetQueue.Add(key)
 	c.updateVpcEipQueue.Add(key)
 
-	patch := util.KVPatch{util.VpcNatGatewayInitAnnotation: "true"}
+	// the backend is fixed for the lifetime of the pod, changing it takes effect after the pod is recreated
+	patch := util.KVPatch{util.VpcNatGatewayInitAnnotation: "true", util.VpcNatGatewayBackendAnnotation: vpcNatGwBackend}
 	if err = util.PatchAnnotations(c.config.KubeClient.CoreV1().Pods(pod.Namespace), pod.Name, patch); err != nil {
 		err := fmt.Errorf("failed to patch pod %s/%s: %w", pod.Namespace, pod.Name, err)
 		klog.Error(err)
@@ -0,0 +1,633 @@
+package controller
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const (
+	natGwGetNatStats = "get-nat-stats"
+
+	natGwStatsConntrack      = "conntrack"
+	natGwStatsConntrackStats = "conntrack-stats"
+	natGwStatsIptables       = "iptables"
+	natGwStatsNftables       = "nftables"
+	natGwStatsTc             = "tc"
+
+	// natGwAcctChain is the mangle chain counting the forwarded traffic of the nat rules
+	natGwAcctChain = "NAT_ACCT"
+)
+
+// natGwConntrackEntry is a connection in the output of `conntrack -L`
+type natGwConntrackEntry struct {
+	protocol  string
+	origSrc   string
+	origDst   string
+	origDport int
+	replyDst  string
+}
+
+// natGwIptablesCounter is a rule with counters in the output of `iptables-save -c`
+type natGwIptablesCounter struct {
+	chain   string
+	args    map[string]string
+	packets float64
+	bytes   float64
+}
+
+// natGwNftCounter is an accounting rule in the output of `nft list table`,
+// the comment is "<type>/<rule name>" of the nat rule
+type natGwNftCounter struct {
+	comment     string
+	packets     float64
+	bytes       float64
+	connections float64
+}
+
+type natGwRuleKey struct {
+	eip      string
+	natType  string
+	ruleName string
+}
+
+type natGwStats struct {
+	entries     float64
+	bytes       float64
+	packets     float64
+	connections float64
+	drops       float64
+}
+
+// natGwMetricSamples are the values of the vpc nat gw gauges of a nat gw, keyed by the joined label values
+type natGwMetricSamples map[*prometheus.GaugeVec]map[string]float64
+
+func (s natGwMetricSamples) set(gauge *prometheus.GaugeVec, value float64, labelValues ...string) {
+	if s[gauge] == nil {
+		s[gauge] = make(map[string]float64)
+	}
+	s[gauge][strings.Join(labelValues, "\x00")] = value
+}
+
+// vpcNatGwMetricSamples are the samples exported by the last round, keyed by the nat gw name
+var vpcNatGwMetricSamples = map[string]natGwMetricSamples{}
+
+// swapVpcNatGwMetrics sets the new samples before deleting the series which no longer exist,
+// so that the series never disappear from a scrape in the middle of an update
+func swapVpcNatGwMetrics(samples map[string]natGwMetricSamples) {
+	for _, gwSamples := range samples {
+		for gauge, values := range gwSamples {
+			for labelValues, value := range values {
+				gauge.WithLabelValues(strings.Split(labelValues, "\x00")...).Set(value)
+			}
+		}
+	}
+	for gw, gwSamples := range vpcNatGwMetricSamples {
+		for gauge, values := range gwSamples {
+			for labelValues := range values {
+				if _, ok := samples[gw][gauge][labelValues]; !ok {
+					gauge.DeleteLabelValues(strings.Split(labelValues, "\x00")...)
+				}
+			}
+		}
+	}
+	vpcNatGwMetricSamples = samples
+}
+
+func (c *Controller) exportVpcNatGwMetrics() {
+	if vpcNatEnabled != "true" || !vpcNatGwMetricsEnabled {
+		swapVpcNatGwMetrics(map[string]natGwMetricSamples{})
+		return
+	}
+
+	gws, err := c.vpcNatGatewayLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc nat gateway, %v", err)
+		return
+	}
+	eips, err := c.iptablesEipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables eips, %v", err)
+		return
+	}
+	fips, err := c.iptablesFipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables fips, %v", err)
+		return
+	}
+	dnats, err := c.iptablesDnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables dnat rules, %v", err)
+		return
+	}
+	snats, err := c.iptablesSnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables snat rules, %v", err)
+		return
+	}
+
+	samples := make(map[string]natGwMetricSamples, len(gws))
+	for _, gw := range gws {
+		eipV4IPs := make(map[string]string)
+		for _, eip := range eips {
+			if eip.Spec.NatGwDp == gw.Name && eip.Status.IP != "" {
+				eipV4IPs[eip.Name] = strings.Split(eip.Status.IP, "/")[0]
+			}
+		}
+		if len(eipV4IPs) == 0 {
+			continue
+		}
+
+		pod, err := c.getNatGwPod(gw.Name)
+		if err != nil {
+			klog.Errorf("failed to get nat gw %s pod, %v", gw.Name, err)
+			samples[gw.Name] = vpcNatGwMetricSamples[gw.Name]
+			continue
+		}
+		backend := natGwBackend(pod)
+		output, err := c.getNatGwStats(pod.Namespace, pod.Name, backend)
+		if err != nil {
+			// keep the last samples rather than dropping the series of the nat gw
+			klog.Errorf("failed to get stats of nat gw %s, %v", gw.Name, err)
+			samples[gw.Name] = vpcNatGwMetricSamples[gw.Name]
+			continue
+		}
+
+		sections := splitNatGwStats(output)
+		rules := newNatGwRules(eipV4IPs, fips, dnats, snats)
+		var ruleCounters map[natGwRuleKey]*natGwStats
+		if backend == util.NatGwBackendNftables {
+			ruleCounters = rules.nftRuleStats(parseNatGwNftCounters(sections[natGwStatsNftables]))
+		} else {
+			ruleCounters = rules.iptablesRuleStats(parseNatGwIptablesCounters(sections[natGwStatsIptables]))
+		}
+		entries := parseNatGwConntrackEntries(sections[natGwStatsConntrack])
+		drops := parseNatGwTcDrops(sections[natGwStatsTc])
+		eipStats, ruleStats := rules.genStats(entries, ruleCounters, drops)
+
+		gwSamples := make(natGwMetricSamples)
+		for eip, stats := range eipStats {
+			gwSamples.set(metricVpcNatGwEipConntrackEntries, stats.entries, gw.Name, eip)
+			gwSamples.set(metricVpcNatGwEipBytes, stats.bytes, gw.Name, eip)
+			gwSamples.set(metricVpcNatGwEipPackets, stats.packets, gw.Name, eip)
+			gwSamples.set(metricVpcNatGwEipDrops, stats.drops, gw.Name, eip)
+		}
+		for key, stats := range ruleStats {
+			gwSamples.set(metricVpcNatGwRuleConntrackEntries, stats.entries, gw.Name, key.eip, key.natType, key.ruleName)
+			gwSamples.set(metricVpcNatGwRuleBytes, stats.bytes, gw.Name, key.eip, key.natType, key.ruleName)
+			gwSamples.set(metricVpcNatGwRulePackets, stats.packets, gw.Name, key.eip, key.natType, key.ruleName)
+			gwSamples.set(metricVpcNatGwRuleConnections, stats.connections, gw.Name, key.eip, key.natType, key.ruleName)
+		}
+		gwSamples.set(metricVpcNatGwConntrackDrops, parseNatGwConntrackDrops(sections[natGwStatsConntrackStats]), gw.Name)
+		samples[gw.Name] = gwSamples
+	}
+	swapVpcNatGwMetrics(samples)
+}
+
+func (c *Controller) getNatGwStats(namespace, podName, backend string) (string, error) {
+	cmd := fmt.Sprintf("bash /kube-ovn/nat-gateway.sh %s %s", natGwGetNatStats, backend)
+	klog.V(5).Info(cmd)
+	stdOutput, errOutput, err := util.ExecuteCommandInContainer(c.config.KubeClient, c.config.KubeRestConfig, namespace, podName, "vpc-nat-gw", []string{"/bin/bash", "-c", cmd}...)
+	if err != nil {
+		if len(errOutput) > 0 {
+			klog.Errorf("failed to ExecuteCommandInContainer, errOutput: %v", errOutput)
+		}
+		klog.Error(err)
+		return "", err
+	}
+	if len(errOutput) > 0 {
+		klog.Errorf("failed to ExecuteCommandInContainer errOutput: %v", errOutput)
+		return "", fmt.Errorf("%s", errOutput)
+	}
+	return stdOutput, nil
+}
+
+// splitNatGwStats splits the output of get-nat-stats into sections by the "#### <name>" lines
+func splitNatGwStats(output string) map[string]string {
+	sections := make(map[string]string)
+	var name string
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if section, ok := strings.CutPrefix(line, "#### "); ok {
+			if name != "" {
+				sections[name] = strings.Join(lines, "\n")
+			}
+			name, lines = strings.TrimSpace(section), nil
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if name != "" {
+		sections[name] = strings.Join(lines, "\n")
+	}
+	return sections
+}
+
+// parseNatGwConntrackEntries parses lines like:
+// tcp 6 431999 ESTABLISHED src=10.0.1.5 dst=1.1.1.1 sport=5000 dport=80 packets=10 bytes=600 src=1.1.1.1 dst=172.18.0.10 sport=80 dport=5000 packets=8 bytes=1200 [ASSURED] mark=0 use=1
+func parseNatGwConntrackEntries(output string) []natGwConntrackEntry {
+	var entries []natGwConntrackEntry
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		entry := natGwConntrackEntry{protocol: fields[0]}
+		var srcCount, dstCount int
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "src":
+				if srcCount++; srcCount == 1 {
+					entry.origSrc = value
+				}
+			case "dst":
+				if dstCount++; dstCount == 1 {
+					entry.origDst = value
+				} else if dstCount == 2 {
+					entry.replyDst = value
+				}
+			case "dport":
+				if dstCount == 1 {
+					entry.origDport, _ = strconv.Atoi(value)
+				}
+			}
+		}
+		if entry.origDst == "" || entry.replyDst == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// parseNatGwConntrackDrops sums drop, early_drop and insert_failed of all cpus in the output of `conntrack -S`
+func parseNatGwConntrackDrops(output string) float64 {
+	var drops float64
+	for _, field := range strings.Fields(output) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || (key != "drop" && key != "early_drop" && key != "insert_failed") {
+			continue
+		}
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			drops += n
+		}
+	}
+	return drops
+}
+
+// parseNatGwIptablesCounters parses lines like:
+// [12:720] -A SHARED_DNAT -d 172.18.0.11/32 -p tcp -m tcp --dport 80 -j DNAT --to-destination 10.0.0.5:8080
+// [120:7200] -A NAT_ACCT -p tcp -m conntrack --ctorigdst 172.18.0.11/32 --ctorigdstport 80 -j RETURN
+func parseNatGwIptablesCounters(output string) []natGwIptablesCounter {
+	var counters []natGwIptablesCounter
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || !strings.HasPrefix(fields[0], "[") || fields[1] != "-A" {
+			continue
+		}
+		packets, bytes, _ := strings.Cut(strings.Trim(fields[0], "[]"), ":")
+		counter := natGwIptablesCounter{chain: fields[2], args: make(map[string]string)}
+		counter.packets, _ = strconv.ParseFloat(packets, 64)
+		counter.bytes, _ = strconv.ParseFloat(bytes, 64)
+		for i := 3; i < len(fields); i++ {
+			if !strings.HasPrefix(fields[i], "-") {
+				continue
+			}
+			if i+1 < len(fields) && !strings.HasPrefix(fields[i+1], "-") {
+				counter.args[fields[i]] = fields[i+1]
+				i++
+			} else {
+				counter.args[fields[i]] = ""
+			}
+		}
+		counters = append(counters, counter)
+	}
+	return counters
+}
+
+// parseNatGwNftCounters parses the accounting rules like:
+// ct original ip daddr 172.18.0.11 meta l4proto tcp ct original proto-dst { 80 } counter packets 120 bytes 7200 ct state new counter packets 2 bytes 120 comment "dnat/dnat1"
+// the first counter is the traffic of the nat rule and the second one is the num of new connections
+func parseNatGwNftCounters(output string) []natGwNftCounter {
+	var counters []natGwNftCounter
+	for _, line := range strings.Split(output, "\n") {
+		rule, comment, ok := strings.Cut(line, ` comment "`)
+		if !ok {
+			continue
+		}
+		counter := natGwNftCounter{comment: strings.TrimSuffix(strings.TrimSpace(comment), `"`)}
+		fields := strings.Fields(rule)
+		var n int
+		for i := 0; i+4 < len(fields); i++ {
+			if fields[i] != "counter" || fields[i+1] != "packets" || fields[i+3] != "bytes" {
+				continue
+			}
+			packets, _ := strconv.ParseFloat(fields[i+2], 64)
+			bytes, _ := strconv.ParseFloat(fields[i+4], 64)
+			if n++; n == 1 {
+				counter.packets, counter.bytes = packets, bytes
+			} else {
+				counter.connections = packets
+			}
+		}
+		counters = append(counters, counter)
+	}
+	return counters
+}
+
+// parseNatGwTcDrops parses the police drops of the eip qos filters in the output of `tc -p -s filter show`,
+// and returns the drops keyed by the eip
+func parseNatGwTcDrops(output string) map[string]float64 {
+	drops := make(map[string]float64)
+	var ip string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "filter ") {
+			ip = ""
+			continue
+		}
+		if match, ok := strings.CutPrefix(line, "match IP "); ok {
+			if fields := strings.Fields(match); len(fields) == 2 && (fields[0] == "src" || fields[0] == "dst") {
+				ip = strings.TrimSuffix(fields[1], "/32")
+			}
+			continue
+		}
+		_, dropped, ok := strings.Cut(line, "(dropped ")
+		if !ok || ip == "" {
+			continue
+		}
+		dropped, _, _ = strings.Cut(dropped, ",")
+		if n, err := strconv.ParseFloat(dropped, 64); err == nil {
+			drops[ip] += n
+		}
+	}
+	return drops
+}
+
+type natGwDnatMatcher struct {
+	name     string
+	protocol string
+	ports    []util.PortRange
+}
+
+type natGwSnatMatcher struct {
+	name   string
+	ipNet  *net.IPNet
+	prefix int
+}
+
+// natGwRules are the nat rules of the eips of a nat gw, used to attribute the counters to the rules
+type natGwRules struct {
+	eipV4IPs   map[string]string
+	eipNames   map[string]string
+	fipByEip   map[string]*kubeovnv1.IptablesFIPRule
+	dnatsByEip map[string][]natGwDnatMatcher
+	snatsByEip map[string][]natGwSnatMatcher
+	// keyed by "<type>/<rule name>"
+	ruleKeys map[string]natGwRuleKey
+}
+
+func newNatGwRules(eipV4IPs map[string]string,
+	fips []*kubeovnv1.IptablesFIPRule,
+	dnats []*kubeovnv1.IptablesDnatRule,
+	snats []*kubeovnv1.IptablesSnatRule,
+) *natGwRules {
+	r := &natGwRules{
+		eipV4IPs:   eipV4IPs,
+		eipNames:   make(map[string]string, len(eipV4IPs)),
+		fipByEip:   make(map[string]*kubeovnv1.IptablesFIPRule),
+		dnatsByEip: make(map[string][]natGwDnatMatcher),
+		snatsByEip: make(map[string][]natGwSnatMatcher),
+		ruleKeys:   make(map[string]natGwRuleKey),
+	}
+	for name, ip := range eipV4IPs {
+		r.eipNames[ip] = name
+	}
+	for _, fip := range fips {
+		if _, ok := eipV4IPs[fip.Spec.EIP]; ok {
+			r.fipByEip[fip.Spec.EIP] = fip
+			r.ruleKeys[util.FipUsingEip+"/"+fip.Name] = natGwRuleKey{eip: fip.Spec.EIP, natType: util.FipUsingEip, ruleName: fip.Name}
+		}
+	}
+	for _, dnat := range dnats {
+		if _, ok := eipV4IPs[dnat.Spec.EIP]; !ok {
+			continue
+		}
+		ports, err := util.ParsePortRanges(dnat.Spec.ExternalPort)
+		if err != nil {
+			continue
+		}
+		r.dnatsByEip[dnat.Spec.EIP] = append(r.dnatsByEip[dnat.Spec.EIP], natGwDnatMatcher{name: dnat.Name, protocol: strings.ToLower(dnat.Spec.Protocol), ports: ports})
+		r.ruleKeys[util.DnatUsingEip+"/"+dnat.Name] = natGwRuleKey{eip: dnat.Spec.EIP, natType: util.DnatUsingEip, ruleName: dnat.Name}
+	}
+	for _, snat := range snats {
+		if _, ok := eipV4IPs[snat.Spec.EIP]; !ok {
+			continue
+		}
+		cidr := snat.Spec.InternalCIDR
+		if !strings.Contains(cidr, "/") {
+			cidr += "/32"
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		prefix, _ := ipNet.Mask.Size()
+		r.snatsByEip[snat.Spec.EIP] = append(r.snatsByEip[snat.Spec.EIP], natGwSnatMatcher{name: snat.Name, ipNet: ipNet, prefix: prefix})
+		r.ruleKeys[util.SnatUsingEip+"/"+snat.Name] = natGwRuleKey{eip: snat.Spec.EIP, natType: util.SnatUsingEip, ruleName: snat.Name}
+	}
+	return r
+}
+
+// matchIngress returns the fip of the eip or the dnat rule of the destination port
+func (r *natGwRules) matchIngress(eip, protocol string, port int) (natGwRuleKey, bool) {
+	if fip, ok := r.fipByEip[eip]; ok {
+		return natGwRuleKey{eip: eip, natType: util.FipUsingEip, ruleName: fip.Name}, true
+	}
+	for _, dnat := range r.dnatsByEip[eip] {
+		if dnat.protocol != protocol {
+			continue
+		}
+		for _, pr := range dnat.ports {
+			if port >= pr.Start && port <= pr.End {
+				return natGwRuleKey{eip: eip, natType: util.DnatUsingEip, ruleName: dnat.name}, true
+			}
+		}
+	}
+	return natGwRuleKey{}, false
+}
+
+// matchEgress returns the fip of the source or the snat rule with the longest matched cidr
+func (r *natGwRules) matchEgress(eip, src string) (natGwRuleKey, bool) {
+	if fip, ok := r.fipByEip[eip]; ok && fip.Spec.InternalIP == src {
+		return natGwRuleKey{eip: eip, natType: util.FipUsingEip, ruleName: fip.Name}, true
+	}
+	ip := net.ParseIP(src)
+	var matched *natGwSnatMatcher
+	for i, snat := range r.snatsByEip[eip] {
+		if ip != nil && snat.ipNet.Contains(ip) && (matched == nil || snat.prefix > matched.prefix) {
+			matched = &r.snatsByEip[eip][i]
+		}
+	}
+	if matched == nil {
+		return natGwRuleKey{}, false
+	}
+	return natGwRuleKey{eip: eip, natType: util.SnatUsingEip, ruleName: matched.name}, true
+}
+
+// matchSource returns the fip of the internal ip or the snat rule of the internal cidr
+func (r *natGwRules) matchSource(src string) (natGwRuleKey, bool) {
+	for eip, fip := range r.fipByEip {
+		if fip.Spec.InternalIP == strings.TrimSuffix(src, "/32") {
+			return natGwRuleKey{eip: eip, natType: util.FipUsingEip, ruleName: fip.Name}, true
+		}
+	}
+	if !strings.Contains(src, "/") {
+		src += "/32"
+	}
+	for eip, snats := range r.snatsByEip {
+		for _, snat := range snats {
+			if snat.ipNet.String() == src {
+				return natGwRuleKey{eip: eip, natType: util.SnatUsingEip, ruleName: snat.name}, true
+			}
+		}
+	}
+	return natGwRuleKey{}, false
+}
+
+// iptablesRuleStats attributes the iptables counters to the nat rules. The accounting rules count
+// the forwarded traffic of the nat rules, and as only the first packet of a connection traverses
+// the nat table, the packet counters of the nat rules are the num of translated connections.
+func (r *natGwRules) iptablesRuleStats(counters []natGwIptablesCounter) map[natGwRuleKey]*natGwStats {
+	ruleStats := make(map[natGwRuleKey]*natGwStats)
+	for _, counter := range counters {
+		var key natGwRuleKey
+		var matched bool
+		switch counter.chain {
+		case natGwAcctChain:
+			if dst, ok := counter.args["--ctorigdst"]; ok {
+				eip, ok := r.eipNames[strings.TrimSuffix(dst, "/32")]
+				if !ok {
+					continue
+				}
+				port, _, _ := strings.Cut(counter.args["--ctorigdstport"], ":")
+				dport, _ := strconv.Atoi(port)
+				key, matched = r.matchIngress(eip, counter.args["-p"], dport)
+			} else if src, ok := counter.args["--ctorigsrc"]; ok {
+				key, matched = r.matchSource(src)
+			}
+			if matched {
+				stats := getNatGwRuleStats(ruleStats, key)
+				stats.bytes += counter.bytes
+				stats.packets += counter.packets
+			}
+			continue
+		case "EXCLUSIVE_DNAT", "SHARED_DNAT":
+			eip, ok := r.eipNames[strings.TrimSuffix(counter.args["-d"], "/32")]
+			if !ok {
+				continue
+			}
+			port, _, _ := strings.Cut(counter.args["--dport"], ":")
+			dport, _ := strconv.Atoi(port)
+			if counter.chain == "SHARED_DNAT" && dport == 0 {
+				continue
+			}
+			key, matched = r.matchIngress(eip, counter.args["-p"], dport)
+		case "EXCLUSIVE_SNAT", "SHARED_SNAT":
+			eip, ok := r.eipNames[counter.args["--to-source"]]
+			if !ok {
+				continue
+			}
+			if counter.chain == "EXCLUSIVE_SNAT" {
+				key, matched = r.matchEgress(eip, strings.TrimSuffix(counter.args["-s"], "/32"))
+				break
+			}
+			for _, snat := range r.snatsByEip[eip] {
+				if snat.ipNet.String() == counter.args["-s"] {
+					key, matched = natGwRuleKey{eip: eip, natType: util.SnatUsingEip, ruleName: snat.name}, true
+					break
+				}
+			}
+		}
+		if matched {
+			getNatGwRuleStats(ruleStats, key).connections += counter.packets
+		}
+	}
+	return ruleStats
+}
+
+// nftRuleStats attributes the nftables accounting rules to the nat rules by their comments
+func (r *natGwRules) nftRuleStats(counters []natGwNftCounter) map[natGwRuleKey]*natGwStats {
+	ruleStats := make(map[natGwRuleKey]*natGwStats)
+	for _, counter := range counters {
+		key, ok := r.ruleKeys[counter.comment]
+		if !ok {
+			continue
+		}
+		stats := getNatGwRuleStats(ruleStats, key)
+		stats.bytes += counter.bytes
+		stats.packets += counter.packets
+		stats.connections += counter.connections
+	}
+	return ruleStats
+}
+
+// genStats adds the conntrack entries of the eips and nat rules to the rule counters,
+// and sums up the rule counters of each eip. Connections to an eip belong to its fip or
+// the dnat rule of the destination port, connections translated to an eip belong to its fip
+// or the snat rule with the longest matched cidr. The drops of an eip are dropped by its qos.
+func (r *natGwRules) genStats(entries []natGwConntrackEntry, ruleStats map[natGwRuleKey]*natGwStats, drops map[string]float64) (map[string]*natGwStats, map[natGwRuleKey]*natGwStats) {
+	eipStats := make(map[string]*natGwStats, len(r.eipV4IPs))
+	for name, ip := range r.eipV4IPs {
+		eipStats[name] = &natGwStats{drops: drops[ip]}
+	}
+	if ruleStats == nil {
+		ruleStats = make(map[natGwRuleKey]*natGwStats)
+	}
+
+	for _, entry := range entries {
+		var eip string
+		var key natGwRuleKey
+		var matched bool
+		if name, ok := r.eipNames[entry.origDst]; ok {
+			eip = name
+			key, matched = r.matchIngress(eip, entry.protocol, entry.origDport)
+		} else if name, ok := r.eipNames[entry.replyDst]; ok && entry.origSrc != entry.replyDst {
+			eip = name
+			key, matched = r.matchEgress(eip, entry.origSrc)
+		} else {
+			continue
+		}
+		eipStats[eip].entries++
+		if matched {
+			getNatGwRuleStats(ruleStats, key).entries++
+		}
+	}
+
+	for key, stats := range ruleStats {
+		if eip := eipStats[key.eip]; eip != nil {
+			eip.bytes += stats.bytes
+			eip.packets += stats.packets
+		}
+	}
+	return eipStats, ruleStats
+}
+
+func getNatGwRuleStats(ruleStats map[natGwRuleKey]*natGwStats, key natGwRuleKey) *natGwStats {
+	if ruleStats[key] == nil {
+		ruleStats[key] = &natGwStats{}
+	}
+	return ruleStats[key]
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_genNatGwStats(t *testing.T) {
+	t.Parallel()
+
+	output := `#### conntrack
+tcp      6 431999 ESTABLISHED src=1.1.1.1 dst=172.18.0.11 sport=5000 dport=30005 packets=10 bytes=600 src=10.0.0.5 dst=1.1.1.1 sport=30005 dport=5000 packets=8 bytes=1200 [ASSURED] mark=0 use=1
+tcp      6 431999 ESTABLISHED src=10.0.1.5 dst=1.1.1.1 sport=5000 dport=80 packets=3 bytes=300 src=1.1.1.1 dst=172.18.0.11 sport=80 dport=5000 packets=2 bytes=200 [ASSURED] mark=0 use=1
+udp      17 20 src=10.0.2.5 dst=8.8.8.8 sport=5353 dport=53 packets=1 bytes=60 src=8.8.8.8 dst=172.18.0.11 sport=53 dport=5353 packets=1 bytes=90 mark=0 use=1
+icmp     1 29 src=1.1.1.1 dst=172.18.0.10 type=8 code=0 id=1 packets=1 bytes=84 src=10.0.0.2 dst=1.1.1.1 type=0 code=0 id=1 packets=1 bytes=84 mark=0 use=1
+tcp      6 100 SYN_SENT src=1.1.1.1 dst=172.18.0.11 sport=5000 dport=22 packets=1 bytes=60 [UNREPLIED] src=172.18.0.11 dst=1.1.1.1 sport=22 dport=5000 packets=0 bytes=0 mark=0 use=1
+#### conntrack-stats
+cpu=0   	found=0 invalid=12 insert=0 insert_failed=1 drop=2 early_drop=0 error=0 search_restart=0
+cpu=1   	found=0 invalid=3 insert=0 insert_failed=0 drop=1 early_drop=1 error=0 search_restart=0
+#### iptables
+# Generated by iptables-save v1.8.9 on Mon Oct 19 00:00:00 2026
+*nat
+:PREROUTING ACCEPT [0:0]
+[0:0] -A PREROUTING -j DNAT_FILTER
+[4:240] -A EXCLUSIVE_DNAT -d 172.18.0.10/32 -j DNAT --to-destination 10.0.0.2
+[2:120] -A EXCLUSIVE_SNAT -s 10.0.0.2/32 -j SNAT --to-source 172.18.0.10
+[7:420] -A SHARED_DNAT -d 172.18.0.11/32 -p tcp -m tcp --dport 30000:30999 -j DNAT --to-destination 10.0.0.5
+[5:300] -A SHARED_SNAT -s 10.0.1.0/24 -o net1 -j SNAT --to-source 172.18.0.11 --random-fully
+[9:540] -A SHARED_SNAT -s 10.0.0.0/16 -o net1 -j SNAT --to-source 172.18.0.11 --random-fully
+COMMIT
+*mangle
+:FORWARD ACCEPT [0:0]
+[0:0] -A FORWARD -j NAT_ACCT
+[0:0] -A NAT_ACCT -i eth0 -o eth0 -j RETURN
+[6:504] -A NAT_ACCT -m conntrack --ctorigdst 172.18.0.10/32 -j RETURN
+[3:180] -A NAT_ACCT -m conntrack --ctorigsrc 10.0.0.2/32 -j RETURN
+[100:9000] -A NAT_ACCT -p tcp -m conntrack --ctorigdst 172.18.0.11/32 --ctorigdstport 30000:30999 -j RETURN
+[20:2000] -A NAT_ACCT -m conntrack --ctorigsrc 10.0.1.0/24 -j RETURN
+[40:3000] -A NAT_ACCT -m conntrack --ctorigsrc 10.0.0.0/16 -j RETURN
+COMMIT
+#### tc
+filter parent ffff: protocol ip pref 10 u32 chain 0
+filter parent ffff: protocol ip pref 10 u32 chain 0 fh 800: ht divisor 1
+filter parent ffff: protocol ip pref 10 u32 chain 0 fh 800::800 order 2048 key ht 800 bkt 0 *flowid :1 not_in_hw
+  match IP dst 172.18.0.11/32
+ police 0x1 rate 10Mbit burst 10Mb mtu 2Kb action drop overhead 0b
+	ref 1 bind 1
+
+ Sent 1200 bytes 10 pkts (dropped 3, overlimits 3)
+filter parent 1: protocol ip pref 10 u32 chain 0 fh 800::800 order 2048 key ht 800 bkt 0 *flowid :1 not_in_hw
+  match IP src 172.18.0.11/32
+ police 0x2 rate 10Mbit burst 10Mb mtu 2Kb action drop overhead 0b
+	ref 1 bind 1
+
+ Sent 600 bytes 5 pkts (dropped 2, overlimits 2)
+`
+	sections := splitNatGwStats(output)
+	require.Len(t, sections, 4)
+	require.Equal(t, float64(5), parseNatGwConntrackDrops(sections[natGwStatsConntrackStats]))
+	require.Equal(t, map[string]float64{"172.18.0.11": 5}, parseNatGwTcDrops(sections[natGwStatsTc]))
+
+	entries := parseNatGwConntrackEntries(sections[natGwStatsConntrack])
+	require.Len(t, entries, 5)
+	require.Equal(t, natGwConntrackEntry{protocol: "tcp", origSrc: "1.1.1.1", origDst: "172.18.0.11", origDport: 30005, replyDst: "1.1.1.1"}, entries[0])
+
+	counters := parseNatGwIptablesCounters(sections[natGwStatsIptables])
+	require.Len(t, counters, 13)
+	require.Equal(t, "30000:30999", counters[3].args["--dport"])
+	require.Contains(t, counters[4].args, "--random-fully")
+	require.Equal(t, natGwIptablesCounter{chain: natGwAcctChain, args: map[string]string{"-m": "conntrack", "--ctorigdst": "172.18.0.10/32", "-j": "RETURN"}, packets: 6, bytes: 504}, counters[8])
+
+	eips := map[string]string{"eip1": "172.18.0.10", "eip2": "172.18.0.11"}
+	fips := []*kubeovnv1.IptablesFIPRule{
+		{ObjectMeta: metav1.ObjectMeta{Name: "fip1"}, Spec: kubeovnv1.IptablesFIPRuleSpec{EIP: "eip1", InternalIP: "10.0.0.2"}},
+	}
+	dnats := []*kubeovnv1.IptablesDnatRule{
+		{ObjectMeta: metav1.ObjectMeta{Name: "dnat1"}, Spec: kubeovnv1.IptablesDnatRuleSpec{EIP: "eip2", Protocol: "tcp", ExternalPort: "30000-30999", InternalIP: "10.0.0.5", InternalPort: "30000-30999"}},
+	}
+	snats := []*kubeovnv1.IptablesSnatRule{
+		{ObjectMeta: metav1.ObjectMeta{Name: "snat1"}, Spec: kubeovnv1.IptablesSnatRuleSpec{EIP: "eip2", InternalCIDR: "10.0.0.0/16"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "snat2"}, Spec: kubeovnv1.IptablesSnatRuleSpec{EIP: "eip2", InternalCIDR: "10.0.1.0/24"}},
+	}
+
+	rules := newNatGwRules(eips, fips, dnats, snats)
+	eipStats, ruleStats := rules.genStats(entries, rules.iptablesRuleStats(counters), parseNatGwTcDrops(sections[natGwStatsTc]))
+	require.Equal(t, natGwStats{entries: 1, packets: 9, bytes: 684}, *eipStats["eip1"])
+	require.Equal(t, natGwStats{entries: 4, packets: 160, bytes: 14000, drops: 5}, *eipStats["eip2"])
+
+	fipKey := natGwRuleKey{eip: "eip1", natType: util.FipUsingEip, ruleName: "fip1"}
+	require.Equal(t, natGwStats{entries: 1, packets: 9, bytes: 684, connections: 6}, *ruleStats[fipKey])
+	dnatKey := natGwRuleKey{eip: "eip2", natType: util.DnatUsingEip, ruleName: "dnat1"}
+	require.Equal(t, natGwStats{entries: 1, packets: 100, bytes: 9000, connections: 7}, *ruleStats[dnatKey])
+	// the more specific snat cidr wins
+	snat1Key := natGwRuleKey{eip: "eip2", natType: util.SnatUsingEip, ruleName: "snat1"}
+	snat2Key := natGwRuleKey{eip: "eip2", natType: util.SnatUsingEip, ruleName: "snat2"}
+	require.Equal(t, natGwStats{entries: 1, packets: 40, bytes: 3000, connections: 9}, *ruleStats[snat1Key])
+	require.Equal(t, natGwStats{entries: 1, packets: 20, bytes: 2000, connections: 5}, *ruleStats[snat2Key])
+	// the connection to port 22 matches no dnat rule
+	require.Len(t, ruleStats, 4)
+}
+
+func Test_nftNatGwStats(t *testing.T) {
+	t.Parallel()
+
+	output := `table ip kube_ovn_nat {
+	chain forward {
+		type filter hook forward priority mangle; policy accept;
+		iifname "eth0" oifname "eth0" return
+		ct original ip daddr 172.18.0.10 counter packets 6 bytes 504 ct state new counter packets 2 bytes 168 comment "fip/fip1"
+		ct original ip daddr 172.18.0.10 return
+		ct original ip saddr 10.0.0.2 counter packets 3 bytes 180 ct state new counter packets 1 bytes 60 comment "fip/fip1"
+		ct original ip saddr 10.0.0.2 return
+		ct original ip daddr 172.18.0.11 meta l4proto tcp ct original proto-dst 30000-30999 counter packets 100 bytes 9000 ct state new counter packets 7 bytes 420 comment "dnat/dnat1"
+		ct original ip daddr 172.18.0.11 meta l4proto tcp ct original proto-dst 30000-30999 return
+		ct original ip saddr 10.0.0.0/16 counter packets 40 bytes 3000 ct state new counter packets 9 bytes 540 comment "snat/snat-other-gw"
+		ct original ip saddr 10.0.0.0/16 return
+	}
+}
+`
+	counters := parseNatGwNftCounters(output)
+	require.Len(t, counters, 4)
+	require.Equal(t, natGwNftCounter{comment: "fip/fip1", packets: 6, bytes: 504, connections: 2}, counters[0])
+
+	eips := map[string]string{"eip1": "172.18.0.10", "eip2": "172.18.0.11"}
+	fips := []*kubeovnv1.IptablesFIPRule{
+		{ObjectMeta: metav1.ObjectMeta{Name: "fip1"}, Spec: kubeovnv1.IptablesFIPRuleSpec{EIP: "eip1", InternalIP: "10.0.0.2"}},
+	}
+	dnats := []*kubeovnv1.IptablesDnatRule{
+		{ObjectMeta: metav1.ObjectMeta{Name: "dnat1"}, Spec: kubeovnv1.IptablesDnatRuleSpec{EIP: "eip2", Protocol: "tcp", ExternalPort: "30000-30999", InternalIP: "10.0.0.5", InternalPort: "30000-30999"}},
+	}
+	rules := newNatGwRules(eips, fips, dnats, nil)
+	eipStats, ruleStats := rules.genStats(nil, rules.nftRuleStats(counters), nil)
+	require.Len(t, ruleStats, 2)
+	require.Equal(t, natGwStats{packets: 9, bytes: 684, connections: 3}, *ruleStats[natGwRuleKey{eip: "eip1", natType: util.FipUsingEip, ruleName: "fip1"}])
+	require.Equal(t, natGwStats{packets: 100, bytes: 9000, connections: 7}, *ruleStats[natGwRuleKey{eip: "eip2", natType: util.DnatUsingEip, ruleName: "dnat1"}])
+	require.Equal(t, natGwStats{packets: 9, bytes: 684}, *eipStats["eip1"])
+	require.Equal(t, natGwStats{packets: 100, bytes: 9000}, *eipStats["eip2"])
+}
+
+func Test_swapVpcNatGwMetrics(t *testing.T) {
+	// the test modifies the exported gauges and must not run in parallel with other metrics tests
+	defer swapVpcNatGwMetrics(map[string]natGwMetricSamples{})
+
+	first := natGwMetricSamples{}
+	first.set(metricVpcNatGwEipBytes, 100, "gw1", "eip1")
+	first.set(metricVpcNatGwEipBytes, 200, "gw1", "eip2")
+	swapVpcNatGwMetrics(map[string]natGwMetricSamples{"gw1": first})
+	require.Equal(t, 2, testutil.CollectAndCount(metricVpcNatGwEipBytes))
+
+	// eip2 is removed and eip1 is updated
+	second := natGwMetricSamples{}
+	second.set(metricVpcNatGwEipBytes, 300, "gw1", "eip1")
+	swapVpcNatGwMetrics(map[string]natGwMetricSamples{"gw1": second})
+	require.Equal(t, 1, testutil.CollectAndCount(metricVpcNatGwEipBytes))
+	require.Equal(t, float64(300), testutil.ToFloat64(metricVpcNatGwEipBytes.WithLabelValues("gw1", "eip1")))
+}
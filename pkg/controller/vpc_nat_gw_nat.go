@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -11,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -696,7 +699,7 @@ func (c *Controller) handleAddIptablesDnatRule(key string) error {
 		klog.Errorf("failed to get eip, %v", err)
 		return err
 	}
-	if dup, err := c.isDnatDuplicated(eip.Spec.NatGwDp, eipName, dnat.Name, dnat.Spec.ExternalPort); dup || err != nil {
+	if dup, err := c.isDnatDuplicated(eip.Spec.NatGwDp, eipName, dnat.Name, dnat.Spec.Protocol, dnat.Spec.ExternalPort); dup || err != nil {
 		return err
 	}
 	// create nat
@@ -769,7 +772,7 @@ func (c *Controller) handleUpdateIptablesDnatRule(key string) error {
 		klog.Errorf("failed to get eip, %v", err)
 		return err
 	}
-	if dup, err := c.isDnatDuplicated(cachedDnat.Status.NatGwDp, eipName, cachedDnat.Name, cachedDnat.Spec.ExternalPort); dup || err != nil {
+	if dup, err := c.isDnatDuplicated(cachedDnat.Status.NatGwDp, eipName, cachedDnat.Name, cachedDnat.Spec.Protocol, cachedDnat.Spec.ExternalPort); dup || err != nil {
 		klog.Errorf("failed to update dnat, %v", err)
 		return err
 	}
@@ -1340,7 +1343,7 @@ func (c *Controller) patchDnatLabel(key string, eip *kubeovnv1.IptablesEIP) erro
 		op = "add"
 		dnat.Labels = map[string]string{
 			util.VpcNatGatewayNameLabel: eip.Spec.NatGwDp,
-			util.VpcDnatEPortLabel:      dnat.Spec.ExternalPort,
+			util.VpcDnatEPortLabel:      dnatPortLabel(dnat.Spec.ExternalPort),
 			util.EipV4IpLabel:           eip.Spec.V4ip,
 		}
 		needUpdateLabel = true
@@ -1348,7 +1351,7 @@ func (c *Controller) patchDnatLabel(key string, eip *kubeovnv1.IptablesEIP) erro
 		dnat.Labels[util.EipV4IpLabel] != eip.Spec.V4ip {
 		op = "replace"
 		dnat.Labels[util.VpcNatGatewayNameLabel] = eip.Spec.NatGwDp
-		dnat.Labels[util.VpcDnatEPortLabel] = dnat.Spec.ExternalPort
+		dnat.Labels[util.VpcDnatEPortLabel] = dnatPortLabel(dnat.Spec.ExternalPort)
 		dnat.Labels[util.EipV4IpLabel] = eip.Spec.V4ip
 		needUpdateLabel = true
 	}
@@ -1601,6 +1604,10 @@ func (c *Controller) createFipInPod(dp, v4ip, internalIP string) error {
 		klog.Error(err)
 		return err
 	}
+	if natGwBackend(gwPod) == util.NatGwBackendNftables {
+		c.enqueueSyncNatGwNftRules(dp)
+		return nil
+	}
 	var addRules []string
 	rule := fmt.Sprintf("%s,%s", v4ip, internalIP)
 	addRules = append(addRules, rule)
@@ -1620,6 +1627,10 @@ func (c *Controller) deleteFipInPod(dp, v4ip, internalIP string) error {
 		klog.Error(err)
 		return err
 	}
+	if natGwBackend(gwPod) == util.NatGwBackendNftables {
+		c.enqueueSyncNatGwNftRules(dp)
+		return nil
+	}
 	// del nat
 	var delRules []string
 	rule := fmt.Sprintf("%s,%s", v4ip, internalIP)
@@ -1637,9 +1648,15 @@ func (c *Controller) createDnatInPod(dp, protocol, v4ip, internalIP, externalPor
 		klog.Errorf("failed to get nat gw pod, %v", err)
 		return err
 	}
-	var addRules []string
-	rule := fmt.Sprintf("%s,%s,%s,%s,%s", v4ip, externalPort, protocol, internalIP, internalPort)
-	addRules = append(addRules, rule)
+	if natGwBackend(gwPod) == util.NatGwBackendNftables {
+		c.enqueueSyncNatGwNftRules(dp)
+		return nil
+	}
+	addRules, err := genNatGwDnatRules(protocol, v4ip, internalIP, externalPort, internalPort)
+	if err != nil {
+		klog.Errorf("failed to generate dnat rules, %v", err)
+		return err
+	}
 
 	if err = c.execNatGwRules(gwPod, natGwDnatAdd, addRules); err != nil {
 		klog.Errorf("failed to create dnat, err: %v", err)
@@ -1657,11 +1674,21 @@ func (c *Controller) deleteDnatInPod(dp, protocol, v4ip, internalIP, externalPor
 		klog.Error(err)
 		return err
 	}
+	if natGwBackend(gwPod) == util.NatGwBackendNftables {
+		c.enqueueSyncNatGwNftRules(dp)
+		return nil
+	}
 
 	// del nat
-	var delRules []string
-	rule := fmt.Sprintf("%s,%s,%s,%s,%s", v4ip, externalPort, protocol, internalIP, internalPort)
-	delRules = append(delRules, rule)
+	if externalPort == "" {
+		// the dnat has never been applied
+		return nil
+	}
+	delRules, err := genNatGwDnatRules(protocol, v4ip, internalIP, externalPort, internalPort)
+	if err != nil {
+		klog.Errorf("failed to generate dnat rules, %v", err)
+		return err
+	}
 	if err = c.execNatGwRules(gwPod, natGwDnatDel, delRules); err != nil {
 		klog.Errorf("failed to delete dnat, err: %v", err)
 		return err
@@ -1669,12 +1696,42 @@ func (c *Controller) deleteDnatInPod(dp, protocol, v4ip, internalIP, externalPor
 	return nil
 }
 
+// genNatGwDnatRules generates one nat gw dnat rule for each port range of the dnat.
+// An internal port range the same as the external one is omitted to keep the destination port,
+// a shifted internal port range maps the external ports one by one with the base port syntax of iptables.
+func genNatGwDnatRules(protocol, v4ip, internalIP, externalPort, internalPort string) ([]string, error) {
+	external, internal, err := util.ParseDnatPortRanges(externalPort, internalPort)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	rules := make([]string, 0, len(external))
+	for i, ext := range external {
+		dport, toPort := strconv.Itoa(ext.Start), strconv.Itoa(internal[i].Start)
+		if ext.Size() != 1 {
+			dport = fmt.Sprintf("%d:%d", ext.Start, ext.End)
+			if internal[i] == ext {
+				toPort = ""
+			} else {
+				toPort = fmt.Sprintf("%s/%d", internal[i], ext.Start)
+			}
+		}
+		rules = append(rules, fmt.Sprintf("%s,%s,%s,%s,%s", v4ip, dport, protocol, internalIP, toPort))
+	}
+	return rules, nil
+}
+
 func (c *Controller) createSnatInPod(dp, v4ip, internalCIDR string) error {
 	gwPod, err := c.getNatGwPod(dp)
 	if err != nil {
 		klog.Errorf("failed to get nat gw pod, %v", err)
 		return err
 	}
+	if natGwBackend(gwPod) == util.NatGwBackendNftables {
+		c.enqueueSyncNatGwNftRules(dp)
+		return nil
+	}
 	var rules []string
 	rule := fmt.Sprintf("%s,%s", v4ip, internalCIDR)
 
@@ -1704,6 +1761,10 @@ func (c *Controller) deleteSnatInPod(dp, v4ip, internalCIDR string) error {
 		klog.Error(err)
 		return err
 	}
+	if natGwBackend(gwPod) == util.NatGwBackendNftables {
+		c.enqueueSyncNatGwNftRules(dp)
+		return nil
+	}
 	// del nat
 	var delRules []string
 	rule := fmt.Sprintf("%s,%s", v4ip, internalCIDR)
@@ -1748,11 +1809,20 @@ func (c *Controller) snatChangeEip(snat *kubeovnv1.IptablesSnatRule, eip *kubeov
 	return false
 }
 
-func (c *Controller) isDnatDuplicated(gwName, eipName, dnatName, externalPort string) (bool, error) {
+// dnatPortLabel converts the external port of a dnat to a label value,
+// a port list too long to be a label value is left empty
+func dnatPortLabel(externalPort string) string {
+	value := strings.ReplaceAll(strings.ReplaceAll(externalPort, " ", ""), ",", "_")
+	if len(validation.IsValidLabelValue(value)) != 0 {
+		return ""
+	}
+	return value
+}
+
+func (c *Controller) isDnatDuplicated(gwName, eipName, dnatName, protocol, externalPort string) (bool, error) {
 	// check if eip:external port already used
 	dnats, err := c.iptablesDnatRulesLister.List(labels.SelectorFromSet(labels.Set{
 		util.VpcNatGatewayNameLabel: gwName,
-		util.VpcDnatEPortLabel:      externalPort,
 	}))
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
@@ -1761,8 +1831,9 @@ func (c *Controller) isDnatDuplicated(gwName, eipName, dnatName, externalPort st
 	}
 	if len(dnats) != 0 {
 		for _, d := range dnats {
-			if d.Name != dnatName && d.Spec.EIP == eipName {
-				err = fmt.Errorf("failed to create dnat %s, duplicate, same eip %s, same external port '%s' is using by dnat %s", dnatName, eipName, externalPort, d.Name)
+			if d.Name != dnatName && d.Spec.EIP == eipName &&
+				util.IsDnatPortConflict(protocol, externalPort, d.Spec.Protocol, d.Spec.ExternalPort) {
+				err = fmt.Errorf("failed to create dnat %s, duplicate, same eip %s, external port '%s' overlaps with port '%s' of dnat %s", dnatName, eipName, externalPort, d.Spec.ExternalPort, d.Name)
 				return true, err
 			}
 		}
@@ -0,0 +1,309 @@
+package controller
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const (
+	natGwNftApply = "nft-apply"
+
+	natGwNftTable = "kube_ovn_nat"
+)
+
+// natGwBackend returns the backend recorded on the nat gw pod when it was initialized,
+// pods initialized before the backend was configurable always use iptables
+func natGwBackend(pod *corev1.Pod) string {
+	if backend := pod.Annotations[util.VpcNatGatewayBackendAnnotation]; backend != "" {
+		return backend
+	}
+	return util.NatGwBackendIptables
+}
+
+// enqueueSyncNatGwNftRules coalesces the changes of the eips and nat rules of a nat gw into one sync,
+// so that the ruleset is rendered and applied once for all the changes queued in the meantime
+func (c *Controller) enqueueSyncNatGwNftRules(natGwDp string) {
+	klog.V(3).Infof("enqueue sync nftables rules of vpc nat gw %s", natGwDp)
+	c.syncVpcNatGwNftRulesQueue.Add(natGwDp)
+}
+
+func (c *Controller) runSyncVpcNatGwNftRulesWorker() {
+	for c.processNextWorkItem("syncVpcNatGwNftRules", c.syncVpcNatGwNftRulesQueue, c.handleSyncNatGwNftRules) {
+	}
+}
+
+func (c *Controller) handleSyncNatGwNftRules(natGwDp string) error {
+	gwPod, err := c.getNatGwPod(natGwDp)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	// the pod may be recreated with another backend after the sync is queued
+	if natGwBackend(gwPod) != util.NatGwBackendNftables {
+		return nil
+	}
+	klog.Infof("handle sync nftables rules of vpc nat gw %s", natGwDp)
+	return c.syncNatGwNftRules(gwPod, natGwDp)
+}
+
+// syncNatGwNftRules renders the nat rules of all eips bound to the nat gw and
+// replaces the whole nftables table in the nat gw pod in one transaction
+func (c *Controller) syncNatGwNftRules(pod *corev1.Pod, natGwDp string) error {
+	eips, err := c.iptablesEipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables eips, %v", err)
+		return err
+	}
+	eipV4IPs := make(map[string]string)
+	for _, eip := range eips {
+		if eip.Spec.NatGwDp != natGwDp || eip.Status.IP == "" || !eip.DeletionTimestamp.IsZero() {
+			continue
+		}
+		eipV4IPs[eip.Name] = strings.Split(eip.Status.IP, "/")[0]
+	}
+
+	fips, err := c.iptablesFipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables fips, %v", err)
+		return err
+	}
+	dnats, err := c.iptablesDnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables dnat rules, %v", err)
+		return err
+	}
+	snats, err := c.iptablesSnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables snat rules, %v", err)
+		return err
+	}
+
+	ruleset := genNatGwNftRuleset(eipV4IPs, fips, dnats, snats)
+	if err = c.execNatGwNftRules(pod, ruleset); err != nil {
+		klog.Errorf("failed to apply nftables ruleset to nat gw %s, %v", natGwDp, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) execNatGwNftRules(pod *corev1.Pod, ruleset string) error {
+	cmd := fmt.Sprintf("bash /kube-ovn/nat-gateway.sh %s", natGwNftApply)
+	klog.V(3).Info(cmd)
+	klog.V(5).Info(ruleset)
+	stdOutput, errOutput, err := util.ExecuteWithOptions(c.config.KubeClient, c.config.KubeRestConfig, util.ExecOptions{
+		Command:       []string{"/bin/bash", "-c", cmd},
+		Namespace:     pod.Namespace,
+		PodName:       pod.Name,
+		ContainerName: "vpc-nat-gw",
+		Stdin:         strings.NewReader(ruleset),
+		CaptureStdout: true,
+		CaptureStderr: true,
+	})
+	if err != nil {
+		if len(errOutput) > 0 {
+			klog.Errorf("failed to ExecuteCommandInContainer, errOutput: %v", errOutput)
+		}
+		if len(stdOutput) > 0 {
+			klog.V(3).Infof("failed to ExecuteCommandInContainer, stdOutput: %v", stdOutput)
+		}
+		klog.Error(err)
+		return err
+	}
+	if len(errOutput) > 0 {
+		klog.Errorf("failed to ExecuteCommandInContainer errOutput: %v", errOutput)
+		return fmt.Errorf("%s", errOutput)
+	}
+	return nil
+}
+
+// genNatGwNftRuleset generates an nftables script which atomically replaces the nat table of a vpc nat gw.
+// Floating ips and dnat rules are stored in maps, so the lookup cost does not grow with the number of rules.
+func genNatGwNftRuleset(eipV4IPs map[string]string,
+	fips []*kubeovnv1.IptablesFIPRule,
+	dnats []*kubeovnv1.IptablesDnatRule,
+	snats []*kubeovnv1.IptablesSnatRule,
+) string {
+	slices.SortFunc(fips, func(a, b *kubeovnv1.IptablesFIPRule) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(dnats, func(a, b *kubeovnv1.IptablesDnatRule) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(snats, func(a, b *kubeovnv1.IptablesSnatRule) int { return cmp.Compare(a.Name, b.Name) })
+
+	var fipDnatElements, fipSnatElements, fipAcctRules, dnatAcctRules []string
+	fipEips, fipInternalIPs := make(map[string]bool), make(map[string]bool)
+	for _, fip := range fips {
+		eip, ok := eipV4IPs[fip.Spec.EIP]
+		if !ok || fip.Spec.InternalIP == "" || !fip.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if fipEips[eip] || fipInternalIPs[fip.Spec.InternalIP] {
+			klog.Warningf("skip fip %s, eip %s or internal ip %s is used by another fip", fip.Name, eip, fip.Spec.InternalIP)
+			continue
+		}
+		fipEips[eip], fipInternalIPs[fip.Spec.InternalIP] = true, true
+		fipDnatElements = append(fipDnatElements, fmt.Sprintf("%s : %s", eip, fip.Spec.InternalIP))
+		fipSnatElements = append(fipSnatElements, fmt.Sprintf("%s : %s", fip.Spec.InternalIP, eip))
+		fipAcctRules = append(fipAcctRules,
+			natGwNftAcctRule(fmt.Sprintf("ct original ip daddr %s", eip), util.FipUsingEip, fip.Name),
+			natGwNftAcctRule(fmt.Sprintf("ct original ip saddr %s", fip.Spec.InternalIP), util.FipUsingEip, fip.Name))
+	}
+
+	dnatElements := map[string][]string{"tcp": nil, "udp": nil}
+	dnatKeys := make(map[string]bool)
+	for _, dnat := range dnats {
+		eip, ok := eipV4IPs[dnat.Spec.EIP]
+		if !ok || dnat.Spec.InternalIP == "" || !dnat.DeletionTimestamp.IsZero() {
+			continue
+		}
+		protocol := strings.ToLower(dnat.Spec.Protocol)
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		if _, ok := dnatElements[protocol]; !ok {
+			klog.Warningf("skip dnat %s, unsupported protocol %s", dnat.Name, dnat.Spec.Protocol)
+			continue
+		}
+		external, internal, err := util.ParseDnatPortRanges(dnat.Spec.ExternalPort, dnat.Spec.InternalPort)
+		if err != nil {
+			klog.Warningf("skip dnat %s, %v", dnat.Name, err)
+			continue
+		}
+		// port ranges are expanded to one element per port, so that every port is mapped one by one
+		var keys, elements, ports []string
+		for i, ext := range external {
+			if ext.Size() == 1 {
+				ports = append(ports, strconv.Itoa(ext.Start))
+			} else {
+				ports = append(ports, fmt.Sprintf("%d-%d", ext.Start, ext.End))
+			}
+			for offset := 0; offset < ext.Size(); offset++ {
+				key := fmt.Sprintf("%s . %d", eip, ext.Start+offset)
+				keys = append(keys, protocol+" "+key)
+				elements = append(elements, fmt.Sprintf("%s : %s . %d", key, dnat.Spec.InternalIP, internal[i].Start+offset))
+			}
+		}
+		if slices.ContainsFunc(keys, func(key string) bool { return dnatKeys[key] }) {
+			klog.Warningf("skip dnat %s, %s port %s of eip %s is used by another dnat", dnat.Name, protocol, dnat.Spec.ExternalPort, eip)
+			continue
+		}
+		for _, key := range keys {
+			dnatKeys[key] = true
+		}
+		dnatElements[protocol] = append(dnatElements[protocol], elements...)
+		match := fmt.Sprintf("ct original ip daddr %s meta l4proto %s ct original proto-dst { %s }", eip, protocol, strings.Join(ports, ", "))
+		dnatAcctRules = append(dnatAcctRules, natGwNftAcctRule(match, util.DnatUsingEip, dnat.Name))
+	}
+
+	type snatRule struct {
+		cidr   string
+		prefix int
+		eip    string
+		name   string
+	}
+	var snatRules []snatRule
+	snatCIDRs := make(map[string]bool)
+	for _, snat := range snats {
+		eip, ok := eipV4IPs[snat.Spec.EIP]
+		if !ok || snat.Spec.InternalCIDR == "" || !snat.DeletionTimestamp.IsZero() {
+			continue
+		}
+		cidr := snat.Spec.InternalCIDR
+		prefix := 32
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			prefix, _ = ipNet.Mask.Size()
+		}
+		if snatCIDRs[cidr] {
+			klog.Warningf("skip snat %s, internal cidr %s is used by another snat", snat.Name, cidr)
+			continue
+		}
+		snatCIDRs[cidr] = true
+		snatRules = append(snatRules, snatRule{cidr: cidr, prefix: prefix, eip: eip, name: snat.Name})
+	}
+	// the most specific cidr takes effect first
+	slices.SortStableFunc(snatRules, func(a, b snatRule) int { return cmp.Compare(b.prefix, a.prefix) })
+
+	var b strings.Builder
+	// declaring the table before deleting it makes the script work whether the table exists or not
+	fmt.Fprintf(&b, "table ip %s\n", natGwNftTable)
+	fmt.Fprintf(&b, "delete table ip %s\n", natGwNftTable)
+	fmt.Fprintf(&b, "table ip %s {\n", natGwNftTable)
+	writeNftMap(&b, "fip_dnat", "ipv4_addr : ipv4_addr", fipDnatElements)
+	writeNftMap(&b, "fip_snat", "ipv4_addr : ipv4_addr", fipSnatElements)
+	writeNftMap(&b, "dnat_tcp", "ipv4_addr . inet_service : ipv4_addr . inet_service", dnatElements["tcp"])
+	writeNftMap(&b, "dnat_udp", "ipv4_addr . inet_service : ipv4_addr . inet_service", dnatElements["udp"])
+
+	b.WriteString("\tchain prerouting {\n")
+	b.WriteString("\t\ttype nat hook prerouting priority dstnat; policy accept;\n")
+	b.WriteString("\t\tjump exclusive_dnat\n")
+	b.WriteString("\t\tjump shared_dnat\n")
+	b.WriteString("\t}\n")
+	b.WriteString("\tchain exclusive_dnat {\n")
+	b.WriteString("\t\tdnat ip to ip daddr map @fip_dnat\n")
+	b.WriteString("\t}\n")
+	b.WriteString("\tchain shared_dnat {\n")
+	b.WriteString("\t\tdnat ip to ip daddr . tcp dport map @dnat_tcp\n")
+	b.WriteString("\t\tdnat ip to ip daddr . udp dport map @dnat_udp\n")
+	b.WriteString("\t}\n")
+
+	b.WriteString("\tchain postrouting {\n")
+	b.WriteString("\t\ttype nat hook postrouting priority srcnat; policy accept;\n")
+	b.WriteString("\t\tjump exclusive_snat\n")
+	b.WriteString("\t\tjump shared_snat\n")
+	b.WriteString("\t}\n")
+	b.WriteString("\tchain exclusive_snat {\n")
+	b.WriteString("\t\tsnat ip to ip saddr map @fip_snat\n")
+	b.WriteString("\t}\n")
+	b.WriteString("\tchain shared_snat {\n")
+	for _, rule := range snatRules {
+		fmt.Fprintf(&b, "\t\toifname \"net1\" ip saddr %s counter snat ip to %s fully-random\n", rule.cidr, rule.eip)
+	}
+	b.WriteString("\t}\n")
+
+	// the nat chains only see the first packet of a connection,
+	// so the traffic of each nat rule is counted by an accounting rule in the forward chain
+	b.WriteString("\tchain forward {\n")
+	b.WriteString("\t\ttype filter hook forward priority mangle; policy accept;\n")
+	// traffic between internal subnets is not translated
+	b.WriteString("\t\tiifname \"eth0\" oifname \"eth0\" return\n")
+	for _, rule := range fipAcctRules {
+		b.WriteString(rule)
+	}
+	for _, rule := range dnatAcctRules {
+		b.WriteString(rule)
+	}
+	for _, rule := range snatRules {
+		b.WriteString(natGwNftAcctRule("ct original ip saddr "+rule.cidr, util.SnatUsingEip, rule.name))
+	}
+	b.WriteString("\t}\n")
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// natGwNftAcctRule counts the bytes and packets of the connections matched by the nat rule,
+// and the new connections with a second counter. The comment identifies the nat rule in the
+// output of `nft list table`, and the connection is not counted again by the following rules.
+func natGwNftAcctRule(match, natType, name string) string {
+	return fmt.Sprintf("\t\t%s counter ct state new counter comment \"%s/%s\"\n\t\t%s return\n", match, natType, name, match)
+}
+
+func writeNftMap(b *strings.Builder, name, typ string, elements []string) {
+	fmt.Fprintf(b, "\tmap %s {\n", name)
+	fmt.Fprintf(b, "\t\ttype %s\n", typ)
+	if len(elements) != 0 {
+		fmt.Fprintf(b, "\t\telements = { %s }\n", strings.Join(elements, ", "))
+	}
+	b.WriteString("\t}\n")
+}
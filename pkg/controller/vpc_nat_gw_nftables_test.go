@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_genNatGwNftRuleset(t *testing.T) {
+	t.Parallel()
+
+	eips := map[string]string{"eip1": "172.18.0.10", "eip2": "172.18.0.11"}
+	now := metav1.Now()
+
+	fips := []*kubeovnv1.IptablesFIPRule{
+		{ObjectMeta: metav1.ObjectMeta{Name: "fip1"}, Spec: kubeovnv1.IptablesFIPRuleSpec{EIP: "eip1", InternalIP: "10.0.0.2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "fip2"}, Spec: kubeovnv1.IptablesFIPRuleSpec{EIP: "eip-other-gw", InternalIP: "10.0.0.3"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "fip3", DeletionTimestamp: &now}, Spec: kubeovnv1.IptablesFIPRuleSpec{EIP: "eip2", InternalIP: "10.0.0.4"}},
+	}
+	dnats := []*kubeovnv1.IptablesDnatRule{
+		{ObjectMeta: metav1.ObjectMeta{Name: "dnat2"}, Spec: kubeovnv1.IptablesDnatRuleSpec{EIP: "eip2", Protocol: "udp", ExternalPort: "53", InternalIP: "10.0.0.6", InternalPort: "5353"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dnat1"}, Spec: kubeovnv1.IptablesDnatRuleSpec{EIP: "eip2", Protocol: "tcp", ExternalPort: "80", InternalIP: "10.0.0.5", InternalPort: "8080"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dnat3"}, Spec: kubeovnv1.IptablesDnatRuleSpec{EIP: "eip2", Protocol: "tcp", ExternalPort: "80", InternalIP: "10.0.0.7", InternalPort: "80"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dnat4"}, Spec: kubeovnv1.IptablesDnatRuleSpec{EIP: "eip1", Protocol: "tcp", ExternalPort: "30000-30001,22", InternalIP: "10.0.0.8", InternalPort: "31000-31001,2222"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dnat5"}, Spec: kubeovnv1.IptablesDnatRuleSpec{EIP: "eip1", Protocol: "tcp", ExternalPort: "29999-30000", InternalIP: "10.0.0.9", InternalPort: "29999-30000"}},
+	}
+	snats := []*kubeovnv1.IptablesSnatRule{
+		{ObjectMeta: metav1.ObjectMeta{Name: "snat1"}, Spec: kubeovnv1.IptablesSnatRuleSpec{EIP: "eip2", InternalCIDR: "10.0.0.0/16"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "snat2"}, Spec: kubeovnv1.IptablesSnatRuleSpec{EIP: "eip1", InternalCIDR: "10.0.1.0/24"}},
+	}
+
+	ruleset := genNatGwNftRuleset(eips, fips, dnats, snats)
+
+	require.Contains(t, ruleset, "table ip kube_ovn_nat\ndelete table ip kube_ovn_nat\ntable ip kube_ovn_nat {\n")
+	require.Contains(t, ruleset, "elements = { 172.18.0.10 : 10.0.0.2 }")
+	require.Contains(t, ruleset, "elements = { 10.0.0.2 : 172.18.0.10 }")
+	require.NotContains(t, ruleset, "10.0.0.3")
+	require.NotContains(t, ruleset, "10.0.0.4")
+
+	// dnat3 duplicates the external port of dnat1 and is skipped
+	// port ranges are expanded port by port, dnat5 overlaps with dnat4 and is skipped
+	require.Contains(t, ruleset, "elements = { 172.18.0.11 . 80 : 10.0.0.5 . 8080, 172.18.0.10 . 30000 : 10.0.0.8 . 31000, 172.18.0.10 . 30001 : 10.0.0.8 . 31001, 172.18.0.10 . 22 : 10.0.0.8 . 2222 }")
+	require.Contains(t, ruleset, "elements = { 172.18.0.11 . 53 : 10.0.0.6 . 5353 }")
+	require.NotContains(t, ruleset, "10.0.0.7")
+	require.NotContains(t, ruleset, "10.0.0.9")
+
+	// the more specific snat cidr comes first
+	snat1 := "oifname \"net1\" ip saddr 10.0.0.0/16 counter snat ip to 172.18.0.11 fully-random"
+	snat2 := "oifname \"net1\" ip saddr 10.0.1.0/24 counter snat ip to 172.18.0.10 fully-random"
+	require.Contains(t, ruleset, snat1)
+	require.Contains(t, ruleset, snat2)
+	require.Less(t, strings.Index(ruleset, snat2), strings.Index(ruleset, snat1))
+
+	// every nat rule is counted by an accounting rule identified by its comment
+	require.Contains(t, ruleset, "\t\tct original ip daddr 172.18.0.10 counter ct state new counter comment \"fip/fip1\"\n\t\tct original ip daddr 172.18.0.10 return\n")
+	require.Contains(t, ruleset, "ct original ip saddr 10.0.0.2 counter ct state new counter comment \"fip/fip1\"")
+	require.Contains(t, ruleset, "ct original ip daddr 172.18.0.10 meta l4proto tcp ct original proto-dst { 30000-30001, 22 } counter ct state new counter comment \"dnat/dnat4\"")
+	require.Contains(t, ruleset, "ct original ip daddr 172.18.0.11 meta l4proto udp ct original proto-dst { 53 } counter ct state new counter comment \"dnat/dnat2\"")
+	require.NotContains(t, ruleset, "dnat/dnat3")
+	require.Less(t, strings.Index(ruleset, "snat/snat2"), strings.Index(ruleset, "snat/snat1"))
+
+	t.Run("empty maps have no elements", func(t *testing.T) {
+		ruleset := genNatGwNftRuleset(nil, nil, nil, nil)
+		require.NotContains(t, ruleset, "elements")
+		require.Contains(t, ruleset, "map dnat_tcp {\n\t\ttype ipv4_addr . inet_service : ipv4_addr . inet_service\n\t}\n")
+	})
+}
+
+func Test_enqueueSyncNatGwNftRules(t *testing.T) {
+	t.Parallel()
+
+	gwPod := func(name, backend string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        util.GenNatGwStsName(name) + "-0",
+				Namespace:   "kube-system",
+				Labels:      map[string]string{"app": util.GenNatGwStsName(name), util.VpcNatGatewayLabel: "true"},
+				Annotations: map[string]string{util.VpcNatGatewayBackendAnnotation: backend},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	fakeController := newFakeController(t, gwPod("gw1", util.NatGwBackendNftables), gwPod("gw2", util.NatGwBackendNftables), gwPod("gw4", util.NatGwBackendIptables))
+	ctrl := fakeController.fakeController
+	queue := ctrl.syncVpcNatGwNftRulesQueue
+
+	// the changes of the nat rules are coalesced into one sync for each nat gw
+	require.NoError(t, ctrl.createFipInPod("gw1", "172.18.0.10", "10.0.0.2"))
+	require.NoError(t, ctrl.createDnatInPod("gw1", "tcp", "172.18.0.11", "10.0.0.5", "80", "8080"))
+	require.NoError(t, ctrl.deleteSnatInPod("gw1", "172.18.0.11", "10.0.0.0/16"))
+	require.NoError(t, ctrl.createSnatInPod("gw2", "172.18.0.12", "10.1.0.0/16"))
+	require.Equal(t, 2, queue.Len())
+
+	key, _ := queue.Get()
+	require.Equal(t, "gw1", key)
+	queue.Done(key)
+	require.NoError(t, ctrl.deleteFipInPod("gw1", "172.18.0.10", "10.0.0.2"))
+	require.Equal(t, 2, queue.Len())
+
+	// the deleted nat gw is not synced
+	require.NoError(t, ctrl.deleteDnatInPod("gw3", "tcp", "172.18.0.13", "10.0.0.5", "80", "8080"))
+	require.NoError(t, ctrl.handleSyncNatGwNftRules("gw3"))
+	require.Equal(t, 2, queue.Len())
+
+	// the nat gw recreated with the iptables backend is not synced
+	require.NoError(t, ctrl.handleSyncNatGwNftRules("gw4"))
+}
@@ -0,0 +1,623 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"sort"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func (c *Controller) enqueueAddVpcRouteTable(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add vpc route table %s", key)
+	c.addOrUpdateVpcRouteTableQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateVpcRouteTable(oldObj, newObj interface{}) {
+	oldRtb := oldObj.(*kubeovnv1.VpcRouteTable)
+	newRtb := newObj.(*kubeovnv1.VpcRouteTable)
+	if !newRtb.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(newRtb, util.ControllerName) {
+			// avoid delete twice
+			return
+		}
+		klog.V(3).Infof("enqueue delete vpc route table %s", newRtb.Name)
+		c.delVpcRouteTableQueue.Add(newRtb)
+		return
+	}
+	if reflect.DeepEqual(oldRtb.Spec, newRtb.Spec) {
+		return
+	}
+	if oldRtb.Spec.Vpc != newRtb.Spec.Vpc {
+		// routes programmed in the old vpc are removed by the delete handler
+		c.delVpcRouteTableQueue.Add(oldRtb)
+	}
+
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(newObj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue update vpc route table %s", key)
+	c.addOrUpdateVpcRouteTableQueue.Add(key)
+}
+
+func (c *Controller) enqueueDelVpcRouteTable(obj interface{}) {
+	var rtb *kubeovnv1.VpcRouteTable
+	switch t := obj.(type) {
+	case *kubeovnv1.VpcRouteTable:
+		rtb = t
+	case cache.DeletedFinalStateUnknown:
+		r, ok := t.Obj.(*kubeovnv1.VpcRouteTable)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		rtb = r
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+	if !rtb.DeletionTimestamp.IsZero() {
+		// the routes have been deleted before the finalizer is removed
+		return
+	}
+	klog.V(3).Infof("enqueue delete vpc route table %s", rtb.Name)
+	c.delVpcRouteTableQueue.Add(rtb)
+}
+
+// enqueueVpcRouteTableOfSubnet enqueues the route table used by the subnet to update the subnets in its status
+func (c *Controller) enqueueVpcRouteTableOfSubnet(subnet *kubeovnv1.Subnet) {
+	if subnet.Spec.RouteTable != "" {
+		c.addOrUpdateVpcRouteTableQueue.Add(subnet.Spec.RouteTable)
+	}
+}
+
+// enqueueVpcRouteTablesOfVpc enqueues the route tables of the vpc, which are skipped by the vpc reconciliation
+func (c *Controller) enqueueVpcRouteTablesOfVpc(vpcName string) {
+	rtbs, err := c.vpcRouteTablesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc route tables: %v", err)
+		return
+	}
+	for _, rtb := range rtbs {
+		if rtb.Spec.Vpc == vpcName {
+			c.addOrUpdateVpcRouteTableQueue.Add(rtb.Name)
+		}
+	}
+}
+
+func (c *Controller) runAddVpcRouteTableWorker() {
+	for c.processNextAddOrUpdateVpcRouteTableWorkItem() {
+	}
+}
+
+func (c *Controller) runDelVpcRouteTableWorker() {
+	for c.processNextDeleteVpcRouteTableWorkItem() {
+	}
+}
+
+func (c *Controller) processNextAddOrUpdateVpcRouteTableWorkItem() bool {
+	obj, shutdown := c.addOrUpdateVpcRouteTableQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.addOrUpdateVpcRouteTableQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.addOrUpdateVpcRouteTableQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleAddOrUpdateVpcRouteTable(key); err != nil {
+			c.addOrUpdateVpcRouteTableQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", key, err.Error())
+		}
+		c.addOrUpdateVpcRouteTableQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) processNextDeleteVpcRouteTableWorkItem() bool {
+	obj, shutdown := c.delVpcRouteTableQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.delVpcRouteTableQueue.Done(obj)
+		var rtb *kubeovnv1.VpcRouteTable
+		var ok bool
+		if rtb, ok = obj.(*kubeovnv1.VpcRouteTable); !ok {
+			c.delVpcRouteTableQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected vpc route table in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleDelVpcRouteTable(rtb); err != nil {
+			c.delVpcRouteTableQueue.AddRateLimited(obj)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", rtb.Name, err.Error())
+		}
+		c.delVpcRouteTableQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+// validateVpcRouteTableRoutes checks the routes of a route table and fills in the default policy
+func validateVpcRouteTableRoutes(routes []*kubeovnv1.VpcRouteTableRoute) error {
+	keys := set.New[string]()
+	for _, route := range routes {
+		if route.Policy == "" {
+			route.Policy = kubeovnv1.PolicyDst
+		}
+		if route.Policy != kubeovnv1.PolicyDst && route.Policy != kubeovnv1.PolicySrc {
+			return fmt.Errorf("invalid policy %q of route %s", route.Policy, route.CIDR)
+		}
+		if _, _, err := net.ParseCIDR(route.CIDR); err != nil && net.ParseIP(route.CIDR) == nil {
+			return fmt.Errorf("invalid CIDR %q", route.CIDR)
+		}
+		key := fmt.Sprintf("%s:%s", route.Policy, route.CIDR)
+		if keys.Has(key) {
+			return fmt.Errorf("duplicate route %s with policy %s", route.CIDR, route.Policy)
+		}
+		keys.Insert(key)
+		if len(route.NextHopIPs) == 0 {
+			return fmt.Errorf("no next hop of route %s", route.CIDR)
+		}
+		for _, nextHop := range route.NextHopIPs {
+			if net.ParseIP(nextHop) == nil {
+				return fmt.Errorf("invalid next hop %q of route %s", nextHop, route.CIDR)
+			}
+			if util.CheckProtocol(nextHop) != util.CheckProtocol(route.CIDR) {
+				return fmt.Errorf("next hop %s and route %s are in different ip families", nextHop, route.CIDR)
+			}
+		}
+	}
+	return nil
+}
+
+// vpcRouteTableTargetRoutes expands the routes of a route table to static routes, one for each next hop
+func vpcRouteTableTargetRoutes(rtb *kubeovnv1.VpcRouteTable, bfdIDs map[string]string) []*kubeovnv1.StaticRoute {
+	var routes []*kubeovnv1.StaticRoute
+	for _, route := range rtb.Spec.Routes {
+		for _, nextHop := range route.NextHopIPs {
+			staticRoute := &kubeovnv1.StaticRoute{
+				Policy:     route.Policy,
+				CIDR:       route.CIDR,
+				NextHopIP:  nextHop,
+				RouteTable: rtb.Name,
+			}
+			if route.EnableBfd {
+				staticRoute.ECMPMode = util.StaticRouteBfdEcmp
+				staticRoute.BfdID = bfdIDs[nextHop]
+			}
+			routes = append(routes, staticRoute)
+		}
+	}
+	return routes
+}
+
+// diffVpcRouteTableRoutes computes the routes to be deleted and added for a route table.
+// Only the routes programmed by the route table are considered, so that the routes added to
+// the same table by other components, e.g. the eip routes of pods, are kept.
+func diffVpcRouteTableRoutes(exist []*ovnnb.LogicalRouterStaticRoute, programmed []*kubeovnv1.VpcRouteTableRouteStatus, target []*kubeovnv1.StaticRoute) (routeNeedDel, routeNeedAdd []*kubeovnv1.StaticRoute, err error) {
+	owned := set.New[string]()
+	for _, route := range programmed {
+		owned.Insert(getStaticRouteItemKey(&kubeovnv1.StaticRoute{Policy: route.Policy, CIDR: route.CIDR, NextHopIP: route.NextHopIP}))
+	}
+	targetBfd := make(map[string]string, len(target))
+	for _, route := range target {
+		targetBfd[getStaticRouteItemKey(&kubeovnv1.StaticRoute{Policy: route.Policy, CIDR: route.CIDR, NextHopIP: route.NextHopIP})] = route.BfdID
+	}
+
+	var ownedExist []*ovnnb.LogicalRouterStaticRoute
+	for _, route := range exist {
+		key := getStaticRouteItemKey(&kubeovnv1.StaticRoute{Policy: reverseStaticRoutePolicy(route.Policy), CIDR: route.IPPrefix, NextHopIP: route.Nexthop})
+		bfdID, ok := targetBfd[key]
+		if !ok && !owned.Has(key) {
+			continue
+		}
+		var existBfdID string
+		if route.BFD != nil {
+			existBfdID = *route.BFD
+		}
+		if ok && existBfdID != bfdID {
+			// the bfd of the route changed, re-create the route
+			routeNeedDel = append(routeNeedDel, &kubeovnv1.StaticRoute{
+				Policy:     reverseStaticRoutePolicy(route.Policy),
+				CIDR:       route.IPPrefix,
+				NextHopIP:  route.Nexthop,
+				RouteTable: route.RouteTable,
+			})
+			continue
+		}
+		ownedExist = append(ownedExist, route)
+	}
+
+	del, add, err := diffStaticRoute(ownedExist, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(routeNeedDel, del...), add, nil
+}
+
+func reverseStaticRoutePolicy(policy *ovnnb.LogicalRouterStaticRoutePolicy) kubeovnv1.RoutePolicy {
+	if policy == nil {
+		return kubeovnv1.PolicyDst
+	}
+	return reversePolicy(*policy)
+}
+
+// vpcRouterPortByNextHop returns the logical router port of the vpc whose networks contain the next hop,
+// which is the logical port of the BFD session to the next hop
+func (c *Controller) vpcRouterPortByNextHop(vpcName, nextHop string) (string, error) {
+	lr, err := c.OVNNbClient.GetLogicalRouter(vpcName, false)
+	if err != nil {
+		klog.Errorf("failed to get logical router %s: %v", vpcName, err)
+		return "", err
+	}
+	ports := set.New(lr.Ports...)
+	lrps, err := c.OVNNbClient.ListLogicalRouterPorts(nil, func(lrp *ovnnb.LogicalRouterPort) bool {
+		return ports.Has(lrp.UUID)
+	})
+	if err != nil {
+		klog.Errorf("failed to list logical router ports of %s: %v", vpcName, err)
+		return "", err
+	}
+	sort.Slice(lrps, func(i, j int) bool { return lrps[i].Name < lrps[j].Name })
+	for _, lrp := range lrps {
+		for _, network := range lrp.Networks {
+			if _, ipNet, err := net.ParseCIDR(network); err == nil && ipNet.Contains(net.ParseIP(nextHop)) {
+				return lrp.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no logical router port of vpc %s is in the same network with next hop %s", vpcName, nextHop)
+}
+
+func (c *Controller) handleAddOrUpdateVpcRouteTable(key string) error {
+	cachedRtb, err := c.vpcRouteTablesLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	if !cachedRtb.DeletionTimestamp.IsZero() {
+		// the routes are deleted by the delete handler
+		return nil
+	}
+	rtb := cachedRtb.DeepCopy()
+	klog.Infof("handle add/update vpc route table %s", key)
+
+	// the vpc lock serializes the route table reconciliation with the vpc static route reconciliation
+	c.vpcKeyMutex.LockKey(rtb.Spec.Vpc)
+	defer func() { _ = c.vpcKeyMutex.UnlockKey(rtb.Spec.Vpc) }()
+
+	subnets, err := c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list subnets: %v", err)
+		return err
+	}
+	rtb.Status.Subnets = nil
+	for _, subnet := range subnets {
+		if subnet.Spec.Vpc == rtb.Spec.Vpc && subnet.Spec.RouteTable == rtb.Name {
+			rtb.Status.Subnets = append(rtb.Status.Subnets, subnet.Name)
+		}
+	}
+	sort.Strings(rtb.Status.Subnets)
+
+	if rtb.Name == util.MainRouteTable {
+		err = fmt.Errorf("route table name %q is reserved", rtb.Name)
+	} else if err = validateVpcRouteTableRoutes(rtb.Spec.Routes); err == nil {
+		if _, err = c.vpcsLister.Get(rtb.Spec.Vpc); err != nil && !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return err
+		}
+	}
+	if err != nil {
+		klog.Errorf("failed to validate vpc route table %s: %v", key, err)
+		rtb.Status.Ready = false
+		rtb.Status.Error = err.Error()
+		// no need to retry until the spec is corrected or the vpc is created
+		return c.patchVpcRouteTableStatus(cachedRtb, rtb)
+	}
+
+	// the finalizer makes sure the routes recorded in the status are deleted with the route table
+	if err = c.handleAddVpcRouteTableFinalizer(cachedRtb); err != nil {
+		return err
+	}
+	if err = c.reconcileVpcRouteTableRoutes(rtb); err != nil {
+		rtb.Status.Ready = false
+		rtb.Status.Error = err.Error()
+		if patchErr := c.patchVpcRouteTableStatus(cachedRtb, rtb); patchErr != nil {
+			klog.Error(patchErr)
+		}
+		return err
+	}
+	rtb.Status.Ready = true
+	rtb.Status.Error = ""
+	// the status is the only record of the routes programmed by the route table, retry if it is not saved
+	return c.patchVpcRouteTableStatus(cachedRtb, rtb)
+}
+
+// reconcileVpcRouteTableRoutes programs the routes of the route table in the vpc logical router
+// and records the programmed routes in the status
+func (c *Controller) reconcileVpcRouteTableRoutes(rtb *kubeovnv1.VpcRouteTable) error {
+	vpcName := rtb.Spec.Vpc
+	bfdIDs := make(map[string]string)
+	for _, route := range rtb.Spec.Routes {
+		if !route.EnableBfd {
+			continue
+		}
+		for _, nextHop := range route.NextHopIPs {
+			if bfdIDs[nextHop] != "" {
+				continue
+			}
+			lrpName, err := c.vpcRouterPortByNextHop(vpcName, nextHop)
+			if err != nil {
+				klog.Error(err)
+				return err
+			}
+			bfd, err := c.OVNNbClient.CreateBFD(lrpName, nextHop, c.config.BfdMinRx, c.config.BfdMinTx, c.config.BfdDetectMult)
+			if err != nil {
+				klog.Errorf("failed to create bfd for next hop %s of vpc route table %s: %v", nextHop, rtb.Name, err)
+				return err
+			}
+			bfdIDs[nextHop] = bfd.UUID
+		}
+	}
+
+	existRoutes, err := c.OVNNbClient.ListLogicalRouterStaticRoutes(vpcName, &rtb.Name, nil, "", nil)
+	if err != nil {
+		klog.Errorf("failed to list static routes of vpc %s route table %s: %v", vpcName, rtb.Name, err)
+		return err
+	}
+	targetRoutes := vpcRouteTableTargetRoutes(rtb, bfdIDs)
+	routeNeedDel, routeNeedAdd, err := diffVpcRouteTableRoutes(existRoutes, rtb.Status.Routes, targetRoutes)
+	if err != nil {
+		klog.Errorf("failed to diff static routes of vpc %s route table %s: %v", vpcName, rtb.Name, err)
+		return err
+	}
+
+	for _, item := range routeNeedDel {
+		klog.Infof("vpc %s route table %s del static route: %+v", vpcName, rtb.Name, item)
+		policy := convertPolicy(item.Policy)
+		if err = c.OVNNbClient.DeleteLogicalRouterStaticRoute(vpcName, &item.RouteTable, &policy, item.CIDR, item.NextHopIP); err != nil {
+			klog.Errorf("failed to delete static route of vpc %s route table %s: %v", vpcName, rtb.Name, err)
+			return err
+		}
+	}
+	nextHops := make(map[string][]string, len(rtb.Spec.Routes))
+	for _, route := range rtb.Spec.Routes {
+		nextHops[fmt.Sprintf("%s:%s", route.Policy, route.CIDR)] = route.NextHopIPs
+	}
+	added := set.New[string]()
+	for _, item := range routeNeedAdd {
+		klog.Infof("vpc %s route table %s add static route: %+v", vpcName, rtb.Name, item)
+		if item.BfdID != "" {
+			err = c.addStaticRouteToVpc(vpcName, item)
+		} else {
+			// ecmp routes without bfd must be added together, otherwise the existing next hops are replaced
+			key := fmt.Sprintf("%s:%s", item.Policy, item.CIDR)
+			if added.Has(key) {
+				continue
+			}
+			added.Insert(key)
+			err = c.OVNNbClient.AddLogicalRouterStaticRoute(vpcName, rtb.Name, convertPolicy(item.Policy), item.CIDR, nil, nextHops[key]...)
+		}
+		if err != nil {
+			klog.Errorf("failed to add static route to vpc %s route table %s: %v", vpcName, rtb.Name, err)
+			return err
+		}
+	}
+	if err = c.deleteUnusedVpcRouteTableBfd(vpcName, rtb.Status.Routes, bfdIDs); err != nil {
+		return err
+	}
+
+	rtb.Status.Routes = nil
+	for _, route := range targetRoutes {
+		rtb.Status.Routes = append(rtb.Status.Routes, &kubeovnv1.VpcRouteTableRouteStatus{
+			Policy:    route.Policy,
+			CIDR:      route.CIDR,
+			NextHopIP: route.NextHopIP,
+			BfdID:     route.BfdID,
+		})
+	}
+	return nil
+}
+
+// deleteUnusedVpcRouteTableBfd deletes the BFD sessions created for the programmed routes
+// which are not used by any static route of the vpc
+func (c *Controller) deleteUnusedVpcRouteTableBfd(vpcName string, programmed []*kubeovnv1.VpcRouteTableRouteStatus, keep map[string]string) error {
+	var nextHops []string
+	for _, route := range programmed {
+		if route.BfdID != "" && keep[route.NextHopIP] == "" && !slices.Contains(nextHops, route.NextHopIP) {
+			nextHops = append(nextHops, route.NextHopIP)
+		}
+	}
+	if len(nextHops) == 0 {
+		return nil
+	}
+
+	routes, err := c.OVNNbClient.ListLogicalRouterStaticRoutes(vpcName, nil, nil, "", nil)
+	if err != nil {
+		klog.Errorf("failed to list static routes of vpc %s: %v", vpcName, err)
+		return err
+	}
+	usedBfd := set.New[string]()
+	for _, route := range routes {
+		if route.BFD != nil {
+			usedBfd.Insert(*route.BFD)
+		}
+	}
+	for _, nextHop := range nextHops {
+		lrpName, err := c.vpcRouterPortByNextHop(vpcName, nextHop)
+		if err != nil {
+			// the router port has been deleted along with its bfd sessions
+			klog.Warning(err)
+			continue
+		}
+		bfdList, err := c.OVNNbClient.ListBFD(lrpName, nextHop)
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		for _, bfd := range bfdList {
+			if usedBfd.Has(bfd.UUID) {
+				continue
+			}
+			if err = c.OVNNbClient.DeleteBFD(lrpName, nextHop); err != nil {
+				klog.Error(err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Controller) handleDelVpcRouteTable(rtb *kubeovnv1.VpcRouteTable) error {
+	klog.Infof("handle delete vpc route table %s", rtb.Name)
+	c.vpcKeyMutex.LockKey(rtb.Spec.Vpc)
+	defer func() { _ = c.vpcKeyMutex.UnlockKey(rtb.Spec.Vpc) }()
+
+	lr, err := c.OVNNbClient.GetLogicalRouter(rtb.Spec.Vpc, true)
+	if err != nil {
+		klog.Errorf("failed to get logical router %s: %v", rtb.Spec.Vpc, err)
+		return err
+	}
+	if lr != nil {
+		for _, route := range rtb.Status.Routes {
+			klog.Infof("vpc %s route table %s del static route: %+v", rtb.Spec.Vpc, rtb.Name, route)
+			if err := c.deleteStaticRouteFromVpc(rtb.Spec.Vpc, rtb.Name, route.CIDR, route.NextHopIP, route.Policy); err != nil {
+				klog.Errorf("failed to delete static route of vpc %s route table %s: %v", rtb.Spec.Vpc, rtb.Name, err)
+				return err
+			}
+		}
+		if err = c.deleteUnusedVpcRouteTableBfd(rtb.Spec.Vpc, rtb.Status.Routes, nil); err != nil {
+			return err
+		}
+	}
+
+	// the route table is also enqueued with the old vpc when its vpc is changed
+	if rtb.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	cachedRtb, err := c.vpcRouteTablesLister.Get(rtb.Name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	return c.handleDelVpcRouteTableFinalizer(cachedRtb)
+}
+
+func (c *Controller) handleAddVpcRouteTableFinalizer(cachedRtb *kubeovnv1.VpcRouteTable) error {
+	if controllerutil.ContainsFinalizer(cachedRtb, util.ControllerName) {
+		return nil
+	}
+	newRtb := cachedRtb.DeepCopy()
+	controllerutil.AddFinalizer(newRtb, util.ControllerName)
+	patch, err := util.GenerateMergePatchPayload(cachedRtb, newRtb)
+	if err != nil {
+		klog.Errorf("failed to generate patch payload for vpc route table %s: %v", cachedRtb.Name, err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcRouteTables().Patch(context.Background(), cachedRtb.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{}, ""); err != nil {
+		klog.Errorf("failed to add finalizer for vpc route table %s: %v", cachedRtb.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) handleDelVpcRouteTableFinalizer(cachedRtb *kubeovnv1.VpcRouteTable) error {
+	if !controllerutil.ContainsFinalizer(cachedRtb, util.ControllerName) {
+		return nil
+	}
+	newRtb := cachedRtb.DeepCopy()
+	controllerutil.RemoveFinalizer(newRtb, util.ControllerName)
+	patch, err := util.GenerateMergePatchPayload(cachedRtb, newRtb)
+	if err != nil {
+		klog.Errorf("failed to generate patch payload for vpc route table %s: %v", cachedRtb.Name, err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcRouteTables().Patch(context.Background(), cachedRtb.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{}, ""); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to remove finalizer from vpc route table %s: %v", cachedRtb.Name, err)
+		return err
+	}
+	return nil
+}
+
+// vpcRouteTableNames returns the names of the route tables managed by VpcRouteTable for the vpc
+func (c *Controller) vpcRouteTableNames(vpcName string) (set.Set[string], error) {
+	rtbs, err := c.vpcRouteTablesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc route tables: %v", err)
+		return nil, err
+	}
+	names := set.New[string]()
+	for _, rtb := range rtbs {
+		if rtb.Spec.Vpc == vpcName && rtb.Name != util.MainRouteTable {
+			names.Insert(rtb.Name)
+		}
+	}
+	return names, nil
+}
+
+func (c *Controller) patchVpcRouteTableStatus(cachedRtb, rtb *kubeovnv1.VpcRouteTable) error {
+	if reflect.DeepEqual(cachedRtb.Status, rtb.Status) {
+		return nil
+	}
+	bytes, err := rtb.Status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcRouteTables().Patch(context.Background(), rtb.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("patch vpc route table %s status failed: %v", rtb.Name, err)
+		return err
+	}
+	return nil
+}
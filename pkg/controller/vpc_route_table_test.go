@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_validateVpcRouteTableRoutes(t *testing.T) {
+	t.Parallel()
+
+	routes := []*kubeovnv1.VpcRouteTableRoute{{CIDR: "10.0.0.0/24", NextHopIPs: []string{"192.168.0.1", "192.168.0.2"}}}
+	require.NoError(t, validateVpcRouteTableRoutes(routes))
+	require.Equal(t, kubeovnv1.PolicyDst, routes[0].Policy)
+
+	require.ErrorContains(t, validateVpcRouteTableRoutes([]*kubeovnv1.VpcRouteTableRoute{{CIDR: "10.0.0.0/33", NextHopIPs: []string{"192.168.0.1"}}}), "invalid CIDR")
+	require.ErrorContains(t, validateVpcRouteTableRoutes([]*kubeovnv1.VpcRouteTableRoute{{CIDR: "10.0.0.0/24"}}), "no next hop")
+	require.ErrorContains(t, validateVpcRouteTableRoutes([]*kubeovnv1.VpcRouteTableRoute{{CIDR: "10.0.0.0/24", NextHopIPs: []string{"fd00::1"}}}), "different ip families")
+	require.ErrorContains(t, validateVpcRouteTableRoutes([]*kubeovnv1.VpcRouteTableRoute{
+		{CIDR: "10.0.0.0/24", NextHopIPs: []string{"192.168.0.1"}},
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.0.0.0/24", NextHopIPs: []string{"192.168.0.2"}},
+	}), "duplicate route")
+}
+
+func Test_diffVpcRouteTableRoutes(t *testing.T) {
+	t.Parallel()
+
+	dst := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	bfdID := "bfd-uuid"
+	exist := []*ovnnb.LogicalRouterStaticRoute{
+		// programmed and still wanted
+		{Policy: &dst, IPPrefix: "10.0.0.0/24", Nexthop: "192.168.0.1", RouteTable: "rtb"},
+		// programmed but removed from the spec
+		{Policy: &dst, IPPrefix: "10.0.1.0/24", Nexthop: "192.168.0.1", RouteTable: "rtb"},
+		// added by other components
+		{Policy: &dst, IPPrefix: "10.0.2.0/24", Nexthop: "192.168.0.1", RouteTable: "rtb"},
+		// bfd enabled in the spec
+		{Policy: &dst, IPPrefix: "10.0.3.0/24", Nexthop: "192.168.0.3", RouteTable: "rtb"},
+	}
+	programmed := []*kubeovnv1.VpcRouteTableRouteStatus{
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.0.0.0/24", NextHopIP: "192.168.0.1"},
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.0.1.0/24", NextHopIP: "192.168.0.1"},
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.0.3.0/24", NextHopIP: "192.168.0.3"},
+	}
+	target := []*kubeovnv1.StaticRoute{
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.0.0.0/24", NextHopIP: "192.168.0.1", RouteTable: "rtb"},
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.0.0.0/24", NextHopIP: "192.168.0.2", RouteTable: "rtb"},
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.0.3.0/24", NextHopIP: "192.168.0.3", RouteTable: "rtb", BfdID: bfdID},
+	}
+
+	del, add, err := diffVpcRouteTableRoutes(exist, programmed, target)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"10.0.1.0/24=>192.168.0.1", "10.0.3.0/24=>192.168.0.3"}, routeStrings(del))
+	require.ElementsMatch(t, []string{"10.0.0.0/24=>192.168.0.2", "10.0.3.0/24=>192.168.0.3"}, routeStrings(add))
+}
+
+func routeStrings(routes []*kubeovnv1.StaticRoute) []string {
+	result := make([]string, 0, len(routes))
+	for _, route := range routes {
+		result = append(result, route.CIDR+"=>"+route.NextHopIP)
+	}
+	return result
+}
+
+func newVpcRouteTableTestTable() *kubeovnv1.VpcRouteTable {
+	return &kubeovnv1.VpcRouteTable{
+		ObjectMeta: metav1.ObjectMeta{Name: "rtb1"},
+		Spec: kubeovnv1.VpcRouteTableSpec{
+			Vpc:    "vpc1",
+			Routes: []*kubeovnv1.VpcRouteTableRoute{{CIDR: "192.168.0.0/16", NextHopIPs: []string{"10.0.1.254"}}},
+		},
+	}
+}
+
+func Test_handleAddOrUpdateVpcRouteTable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("add finalizer and record routes", func(t *testing.T) {
+		t.Parallel()
+
+		fakeController := newFakeController(t, &kubeovnv1.Vpc{ObjectMeta: metav1.ObjectMeta{Name: "vpc1"}}, newVpcRouteTableTestTable())
+		ctrl, mockOvnClient := fakeController.fakeController, fakeController.mockOvnClient
+		mockOvnClient.EXPECT().ListLogicalRouterStaticRoutes("vpc1", gomock.Any(), nil, "", nil).Return(nil, nil)
+		mockOvnClient.EXPECT().AddLogicalRouterStaticRoute("vpc1", "rtb1", ovnnb.LogicalRouterStaticRoutePolicyDstIP, "192.168.0.0/16", nil, "10.0.1.254").Return(nil)
+
+		require.NoError(t, ctrl.handleAddOrUpdateVpcRouteTable("rtb1"))
+
+		rtb, err := ctrl.config.KubeOvnClient.KubeovnV1().VpcRouteTables().Get(context.Background(), "rtb1", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{util.ControllerName}, rtb.Finalizers)
+		require.True(t, rtb.Status.Ready)
+		require.Equal(t, []*kubeovnv1.VpcRouteTableRouteStatus{{Policy: kubeovnv1.PolicyDst, CIDR: "192.168.0.0/16", NextHopIP: "10.0.1.254"}}, rtb.Status.Routes)
+	})
+
+	t.Run("status patch failure is returned", func(t *testing.T) {
+		t.Parallel()
+
+		rtb := newVpcRouteTableTestTable()
+		rtb.Finalizers = []string{util.ControllerName}
+		fakeController := newFakeController(t, &kubeovnv1.Vpc{ObjectMeta: metav1.ObjectMeta{Name: "vpc1"}})
+		ctrl, mockOvnClient := fakeController.fakeController, fakeController.mockOvnClient
+		// the route table is only known by the informer
+		require.NoError(t, fakeController.fakeinformers.vpcRouteTableInformer.Informer().GetStore().Add(rtb))
+		mockOvnClient.EXPECT().ListLogicalRouterStaticRoutes("vpc1", gomock.Any(), nil, "", nil).Return(nil, nil)
+		mockOvnClient.EXPECT().AddLogicalRouterStaticRoute("vpc1", "rtb1", ovnnb.LogicalRouterStaticRoutePolicyDstIP, "192.168.0.0/16", nil, "10.0.1.254").Return(nil)
+
+		require.Error(t, ctrl.handleAddOrUpdateVpcRouteTable("rtb1"))
+	})
+}
+
+func Test_deleteVpcRouteTable(t *testing.T) {
+	t.Parallel()
+
+	rtb := newVpcRouteTableTestTable()
+	rtb.Finalizers = []string{util.ControllerName}
+	rtb.Status.Routes = []*kubeovnv1.VpcRouteTableRouteStatus{{Policy: kubeovnv1.PolicyDst, CIDR: "192.168.0.0/16", NextHopIP: "10.0.1.254"}}
+	fakeController := newFakeController(t, rtb)
+	ctrl, mockOvnClient := fakeController.fakeController, fakeController.mockOvnClient
+
+	// the routes are deleted when the deletion of the route table is requested
+	deleting := rtb.DeepCopy()
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	require.NoError(t, fakeController.fakeinformers.vpcRouteTableInformer.Informer().GetStore().Update(deleting))
+	ctrl.enqueueUpdateVpcRouteTable(rtb, deleting)
+	require.Equal(t, 1, ctrl.delVpcRouteTableQueue.Len())
+	require.Zero(t, ctrl.addOrUpdateVpcRouteTableQueue.Len())
+	item, _ := ctrl.delVpcRouteTableQueue.Get()
+	ctrl.delVpcRouteTableQueue.Done(item)
+
+	mockOvnClient.EXPECT().GetLogicalRouter("vpc1", true).Return(&ovnnb.LogicalRouter{Name: "vpc1"}, nil)
+	mockOvnClient.EXPECT().DeleteLogicalRouterStaticRoute("vpc1", gomock.Any(), gomock.Any(), "192.168.0.0/16", "10.0.1.254").Return(nil)
+	require.NoError(t, ctrl.handleDelVpcRouteTable(item.(*kubeovnv1.VpcRouteTable)))
+
+	got, err := ctrl.config.KubeOvnClient.KubeovnV1().VpcRouteTables().Get(context.Background(), "rtb1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, got.Finalizers)
+
+	// the delete event after the finalizer is removed is ignored
+	deleting.Finalizers = nil
+	ctrl.enqueueUpdateVpcRouteTable(deleting, deleting)
+	ctrl.enqueueDelVpcRouteTable(deleting)
+	require.Zero(t, ctrl.delVpcRouteTableQueue.Len())
+}
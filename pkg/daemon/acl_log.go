@@ -0,0 +1,260 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// aclLogHeaderRegex matches the key/value pairs before the flow in the acl log, e.g.
+// name="sg/web/ingress/0", verdict=allow, severity=info, direction=to-lport
+var aclLogHeaderRegex = regexp.MustCompile(`(\w+)=("[^"]*"|[^,\s]*)`)
+
+// aclLogEntry is the structured acl log written by the shipper
+type aclLogEntry struct {
+	Time          string `json:"time"`
+	Node          string `json:"node"`
+	Name          string `json:"name"`
+	SecurityGroup string `json:"securityGroup,omitempty"`
+	Rule          string `json:"rule,omitempty"`
+	Verdict       string `json:"verdict"`
+	Severity      string `json:"severity"`
+	Direction     string `json:"direction"`
+	Protocol      string `json:"protocol,omitempty"`
+	SrcMAC        string `json:"srcMAC,omitempty"`
+	DstMAC        string `json:"dstMAC,omitempty"`
+	SrcIP         string `json:"srcIP,omitempty"`
+	DstIP         string `json:"dstIP,omitempty"`
+	SrcPort       int    `json:"srcPort,omitempty"`
+	DstPort       int    `json:"dstPort,omitempty"`
+	ICMPType      string `json:"icmpType,omitempty"`
+	ICMPCode      string `json:"icmpCode,omitempty"`
+	SrcPod        string `json:"srcPod,omitempty"`
+	SrcNamespace  string `json:"srcNamespace,omitempty"`
+	DstPod        string `json:"dstPod,omitempty"`
+	DstNamespace  string `json:"dstNamespace,omitempty"`
+}
+
+// parseACLLog parses an acl log line of ovn-controller, e.g.
+// 2024-01-01T00:00:00.000Z|00009|acl_log(ovn_pinctrl0)|INFO|name="sg/web/ingress/0", verdict=allow, severity=info, direction=to-lport: tcp,vlan_tci=0x0000,dl_src=...,nw_src=10.16.0.2,nw_dst=10.16.0.3,...,tp_src=34567,tp_dst=80
+// false is returned if the line is not an acl log
+func parseACLLog(line string) (*aclLogEntry, bool) {
+	fields := strings.SplitN(line, "|", 5)
+	if len(fields) != 5 || !strings.HasPrefix(fields[2], "acl_log") {
+		return nil, false
+	}
+	header, flow, found := strings.Cut(fields[4], ": ")
+	if !found {
+		return nil, false
+	}
+
+	entry := &aclLogEntry{Time: fields[0]}
+	for _, kv := range aclLogHeaderRegex.FindAllStringSubmatch(header, -1) {
+		value := strings.Trim(kv[2], `"`)
+		switch kv[1] {
+		case "name":
+			entry.Name = value
+		case "verdict":
+			entry.Verdict = value
+		case "severity":
+			entry.Severity = value
+		case "direction":
+			entry.Direction = value
+		}
+	}
+	// the acl of security group rule is named as sg/<security group>/<direction>/<rule index>
+	if parts := strings.Split(entry.Name, "/"); len(parts) == 4 && parts[0] == "sg" {
+		entry.SecurityGroup = parts[1]
+		entry.Rule = parts[2] + "/" + parts[3]
+	}
+
+	for i, field := range strings.Split(strings.TrimSpace(flow), ",") {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			if i == 0 {
+				entry.Protocol = key
+			}
+			continue
+		}
+		switch key {
+		case "dl_src":
+			entry.SrcMAC = value
+		case "dl_dst":
+			entry.DstMAC = value
+		case "nw_src", "ipv6_src":
+			entry.SrcIP = value
+		case "nw_dst", "ipv6_dst":
+			entry.DstIP = value
+		case "tp_src":
+			entry.SrcPort, _ = strconv.Atoi(value)
+		case "tp_dst":
+			entry.DstPort, _ = strconv.Atoi(value)
+		case "icmp_type":
+			entry.ICMPType = value
+		case "icmp_code":
+			entry.ICMPCode = value
+		}
+	}
+	return entry, true
+}
+
+// aclLogPodResolver resolves the pods of the ip addresses of the logical switch ports
+type aclLogPodResolver struct {
+	mutex      sync.Mutex
+	pods       map[string]string
+	lastSync   time.Time
+	listPods   func() (map[string]string, error)
+	syncPeriod time.Duration
+}
+
+func (r *aclLogPodResolver) resolve(ip string) (namespace, name string) {
+	if ip == "" {
+		return "", ""
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	pod, ok := r.pods[ip]
+	if !ok && time.Since(r.lastSync) > r.syncPeriod {
+		r.lastSync = time.Now()
+		pods, err := r.listPods()
+		if err != nil {
+			klog.Errorf("failed to list pods of interfaces: %v", err)
+			return "", ""
+		}
+		r.pods = pods
+		pod = r.pods[ip]
+	}
+	namespace, name, _ = strings.Cut(pod, "/")
+	return namespace, name
+}
+
+// listIPPods returns the pods of the ip addresses indexed by the addresses, the value is namespace/name.
+// The ip crds are created for the logical switch ports of all the pods in the cluster,
+// so that the pods running on other nodes are resolved as well.
+func (c *Controller) listIPPods() (map[string]string, error) {
+	ips, err := c.ipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list ips: %v", err)
+		return nil, err
+	}
+
+	result := make(map[string]string, len(ips))
+	for _, ip := range ips {
+		// the ips of node ports have no namespace
+		if ip.Spec.PodName == "" || ip.Spec.Namespace == "" {
+			continue
+		}
+		pod := ip.Spec.Namespace + "/" + ip.Spec.PodName
+		for _, address := range append(strings.Split(ip.Spec.IPAddress, ","), ip.Spec.AttachIPs...) {
+			if address != "" {
+				result[address] = pod
+			}
+		}
+	}
+	return result, nil
+}
+
+// runACLLogShipper follows the log of ovn-controller and writes the acl logs enriched with pods as json lines
+func (c *Controller) runACLLogShipper(stopCh <-chan struct{}) {
+	var out io.Writer = os.Stdout
+	if c.config.ACLLogOutput != "" && c.config.ACLLogOutput != "stdout" {
+		f, err := os.OpenFile(c.config.ACLLogOutput, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			klog.Errorf("failed to open acl log output %s: %v", c.config.ACLLogOutput, err)
+			return
+		}
+		defer f.Close()
+		out = f
+	}
+
+	encoder := json.NewEncoder(out)
+	resolver := &aclLogPodResolver{listPods: c.listIPPods, syncPeriod: 5 * time.Second}
+	handle := func(line string) {
+		entry, ok := parseACLLog(line)
+		if !ok {
+			return
+		}
+		entry.Node = c.config.NodeName
+		entry.SrcNamespace, entry.SrcPod = resolver.resolve(entry.SrcIP)
+		entry.DstNamespace, entry.DstPod = resolver.resolve(entry.DstIP)
+		if err := encoder.Encode(entry); err != nil {
+			klog.Errorf("failed to write acl log: %v", err)
+		}
+	}
+
+	// skip the existing logs only when the shipper starts
+	seekEnd := true
+	wait.Until(func() {
+		if err := followFile(c.config.OVNControllerLogFile, seekEnd, stopCh, handle); err != nil {
+			klog.Errorf("failed to follow %s: %v", c.config.OVNControllerLogFile, err)
+			return
+		}
+		seekEnd = false
+	}, 5*time.Second, stopCh)
+}
+
+// followFile reads the lines appended to the file until it is rotated or truncated
+func followFile(path string, seekEnd bool, stopCh <-chan struct{}, handle func(line string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	var offset int64
+	if seekEnd {
+		if offset, err = f.Seek(0, io.SeekEnd); err != nil {
+			return err
+		}
+	}
+
+	reader := bufio.NewReader(f)
+	var partial string
+	for {
+		line, err := reader.ReadString('\n')
+		offset += int64(len(line))
+		if err == nil {
+			handle(partial + strings.TrimSuffix(line, "\n"))
+			partial = ""
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			return err
+		}
+		partial += line
+
+		select {
+		case <-stopCh:
+			return nil
+		case <-time.After(time.Second):
+		}
+
+		current, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !os.SameFile(info, current) || current.Size() < offset {
+			klog.Infof("%s is rotated, reopen it", path)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	kubeovnlister "github.com/kubeovn/kube-ovn/pkg/client/listers/kubeovn/v1"
+)
+
+func TestParseACLLog(t *testing.T) {
+	line := `2024-01-01T00:00:00.000Z|00009|acl_log(ovn_pinctrl0)|INFO|name="sg/web/ingress/0", verdict=allow, severity=info, direction=to-lport: tcp,vlan_tci=0x0000,dl_src=00:00:00:5d:b5:83,dl_dst=00:00:00:2a:2b:d7,nw_src=10.16.0.10,nw_dst=10.16.0.9,nw_tos=0,nw_ecn=0,nw_ttl=64,tp_src=34567,tp_dst=80,tcp_flags=syn`
+	entry, ok := parseACLLog(line)
+	require.True(t, ok)
+	require.Equal(t, &aclLogEntry{
+		Time:          "2024-01-01T00:00:00.000Z",
+		Name:          "sg/web/ingress/0",
+		SecurityGroup: "web",
+		Rule:          "ingress/0",
+		Verdict:       "allow",
+		Severity:      "info",
+		Direction:     "to-lport",
+		Protocol:      "tcp",
+		SrcMAC:        "00:00:00:5d:b5:83",
+		DstMAC:        "00:00:00:2a:2b:d7",
+		SrcIP:         "10.16.0.10",
+		DstIP:         "10.16.0.9",
+		SrcPort:       34567,
+		DstPort:       80,
+	}, entry)
+
+	line = `2024-01-01T00:00:00.000Z|00010|acl_log(ovn_pinctrl0)|INFO|name="np/test.default/egress/IPv6/0", verdict=drop, severity=warning, direction=from-lport: icmp6,vlan_tci=0x0000,dl_src=00:00:00:5d:b5:83,dl_dst=00:00:00:2a:2b:d7,ipv6_src=fd00::a,ipv6_dst=fd00::9,ipv6_label=0x00000,nw_tos=0,nw_ecn=0,nw_ttl=64,icmp_type=128,icmp_code=0`
+	entry, ok = parseACLLog(line)
+	require.True(t, ok)
+	require.Empty(t, entry.SecurityGroup)
+	require.Equal(t, "drop", entry.Verdict)
+	require.Equal(t, "fd00::a", entry.SrcIP)
+	require.Equal(t, "fd00::9", entry.DstIP)
+	require.Equal(t, "128", entry.ICMPType)
+
+	_, ok = parseACLLog(`2024-01-01T00:00:00.000Z|00011|binding|INFO|Claiming lport test.default for this chassis.`)
+	require.False(t, ok)
+}
+
+func TestACLLogPodResolver(t *testing.T) {
+	var listed int
+	r := &aclLogPodResolver{
+		listPods: func() (map[string]string, error) {
+			listed++
+			return map[string]string{"10.16.0.9": "default/web"}, nil
+		},
+		syncPeriod: time.Hour,
+	}
+
+	namespace, name := r.resolve("10.16.0.9")
+	require.Equal(t, "default", namespace)
+	require.Equal(t, "web", name)
+
+	// the pods are not listed again within the sync period
+	namespace, name = r.resolve("10.16.0.10")
+	require.Empty(t, namespace)
+	require.Empty(t, name)
+	require.Equal(t, 1, listed)
+}
+
+func TestListIPPods(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(&kubeovnv1.IP{
+		ObjectMeta: metav1.ObjectMeta{Name: "web.default"},
+		Spec: kubeovnv1.IPSpec{
+			PodName:   "web",
+			Namespace: "default",
+			NodeName:  "node2",
+			IPAddress: "10.16.0.9,fd00:10:16::9",
+			AttachIPs: []string{"172.17.0.9"},
+		},
+	}))
+	require.NoError(t, indexer.Add(&kubeovnv1.IP{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-node1"},
+		Spec:       kubeovnv1.IPSpec{PodName: "node1", NodeName: "node1", IPAddress: "100.64.0.2"},
+	}))
+
+	c := &Controller{ipsLister: kubeovnlister.NewIPLister(indexer)}
+	pods, err := c.listIPPods()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"10.16.0.9":     "default/web",
+		"fd00:10:16::9": "default/web",
+		"172.17.0.9":    "default/web",
+	}, pods)
+}
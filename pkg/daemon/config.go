@@ -66,6 +66,11 @@ type Configuration struct {
 	UDPConnCheckPort          int32
 	EnableTProxy              bool
 	OVSVsctlConcurrency       int32
+	EnableNPACLStats          bool
+	PodNamespace              string
+	EnableACLLogShipper       bool
+	OVNControllerLogFile      string
+	ACLLogOutput              string
 }
 
 // ParseFlags will parse cmd args then init kubeClient and configuration
@@ -106,6 +111,10 @@ func ParseFlags() *Configuration {
 		argUDPConnectivityCheckPort  = pflag.Int32("udp-conn-check-port", 8101, "UDP connectivity Check Port")
 		argEnableTProxy              = pflag.Bool("enable-tproxy", false, "enable tproxy for vpc pod liveness or readiness probe")
 		argOVSVsctlConcurrency       = pflag.Int32("ovs-vsctl-concurrency", 100, "concurrency limit of ovs-vsctl")
+		argEnableNPACLStats          = pflag.Bool("enable-np-acl-stats", false, "Whether to export hit counters of network policy acls")
+		argEnableACLLogShipper       = pflag.Bool("enable-acl-log-shipper", false, "Whether to ship the acl logs of ovn-controller as json lines enriched with pods")
+		argOVNControllerLogFile      = pflag.String("ovn-controller-log-file", "/var/log/ovn/ovn-controller.log", "The log file of ovn-controller to read the acl logs from")
+		argACLLogOutput              = pflag.String("acl-log-output", "stdout", "The file to write the shipped acl logs to, or stdout")
 	)
 
 	// mute info log for ipset lib
@@ -163,6 +172,11 @@ func ParseFlags() *Configuration {
 		UDPConnCheckPort:          *argUDPConnectivityCheckPort,
 		EnableTProxy:              *argEnableTProxy,
 		OVSVsctlConcurrency:       *argOVSVsctlConcurrency,
+		EnableNPACLStats:          *argEnableNPACLStats,
+		PodNamespace:              os.Getenv("POD_NAMESPACE"),
+		EnableACLLogShipper:       *argEnableACLLogShipper,
+		OVNControllerLogFile:      *argOVNControllerLogFile,
+		ACLLogOutput:              *argACLLogOutput,
 	}
 	return config
 }
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"os/exec"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/scylladb/go-set/strset"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
@@ -57,8 +59,23 @@ type Controller struct {
 	nodesLister listerv1.NodeLister
 	nodesSynced cache.InformerSynced
 
+	ipsLister kubeovnlister.IPLister
+	ipsSynced cache.InformerSynced
+
+	npACLCookiesLister listerv1.ConfigMapLister
+	npACLCookiesSynced cache.InformerSynced
+	// label values of the network policy acl hit counters exported in the last round
+	npACLMetricLabels map[string][]string
+
 	recorder record.EventRecorder
 
+	// underlayGatewayErrors records the errors of the underlay subnet gateways on the node,
+	// the key is the subnet name and the value is empty if the gateway is reachable
+	underlayGatewayErrors map[string]string
+	// underlayGatewayFailures records the numbers of consecutive failed probes of the underlay subnet gateways
+	underlayGatewayFailures map[string]int
+	underlayGatewayMutex    sync.RWMutex
+
 	protocol string
 
 	ControllerRuntime
@@ -122,6 +139,26 @@ func NewController(config *Configuration, stopCh <-chan struct{}, podInformerFac
 		return nil, err
 	}
 
+	if config.EnableACLLogShipper {
+		ipInformer := kubeovnInformerFactory.Kubeovn().V1().IPs()
+		controller.ipsLister = ipInformer.Lister()
+		controller.ipsSynced = ipInformer.Informer().HasSynced
+	}
+
+	var cmInformerFactory informers.SharedInformerFactory
+	if config.EnableNPACLStats {
+		cmInformerFactory = informers.NewSharedInformerFactoryWithOptions(config.KubeClient, 0,
+			informers.WithNamespace(config.PodNamespace),
+			informers.WithTweakListOptions(func(listOption *metav1.ListOptions) {
+				listOption.FieldSelector = fields.OneTermEqualSelector("metadata.name", util.NetworkPolicyACLCookiesConfigMap).String()
+				listOption.AllowWatchBookmarks = true
+			}))
+		cmInformer := cmInformerFactory.Core().V1().ConfigMaps()
+		controller.npACLCookiesLister = cmInformer.Lister()
+		controller.npACLCookiesSynced = cmInformer.Informer().HasSynced
+		cmInformerFactory.Start(stopCh)
+	}
+
 	podInformerFactory.Start(stopCh)
 	nodeInformerFactory.Start(stopCh)
 	kubeovnInformerFactory.Start(stopCh)
@@ -131,6 +168,12 @@ func NewController(config *Configuration, stopCh <-chan struct{}, podInformerFac
 		controller.podsSynced, controller.nodesSynced, controller.vlanSynced) {
 		util.LogFatalAndExit(nil, "failed to wait for caches to sync")
 	}
+	if config.EnableACLLogShipper && !cache.WaitForCacheSync(stopCh, controller.ipsSynced) {
+		util.LogFatalAndExit(nil, "failed to wait for ip caches to sync")
+	}
+	if config.EnableNPACLStats && !cache.WaitForCacheSync(stopCh, controller.npACLCookiesSynced) {
+		util.LogFatalAndExit(nil, "failed to wait for configmap caches to sync")
+	}
 
 	if _, err = providerNetworkInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.enqueueAddProviderNetwork,
@@ -610,7 +653,14 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	go wait.Until(c.runPodWorker, time.Second, stopCh)
 	go wait.Until(c.runGateway, 3*time.Second, stopCh)
 	go wait.Until(c.loopEncapIPCheck, 3*time.Second, stopCh)
+	go wait.Until(c.checkUnderlayGateways, 10*time.Second, stopCh)
 	go wait.Until(c.ovnMetricsUpdate, 3*time.Second, stopCh)
+	if c.config.EnableNPACLStats {
+		go wait.Until(c.setNetworkPolicyACLMetric, 30*time.Second, stopCh)
+	}
+	if c.config.EnableACLLogShipper {
+		go c.runACLLogShipper(stopCh)
+	}
 	go wait.Until(func() {
 		if err := c.reconcileRouters(nil); err != nil {
 			klog.Errorf("failed to reconcile ovn0 routes: %v", err)
@@ -621,6 +621,8 @@ func (c *Controller) handlePod(key string) error {
 	podName := pod.Name
 	if pod.Annotations[fmt.Sprintf(util.VMAnnotationTemplate, util.OvnProvider)] != "" {
 		podName = pod.Annotations[fmt.Sprintf(util.VMAnnotationTemplate, util.OvnProvider)]
+	} else if pod.Annotations[util.IPHandoffPortAnnotation] != "" {
+		podName = pod.Annotations[util.IPHandoffPortAnnotation]
 	}
 
 	// set default nic bandwidth
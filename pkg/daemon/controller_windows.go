@@ -161,6 +161,8 @@ func (c *Controller) handlePod(key string) error {
 	podName := pod.Name
 	if pod.Annotations[fmt.Sprintf(util.VMAnnotationTemplate, util.OvnProvider)] != "" {
 		podName = pod.Annotations[fmt.Sprintf(util.VMAnnotationTemplate, util.OvnProvider)]
+	} else if pod.Annotations[util.IPHandoffPortAnnotation] != "" {
+		podName = pod.Annotations[util.IPHandoffPortAnnotation]
 	}
 
 	// set default nic bandwidth
@@ -215,5 +217,9 @@ func (c *Controller) ovnMetricsUpdate() {
 	// TODO
 }
 
+func (c *Controller) setNetworkPolicyACLMetric() {
+	// TODO
+}
+
 func (c *Controller) operateMod() {
 }
@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	ovsutil "github.com/digitalocean/go-openvswitch/ovs"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// setNetworkPolicyACLMetric exports the hit counters of network policy acls on this node from the statistics
+// of br-int flows, whose cookies are recorded in a configmap by kube-ovn-controller.
+// Only the packets evaluated by the acls are counted, the packets of established connections are not.
+func (c *Controller) setNetworkPolicyACLMetric() {
+	var cookies map[string]string
+	cm, err := c.npACLCookiesLister.ConfigMaps(c.config.PodNamespace).Get(util.NetworkPolicyACLCookiesConfigMap)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get configmap %s: %v", util.NetworkPolicyACLCookiesConfigMap, err)
+			return
+		}
+	} else {
+		cookies = cm.Data
+	}
+
+	// a rule may be translated into several acls and logical flows
+	ruleStats := make(map[string]*ovsutil.FlowStats)
+	for cookie, rule := range cookies {
+		value, err := strconv.ParseUint(cookie, 0, 64)
+		if err != nil || value == 0 {
+			klog.Errorf("invalid acl cookie %q of network policy rule %s", cookie, rule)
+			continue
+		}
+		stats, err := c.ovsClient.OpenFlow.DumpAggregate("br-int", &ovsutil.MatchFlow{Cookie: value})
+		if err != nil {
+			klog.Errorf("failed to dump flows with cookie %s: %v", cookie, err)
+			continue
+		}
+		if ruleStats[rule] == nil {
+			ruleStats[rule] = &ovsutil.FlowStats{}
+		}
+		ruleStats[rule].PacketCount += stats.PacketCount
+		ruleStats[rule].ByteCount += stats.ByteCount
+	}
+
+	// the counters are updated in place and only the ones of removed rules are deleted,
+	// so that no scrape observes the metrics partially exported
+	hostname := os.Getenv(util.HostnameEnv)
+	labels := make(map[string][]string, len(ruleStats))
+	for rule, stats := range ruleStats {
+		// namespace/policy/direction/index
+		fields := strings.SplitN(rule, "/", 4)
+		if len(fields) != 4 {
+			klog.Errorf("invalid network policy rule %q", rule)
+			continue
+		}
+		labelValues := append([]string{hostname}, fields...)
+		metricNetworkPolicyACLHitPackets.WithLabelValues(labelValues...).Set(float64(stats.PacketCount))
+		metricNetworkPolicyACLHitBytes.WithLabelValues(labelValues...).Set(float64(stats.ByteCount))
+		labels[rule] = labelValues
+	}
+	for rule, labelValues := range c.npACLMetricLabels {
+		if _, ok := labels[rule]; !ok {
+			metricNetworkPolicyACLHitPackets.DeleteLabelValues(labelValues...)
+			metricNetworkPolicyACLHitBytes.DeleteLabelValues(labelValues...)
+		}
+	}
+	c.npACLMetricLabels = labels
+}
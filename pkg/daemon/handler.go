@@ -15,6 +15,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
@@ -33,6 +34,8 @@ const (
 	gatewayCheckModeArpingNotConcerned
 )
 
+const slaacAddressTimeout = 30 * time.Second
+
 type cniServerHandler struct {
 	Config        *Configuration
 	KubeClient    kubernetes.Interface
@@ -90,8 +93,9 @@ func (csh cniServerHandler) handleAdd(req *restful.Request, resp *restful.Respon
 
 	var gatewayCheckMode int
 	var macAddr, ip, ipAddr, cidr, gw, subnet, ingress, egress, providerNetwork, ifName, nicType, podNicName, vmName, latency, limit, loss, jitter, u2oInterconnectionIP string
+	var ipv6AddressMode, nicCIDR, nicGw string
 	var routes []request.Route
-	var isDefaultRoute bool
+	var isDefaultRoute, policyRouting bool
 	var pod *v1.Pod
 	var err error
 	for i := 0; i < 20; i++ {
@@ -130,7 +134,21 @@ func (csh cniServerHandler) handleAdd(req *restful.Request, resp *restful.Respon
 		jitter = pod.Annotations[fmt.Sprintf(util.NetemQosJitterAnnotationTemplate, podRequest.Provider)]
 		providerNetwork = pod.Annotations[fmt.Sprintf(util.ProviderNetworkTemplate, podRequest.Provider)]
 		vmName = pod.Annotations[fmt.Sprintf(util.VMAnnotationTemplate, podRequest.Provider)]
-		ipAddr, err = util.GetIPAddrWithMask(ip, cidr)
+		if podRequest.Provider == util.OvnProvider && pod.Annotations[util.IPHandoffPortAnnotation] != "" {
+			// the pod takes over the logical switch port of another pod by an ip handoff
+			vmName = pod.Annotations[util.IPHandoffPortAnnotation]
+		}
+		nicCIDR, nicGw = cidr, gw
+		if ipv6AddressMode = csh.subnetIPv6AddressMode(subnet); ipv6AddressMode != "" {
+			if _, v6IP := util.SplitStringIP(ip); v6IP == "" {
+				// the ipv6 address has not been learned from the upstream router
+				nicCIDR, _ = util.SplitStringIP(cidr)
+				nicGw, _ = util.SplitStringIP(gw)
+			}
+		}
+		if ipAddr = ""; nicCIDR != "" {
+			ipAddr, err = util.GetIPAddrWithMask(ip, nicCIDR)
+		}
 		if err != nil {
 			errMsg := fmt.Errorf("failed to get ip address with mask, %v", err)
 			klog.Error(errMsg)
@@ -177,6 +195,15 @@ func (csh cniServerHandler) handleAdd(req *restful.Request, resp *restful.Respon
 		default:
 			isDefaultRoute = ifName == "eth0"
 		}
+		// the replies of the attachments without the default route follow the requests by default
+		switch pod.Annotations[fmt.Sprintf(util.PolicyRoutingAnnotationTemplate, podRequest.Provider)] {
+		case "true":
+			policyRouting = true
+		case "false":
+			policyRouting = false
+		default:
+			policyRouting = !isDefaultRoute
+		}
 
 		if isDefaultRoute && pod.Annotations[fmt.Sprintf(util.RoutedAnnotationTemplate, podRequest.Provider)] != "true" && strings.HasSuffix(podRequest.Provider, util.OvnProvider) {
 			klog.Infof("wait route ready for pod %s/%s provider %s", podRequest.PodNamespace, podRequest.PodName, podRequest.Provider)
@@ -294,17 +321,32 @@ func (csh cniServerHandler) handleAdd(req *restful.Request, resp *restful.Respon
 			}
 		}
 
+		if nicGw == "" {
+			gatewayCheckMode = gatewayModeDisabled
+		}
+		if gatewayCheckMode == gatewayCheckModeArping {
+			if gwErr := csh.Controller.underlayGatewayError(podSubnet.Name); gwErr != "" {
+				errMsg := fmt.Errorf("%s on node %s", gwErr, csh.Config.NodeName)
+				klog.Error(errMsg)
+				csh.Controller.recorder.Eventf(pod, v1.EventTypeWarning, "UnderlayGatewayUnreachable", errMsg.Error())
+				if err = resp.WriteHeaderAndEntity(http.StatusInternalServerError, request.CniResponse{Err: errMsg.Error()}); err != nil {
+					klog.Errorf("failed to write response: %v", err)
+				}
+				return
+			}
+		}
+
 		macAddr = pod.Annotations[fmt.Sprintf(util.MacAddressAnnotationTemplate, podRequest.Provider)]
 		klog.Infof("create container interface %s mac %s, ip %s, cidr %s, gw %s, custom routes %v", ifName, macAddr, ipAddr, cidr, gw, routes)
 		podNicName = ifName
 		switch nicType {
 		case util.InternalType:
-			podNicName, routes, err = csh.configureNicWithInternalPort(podRequest.PodName, podRequest.PodNamespace, podRequest.Provider, podRequest.NetNs, podRequest.ContainerID, ifName, macAddr, mtu, ipAddr, gw, isDefaultRoute, detectIPConflict, routes, podRequest.DNS.Nameservers, podRequest.DNS.Search, ingress, egress, podRequest.DeviceID, nicType, latency, limit, loss, jitter, gatewayCheckMode, u2oInterconnectionIP)
+			podNicName, routes, err = csh.configureNicWithInternalPort(podRequest.PodName, podRequest.PodNamespace, podRequest.Provider, podRequest.NetNs, podRequest.ContainerID, ifName, macAddr, mtu, ipAddr, nicGw, isDefaultRoute, detectIPConflict, policyRouting, routes, podRequest.DNS.Nameservers, podRequest.DNS.Search, ingress, egress, podRequest.DeviceID, nicType, latency, limit, loss, jitter, gatewayCheckMode, u2oInterconnectionIP, ipv6AddressMode)
 		case util.DpdkType:
 			err = csh.configureDpdkNic(podRequest.PodName, podRequest.PodNamespace, podRequest.Provider, podRequest.NetNs, podRequest.ContainerID, ifName, macAddr, mtu, ipAddr, gw, ingress, egress, getShortSharedDir(pod.UID, podRequest.VhostUserSocketVolumeName), podRequest.VhostUserSocketName)
 			routes = nil
 		default:
-			routes, err = csh.configureNic(podRequest.PodName, podRequest.PodNamespace, podRequest.Provider, podRequest.NetNs, podRequest.ContainerID, podRequest.VfDriver, ifName, macAddr, mtu, ipAddr, gw, isDefaultRoute, detectIPConflict, routes, podRequest.DNS.Nameservers, podRequest.DNS.Search, ingress, egress, podRequest.DeviceID, nicType, latency, limit, loss, jitter, gatewayCheckMode, u2oInterconnectionIP)
+			routes, err = csh.configureNic(podRequest.PodName, podRequest.PodNamespace, podRequest.Provider, podRequest.NetNs, podRequest.ContainerID, podRequest.VfDriver, ifName, macAddr, mtu, ipAddr, nicGw, isDefaultRoute, detectIPConflict, policyRouting, routes, podRequest.DNS.Nameservers, podRequest.DNS.Search, ingress, egress, podRequest.DeviceID, nicType, latency, limit, loss, jitter, gatewayCheckMode, u2oInterconnectionIP, ipv6AddressMode)
 		}
 		if err != nil {
 			errMsg := fmt.Errorf("configure nic failed %v", err)
@@ -315,6 +357,18 @@ func (csh cniServerHandler) handleAdd(req *restful.Request, resp *restful.Respon
 			return
 		}
 
+		if nicCIDR != cidr {
+			if ip, err = csh.learnIPv6Address(req.Request.Context(), pod, podRequest.Provider, podRequest.NetNs, podNicName, ip, cidr); err != nil {
+				errMsg := fmt.Errorf("failed to learn ipv6 address: %v", err)
+				klog.Error(errMsg)
+				if err = resp.WriteHeaderAndEntity(http.StatusInternalServerError, request.CniResponse{Err: errMsg.Error()}); err != nil {
+					klog.Errorf("failed to write response, %v", err)
+				}
+				return
+			}
+			nicCIDR, nicGw = cidr, gw
+		}
+
 		ifaceID := ovs.PodNameToPortName(podRequest.PodName, podRequest.PodNamespace, podRequest.Provider)
 		if err = ovs.ConfigInterfaceMirror(csh.Config.EnableMirror, pod.Annotations[fmt.Sprintf(util.MirrorControlAnnotationTemplate, podRequest.Provider)], ifaceID); err != nil {
 			klog.Errorf("failed mirror to mirror0, %v", err)
@@ -356,21 +410,67 @@ func (csh cniServerHandler) handleAdd(req *restful.Request, resp *restful.Respon
 	}
 
 	response := &request.CniResponse{
-		Protocol:   util.CheckProtocol(cidr),
+		Protocol:   util.CheckProtocol(nicCIDR),
 		IPAddress:  ip,
 		MacAddress: macAddr,
-		CIDR:       cidr,
+		CIDR:       nicCIDR,
 		PodNicName: podNicName,
 		Routes:     routes,
 	}
 	if isDefaultRoute {
-		response.Gateway = gw
+		response.Gateway = nicGw
 	}
 	if err := resp.WriteHeaderAndEntity(http.StatusOK, response); err != nil {
 		klog.Errorf("failed to write response, %v", err)
 	}
 }
 
+// subnetIPv6AddressMode returns the ipv6 address mode of the subnet
+func (csh cniServerHandler) subnetIPv6AddressMode(name string) string {
+	if name == "" {
+		return ""
+	}
+	subnet, err := csh.Controller.subnetsLister.Get(name)
+	if err != nil {
+		return ""
+	}
+	return subnet.Spec.IPv6AddressMode
+}
+
+// learnIPv6Address waits for the ipv6 address configured via slaac and records it into the pod annotation,
+// which is synchronized into ipam and the ip CR by kube-ovn-controller.
+// The wait is canceled with the cni request, and the pod recreated with the same name is not patched.
+func (csh cniServerHandler) learnIPv6Address(ctx context.Context, pod *v1.Pod, provider, netns, nicName, ip, cidr string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, slaacAddressTimeout)
+	defer cancel()
+
+	_, v6CIDR := util.SplitStringIP(cidr)
+	v6IP, err := waitIPv6Address(ctx, netns, nicName, v6CIDR)
+	if err != nil {
+		klog.Error(err)
+		return "", err
+	}
+	v4IP, _ := util.SplitStringIP(ip)
+	ipStr := util.GetStringIP(v4IP, v6IP)
+	patch := map[string]any{
+		"metadata": map[string]any{
+			"uid":         pod.UID,
+			"annotations": map[string]string{fmt.Sprintf(util.IPAddressAnnotationTemplate, provider): ipStr},
+		},
+	}
+	patchData, err := json.Marshal(patch)
+	if err != nil {
+		klog.Error(err)
+		return "", err
+	}
+	if _, err = csh.KubeClient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patchData, metav1.PatchOptions{}); err != nil {
+		klog.Errorf("failed to patch annotations of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return "", err
+	}
+	klog.Infof("learned ipv6 address %s for pod %s/%s provider %s", v6IP, pod.Namespace, pod.Name, provider)
+	return ipStr, nil
+}
+
 func (csh cniServerHandler) UpdateIPCr(podRequest request.CniRequest, subnet, ip string) error {
 	ipCrName := ovs.PodNameToPortName(podRequest.PodName, podRequest.PodNamespace, podRequest.Provider)
 	for i := 0; i < 20; i++ {
@@ -471,6 +571,9 @@ func (csh cniServerHandler) handleDel(req *restful.Request, resp *restful.Respon
 			nicType = pod.Annotations[fmt.Sprintf(util.PodNicAnnotationTemplate, podRequest.Provider)]
 		}
 		vmName := pod.Annotations[fmt.Sprintf(util.VMAnnotationTemplate, podRequest.Provider)]
+		if podRequest.Provider == util.OvnProvider && pod.Annotations[util.IPHandoffPortAnnotation] != "" {
+			vmName = pod.Annotations[util.IPHandoffPortAnnotation]
+		}
 		if vmName != "" {
 			podRequest.PodName = vmName
 		}
@@ -68,6 +68,28 @@ var (
 		},
 	)
 
+	metricNetworkPolicyACLHitPackets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "network_policy_acl_hit_packets",
+		Help: "the packets matched by the acls of a network policy rule on the node, reset when the flows are recreated",
+	}, []string{
+		"hostname",
+		"namespace",
+		"policy",
+		"direction",
+		"rule",
+	})
+
+	metricNetworkPolicyACLHitBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "network_policy_acl_hit_bytes",
+		Help: "the bytes matched by the acls of a network policy rule on the node, reset when the flows are recreated",
+	}, []string{
+		"hostname",
+		"namespace",
+		"policy",
+		"direction",
+		"rule",
+	})
+
 	metricIPLocalPortRange = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "ip_local_port_range",
 		Help: "value of system parameter /proc/sys/net/ipv4/ip_local_port_range, which should not conflict with the nodeport range",
@@ -150,6 +172,7 @@ var (
 func InitMetrics() {
 	registerOvnSubnetGatewayMetrics()
 	registerSystemParameterMetrics()
+	registerNetworkPolicyMetrics()
 	metrics.Registry.MustRegister(cniOperationHistogram)
 	metrics.Registry.MustRegister(cniWaitAddressResult)
 	metrics.Registry.MustRegister(cniConnectivityResult)
@@ -160,6 +183,11 @@ func registerOvnSubnetGatewayMetrics() {
 	metrics.Registry.MustRegister(metricOvnSubnetGatewayPackets)
 }
 
+func registerNetworkPolicyMetrics() {
+	metrics.Registry.MustRegister(metricNetworkPolicyACLHitPackets)
+	metrics.Registry.MustRegister(metricNetworkPolicyACLHitBytes)
+}
+
 func registerSystemParameterMetrics() {
 	metrics.Registry.MustRegister(metricIPLocalPortRange)
 	metrics.Registry.MustRegister(metricCheckSumErr)
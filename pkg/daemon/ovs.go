@@ -15,6 +15,12 @@ import (
 
 const gatewayCheckMaxRetry = 200
 
+const (
+	// the route table of a pod interface with policy routing enabled is the sum of the base and the link index
+	podPolicyRoutingTableBase = 1000
+	podPolicyRoutingPriority  = 1000
+)
+
 func pingGateway(gw, src string, verbose bool, maxRetry int) (count int, err error) {
 	pinger, err := goping.NewPinger(gw)
 	if err != nil {
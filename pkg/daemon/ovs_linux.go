@@ -61,7 +61,7 @@ func (csh cniServerHandler) configureDpdkNic(podName, podNamespace, provider, ne
 	return ovs.SetInterfaceBandwidth(podName, podNamespace, ifaceID, egress, ingress)
 }
 
-func (csh cniServerHandler) configureNic(podName, podNamespace, provider, netns, containerID, vfDriver, ifName, mac string, mtu int, ip, gateway string, isDefaultRoute, detectIPConflict bool, routes []request.Route, _, _ []string, ingress, egress, deviceID, nicType, latency, limit, loss, jitter string, gwCheckMode int, u2oInterconnectionIP string) ([]request.Route, error) {
+func (csh cniServerHandler) configureNic(podName, podNamespace, provider, netns, containerID, vfDriver, ifName, mac string, mtu int, ip, gateway string, isDefaultRoute, detectIPConflict, policyRouting bool, routes []request.Route, _, _ []string, ingress, egress, deviceID, nicType, latency, limit, loss, jitter string, gwCheckMode int, u2oInterconnectionIP, ipv6AddressMode string) ([]request.Route, error) {
 	var err error
 	var hostNicName, containerNicName string
 	if deviceID == "" {
@@ -127,7 +127,7 @@ func (csh cniServerHandler) configureNic(podName, podNamespace, provider, netns,
 	if err != nil {
 		return nil, fmt.Errorf("failed to open netns %q: %v", netns, err)
 	}
-	return configureContainerNic(containerNicName, ifName, ip, gateway, isDefaultRoute, detectIPConflict, routes, macAddr, podNS, mtu, nicType, gwCheckMode, u2oInterconnectionIP)
+	return configureContainerNic(containerNicName, ifName, ip, gateway, isDefaultRoute, detectIPConflict, policyRouting, routes, macAddr, podNS, mtu, nicType, gwCheckMode, u2oInterconnectionIP, ipv6AddressMode)
 }
 
 func (csh cniServerHandler) deleteNic(podName, podNamespace, containerID, _, deviceID, ifName, nicType string) error {
@@ -215,7 +215,7 @@ func configureHostNic(nicName string) error {
 	return nil
 }
 
-func configureContainerNic(nicName, ifName, ipAddr, gateway string, isDefaultRoute, detectIPConflict bool, routes []request.Route, macAddr net.HardwareAddr, netns ns.NetNS, mtu int, nicType string, gwCheckMode int, u2oInterconnectionIP string) ([]request.Route, error) {
+func configureContainerNic(nicName, ifName, ipAddr, gateway string, isDefaultRoute, detectIPConflict, policyRouting bool, routes []request.Route, macAddr net.HardwareAddr, netns ns.NetNS, mtu int, nicType string, gwCheckMode int, u2oInterconnectionIP, ipv6AddressMode string) ([]request.Route, error) {
 	containerLink, err := netlink.LinkByName(nicName)
 	if err != nil {
 		return nil, fmt.Errorf("can not find container nic %s: %v", nicName, err)
@@ -239,7 +239,7 @@ func configureContainerNic(nicName, ifName, ipAddr, gateway string, isDefaultRou
 			}
 		}
 
-		if util.CheckProtocol(ipAddr) == kubeovnv1.ProtocolDual || util.CheckProtocol(ipAddr) == kubeovnv1.ProtocolIPv6 {
+		if ipv6AddressMode != "" || util.CheckProtocol(ipAddr) == kubeovnv1.ProtocolDual || util.CheckProtocol(ipAddr) == kubeovnv1.ProtocolIPv6 {
 			// For docker version >=17.x the "none" network will disable ipv6 by default.
 			// We have to enable ipv6 here to add v6 address and gateway.
 			// See https://github.com/containernetworking/cni/issues/531
@@ -254,6 +254,16 @@ func configureContainerNic(nicName, ifName, ipAddr, gateway string, isDefaultRou
 			}
 		}
 
+		if ipv6AddressMode != "" {
+			interfaceName := ifName
+			if nicType == util.InternalType {
+				interfaceName = nicName
+			}
+			if err = configureIPv6AddressMode(interfaceName); err != nil {
+				return err
+			}
+		}
+
 		if nicType == util.InternalType {
 			if err = addAdditionalNic(ifName); err != nil {
 				return err
@@ -270,13 +280,13 @@ func configureContainerNic(nicName, ifName, ipAddr, gateway string, isDefaultRou
 			}
 		}
 
-		if isDefaultRoute {
+		containerGw := gateway
+		if u2oInterconnectionIP != "" {
+			containerGw = u2oInterconnectionIP
+		}
+		// the ipv6 default route is learned from the router advertisements if the ipv6 address is not assigned yet
+		if isDefaultRoute && containerGw != "" {
 			// Only eth0 requires the default route and gateway
-			containerGw := gateway
-			if u2oInterconnectionIP != "" {
-				containerGw = u2oInterconnectionIP
-			}
-
 			for _, gw := range strings.Split(containerGw, ",") {
 				if err = netlink.RouteReplace(&netlink.Route{
 					LinkIndex: containerLink.Attrs().Index,
@@ -315,6 +325,13 @@ func configureContainerNic(nicName, ifName, ipAddr, gateway string, isDefaultRou
 			}
 		}
 
+		if policyRouting {
+			// let the replies leave through the interface the requests came in on
+			if err = configurePolicyRouting(containerLink, ipAddr, containerGw, routes); err != nil {
+				return err
+			}
+		}
+
 		linkRoutes, err := netlink.RouteList(containerLink, netlink.FAMILY_ALL)
 		if err != nil {
 			return fmt.Errorf("failed to get routes on interface %s: %v", ifName, err)
@@ -368,6 +385,137 @@ func configureContainerNic(nicName, ifName, ipAddr, gateway string, isDefaultRou
 	return finalRoutes, err
 }
 
+// configurePolicyRouting installs the routes of the interface into a dedicated route table,
+// and adds rules to look up the table for the packets from the addresses of the interface
+func configurePolicyRouting(link netlink.Link, ipAddr, gateway string, routes []request.Route) error {
+	table := podPolicyRoutingTableBase + link.Attrs().Index
+	for _, addr := range strings.Split(ipAddr, ",") {
+		if addr == "" {
+			continue
+		}
+		ip, cidr, err := net.ParseCIDR(addr)
+		if err != nil {
+			return fmt.Errorf("invalid address %s: %v", addr, err)
+		}
+		protocol := util.CheckProtocol(addr)
+		family, _ := util.ProtocolToFamily(protocol)
+
+		if err = netlink.RouteReplace(&netlink.Route{
+			LinkIndex: link.Attrs().Index,
+			Dst:       cidr,
+			Scope:     netlink.SCOPE_LINK,
+			Table:     table,
+		}); err != nil {
+			return fmt.Errorf("failed to add route %s to table %d: %v", cidr, table, err)
+		}
+		for _, gw := range strings.Split(gateway, ",") {
+			if gw == "" || util.CheckProtocol(gw) != protocol {
+				continue
+			}
+			if err = netlink.RouteReplace(&netlink.Route{
+				LinkIndex: link.Attrs().Index,
+				Scope:     netlink.SCOPE_UNIVERSE,
+				Gw:        net.ParseIP(gw),
+				Table:     table,
+			}); err != nil {
+				return fmt.Errorf("failed to add default route via %s to table %d: %v", gw, table, err)
+			}
+		}
+
+		maskBits := 32
+		if family == netlink.FAMILY_V6 {
+			maskBits = 128
+		}
+		rule := netlink.NewRule()
+		rule.Family = family
+		rule.Table = table
+		rule.Priority = podPolicyRoutingPriority
+		rule.Src = &net.IPNet{IP: ip, Mask: net.CIDRMask(maskBits, maskBits)}
+		if err = netlink.RuleAdd(rule); err != nil && !errors.Is(err, syscall.EEXIST) {
+			return fmt.Errorf("failed to add rule from %s lookup table %d: %v", ip, table, err)
+		}
+	}
+
+	for _, r := range routes {
+		var dst *net.IPNet
+		var err error
+		if r.Destination != "" {
+			if _, dst, err = net.ParseCIDR(r.Destination); err != nil {
+				return fmt.Errorf("invalid route destination %s: %v", r.Destination, err)
+			}
+		}
+		route := &netlink.Route{
+			Dst:       dst,
+			Gw:        net.ParseIP(r.Gateway),
+			LinkIndex: link.Attrs().Index,
+			Table:     table,
+		}
+		if err = netlink.RouteReplace(route); err != nil {
+			return fmt.Errorf("failed to add route %+v to table %d: %v", r, table, err)
+		}
+	}
+	return nil
+}
+
+// configureIPv6AddressMode lets the nic accept the router advertisements from the upstream router
+// and configure its ipv6 address via slaac. The addresses are generated from the mac address,
+// so that the link local address is allowed by the port security before the address is learned.
+func configureIPv6AddressMode(nicName string) error {
+	for _, kv := range [][2]string{
+		{fmt.Sprintf("net.ipv6.conf.%s.disable_ipv6", nicName), "0"},
+		{fmt.Sprintf("net.ipv6.conf.%s.addr_gen_mode", nicName), "0"},
+		{fmt.Sprintf("net.ipv6.conf.%s.accept_ra", nicName), "1"},
+		{fmt.Sprintf("net.ipv6.conf.%s.autoconf", nicName), "1"},
+	} {
+		if _, err := sysctl.Sysctl(kv[0], kv[1]); err != nil {
+			return fmt.Errorf("failed to set sysctl %s to %s: %v", kv[0], kv[1], err)
+		}
+	}
+	return nil
+}
+
+// waitIPv6Address waits for an ipv6 address in the cidr to be assigned to the nic in the netns and pass DAD,
+// until the context is done
+func waitIPv6Address(ctx context.Context, netns, nicName, cidr string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", fmt.Errorf("invalid cidr %s: %v", cidr, err)
+	}
+
+	var address string
+	for {
+		err = ns.WithNetNSPath(netns, func(_ ns.NetNS) error {
+			link, err := netlink.LinkByName(nicName)
+			if err != nil {
+				return fmt.Errorf("can not find nic %s: %v", nicName, err)
+			}
+			addrs, err := netlink.AddrList(link, netlink.FAMILY_V6)
+			if err != nil {
+				return fmt.Errorf("can not get addresses of nic %s: %v", nicName, err)
+			}
+			for _, addr := range addrs {
+				if addr.Flags&(unix.IFA_F_TENTATIVE|unix.IFA_F_DADFAILED) == 0 && addr.IP.IsGlobalUnicast() && ipNet.Contains(addr.IP) {
+					address = addr.IP.String()
+					break
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			klog.Error(err)
+			return "", err
+		}
+		if address != "" {
+			return address, nil
+		}
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("no ipv6 address in %s is assigned to nic %s: %w", cidr, nicName, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
 func checkGatewayReady(gwCheckMode int, intr, ipAddr, gateway string, underlayGateway, verbose bool) error {
 	var err error
 
@@ -957,6 +1105,10 @@ func configureNic(link, ip string, macAddr net.HardwareAddr, mtu int, detectIPCo
 	}
 
 	for _, ipStr := range strings.Split(ip, ",") {
+		if ipStr == "" {
+			// the ipv6 address is learned from the upstream router
+			continue
+		}
 		// Do not reassign same address for link
 		if _, ok := ipDelMap[ipStr]; ok {
 			delete(ipDelMap, ipStr)
@@ -1451,7 +1603,7 @@ func renameLink(curName, newName string) error {
 	return netlink.LinkSetUp(link)
 }
 
-func (csh cniServerHandler) configureNicWithInternalPort(podName, podNamespace, provider, netns, containerID, ifName, mac string, mtu int, ip, gateway string, isDefaultRoute, detectIPConflict bool, routes []request.Route, _, _ []string, ingress, egress, _, nicType, latency, limit, loss, jitter string, gwCheckMode int, u2oInterconnectionIP string) (string, []request.Route, error) {
+func (csh cniServerHandler) configureNicWithInternalPort(podName, podNamespace, provider, netns, containerID, ifName, mac string, mtu int, ip, gateway string, isDefaultRoute, detectIPConflict, policyRouting bool, routes []request.Route, _, _ []string, ingress, egress, _, nicType, latency, limit, loss, jitter string, gwCheckMode int, u2oInterconnectionIP, ipv6AddressMode string) (string, []request.Route, error) {
 	_, containerNicName := generateNicName(containerID, ifName)
 	ipStr := util.GetIPWithoutMask(ip)
 	ifaceID := ovs.PodNameToPortName(podName, podNamespace, provider)
@@ -1488,7 +1640,7 @@ func (csh cniServerHandler) configureNicWithInternalPort(podName, podNamespace,
 	if err != nil {
 		return containerNicName, nil, fmt.Errorf("failed to open netns %q: %v", netns, err)
 	}
-	routes, err = configureContainerNic(containerNicName, ifName, ip, gateway, isDefaultRoute, detectIPConflict, routes, macAddr, podNS, mtu, nicType, gwCheckMode, u2oInterconnectionIP)
+	routes, err = configureContainerNic(containerNicName, ifName, ip, gateway, isDefaultRoute, detectIPConflict, policyRouting, routes, macAddr, podNS, mtu, nicType, gwCheckMode, u2oInterconnectionIP, ipv6AddressMode)
 	return containerNicName, routes, err
 }
 
@@ -0,0 +1,150 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/kubeovn/kube-ovn/pkg/request"
+)
+
+func TestConfigurePolicyRouting(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("creating network namespaces requires root privileges")
+	}
+
+	podNS, err := testutils.NewNS()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, podNS.Close())
+		require.NoError(t, testutils.UnmountNS(podNS))
+	}()
+
+	var table int
+	var tableRoutes []netlink.Route
+	var rules []netlink.Rule
+	var configErr, reconfigErr, unreachableErr, invalidErr error
+	routes := []request.Route{{Destination: "192.168.0.0/16", Gateway: "10.10.0.254"}}
+	err = podNS.Do(func(ns.NetNS) error {
+		if err := netlink.LinkAdd(&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "net1"}}); err != nil {
+			return err
+		}
+		link, err := netlink.LinkByName("net1")
+		if err != nil {
+			return err
+		}
+		if err = netlink.LinkSetUp(link); err != nil {
+			return err
+		}
+		addr, err := netlink.ParseAddr("10.10.0.2/24")
+		if err != nil {
+			return err
+		}
+		if err = netlink.AddrAdd(link, addr); err != nil {
+			return err
+		}
+
+		configErr = configurePolicyRouting(link, "10.10.0.2/24", "10.10.0.1", routes)
+		reconfigErr = configurePolicyRouting(link, "10.10.0.2/24", "10.10.0.1", routes)
+		unreachableErr = configurePolicyRouting(link, "10.10.0.2/24", "10.10.0.1", []request.Route{{Destination: "172.16.0.0/16", Gateway: "10.20.0.1"}})
+		invalidErr = configurePolicyRouting(link, "10.10.0.2/24", "10.10.0.1", []request.Route{{Destination: "172.16.0.0/33"}})
+
+		table = podPolicyRoutingTableBase + link.Attrs().Index
+		if tableRoutes, err = netlink.RouteListFiltered(netlink.FAMILY_V4, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE); err != nil {
+			return err
+		}
+		rules, err = netlink.RuleList(netlink.FAMILY_V4)
+		return err
+	})
+	require.NoError(t, err)
+	require.NoError(t, configErr)
+	require.NoError(t, reconfigErr)
+	require.Error(t, unreachableErr)
+	require.Error(t, invalidErr)
+
+	gateways := make(map[string]string, len(tableRoutes))
+	for _, r := range tableRoutes {
+		// the default route may be listed without the destination
+		dst := "0.0.0.0/0"
+		if r.Dst != nil {
+			dst = r.Dst.String()
+		}
+		gateways[dst] = ""
+		if r.Gw != nil {
+			gateways[dst] = r.Gw.String()
+		}
+	}
+	require.Equal(t, map[string]string{"0.0.0.0/0": "10.10.0.1", "10.10.0.0/24": "", "192.168.0.0/16": "10.10.0.254"}, gateways)
+
+	var tableRules []netlink.Rule
+	for _, rule := range rules {
+		if rule.Table == table {
+			tableRules = append(tableRules, rule)
+		}
+	}
+	require.Len(t, tableRules, 1)
+	require.Equal(t, podPolicyRoutingPriority, tableRules[0].Priority)
+	require.Equal(t, (&net.IPNet{IP: net.ParseIP("10.10.0.2").To4(), Mask: net.CIDRMask(32, 32)}).String(), tableRules[0].Src.String())
+}
+
+func TestWaitIPv6Address(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("creating network namespaces requires root privileges")
+	}
+
+	podNS, err := testutils.NewNS()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, podNS.Close())
+		require.NoError(t, testutils.UnmountNS(podNS))
+	}()
+
+	err = podNS.Do(func(ns.NetNS) error {
+		if err := netlink.LinkAdd(&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "net1"}}); err != nil {
+			return err
+		}
+		link, err := netlink.LinkByName("net1")
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(link)
+	})
+	require.NoError(t, err)
+
+	// canceled without the address learned
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = waitIPv6Address(ctx, podNS.Path(), "net1", "fd00::/64")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = podNS.Do(func(ns.NetNS) error {
+		link, err := netlink.LinkByName("net1")
+		if err != nil {
+			return err
+		}
+		for _, s := range []string{"fd01::200:ff:fe00:1/64", "fd00::200:ff:fe00:1/64"} {
+			addr, err := netlink.ParseAddr(s)
+			if err != nil {
+				return err
+			}
+			addr.Flags = unix.IFA_F_NODAD
+			if err = netlink.AddrAdd(link, addr); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	address, err := waitIPv6Address(context.Background(), podNS.Path(), "net1", "fd00::/64")
+	require.NoError(t, err)
+	require.Equal(t, "fd00::200:ff:fe00:1", address)
+}
@@ -0,0 +1,155 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// underlayGatewayFailureThreshold is the number of consecutive failed probes
+// before the gateway of an underlay subnet is reported unreachable
+const underlayGatewayFailureThreshold = 3
+
+// checkUnderlayGateways probes the gateways of the underlay subnets on the provider network bridges,
+// and records the reachability of the gateways on the node in the conditions of the provider networks
+func (c *Controller) checkUnderlayGateways() {
+	node, err := c.nodesLister.Get(c.config.NodeName)
+	if err != nil {
+		klog.Errorf("failed to get node %s: %v", c.config.NodeName, err)
+		return
+	}
+	subnets, err := c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list subnets: %v", err)
+		return
+	}
+
+	probeErrors := make(map[string]string)
+	providerSubnets := make(map[string][]string)
+	for _, subnet := range subnets {
+		if subnet.Spec.Vlan == "" || subnet.Spec.LogicalGateway || subnet.Spec.DisableGatewayCheck {
+			continue
+		}
+		if subnet.Spec.Gateway == "" {
+			continue
+		}
+		vlan, err := c.vlansLister.Get(subnet.Spec.Vlan)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				klog.Errorf("failed to get vlan %s: %v", subnet.Spec.Vlan, err)
+			}
+			continue
+		}
+		// the provider network is excluded or not initialized on the node
+		if node.Labels[fmt.Sprintf(util.ProviderNetworkReadyTemplate, vlan.Spec.Provider)] != "true" {
+			continue
+		}
+
+		bridge := util.ExternalBridgeName(vlan.Spec.Provider)
+		var messages []string
+		for _, gateway := range strings.Split(subnet.Spec.Gateway, ",") {
+			if err = probeUnderlayGateway(bridge, vlan.Spec.ID, gateway); err != nil {
+				if errors.Is(err, errors.ErrUnsupported) {
+					return
+				}
+				messages = append(messages, fmt.Sprintf("gateway %s of subnet %s is unreachable from bridge %s: %v", gateway, subnet.Name, bridge, err))
+				klog.Warning(messages[len(messages)-1])
+			}
+		}
+		probeErrors[subnet.Name] = strings.Join(messages, "; ")
+		providerSubnets[vlan.Spec.Provider] = append(providerSubnets[vlan.Spec.Provider], subnet.Name)
+	}
+
+	gatewayErrors := c.setUnderlayGatewayErrors(probeErrors)
+
+	pns, err := c.providerNetworksLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list provider networks: %v", err)
+		return
+	}
+	for _, pn := range pns {
+		if err = c.updateProviderNetworkGatewayCondition(pn, providerSubnets[pn.Name], gatewayErrors); err != nil {
+			klog.Errorf("failed to update gateway condition of provider network %s: %v", pn.Name, err)
+		}
+	}
+}
+
+// setUnderlayGatewayErrors records the errors of the probes of the underlay subnet gateways,
+// and returns the errors of the gateways failed in underlayGatewayFailureThreshold consecutive probes
+func (c *Controller) setUnderlayGatewayErrors(probeErrors map[string]string) map[string]string {
+	c.underlayGatewayMutex.Lock()
+	defer c.underlayGatewayMutex.Unlock()
+
+	failures := make(map[string]int, len(probeErrors))
+	gatewayErrors := make(map[string]string, len(probeErrors))
+	for subnet, probeErr := range probeErrors {
+		if probeErr == "" {
+			gatewayErrors[subnet] = ""
+			continue
+		}
+		failures[subnet] = c.underlayGatewayFailures[subnet] + 1
+		if failures[subnet] >= underlayGatewayFailureThreshold {
+			gatewayErrors[subnet] = probeErr
+		} else {
+			klog.Infof("%d consecutive probes of the gateways of subnet %s failed", failures[subnet], subnet)
+			gatewayErrors[subnet] = ""
+		}
+	}
+	c.underlayGatewayFailures = failures
+	c.underlayGatewayErrors = gatewayErrors
+	return gatewayErrors
+}
+
+// underlayGatewayError returns the error of the gateway of the underlay subnet on the node,
+// or an empty string if the gateway is reachable, has not been probed yet
+// or has not failed in underlayGatewayFailureThreshold consecutive probes
+func (c *Controller) underlayGatewayError(subnet string) string {
+	c.underlayGatewayMutex.RLock()
+	defer c.underlayGatewayMutex.RUnlock()
+	return c.underlayGatewayErrors[subnet]
+}
+
+func (c *Controller) updateProviderNetworkGatewayCondition(cachedPn *kubeovnv1.ProviderNetwork, subnets []string, gatewayErrors map[string]string) error {
+	pn := cachedPn.DeepCopy()
+	nodeName := c.config.NodeName
+	if !setGatewayReachableCondition(&pn.Status, nodeName, subnets, gatewayErrors) {
+		return nil
+	}
+	_, err := c.config.KubeOvnClient.KubeovnV1().ProviderNetworks().UpdateStatus(context.Background(), pn, metav1.UpdateOptions{})
+	return err
+}
+
+// setGatewayReachableCondition sets the GatewayReachable condition of the node to the probe results
+// of the subnet gateways, and removes the condition if no gateway is probed on the node
+func setGatewayReachableCondition(status *kubeovnv1.ProviderNetworkStatus, node string, subnets []string, gatewayErrors map[string]string) bool {
+	if len(subnets) == 0 {
+		if status.GetNodeCondition(node, kubeovnv1.GatewayReachable) == nil {
+			return false
+		}
+		status.RemoveNodeCondition(node, kubeovnv1.GatewayReachable)
+		return true
+	}
+
+	sort.Strings(subnets)
+	var messages []string
+	for _, subnet := range subnets {
+		if gatewayErrors[subnet] != "" {
+			messages = append(messages, gatewayErrors[subnet])
+		}
+	}
+	if len(messages) != 0 {
+		return status.ClearNodeCondition(node, kubeovnv1.GatewayReachable, "GatewayUnreachable", strings.Join(messages, "; "))
+	}
+	return status.SetNodeCondition(node, kubeovnv1.GatewayReachable, "GatewayReachable",
+		fmt.Sprintf("gateways of subnets %s are reachable", strings.Join(subnets, ", ")))
+}
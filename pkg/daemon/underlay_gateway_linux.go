@@ -0,0 +1,23 @@
+package daemon
+
+import (
+	"time"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const (
+	underlayGatewayProbeTimeout  = time.Second
+	underlayGatewayProbeMaxRetry = 3
+)
+
+func probeUnderlayGateway(bridge string, vlanID int, gateway string) error {
+	var err error
+	if util.CheckProtocol(gateway) == kubeovnv1.ProtocolIPv6 {
+		_, _, err = util.NdpProbe(bridge, uint16(vlanID), gateway, underlayGatewayProbeTimeout, underlayGatewayProbeMaxRetry)
+	} else {
+		_, _, err = util.ArpProbe(bridge, uint16(vlanID), gateway, underlayGatewayProbeTimeout, underlayGatewayProbeMaxRetry)
+	}
+	return err
+}
@@ -0,0 +1,61 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func TestSetGatewayReachableCondition(t *testing.T) {
+	status := &kubeovnv1.ProviderNetworkStatus{}
+	require.False(t, setGatewayReachableCondition(status, "node1", nil, nil))
+	require.Empty(t, status.Conditions)
+
+	gatewayErrors := map[string]string{"vlan10": "", "vlan20": ""}
+	require.True(t, setGatewayReachableCondition(status, "node1", []string{"vlan20", "vlan10"}, gatewayErrors))
+	condition := status.GetNodeCondition("node1", kubeovnv1.GatewayReachable)
+	require.NotNil(t, condition)
+	require.Equal(t, corev1.ConditionTrue, condition.Status)
+	require.Equal(t, "gateways of subnets vlan10, vlan20 are reachable", condition.Message)
+	require.False(t, setGatewayReachableCondition(status, "node1", []string{"vlan10", "vlan20"}, gatewayErrors))
+
+	gatewayErrors["vlan20"] = "gateway 10.0.20.1 of subnet vlan20 is unreachable"
+	require.True(t, setGatewayReachableCondition(status, "node1", []string{"vlan10", "vlan20"}, gatewayErrors))
+	condition = status.GetNodeCondition("node1", kubeovnv1.GatewayReachable)
+	require.Equal(t, corev1.ConditionFalse, condition.Status)
+	require.Equal(t, "GatewayUnreachable", condition.Reason)
+	require.Equal(t, gatewayErrors["vlan20"], condition.Message)
+
+	require.True(t, setGatewayReachableCondition(status, "node2", []string{"vlan10"}, gatewayErrors))
+	require.True(t, setGatewayReachableCondition(status, "node1", nil, gatewayErrors))
+	require.Nil(t, status.GetNodeCondition("node1", kubeovnv1.GatewayReachable))
+	require.NotNil(t, status.GetNodeCondition("node2", kubeovnv1.GatewayReachable))
+}
+
+func TestSetUnderlayGatewayErrors(t *testing.T) {
+	c := &Controller{}
+	gatewayErr := "gateway 10.0.20.1 of subnet vlan20 is unreachable"
+	for i := 1; i < underlayGatewayFailureThreshold; i++ {
+		gatewayErrors := c.setUnderlayGatewayErrors(map[string]string{"vlan10": "", "vlan20": gatewayErr})
+		require.Equal(t, map[string]string{"vlan10": "", "vlan20": ""}, gatewayErrors)
+		require.Empty(t, c.underlayGatewayError("vlan20"))
+	}
+	gatewayErrors := c.setUnderlayGatewayErrors(map[string]string{"vlan10": "", "vlan20": gatewayErr})
+	require.Equal(t, map[string]string{"vlan10": "", "vlan20": gatewayErr}, gatewayErrors)
+	require.Equal(t, gatewayErr, c.underlayGatewayError("vlan20"))
+	require.Empty(t, c.underlayGatewayError("vlan10"))
+
+	// a successful probe resets the consecutive failures
+	c.setUnderlayGatewayErrors(map[string]string{"vlan20": ""})
+	require.Empty(t, c.underlayGatewayError("vlan20"))
+	c.setUnderlayGatewayErrors(map[string]string{"vlan20": gatewayErr})
+	require.Empty(t, c.underlayGatewayError("vlan20"))
+
+	// subnets no longer probed are forgotten
+	c.setUnderlayGatewayErrors(map[string]string{})
+	c.setUnderlayGatewayErrors(map[string]string{"vlan20": gatewayErr})
+	require.Empty(t, c.underlayGatewayError("vlan20"))
+}
@@ -0,0 +1,7 @@
+package daemon
+
+import "errors"
+
+func probeUnderlayGateway(_ string, _ int, _ string) error {
+	return errors.ErrUnsupported
+}
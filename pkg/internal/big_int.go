@@ -33,6 +33,11 @@ func (b BigInt) Sub(n BigInt) BigInt {
 	return BigInt{*big.NewInt(0).Sub(&b.Int, &n.Int)}
 }
 
+func (b BigInt) Float64() float64 {
+	f, _ := new(big.Float).SetInt(&b.Int).Float64()
+	return f
+}
+
 func (b BigInt) String() string {
 	return b.Int.String()
 }
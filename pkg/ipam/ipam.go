@@ -108,10 +108,10 @@ func (ipam *IPAM) GetStaticAddress(podName, nicName, ip string, mac *string, sub
 		if ips[0] != nil {
 			v4 = ips[0].String()
 		}
-		if ips[1] != nil {
+		if len(ips) > 1 && ips[1] != nil {
 			v6 = ips[1].String()
 		}
-		klog.Infof("allocate v4 %s, v6 %s, mac %s for %s from subnet %s", ips[0].String(), ips[1].String(), macStr, podName, subnetName)
+		klog.Infof("allocate v4 %s, v6 %s, mac %s for %s from subnet %s", v4, v6, macStr, podName, subnetName)
 		return v4, v6, macStr, err
 	}
 	return "", "", "", ErrNoAvailable
@@ -122,6 +122,10 @@ func checkAndAppendIpsForDual(ips []IP, mac, podName, nicName string, subnet *Su
 	if subnet.Protocol != kubeovnv1.ProtocolDual || len(ips) == 2 {
 		return ips, nil
 	}
+	if subnet.V6Learned && ips[0].To4() != nil {
+		// the ipv6 address has not been learned yet
+		return ips, nil
+	}
 
 	var newIps []IP
 	var ipAddr IP
@@ -153,6 +157,17 @@ func (ipam *IPAM) ReleaseAddressByPod(podName, subnetName string) {
 	}
 }
 
+// SetSubnetV6Learned sets whether the ipv6 addresses of the subnet are learned from the upstream router
+func (ipam *IPAM) SetSubnetV6Learned(name string, learned bool) {
+	ipam.mutex.RLock()
+	defer ipam.mutex.RUnlock()
+	if subnet, ok := ipam.Subnets[name]; ok {
+		subnet.Mutex.Lock()
+		subnet.V6Learned = learned
+		subnet.Mutex.Unlock()
+	}
+}
+
 func (ipam *IPAM) AddOrUpdateSubnet(name, cidrStr, gw string, excludeIps []string) error {
 	excludeIps = util.ExpandExcludeIPs(excludeIps, cidrStr)
 
@@ -417,6 +432,42 @@ func (ipam *IPAM) AddOrUpdateIPPool(subnet, ippool string, ips []string) error {
 	return s.AddOrUpdateIPPool(ippool, ips)
 }
 
+// CarvedOrUsedIPs returns the addresses in the subnet which can not be carved into new ippools:
+// the addresses of the ippools, the allocated and excluded addresses and the gateways
+func (ipam *IPAM) CarvedOrUsedIPs(subnet string) (v4, v6 *IPRangeList) {
+	ipam.mutex.RLock()
+	defer ipam.mutex.RUnlock()
+
+	v4, v6 = NewEmptyIPRangeList(), NewEmptyIPRangeList()
+	s := ipam.Subnets[subnet]
+	if s == nil {
+		return v4, v6
+	}
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	for name, pool := range s.IPPools {
+		if name == "" {
+			continue
+		}
+		v4 = v4.Merge(pool.V4IPs)
+		v6 = v6.Merge(pool.V6IPs)
+	}
+	v4 = v4.Merge(s.V4Using).Merge(s.V4Reserved)
+	v6 = v6.Merge(s.V6Using).Merge(s.V6Reserved)
+	for _, gw := range strings.Split(s.V4Gw+","+s.V6Gw, ",") {
+		ip, err := NewIP(gw)
+		if err != nil {
+			continue
+		}
+		if ip.To4() != nil {
+			v4 = v4.MergeRange(NewIPRange(ip, ip))
+		} else {
+			v6 = v6.MergeRange(NewIPRange(ip, ip))
+		}
+	}
+	return v4, v6
+}
+
 func (ipam *IPAM) RemoveIPPool(subnet, ippool string) {
 	ipam.mutex.Lock()
 	if s := ipam.Subnets[subnet]; s != nil {
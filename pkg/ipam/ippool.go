@@ -1,5 +1,14 @@
 package ipam
 
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"slices"
+
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
 type IPPool struct {
 	V4IPs       *IPRangeList
 	V4Free      *IPRangeList
@@ -14,3 +23,78 @@ type IPPool struct {
 	V6Released  *IPRangeList
 	V6Using     *IPRangeList
 }
+
+// IPPoolAddresses returns the addresses of an ippool, which consist of the IPs and the CIDR blocks
+// excluding the excluded IPs, in the format accepted by NewIPRangeListFrom
+func IPPoolAddresses(ips, cidrBlocks, excludeIPs []string) ([]string, error) {
+	if len(cidrBlocks) == 0 && len(excludeIPs) == 0 {
+		return ips, nil
+	}
+
+	v4IPs, v6IPs := util.SplitIpsByProtocol(append(slices.Clone(ips), cidrBlocks...))
+	v4Excluded, v6Excluded := util.SplitIpsByProtocol(excludeIPs)
+	var result []string
+	for _, item := range [][2][]string{{v4IPs, v4Excluded}, {v6IPs, v6Excluded}} {
+		included, err := NewIPRangeListFrom(item[0]...)
+		if err != nil {
+			return nil, err
+		}
+		excluded, err := NewIPRangeListFrom(item[1]...)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, included.Separate(excluded).rangeStrings()...)
+	}
+	return result, nil
+}
+
+// rangeStrings returns the ranges in the format accepted by NewIPRangeListFrom
+func (r *IPRangeList) rangeStrings() []string {
+	s := make([]string, 0, r.Len())
+	for i := 0; i < r.Len(); i++ {
+		if r.At(i).Start().Equal(r.At(i).End()) {
+			s = append(s, r.At(i).Start().String())
+		} else {
+			s = append(s, fmt.Sprintf("%s..%s", r.At(i).Start(), r.At(i).End()))
+		}
+	}
+	return s
+}
+
+// CarveCIDR returns the first CIDR block with the prefix length in the parent CIDR,
+// which has no address in the used list
+func CarveCIDR(parent string, prefixLength int, used *IPRangeList) (string, error) {
+	_, parentNet, err := net.ParseCIDR(parent)
+	if err != nil {
+		return "", err
+	}
+	ones, bits := parentNet.Mask.Size()
+	if prefixLength < ones || prefixLength > bits {
+		return "", fmt.Errorf("prefix length %d is out of range [%d, %d] of CIDR %s", prefixLength, ones, bits, parent)
+	}
+
+	free := (&IPRangeList{[]*IPRange{NewIPRangeFromCIDR(*parentNet)}}).Separate(used)
+	blockSize := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLength))
+	mask := net.CIDRMask(prefixLength, bits)
+	for i := 0; i < free.Len(); i++ {
+		r := free.At(i)
+		// the first block aligned to the prefix length starting in the free range
+		start := net.IP(r.Start()).Mask(mask)
+		if !IP(start).Equal(r.Start()) {
+			startInt := new(big.Int).Add(new(big.Int).SetBytes(start), blockSize)
+			start = net.IP(bytes2IP(startInt.Bytes(), len(start)))
+			if IP(start).LessThan(r.Start()) {
+				// overflowed
+				continue
+			}
+		}
+		block := &net.IPNet{IP: start, Mask: mask}
+		if !parentNet.Contains(start) {
+			continue
+		}
+		if blockRange := NewIPRangeFromCIDR(*block); !blockRange.End().GreaterThan(r.End()) {
+			return block.String(), nil
+		}
+	}
+	return "", fmt.Errorf("no free /%d block in CIDR %s", prefixLength, parent)
+}
@@ -0,0 +1,80 @@
+package ipam
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIPPoolAddresses(t *testing.T) {
+	tests := []struct {
+		name       string
+		ips        []string
+		cidrBlocks []string
+		excludeIPs []string
+		want       []string
+	}{
+		{
+			name: "ips only",
+			ips:  []string{"10.0.0.1", "10.0.0.5..10.0.0.10"},
+			want: []string{"10.0.0.1", "10.0.0.5..10.0.0.10"},
+		},
+		{
+			name:       "cidr blocks with excluded ips",
+			cidrBlocks: []string{"10.0.0.0/28", "fd00::/124"},
+			excludeIPs: []string{"10.0.0.0..10.0.0.3", "10.0.0.15", "fd00::/125"},
+			want:       []string{"10.0.0.4..10.0.0.14", "fd00::8..fd00::f"},
+		},
+		{
+			name:       "ips and cidr blocks",
+			ips:        []string{"10.0.1.1"},
+			cidrBlocks: []string{"10.0.0.0/30"},
+			want:       []string{"10.0.0.0..10.0.0.3", "10.0.1.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IPPoolAddresses(tt.ips, tt.cidrBlocks, tt.excludeIPs)
+			if err != nil {
+				t.Fatalf("IPPoolAddresses() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("IPPoolAddresses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCarveCIDR(t *testing.T) {
+	used, err := NewIPRangeListFrom("10.0.0.0/26", "10.0.0.70")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		parent       string
+		prefixLength int
+		used         *IPRangeList
+		want         string
+		wantErr      bool
+	}{
+		{"empty parent", "10.0.0.0/24", 26, NewEmptyIPRangeList(), "10.0.0.0/26", false},
+		{"skip used blocks", "10.0.0.0/24", 26, used, "10.0.0.128/26", false},
+		{"smaller block", "10.0.0.0/24", 30, used, "10.0.0.64/30", false},
+		{"unaligned free range", "10.0.0.0/24", 28, used, "10.0.0.80/28", false},
+		{"ipv6", "fd00::/120", 124, NewEmptyIPRangeList(), "fd00::/124", false},
+		{"prefix length out of range", "10.0.0.0/24", 16, used, "", true},
+		{"exhausted", "10.0.0.0/26", 27, used, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CarveCIDR(tt.parent, tt.prefixLength, tt.used)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CarveCIDR() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CarveCIDR() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
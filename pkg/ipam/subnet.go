@@ -37,6 +37,8 @@ type Subnet struct {
 	PodToNicList map[string][]string
 	V4Gw         string
 	V6Gw         string
+	// V6Learned means the ipv6 addresses are learned from the upstream router rather than allocated
+	V6Learned bool
 
 	IPPools map[string]*IPPool
 }
@@ -180,14 +182,35 @@ func (s *Subnet) GetRandomAddress(poolName, podName, nicName string, mac *string
 
 	switch s.Protocol {
 	case kubeovnv1.ProtocolDual:
+		if s.V6Learned {
+			v4IP, _, macStr, err := s.getV4RandomAddress(poolName, podName, nicName, mac, skippedAddrs, checkConflict)
+			if err != nil {
+				return nil, nil, "", err
+			}
+			return v4IP, s.V6NicToIP[nicName], macStr, nil
+		}
 		return s.getDualRandomAddress(poolName, podName, nicName, mac, skippedAddrs, checkConflict)
 	case kubeovnv1.ProtocolIPv4:
 		return s.getV4RandomAddress(poolName, podName, nicName, mac, skippedAddrs, checkConflict)
 	default:
+		if s.V6Learned {
+			return s.getV6LearnedAddress(podName, nicName, mac, checkConflict)
+		}
 		return s.getV6RandomAddress(poolName, podName, nicName, mac, skippedAddrs, checkConflict)
 	}
 }
 
+// getV6LearnedAddress allocates the mac address only, the ipv6 address is recorded once it's learned
+func (s *Subnet) getV6LearnedAddress(podName, nicName string, mac *string, checkConflict bool) (IP, IP, string, error) {
+	if mac == nil {
+		return nil, s.V6NicToIP[nicName], s.GetRandomMac(podName, nicName), nil
+	}
+	if err := s.GetStaticMac(podName, nicName, *mac, checkConflict); err != nil {
+		return nil, nil, "", err
+	}
+	return nil, s.V6NicToIP[nicName], *mac, nil
+}
+
 func (s *Subnet) getDualRandomAddress(poolName, podName, nicName string, mac *string, skippedAddrs []string, checkConflict bool) (IP, IP, string, error) {
 	v4IP, _, _, err := s.getV4RandomAddress(poolName, podName, nicName, mac, skippedAddrs, checkConflict)
 	if err != nil {
@@ -0,0 +1,87 @@
+package ipam
+
+import (
+	"testing"
+)
+
+func TestSubnetV6Learned(t *testing.T) {
+	ipam := NewIPAM()
+	if err := ipam.AddOrUpdateSubnet("dual", "10.0.0.0/24,fd00::/64", "10.0.0.1,fd00::1", nil); err != nil {
+		t.Fatalf("AddOrUpdateSubnet() error = %v", err)
+	}
+	if err := ipam.AddOrUpdateSubnet("v6", "fd01::/64", "fd01::1", nil); err != nil {
+		t.Fatalf("AddOrUpdateSubnet() error = %v", err)
+	}
+	ipam.SetSubnetV6Learned("dual", true)
+	ipam.SetSubnetV6Learned("v6", true)
+
+	v4, v6, mac, err := ipam.GetRandomAddress("ns/pod1", "pod1.ns", nil, "dual", "", nil, true)
+	if err != nil {
+		t.Fatalf("GetRandomAddress() error = %v", err)
+	}
+	if v4 == "" || v6 != "" || mac == "" {
+		t.Fatalf("GetRandomAddress() = %q, %q, %q, want an ipv4 address and a mac address only", v4, v6, mac)
+	}
+
+	// record the learned ipv6 address
+	if _, v6, _, err = ipam.GetStaticAddress("ns/pod1", "pod1.ns", v4+",fd00::200:ff:fe00:1", &mac, "dual", true); err != nil {
+		t.Fatalf("GetStaticAddress() error = %v", err)
+	}
+	if v6 != "fd00::200:ff:fe00:1" {
+		t.Fatalf("GetStaticAddress() v6 = %q, want fd00::200:ff:fe00:1", v6)
+	}
+	if _, got, _, _ := ipam.GetRandomAddress("ns/pod1", "pod1.ns", nil, "dual", "", nil, true); got != v6 {
+		t.Fatalf("GetRandomAddress() v6 = %q, want the learned address %q", got, v6)
+	}
+
+	v4, v6, mac, err = ipam.GetRandomAddress("ns/pod2", "pod2.ns", nil, "v6", "", nil, true)
+	if err != nil {
+		t.Fatalf("GetRandomAddress() error = %v", err)
+	}
+	if v4 != "" || v6 != "" || mac == "" {
+		t.Fatalf("GetRandomAddress() = %q, %q, %q, want a mac address only", v4, v6, mac)
+	}
+}
+
+func TestCarvedOrUsedIPs(t *testing.T) {
+	ipam := NewIPAM()
+	if err := ipam.AddOrUpdateSubnet("dual", "10.0.0.0/24,fd00::/64", "10.0.0.1,fd00::1", []string{"10.0.0.100..10.0.0.101"}); err != nil {
+		t.Fatalf("AddOrUpdateSubnet() error = %v", err)
+	}
+	ips, err := IPPoolAddresses(nil, []string{"10.0.0.64/27"}, nil)
+	if err != nil {
+		t.Fatalf("IPPoolAddresses() error = %v", err)
+	}
+	if err = ipam.AddOrUpdateIPPool("dual", "pool", ips); err != nil {
+		t.Fatalf("AddOrUpdateIPPool() error = %v", err)
+	}
+	if _, _, _, err = ipam.GetStaticAddress("ns/pod1", "pod1.ns", "10.0.0.40,fd00::10", nil, "dual", true); err != nil {
+		t.Fatalf("GetStaticAddress() error = %v", err)
+	}
+
+	v4, v6 := ipam.CarvedOrUsedIPs("dual")
+	for _, ip := range []string{"10.0.0.1", "10.0.0.40", "10.0.0.64", "10.0.0.95", "10.0.0.100", "10.0.0.101"} {
+		if got, _ := NewIP(ip); !v4.Contains(got) {
+			t.Errorf("CarvedOrUsedIPs() v4 = %v, want %s included", v4, ip)
+		}
+	}
+	for _, ip := range []string{"10.0.0.2", "10.0.0.96", "10.0.0.102"} {
+		if got, _ := NewIP(ip); v4.Contains(got) {
+			t.Errorf("CarvedOrUsedIPs() v4 = %v, want %s excluded", v4, ip)
+		}
+	}
+	for _, ip := range []string{"fd00::1", "fd00::10"} {
+		if got, _ := NewIP(ip); !v6.Contains(got) {
+			t.Errorf("CarvedOrUsedIPs() v6 = %v, want %s included", v6, ip)
+		}
+	}
+
+	// the block containing the gateway, the allocated and excluded addresses is skipped
+	block, err := CarveCIDR("10.0.0.0/24", 27, v4)
+	if err != nil {
+		t.Fatalf("CarveCIDR() error = %v", err)
+	}
+	if block != "10.0.0.128/27" {
+		t.Errorf("CarveCIDR() = %s, want 10.0.0.128/27", block)
+	}
+}
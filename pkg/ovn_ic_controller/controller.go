@@ -36,6 +36,9 @@ type Controller struct {
 	vpcsLister       kubeovnlister.VpcLister
 	vpcSynced        cache.InformerSynced
 
+	interConnectionsLister kubeovnlister.InterConnectionLister
+	interConnectionSynced  cache.InformerSynced
+
 	informerFactory        kubeinformers.SharedInformerFactory
 	kubeovnInformerFactory kubeovninformer.SharedInformerFactory
 	recorder               record.EventRecorder
@@ -43,6 +46,9 @@ type Controller struct {
 	ovnLegacyClient *ovs.LegacyClient
 	OVNNbClient     ovs.NbClient
 	OVNSbClient     ovs.SbClient
+
+	// error of connecting to the ovn-ic databases in the last resync
+	icDBErr error
 }
 
 func NewController(config *Configuration) *Controller {
@@ -66,6 +72,7 @@ func NewController(config *Configuration) *Controller {
 	nodeInformer := informerFactory.Core().V1().Nodes()
 	subnetInformer := kubeovnInformerFactory.Kubeovn().V1().Subnets()
 	configMapInformer := informerFactory.Core().V1().ConfigMaps()
+	interConnectionInformer := kubeovnInformerFactory.Kubeovn().V1().InterConnections()
 
 	controller := &Controller{
 		config: config,
@@ -79,6 +86,9 @@ func NewController(config *Configuration) *Controller {
 		configMapsLister: configMapInformer.Lister(),
 		configMapsSynced: configMapInformer.Informer().HasSynced,
 
+		interConnectionsLister: interConnectionInformer.Lister(),
+		interConnectionSynced:  interConnectionInformer.Informer().HasSynced,
+
 		informerFactory:        informerFactory,
 		kubeovnInformerFactory: kubeovnInformerFactory,
 		recorder:               recorder,
@@ -112,7 +122,7 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	c.informerFactory.Start(stopCh)
 	c.kubeovnInformerFactory.Start(stopCh)
 
-	if !cache.WaitForCacheSync(stopCh, c.subnetSynced, c.nodesSynced) {
+	if !cache.WaitForCacheSync(stopCh, c.subnetSynced, c.nodesSynced, c.vpcSynced, c.interConnectionSynced) {
 		util.LogFatalAndExit(nil, "failed to wait for caches to sync")
 		return
 	}
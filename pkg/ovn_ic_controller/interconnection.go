@@ -0,0 +1,192 @@
+package ovn_ic_controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// getInterConnectionConfig returns the InterConnection and the configuration converted to the ovn-ic-config format.
+// The ovn-ic-config ConfigMap is used if there is no InterConnection, and nil config is returned if neither exists.
+// The InterConnection is returned together with the validation error if its spec is invalid.
+func (c *Controller) getInterConnectionConfig() (*kubeovnv1.InterConnection, map[string]string, error) {
+	ics, err := c.interConnectionsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list inter connections, %v", err)
+		return nil, nil, err
+	}
+	if len(ics) != 0 {
+		if len(ics) > 1 {
+			sort.Slice(ics, func(i, j int) bool { return ics[i].Name < ics[j].Name })
+			klog.Warningf("found %d inter connections, only %s takes effect", len(ics), ics[0].Name)
+		}
+		ic := ics[0].DeepCopy()
+		if err = util.ValidateInterConnection(&ic.Spec); err != nil {
+			klog.Errorf("invalid inter connection %s: %v", ic.Name, err)
+			return ic, nil, err
+		}
+		return ic, interConnectionConfigData(&ic.Spec), nil
+	}
+
+	cm, err := c.configMapsLister.ConfigMaps(c.config.PodNamespace).Get(util.InterconnectionConfig)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		klog.Errorf("failed to get ovn-ic-config, %v", err)
+		return nil, nil, err
+	}
+	spec, err := interConnectionFromConfigMap(cm.Data)
+	if err != nil {
+		klog.Errorf("invalid ovn-ic-config: %v", err)
+		return nil, nil, err
+	}
+	if err = util.ValidateInterConnection(spec); err != nil {
+		klog.Errorf("invalid ovn-ic-config: %v", err)
+		return nil, nil, err
+	}
+	return nil, interConnectionConfigData(spec), nil
+}
+
+// interConnectionFromConfigMap converts the data of ovn-ic-config to the InterConnection spec
+func interConnectionFromConfigMap(data map[string]string) (*kubeovnv1.InterConnectionSpec, error) {
+	spec := &kubeovnv1.InterConnectionSpec{
+		Enable:       data["enable-ic"] == "true",
+		AzName:       data["az-name"],
+		ICDBHosts:    splitConfigList(data["ic-db-host"]),
+		GatewayNodes: splitConfigList(data["gw-nodes"]),
+		AutoRoute:    data["auto-route"] == "true",
+	}
+	for key, port := range map[string]*int32{"ic-nb-port": &spec.ICNbPort, "ic-sb-port": &spec.ICSbPort} {
+		if data[key] == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(data[key], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", key, data[key])
+		}
+		*port = int32(value)
+	}
+	return spec, nil
+}
+
+// interConnectionConfigData converts the InterConnection spec to the data of ovn-ic-config
+func interConnectionConfigData(spec *kubeovnv1.InterConnectionSpec) map[string]string {
+	if !spec.Enable {
+		return map[string]string{"enable-ic": "false"}
+	}
+	return map[string]string{
+		"enable-ic":  "true",
+		"az-name":    spec.AzName,
+		"ic-db-host": strings.Join(spec.ICDBHosts, ","),
+		"ic-nb-port": strconv.Itoa(int(spec.ICNbPort)),
+		"ic-sb-port": strconv.Itoa(int(spec.ICSbPort)),
+		"gw-nodes":   strings.Join(spec.GatewayNodes, ","),
+		"auto-route": strconv.FormatBool(spec.AutoRoute),
+	}
+}
+
+func splitConfigList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// updateInterConnectionStatus updates the transit switches and the conditions of the InterConnection,
+// only the Validated condition is updated if the spec is invalid
+func (c *Controller) updateInterConnectionStatus(ic *kubeovnv1.InterConnection, validationErr error) {
+	status := ic.Status.DeepCopy()
+	if validationErr != nil {
+		status.ClearCondition(kubeovnv1.Validated, "InvalidSpec", validationErr.Error())
+		c.patchInterConnectionStatus(ic, status)
+		return
+	}
+	status.SetCondition(kubeovnv1.Validated, "ValidatedSpec", "spec is valid")
+
+	if !ic.Spec.Enable || icEnabled != "true" {
+		reason, message := "NotEstablished", "ovn-ic is not established"
+		if !ic.Spec.Enable {
+			reason, message = "Disabled", "ovn-ic is disabled"
+		}
+		status.TransitSwitches = nil
+		status.ClearCondition(kubeovnv1.ICDBReachable, reason, message)
+		status.ClearCondition(kubeovnv1.GatewayChassisRegistered, reason, message)
+		status.ClearCondition(kubeovnv1.TransitSwitchPortsReady, reason, message)
+		c.patchInterConnectionStatus(ic, status)
+		return
+	}
+
+	if c.icDBErr != nil {
+		status.ClearCondition(kubeovnv1.ICDBReachable, "ConnectionFailed", c.icDBErr.Error())
+	} else {
+		status.SetCondition(kubeovnv1.ICDBReachable, "Connected", "ovn-ic databases are reachable")
+	}
+
+	var unregistered []string
+	for _, gw := range ic.Spec.GatewayNodes {
+		chassis, err := c.OVNSbClient.GetChassisByHost(gw)
+		if err != nil || chassis == nil || chassis.Name == "" {
+			unregistered = append(unregistered, gw)
+		}
+	}
+	if len(unregistered) != 0 {
+		status.ClearCondition(kubeovnv1.GatewayChassisRegistered, "ChassisNotFound", fmt.Sprintf("chassis of gateway nodes %s not found", strings.Join(unregistered, ",")))
+	} else {
+		status.SetCondition(kubeovnv1.GatewayChassisRegistered, "Registered", "chassis of all gateway nodes are registered")
+	}
+
+	var readyPorts int
+	var missingPorts []string
+	for _, ts := range lastTSs {
+		tsPort := fmt.Sprintf("%s-%s", ts, ic.Spec.AzName)
+		exist, err := c.OVNNbClient.LogicalSwitchPortExists(tsPort)
+		if err != nil || !exist {
+			missingPorts = append(missingPorts, tsPort)
+			continue
+		}
+		readyPorts++
+	}
+	switch {
+	case len(missingPorts) != 0:
+		status.ClearCondition(kubeovnv1.TransitSwitchPortsReady, "PortNotFound", fmt.Sprintf("transit switch ports %s not found", strings.Join(missingPorts, ",")))
+	case readyPorts == 0:
+		status.ClearCondition(kubeovnv1.TransitSwitchPortsReady, "TransitSwitchNotFound", "no transit switch found")
+	default:
+		status.SetCondition(kubeovnv1.TransitSwitchPortsReady, "Ready", fmt.Sprintf("%d/%d transit switch ports are ready", readyPorts, len(lastTSs)))
+	}
+	status.TransitSwitches = nil
+	if len(lastTSs) != 0 {
+		status.TransitSwitches = lastTSs
+	}
+	c.patchInterConnectionStatus(ic, status)
+}
+
+func (c *Controller) patchInterConnectionStatus(ic *kubeovnv1.InterConnection, status *kubeovnv1.InterConnectionStatus) {
+	if reflect.DeepEqual(&ic.Status, status) {
+		return
+	}
+	bytes, err := status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().InterConnections().Patch(context.Background(), ic.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("failed to patch status of inter connection %s: %v", ic.Name, err)
+	}
+}
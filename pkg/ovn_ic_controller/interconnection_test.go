@@ -0,0 +1,68 @@
+package ovn_ic_controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	kubeovnfake "github.com/kubeovn/kube-ovn/pkg/client/clientset/versioned/fake"
+)
+
+func Test_interConnectionFromConfigMap(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]string{
+		"enable-ic":  "true",
+		"az-name":    "az1",
+		"ic-db-host": "192.168.65.3, ovn-ic-db.example.com,",
+		"ic-nb-port": "6645",
+		"ic-sb-port": "6646",
+		"gw-nodes":   "kube-ovn-worker,kube-ovn-control-plane",
+		"auto-route": "true",
+	}
+	spec, err := interConnectionFromConfigMap(data)
+	require.NoError(t, err)
+	require.Equal(t, &kubeovnv1.InterConnectionSpec{
+		Enable:       true,
+		AzName:       "az1",
+		ICDBHosts:    []string{"192.168.65.3", "ovn-ic-db.example.com"},
+		ICNbPort:     6645,
+		ICSbPort:     6646,
+		GatewayNodes: []string{"kube-ovn-worker", "kube-ovn-control-plane"},
+		AutoRoute:    true,
+	}, spec)
+
+	data["ic-db-host"] = "192.168.65.3,ovn-ic-db.example.com"
+	require.Equal(t, data, interConnectionConfigData(spec))
+
+	spec.Enable = false
+	require.Equal(t, map[string]string{"enable-ic": "false"}, interConnectionConfigData(spec))
+
+	data["ic-sb-port"] = "abc"
+	_, err = interConnectionFromConfigMap(data)
+	require.ErrorContains(t, err, `invalid ic-sb-port "abc"`)
+}
+
+func Test_updateInterConnectionStatus(t *testing.T) {
+	t.Parallel()
+
+	ic := &kubeovnv1.InterConnection{
+		ObjectMeta: metav1.ObjectMeta{Name: "ic"},
+		Spec:       kubeovnv1.InterConnectionSpec{Enable: true},
+	}
+	client := kubeovnfake.NewSimpleClientset(ic)
+	c := &Controller{config: &Configuration{KubeOvnClient: client}}
+
+	c.updateInterConnectionStatus(ic, errors.New("azName is required"))
+	ic, err := client.KubeovnV1().InterConnections().Get(context.Background(), ic.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, ic.Status.Conditions, 1)
+	require.Equal(t, kubeovnv1.ConditionType(kubeovnv1.Validated), ic.Status.Conditions[0].Type)
+	require.Equal(t, corev1.ConditionFalse, ic.Status.Conditions[0].Status)
+	require.Equal(t, "azName is required", ic.Status.Conditions[0].Message)
+}
@@ -3,6 +3,7 @@ package ovn_ic_controller
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
 	"reflect"
@@ -35,6 +36,10 @@ const (
 )
 
 func (c *Controller) disableOVNIC(azName string) error {
+	if err := c.removeVpcInterConnection(azName); err != nil {
+		klog.Errorf("failed to disconnect vpcs from transit switches: %v", err)
+		return err
+	}
 	if err := c.removeInterConnection(azName); err != nil {
 		klog.Errorf("failed to remove ovn-ic: %v", err)
 		return err
@@ -51,13 +56,25 @@ func (c *Controller) disableOVNIC(azName string) error {
 	return nil
 }
 
-func (c *Controller) setAutoRoute(autoRoute bool) {
+// setAutoRoute sets the route options of ovn-ic, the deny list contains the routes rejected by the route filters of the default vpc
+func (c *Controller) setAutoRoute(autoRoute bool, denyList []string) {
 	var blackList []string
 	subnets, err := c.subnetsLister.List(labels.Everything())
 	if err != nil {
 		klog.Errorf("failed to list subnets, %v", err)
 		return
 	}
+	// routes of custom vpcs are exchanged by kube-ovn-ic, so only the advertisement filter of the default vpc is applied
+	vpc, err := c.vpcsLister.Get(c.config.ClusterRouter)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get vpc %s, %v", c.config.ClusterRouter, err)
+			return
+		}
+	} else {
+		blackList = append(blackList, vpcAdvertiseBlackList(vpc, c.vpcSubnetCIDRs(vpc.Name, subnets))...)
+	}
+	blackList = append(blackList, denyList...)
 	for _, subnet := range subnets {
 		if subnet.Spec.DisableInterConnection || subnet.Name == c.config.NodeSwitch {
 			blackList = append(blackList, subnet.Spec.CIDRBlock)
@@ -132,6 +149,7 @@ func (c *Controller) getICState(cmData, lastcmData map[string]string) int {
 		var err error
 		c.ovnLegacyClient.OvnICNbAddress = genHostAddress(cmData["ic-db-host"], cmData["ic-nb-port"])
 		curTSs, err = c.ovnLegacyClient.GetTs()
+		c.icDBErr = err
 		if err != nil {
 			klog.Errorf("failed to get Transit_Switch, %v", err)
 			return icNoAction
@@ -145,23 +163,27 @@ func (c *Controller) getICState(cmData, lastcmData map[string]string) int {
 }
 
 func (c *Controller) resyncInterConnection() {
-	cm, err := c.configMapsLister.ConfigMaps(c.config.PodNamespace).Get(util.InterconnectionConfig)
-	if err != nil && !k8serrors.IsNotFound(err) {
-		klog.Errorf("failed to get ovn-ic-config, %v", err)
+	ic, config, err := c.getInterConnectionConfig()
+	if ic != nil {
+		// the validation error is reported in the status of the InterConnection
+		defer c.updateInterConnectionStatus(ic, err)
+	}
+	if err != nil {
+		klog.Errorf("failed to get ovn-ic config, %v", err)
 		return
 	}
 
-	if k8serrors.IsNotFound(err) || cm.Data["enable-ic"] == "false" {
+	if config == nil || config["enable-ic"] == "false" {
 		if icEnabled == "false" {
 			return
 		}
 		klog.Info("start to remove ovn-ic")
 		var azName, icDBHost, icSBPort, icNBPort string
-		if cm != nil {
-			azName = cm.Data["az-name"]
-			icDBHost = cm.Data["ic-db-host"]
-			icSBPort = cm.Data["ic-sb-port"]
-			icNBPort = cm.Data["ic-nb-port"]
+		if config != nil && config["az-name"] != "" {
+			azName = config["az-name"]
+			icDBHost = config["ic-db-host"]
+			icSBPort = config["ic-sb-port"]
+			icNBPort = config["ic-nb-port"]
 		} else if lastIcCm != nil {
 			azName = lastIcCm["az-name"]
 			icDBHost = lastIcCm["ic-db-host"]
@@ -186,19 +208,27 @@ func (c *Controller) resyncInterConnection() {
 		return
 	}
 
-	autoRoute := false
-	if cm.Data["auto-route"] == "true" {
-		autoRoute = true
+	autoRoute := config["auto-route"] == "true"
+	state := c.getICState(config, lastIcCm)
+	// the routes rejected by the route filters are applied in the same resync,
+	// and the route options of ovn-ic are kept unchanged if the routes failed to be synchronized
+	var denyList []string
+	if state == icNoAction {
+		if denyList, err = c.syncVpcInterConnection(config, autoRoute); err != nil {
+			klog.Errorf("failed to sync inter connection of vpcs: %v", err)
+			return
+		}
 	}
-	c.setAutoRoute(autoRoute)
+	c.setAutoRoute(autoRoute, denyList)
 
-	switch c.getICState(cm.Data, lastIcCm) {
+	switch state {
 	case icNoAction:
 		return
 	case icFirstEstablish:
-		c.ovnLegacyClient.OvnICNbAddress = genHostAddress(cm.Data["ic-db-host"], cm.Data["ic-nb-port"])
+		c.ovnLegacyClient.OvnICNbAddress = genHostAddress(config["ic-db-host"], config["ic-nb-port"])
+		c.ovnLegacyClient.OvnICSbAddress = genHostAddress(config["ic-db-host"], config["ic-sb-port"])
 		klog.Info("start to establish ovn-ic")
-		if err := c.establishInterConnection(cm.Data); err != nil {
+		if err := c.establishInterConnection(config); err != nil {
 			klog.Errorf("failed to establish ovn-ic, %v", err)
 			return
 		}
@@ -208,26 +238,26 @@ func (c *Controller) resyncInterConnection() {
 			return
 		}
 		icEnabled = "true"
-		lastIcCm = cm.Data
+		lastIcCm = config
 		lastTSs = curTSs
 		klog.Info("finish establishing ovn-ic")
 		return
 	case icConfigChange:
-		c.ovnLegacyClient.OvnICSbAddress = genHostAddress(lastIcCm["ic-db-host"], cm.Data["ic-sb-port"])
-		c.ovnLegacyClient.OvnICNbAddress = genHostAddress(lastIcCm["ic-db-host"], cm.Data["ic-nb-port"])
+		c.ovnLegacyClient.OvnICSbAddress = genHostAddress(lastIcCm["ic-db-host"], config["ic-sb-port"])
+		c.ovnLegacyClient.OvnICNbAddress = genHostAddress(lastIcCm["ic-db-host"], config["ic-nb-port"])
 		err := c.disableOVNIC(lastIcCm["az-name"])
 		if err != nil {
 			klog.Errorf("Disable az %s OVN IC failed ", lastIcCm["az-name"])
 			return
 		}
 		klog.Info("start to reestablish ovn-ic")
-		if err := c.establishInterConnection(cm.Data); err != nil {
+		if err := c.establishInterConnection(config); err != nil {
 			klog.Errorf("failed to reestablish ovn-ic, %v", err)
 			return
 		}
 
 		icEnabled = "true"
-		lastIcCm = cm.Data
+		lastIcCm = config
 		lastTSs = curTSs
 		klog.Info("finish reestablishing ovn-ic")
 		return
@@ -472,21 +502,14 @@ func (c *Controller) deleteStaticRouteFromVpc(name, table, cidr, nextHop string,
 	return nil
 }
 
-func genHostAddress(host, port string) (hostAddress string) {
+// genHostAddress generates the ovsdb connection addresses of the hosts, which can be ip addresses or hostnames
+func genHostAddress(host, port string) string {
 	hostList := strings.Split(strings.Trim(host, ","), ",")
-	if len(hostList) == 1 {
-		hostAddress = fmt.Sprintf("tcp:[%s]:%s", hostList[0], port)
-	} else {
-		var builder strings.Builder
-		i := 0
-		for i < len(hostList)-1 {
-			builder.WriteString(fmt.Sprintf("tcp:[%s]:%s,", hostList[i], port))
-			i++
-		}
-		builder.WriteString(fmt.Sprintf("tcp:[%s]:%s", hostList[i], port))
-		hostAddress = builder.String()
+	addresses := make([]string, 0, len(hostList))
+	for _, h := range hostList {
+		addresses = append(addresses, "tcp:"+net.JoinHostPort(strings.TrimSpace(h), port))
 	}
-	return hostAddress
+	return strings.Join(addresses, ",")
 }
 
 func (c *Controller) SynRouteToPolicy() {
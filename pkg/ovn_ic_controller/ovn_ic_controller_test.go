@@ -0,0 +1,14 @@
+package ovn_ic_controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_genHostAddress(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "tcp:192.168.65.3:6645", genHostAddress("192.168.65.3", "6645"))
+	require.Equal(t, "tcp:[fd00::3]:6645,tcp:ovn-ic-db.example.com:6645", genHostAddress("fd00::3, ovn-ic-db.example.com,", "6645"))
+}
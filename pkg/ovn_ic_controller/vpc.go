@@ -0,0 +1,676 @@
+package ovn_ic_controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/set"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// icLearnedRouteTable is the route table of the transit switch ports of custom vpcs,
+// where the routes learned by ovn-ic are put so that they are not used by the vpcs
+const icLearnedRouteTable = "ovn-ic"
+
+// icVpcRoute is a route advertised by a vpc to the transit switch
+type icVpcRoute struct {
+	CIDR    string
+	NextHop string
+	// availability zones allowed to learn the route, all of them if empty
+	AZs []string
+}
+
+// vpcInterConnection returns the inter connection of a custom vpc,
+// the default vpc is connected to all the transit switches not belonging to custom vpcs
+func (c *Controller) vpcInterConnection(vpc *kubeovnv1.Vpc) *kubeovnv1.VpcInterConnection {
+	if vpc.Name == c.config.ClusterRouter || vpc.Spec.InterConnection == nil || vpc.Spec.InterConnection.TransitSwitch == "" {
+		return nil
+	}
+	return vpc.Spec.InterConnection
+}
+
+// syncVpcInterConnection connects the custom vpcs to their transit switches, removes the stale transit switch ports,
+// exchanges the routes of the custom vpcs with other availability zones and writes the routes back to the vpc status.
+// The routes of custom vpcs are advertised, filtered and learned per router by kube-ovn-ic rather than ovn-ic,
+// so that they do not depend on the global options of ovn-ic used by the default vpc.
+// The routes rejected by the route filters of the default vpc are returned, which are learned by ovn-ic.
+func (c *Controller) syncVpcInterConnection(config map[string]string, autoRoute bool) ([]string, error) {
+	vpcs, err := c.vpcsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpcs, %v", err)
+		return nil, err
+	}
+	sort.Slice(vpcs, func(i, j int) bool { return vpcs[i].Name < vpcs[j].Name })
+	subnets, err := c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list subnets, %v", err)
+		return nil, err
+	}
+	icRoutes, err := c.ovnLegacyClient.ListICRoutes()
+	if err != nil {
+		klog.Errorf("failed to list routes of ovn-ic: %v", err)
+		return nil, err
+	}
+	tsExternalIDs, err := c.ovnLegacyClient.ListVpcTsExternalIDs()
+	if err != nil {
+		klog.Errorf("failed to list transit switches of vpcs: %v", err)
+		return nil, err
+	}
+
+	var denyList []string
+	azName := config["az-name"]
+	tsPorts := set.New[string]()
+	advertisements := make(map[string]map[string]string)
+	gwNodes := strings.Split(strings.Trim(config["gw-nodes"], ","), ",")
+	for i, vpc := range vpcs {
+		var acceptCIDRs []string
+		if vpc.Spec.InterConnection != nil {
+			acceptCIDRs = vpc.Spec.InterConnection.AcceptCIDRs
+		}
+		if vpc.Name == c.config.ClusterRouter {
+			if !autoRoute {
+				c.patchVpcInterConnectionStatus(vpc, "", nil, nil, nil)
+				continue
+			}
+			advertised, peerRoutes := splitICRoutes(icRoutes, azName, lastTSs)
+			_, rejected := filterLearnedRoutes(peerRoutes, acceptCIDRs, c.vpcSubnetCIDRs(vpc.Name, subnets))
+			for _, route := range rejected {
+				denyList = append(denyList, route.CIDR)
+			}
+			learned, err := c.listVpcLearnedRoutes(vpc.Name, icRoutes)
+			if err != nil {
+				klog.Errorf("failed to list learned routes of vpc %s: %v", vpc.Name, err)
+				continue
+			}
+			c.patchVpcInterConnectionStatus(vpc, "", learned, advertised, rejected)
+			continue
+		}
+
+		ic := c.vpcInterConnection(vpc)
+		if ic == nil {
+			if vpc.Status.InterConnectionTransitSwitch != "" || len(vpc.Status.LearnedRoutes) != 0 {
+				if err = c.syncVpcLearnedRoutes(vpc.Name, "", nil); err != nil {
+					klog.Errorf("failed to remove learned routes of vpc %s: %v", vpc.Name, err)
+					continue
+				}
+			}
+			c.patchVpcInterConnectionStatus(vpc, "", nil, nil, nil)
+			continue
+		}
+		tsName := ic.TransitSwitch
+		tsPort, lrpNetworks, err := c.connectVpcToTs(vpc.Name, ic, azName, generateNewOrderGwNodes(gwNodes, i))
+		if err != nil {
+			klog.Errorf("failed to connect vpc %s to transit switch %s: %v", vpc.Name, tsName, err)
+			// keep the existing port and advertisement until the vpc is connected successfully
+			tsPorts.Insert(fmt.Sprintf("%s-%s-%s", tsName, azName, vpc.Name))
+			key := icVpcRoutesKey(azName, vpc.Name)
+			if value, ok := tsExternalIDs[tsName][key]; ok {
+				if advertisements[tsName] == nil {
+					advertisements[tsName] = make(map[string]string)
+				}
+				advertisements[tsName][key] = value
+			}
+			continue
+		}
+		tsPorts.Insert(tsPort)
+
+		advertised := c.vpcAdvertisedRoutes(vpc, subnets, lrpNetworks)
+		if advertisements[tsName] == nil {
+			advertisements[tsName] = make(map[string]string)
+		}
+		advertisements[tsName][icVpcRoutesKey(azName, vpc.Name)] = formatICVpcRoutes(advertised)
+		advertisedCIDRs := make([]string, 0, len(advertised))
+		for _, route := range advertised {
+			advertisedCIDRs = append(advertisedCIDRs, route.CIDR)
+		}
+
+		learned, rejected := filterLearnedRoutes(peerICVpcRoutes(tsExternalIDs[tsName], azName), acceptCIDRs, c.vpcSubnetCIDRs(vpc.Name, subnets))
+		if err = c.syncVpcLearnedRoutes(vpc.Name, tsName, learned); err != nil {
+			klog.Errorf("failed to sync learned routes of vpc %s: %v", vpc.Name, err)
+			continue
+		}
+		c.patchVpcInterConnectionStatus(vpc, tsName, learned, advertisedCIDRs, rejected)
+	}
+
+	if err = c.publishVpcRoutes(azName, tsExternalIDs, advertisements); err != nil {
+		klog.Errorf("failed to publish routes of vpcs: %v", err)
+	}
+	if err = c.deleteVpcTsPorts(tsPorts); err != nil {
+		klog.Errorf("failed to delete stale transit switch ports of vpcs: %v", err)
+	}
+	return denyList, nil
+}
+
+// connectVpcToTs creates the transit switch and connects the vpc router to it,
+// the name of the transit switch port and the networks of the router port are returned.
+// The routes learned by ovn-ic are put into a dedicated route table of the router port,
+// since the routes of custom vpcs are learned by kube-ovn-ic.
+func (c *Controller) connectVpcToTs(vpcName string, ic *kubeovnv1.VpcInterConnection, azName string, gwNodes []string) (string, []string, error) {
+	tsName := ic.TransitSwitch
+	tsPort := fmt.Sprintf("%s-%s-%s", tsName, azName, vpcName)
+	lrpName := fmt.Sprintf("%s-%s-%s", azName, vpcName, tsName)
+	externalIDs := map[string]string{util.OvnICVpcKey: vpcName, util.OvnICTransitSwitchKey: tsName}
+
+	exist, err := c.OVNNbClient.LogicalSwitchPortExists(tsPort)
+	if err != nil {
+		klog.Errorf("failed to check logical switch port %q: %v", tsPort, err)
+		return "", nil, err
+	}
+	if exist {
+		if err = c.OVNNbClient.SetLogicalSwitchPortExternalIDs(tsPort, externalIDs); err != nil {
+			klog.Errorf("failed to set external ids of ts port %s: %v", tsPort, err)
+			return "", nil, err
+		}
+		lrp, err := c.OVNNbClient.GetLogicalRouterPort(lrpName, false)
+		if err != nil {
+			klog.Errorf("failed to get logical router port %s: %v", lrpName, err)
+			return "", nil, err
+		}
+		if lrp.Options["route_table"] != icLearnedRouteTable {
+			if err = c.OVNNbClient.UpdateLogicalRouterPortOptions(lrpName, map[string]string{"route_table": icLearnedRouteTable}); err != nil {
+				klog.Errorf("failed to set route table of logical router port %s: %v", lrpName, err)
+				return "", nil, err
+			}
+		}
+		return tsPort, lrp.Networks, nil
+	}
+
+	if err = c.ovnLegacyClient.CreateVpcTs(tsName, ic.TransitSwitchCIDR); err != nil {
+		klog.Errorf("failed to create transit switch %s: %v", tsName, err)
+		return "", nil, err
+	}
+	// the transit switch is synchronized to the northbound database by ovn-ic asynchronously
+	exist, err = c.OVNNbClient.LogicalSwitchExists(tsName)
+	if err != nil {
+		klog.Errorf("failed to check logical switch %q: %v", tsName, err)
+		return "", nil, err
+	}
+	if !exist {
+		return "", nil, fmt.Errorf("transit switch %s has not been synchronized to the northbound database", tsName)
+	}
+
+	chassises := make([]string, 0, len(gwNodes))
+	for _, gw := range gwNodes {
+		gw = strings.TrimSpace(gw)
+		chassis, err := c.OVNSbClient.GetChassisByHost(gw)
+		if err != nil {
+			klog.Errorf("failed to get gw %q chassis: %v", gw, err)
+			return "", nil, err
+		}
+		if chassis.Name == "" {
+			return "", nil, fmt.Errorf("no chassis for gw %q", gw)
+		}
+		chassises = append(chassises, chassis.Name)
+	}
+
+	lrpAddr, err := c.acquireLrpAddress(tsName)
+	if err != nil {
+		klog.Errorf("failed to acquire lrp address for ts %q: %v", tsName, err)
+		return "", nil, err
+	}
+	if err = c.OVNNbClient.CreateLogicalPatchPort(tsName, vpcName, tsPort, lrpName, lrpAddr, util.GenerateMac(), chassises...); err != nil {
+		klog.Errorf("failed to create ovn-ic lrp %q: %v", lrpName, err)
+		return "", nil, err
+	}
+	if err = c.OVNNbClient.UpdateLogicalRouterPortOptions(lrpName, map[string]string{"route_table": icLearnedRouteTable}); err != nil {
+		klog.Errorf("failed to set route table of logical router port %s: %v", lrpName, err)
+		return "", nil, err
+	}
+	if err = c.OVNNbClient.SetLogicalSwitchPortExternalIDs(tsPort, externalIDs); err != nil {
+		klog.Errorf("failed to set external ids of ts port %s: %v", tsPort, err)
+		return "", nil, err
+	}
+	klog.Infof("vpc %s is connected to transit switch %s", vpcName, tsName)
+	return tsPort, strings.Split(lrpAddr, ","), nil
+}
+
+// deleteVpcTsPorts deletes the transit switch ports of vpcs and the peer router ports except the given ones
+func (c *Controller) deleteVpcTsPorts(keep set.Set[string]) error {
+	lsps, err := c.OVNNbClient.ListLogicalSwitchPorts(false, map[string]string{util.OvnICVpcKey: ""}, func(lsp *ovnnb.LogicalSwitchPort) bool {
+		return !keep.Has(lsp.Name)
+	})
+	if err != nil {
+		klog.Errorf("failed to list transit switch ports of vpcs: %v", err)
+		return err
+	}
+	for _, lsp := range lsps {
+		if lrpName := lsp.Options["router-port"]; lrpName != "" {
+			if err = c.OVNNbClient.DeleteLogicalRouterPort(lrpName); err != nil {
+				klog.Errorf("failed to delete logical router port %s: %v", lrpName, err)
+				return err
+			}
+		}
+		if err = c.OVNNbClient.DeleteLogicalSwitchPort(lsp.Name); err != nil {
+			klog.Errorf("failed to delete logical switch port %s: %v", lsp.Name, err)
+			return err
+		}
+		klog.Infof("vpc %s is disconnected from transit switch %s", lsp.ExternalIDs[util.OvnICVpcKey], lsp.ExternalIDs[util.OvnICTransitSwitchKey])
+	}
+	return nil
+}
+
+// removeVpcInterConnection disconnects all the vpcs from the transit switches,
+// and removes the routes advertised and learned by the vpcs
+func (c *Controller) removeVpcInterConnection(azName string) error {
+	lsps, err := c.OVNNbClient.ListLogicalSwitchPorts(false, map[string]string{util.OvnICVpcKey: ""}, nil)
+	if err != nil {
+		klog.Errorf("failed to list transit switch ports of vpcs: %v", err)
+		return err
+	}
+	tsNames := set.New[string]()
+	for _, lsp := range lsps {
+		tsNames.Insert(lsp.ExternalIDs[util.OvnICTransitSwitchKey])
+		if err = c.syncVpcLearnedRoutes(lsp.ExternalIDs[util.OvnICVpcKey], "", nil); err != nil {
+			klog.Errorf("failed to remove learned routes of vpc %s: %v", lsp.ExternalIDs[util.OvnICVpcKey], err)
+			return err
+		}
+	}
+	if len(lsps) != 0 {
+		tsExternalIDs, err := c.ovnLegacyClient.ListVpcTsExternalIDs()
+		if err != nil {
+			klog.Errorf("failed to list transit switches of vpcs: %v", err)
+			return err
+		}
+		if err = c.publishVpcRoutes(azName, tsExternalIDs, nil); err != nil {
+			klog.Errorf("failed to remove routes of vpcs: %v", err)
+			return err
+		}
+	}
+	if err = c.deleteVpcTsPorts(set.New[string]()); err != nil {
+		return err
+	}
+	for _, tsName := range tsNames.SortedList() {
+		if err = c.OVNNbClient.DeleteLogicalSwitch(tsName); err != nil {
+			klog.Errorf("failed to delete transit switch %s: %v", tsName, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// icVpcRoutesKey returns the external id key of the transit switch holding the routes advertised by the vpc
+func icVpcRoutesKey(azName, vpcName string) string {
+	return fmt.Sprintf("%s/%s/%s", util.OvnICVpcRoutesKey, azName, vpcName)
+}
+
+// formatICVpcRoutes formats the routes as "cidr,nexthop[,az...];cidr,nexthop[,az...]"
+func formatICVpcRoutes(routes []icVpcRoute) string {
+	entries := make([]string, 0, len(routes))
+	for _, route := range routes {
+		entries = append(entries, strings.Join(append([]string{route.CIDR, route.NextHop}, route.AZs...), ","))
+	}
+	return strings.Join(entries, ";")
+}
+
+// parseICVpcRoutes parses the routes formatted by formatICVpcRoutes, invalid routes are ignored
+func parseICVpcRoutes(s string) []icVpcRoute {
+	var routes []icVpcRoute
+	for _, entry := range strings.Split(s, ";") {
+		fields := strings.Split(entry, ",")
+		if len(fields) < 2 || fields[0] == "" || net.ParseIP(fields[1]) == nil {
+			continue
+		}
+		routes = append(routes, icVpcRoute{CIDR: fields[0], NextHop: fields[1], AZs: fields[2:]})
+	}
+	return routes
+}
+
+// peerICVpcRoutes returns the routes advertised to the transit switch by the vpcs of other availability zones,
+// except the ones not allowed to be learned by the availability zone
+func peerICVpcRoutes(tsExternalIDs map[string]string, azName string) []*kubeovnv1.VpcLearnedRoute {
+	var routes []*kubeovnv1.VpcLearnedRoute
+	prefix := util.OvnICVpcRoutesKey + "/"
+	for key, value := range tsExternalIDs {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		// the vpc name can not contain "/"
+		idx := strings.LastIndex(key, "/")
+		if idx <= len(prefix) || key[len(prefix):idx] == azName {
+			continue
+		}
+		for _, route := range parseICVpcRoutes(value) {
+			if len(route.AZs) != 0 && !slices.Contains(route.AZs, azName) {
+				continue
+			}
+			routes = append(routes, &kubeovnv1.VpcLearnedRoute{
+				CIDR:             route.CIDR,
+				NextHopIP:        route.NextHop,
+				AvailabilityZone: key[len(prefix):idx],
+			})
+		}
+	}
+	sortVpcLearnedRoutes(routes)
+	return routes
+}
+
+// vpcAdvertisedRoutes returns the routes advertised by the vpc, including the CIDRs of the subnets
+// and the static routes of the main route table, the next hops are the addresses of the transit switch port
+func (c *Controller) vpcAdvertisedRoutes(vpc *kubeovnv1.Vpc, subnets []*kubeovnv1.Subnet, lrpNetworks []string) []icVpcRoute {
+	var cidrs []string
+	cidrAZs := make(map[string][]string)
+	for _, subnet := range subnets {
+		if subnet.Spec.Vpc == vpc.Name && !subnet.Spec.DisableInterConnection && subnet.Spec.CIDRBlock != "" {
+			for _, cidr := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+				cidrs = append(cidrs, cidr)
+				cidrAZs[cidr] = subnet.Spec.InterConnectionAZs
+			}
+		}
+	}
+	for _, route := range vpc.Spec.StaticRoutes {
+		if route.RouteTable == util.MainRouteTable && (route.Policy == "" || route.Policy == kubeovnv1.PolicyDst) {
+			cidrs = append(cidrs, route.CIDR)
+		}
+	}
+
+	var nextHopV4, nextHopV6 string
+	for _, network := range lrpNetworks {
+		ip := strings.Split(network, "/")[0]
+		switch util.CheckProtocol(ip) {
+		case kubeovnv1.ProtocolIPv4:
+			nextHopV4 = ip
+		case kubeovnv1.ProtocolIPv6:
+			nextHopV6 = ip
+		}
+	}
+
+	var advertiseCIDRs []string
+	if vpc.Spec.InterConnection != nil {
+		advertiseCIDRs = vpc.Spec.InterConnection.AdvertiseCIDRs
+	}
+	var routes []icVpcRoute
+	advertised := set.New[string]()
+	for _, cidr := range cidrs {
+		// default routes are never advertised
+		if advertised.Has(cidr) || strings.HasSuffix(cidr, "/0") {
+			continue
+		}
+		if len(advertiseCIDRs) != 0 && !cidrContained(cidr, advertiseCIDRs) {
+			continue
+		}
+		nextHop := nextHopV4
+		if util.CheckProtocol(cidr) == kubeovnv1.ProtocolIPv6 {
+			nextHop = nextHopV6
+		}
+		if nextHop == "" {
+			continue
+		}
+		advertised.Insert(cidr)
+		routes = append(routes, icVpcRoute{CIDR: cidr, NextHop: nextHop, AZs: cidrAZs[cidr]})
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].CIDR < routes[j].CIDR })
+	return routes
+}
+
+// publishVpcRoutes writes the routes advertised by the vpcs of the availability zone to the transit switches
+// and removes the stale ones
+func (c *Controller) publishVpcRoutes(azName string, tsExternalIDs, advertisements map[string]map[string]string) error {
+	tsNames := set.New[string]()
+	for tsName := range tsExternalIDs {
+		tsNames.Insert(tsName)
+	}
+	for tsName := range advertisements {
+		tsNames.Insert(tsName)
+	}
+
+	prefix := icVpcRoutesKey(azName, "")
+	for _, tsName := range tsNames.SortedList() {
+		changes := make(map[string]string)
+		for key := range tsExternalIDs[tsName] {
+			if _, ok := advertisements[tsName][key]; !ok && strings.HasPrefix(key, prefix) {
+				changes[key] = ""
+			}
+		}
+		// the key is removed if the vpc advertises nothing
+		for key, value := range advertisements[tsName] {
+			if tsExternalIDs[tsName][key] != value {
+				changes[key] = value
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if err := c.ovnLegacyClient.SetTsExternalIDs(tsName, changes); err != nil {
+			klog.Errorf("failed to publish routes of vpcs to transit switch %s: %v", tsName, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// syncVpcLearnedRoutes installs the routes learned from the transit switch on the vpc router
+// and removes the stale ones
+func (c *Controller) syncVpcLearnedRoutes(vpcName, tsName string, routes []*kubeovnv1.VpcLearnedRoute) error {
+	existing, err := c.OVNNbClient.ListLogicalRouterStaticRoutes(vpcName, nil, nil, "", map[string]string{util.OvnICAvailabilityZoneKey: ""})
+	if err != nil {
+		klog.Errorf("failed to list learned static routes on logical router %s: %v", vpcName, err)
+		return err
+	}
+
+	wanted := make(map[string]*kubeovnv1.VpcLearnedRoute, len(routes))
+	for _, route := range routes {
+		wanted[route.CIDR+"-"+route.NextHopIP] = route
+	}
+	for _, route := range existing {
+		key := route.IPPrefix + "-" + route.Nexthop
+		if r := wanted[key]; r != nil && route.ExternalIDs[util.OvnICTransitSwitchKey] == tsName && route.ExternalIDs[util.OvnICAvailabilityZoneKey] == r.AvailabilityZone {
+			delete(wanted, key)
+			continue
+		}
+		klog.Infof("delete route %s via %s learned from transit switch %s on logical router %s", route.IPPrefix, route.Nexthop, route.ExternalIDs[util.OvnICTransitSwitchKey], vpcName)
+		if err = c.OVNNbClient.DeleteLogicalRouterStaticRoute(vpcName, &route.RouteTable, route.Policy, route.IPPrefix, route.Nexthop); err != nil {
+			klog.Errorf("failed to delete static route %s on logical router %s: %v", route.IPPrefix, vpcName, err)
+			return err
+		}
+	}
+	for _, route := range routes {
+		if wanted[route.CIDR+"-"+route.NextHopIP] == nil {
+			continue
+		}
+		klog.Infof("add route %s via %s learned from availability zone %s to logical router %s", route.CIDR, route.NextHopIP, route.AvailabilityZone, vpcName)
+		externalIDs := map[string]string{util.OvnICTransitSwitchKey: tsName, util.OvnICAvailabilityZoneKey: route.AvailabilityZone}
+		if err = c.OVNNbClient.AddLogicalRouterStaticRouteWithExternalIDs(vpcName, util.MainRouteTable, ovnnb.LogicalRouterStaticRoutePolicyDstIP, route.CIDR, route.NextHopIP, externalIDs); err != nil {
+			klog.Errorf("failed to add static route %s on logical router %s: %v", route.CIDR, vpcName, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// listVpcLearnedRoutes lists the routes learned by the vpc router, the availability zones of the routes are looked up in the routes of ovn-ic
+func (c *Controller) listVpcLearnedRoutes(vpcName string, icRoutes []ovs.ICRoute) ([]*kubeovnv1.VpcLearnedRoute, error) {
+	routes, err := c.OVNNbClient.ListLogicalRouterStaticRoutes(vpcName, nil, nil, "", map[string]string{"ic-learned-route": ""})
+	if err != nil {
+		klog.Errorf("failed to list learned static routes on logical router %s: %v", vpcName, err)
+		return nil, err
+	}
+	learnedRoutes := make([]*kubeovnv1.VpcLearnedRoute, 0, len(routes))
+	for _, r := range routes {
+		route := &kubeovnv1.VpcLearnedRoute{
+			CIDR:       r.IPPrefix,
+			NextHopIP:  r.Nexthop,
+			RouteTable: r.RouteTable,
+		}
+		for _, icRoute := range icRoutes {
+			if icRoute.IPPrefix == r.IPPrefix && icRoute.Nexthop == r.Nexthop {
+				route.AvailabilityZone = icRoute.AvailabilityZone
+				break
+			}
+		}
+		learnedRoutes = append(learnedRoutes, route)
+	}
+	sortVpcLearnedRoutes(learnedRoutes)
+	return learnedRoutes, nil
+}
+
+func sortVpcLearnedRoutes(routes []*kubeovnv1.VpcLearnedRoute) {
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].CIDR != routes[j].CIDR {
+			return routes[i].CIDR < routes[j].CIDR
+		}
+		return routes[i].NextHopIP < routes[j].NextHopIP
+	})
+}
+
+// splitICRoutes returns the routes advertised by the local availability zone to the transit switches
+// and the routes advertised by other availability zones
+func splitICRoutes(icRoutes []ovs.ICRoute, azName string, tsNames []string) ([]string, []*kubeovnv1.VpcLearnedRoute) {
+	advertised := set.New[string]()
+	var peerRoutes []*kubeovnv1.VpcLearnedRoute
+	for _, route := range icRoutes {
+		if !slices.Contains(tsNames, route.TransitSwitch) {
+			continue
+		}
+		if route.AvailabilityZone == azName {
+			advertised.Insert(route.IPPrefix)
+			continue
+		}
+		peerRoutes = append(peerRoutes, &kubeovnv1.VpcLearnedRoute{
+			CIDR:             route.IPPrefix,
+			NextHopIP:        route.Nexthop,
+			RouteTable:       route.RouteTable,
+			AvailabilityZone: route.AvailabilityZone,
+		})
+	}
+	sortVpcLearnedRoutes(peerRoutes)
+	return advertised.SortedList(), peerRoutes
+}
+
+// filterLearnedRoutes returns the routes accepted and rejected by the vpc.
+// A route is rejected if it is not accepted by the route filter, or it is more specific than
+// a subnet of the vpc, which overrides the route of the subnet silently.
+func filterLearnedRoutes(routes []*kubeovnv1.VpcLearnedRoute, acceptCIDRs, subnetCIDRs []string) ([]*kubeovnv1.VpcLearnedRoute, []*kubeovnv1.VpcLearnedRoute) {
+	var accepted, rejected []*kubeovnv1.VpcLearnedRoute
+	for _, route := range routes {
+		var reason string
+		if len(acceptCIDRs) != 0 && !cidrContained(route.CIDR, acceptCIDRs) {
+			reason = "not accepted by the route filter"
+		} else if subnetCIDR := overriddenSubnetCIDR(route.CIDR, subnetCIDRs); subnetCIDR != "" {
+			reason = fmt.Sprintf("overrides the route of subnet CIDR %s", subnetCIDR)
+		}
+		if reason == "" {
+			accepted = append(accepted, route)
+			continue
+		}
+		r := *route
+		r.Reason = reason
+		rejected = append(rejected, &r)
+	}
+	return accepted, rejected
+}
+
+// overriddenSubnetCIDR returns the subnet CIDR strictly containing the route prefix,
+// routes of the same prefix are not rejected since the connected route of the subnet takes precedence
+func overriddenSubnetCIDR(prefix string, subnetCIDRs []string) string {
+	for _, cidr := range subnetCIDRs {
+		if cidrContained(prefix, []string{cidr}) && !cidrContained(cidr, []string{prefix}) {
+			return cidr
+		}
+	}
+	return ""
+}
+
+// vpcSubnetCIDRs returns the CIDRs of the subnets in the vpc
+func (c *Controller) vpcSubnetCIDRs(vpcName string, subnets []*kubeovnv1.Subnet) []string {
+	var cidrs []string
+	for _, subnet := range subnets {
+		subnetVpc := subnet.Spec.Vpc
+		if subnetVpc == "" {
+			subnetVpc = c.config.ClusterRouter
+		}
+		if subnetVpc == vpcName && subnet.Spec.CIDRBlock != "" {
+			cidrs = append(cidrs, strings.Split(subnet.Spec.CIDRBlock, ",")...)
+		}
+	}
+	return cidrs
+}
+
+// patchVpcInterConnectionStatus patches only the status fields owned by ovn-ic-controller
+func (c *Controller) patchVpcInterConnectionStatus(vpc *kubeovnv1.Vpc, tsName string, learned []*kubeovnv1.VpcLearnedRoute, advertised []string, rejected []*kubeovnv1.VpcLearnedRoute) {
+	if vpc.Status.InterConnectionTransitSwitch == tsName &&
+		((len(vpc.Status.LearnedRoutes) == 0 && len(learned) == 0) || reflect.DeepEqual(vpc.Status.LearnedRoutes, learned)) &&
+		((len(vpc.Status.AdvertisedRoutes) == 0 && len(advertised) == 0) || reflect.DeepEqual(vpc.Status.AdvertisedRoutes, advertised)) &&
+		((len(vpc.Status.RejectedRoutes) == 0 && len(rejected) == 0) || reflect.DeepEqual(vpc.Status.RejectedRoutes, rejected)) {
+		return
+	}
+
+	status := map[string]interface{}{
+		"interConnectionTransitSwitch": tsName,
+		"learnedRoutes":                learned,
+		"advertisedRoutes":             advertised,
+		"rejectedRoutes":               rejected,
+	}
+	bytes, err := json.Marshal(map[string]interface{}{"status": status})
+	if err != nil {
+		klog.Error(err)
+		return
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().Vpcs().Patch(context.Background(), vpc.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("failed to patch inter connection status of vpc %s: %v", vpc.Name, err)
+	}
+}
+
+// vpcAdvertiseBlackList returns the CIDRs of the vpc not allowed to be advertised by the advertisement filter.
+// OVN-IC only supports a global blacklist, so the CIDRs are neither advertised nor learned by other vpcs.
+func vpcAdvertiseBlackList(vpc *kubeovnv1.Vpc, subnetCIDRs []string) []string {
+	if vpc.Spec.InterConnection == nil || len(vpc.Spec.InterConnection.AdvertiseCIDRs) == 0 {
+		return nil
+	}
+	advertiseCIDRs := vpc.Spec.InterConnection.AdvertiseCIDRs
+
+	cidrs := slices.Clone(subnetCIDRs)
+	for _, route := range vpc.Spec.StaticRoutes {
+		cidrs = append(cidrs, route.CIDR)
+	}
+
+	var blackList []string
+	for _, cidr := range cidrs {
+		if !cidrContained(cidr, advertiseCIDRs) {
+			blackList = append(blackList, cidr)
+		}
+	}
+	return blackList
+}
+
+// cidrContained checks whether the CIDR is contained by one of the CIDRs
+func cidrContained(cidr string, cidrs []string) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		// route of a single ip
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return false
+		}
+		bits := 128
+		if ip.To4() != nil {
+			bits = 32
+		}
+		ipNet = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+	}
+	ones, bits := ipNet.Mask.Size()
+	for _, s := range cidrs {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			continue
+		}
+		nOnes, nBits := n.Mask.Size()
+		if nBits == bits && nOnes <= ones && n.Contains(ipNet.IP) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package ovn_ic_controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mockovs "github.com/kubeovn/kube-ovn/mocks/pkg/ovs"
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_vpcAdvertiseBlackList(t *testing.T) {
+	t.Parallel()
+
+	subnetCIDRs := []string{"10.0.1.0/24", "fd00:10:1::/120", "10.1.0.0/24"}
+	vpc := &kubeovnv1.Vpc{
+		ObjectMeta: metav1.ObjectMeta{Name: "vpc1"},
+		Spec: kubeovnv1.VpcSpec{
+			StaticRoutes: []*kubeovnv1.StaticRoute{
+				{CIDR: "10.0.2.1", NextHopIP: "10.0.1.254"},
+				{CIDR: "0.0.0.0/0", NextHopIP: "10.0.1.254"},
+			},
+			InterConnection: &kubeovnv1.VpcInterConnection{TransitSwitch: "tenant-a"},
+		},
+	}
+	require.Empty(t, vpcAdvertiseBlackList(vpc, subnetCIDRs))
+
+	vpc.Spec.InterConnection.AdvertiseCIDRs = []string{"10.0.0.0/16", "fd00:10::/32"}
+	require.Equal(t, []string{"10.1.0.0/24", "0.0.0.0/0"}, vpcAdvertiseBlackList(vpc, subnetCIDRs))
+
+	vpc.Spec.InterConnection.AdvertiseCIDRs = []string{"10.0.1.0/25"}
+	require.Equal(t, []string{"10.0.1.0/24", "fd00:10:1::/120", "10.1.0.0/24", "10.0.2.1", "0.0.0.0/0"}, vpcAdvertiseBlackList(vpc, subnetCIDRs))
+}
+
+func Test_splitICRoutes(t *testing.T) {
+	t.Parallel()
+
+	icRoutes := []ovs.ICRoute{
+		{AvailabilityZone: "az1", TransitSwitch: "ts", IPPrefix: "10.0.1.0/24", Nexthop: "169.254.100.1"},
+		{AvailabilityZone: "az1", TransitSwitch: "tenant-b", IPPrefix: "10.9.0.0/24", Nexthop: "169.254.201.1"},
+		{AvailabilityZone: "az2", TransitSwitch: "ts", IPPrefix: "10.0.2.0/24", Nexthop: "169.254.100.2"},
+		{AvailabilityZone: "az3", TransitSwitch: "tenant-b", IPPrefix: "10.0.1.1/32", Nexthop: "169.254.201.3"},
+	}
+	advertised, peerRoutes := splitICRoutes(icRoutes, "az1", []string{"ts"})
+	require.Equal(t, []string{"10.0.1.0/24"}, advertised)
+	require.Equal(t, []*kubeovnv1.VpcLearnedRoute{
+		{CIDR: "10.0.2.0/24", NextHopIP: "169.254.100.2", AvailabilityZone: "az2"},
+	}, peerRoutes)
+}
+
+func Test_filterLearnedRoutes(t *testing.T) {
+	t.Parallel()
+
+	routes := []*kubeovnv1.VpcLearnedRoute{
+		{CIDR: "10.0.1.0/24", NextHopIP: "169.254.200.3", AvailabilityZone: "az3"},
+		{CIDR: "10.0.1.128/25", NextHopIP: "169.254.200.2", AvailabilityZone: "az2"},
+		{CIDR: "10.0.2.0/24", NextHopIP: "169.254.200.2", AvailabilityZone: "az2"},
+		{CIDR: "192.168.0.0/24", NextHopIP: "169.254.200.3", AvailabilityZone: "az3"},
+	}
+	subnetCIDRs := []string{"10.0.1.0/24"}
+
+	accepted, rejected := filterLearnedRoutes(routes, nil, subnetCIDRs)
+	require.Equal(t, []*kubeovnv1.VpcLearnedRoute{routes[0], routes[2], routes[3]}, accepted)
+	require.Equal(t, []*kubeovnv1.VpcLearnedRoute{
+		{CIDR: "10.0.1.128/25", NextHopIP: "169.254.200.2", AvailabilityZone: "az2", Reason: "overrides the route of subnet CIDR 10.0.1.0/24"},
+	}, rejected)
+
+	accepted, rejected = filterLearnedRoutes(routes, []string{"10.0.0.0/8"}, subnetCIDRs)
+	require.Equal(t, []*kubeovnv1.VpcLearnedRoute{routes[0], routes[2]}, accepted)
+	require.Equal(t, []*kubeovnv1.VpcLearnedRoute{
+		{CIDR: "10.0.1.128/25", NextHopIP: "169.254.200.2", AvailabilityZone: "az2", Reason: "overrides the route of subnet CIDR 10.0.1.0/24"},
+		{CIDR: "192.168.0.0/24", NextHopIP: "169.254.200.3", AvailabilityZone: "az3", Reason: "not accepted by the route filter"},
+	}, rejected)
+	// the learned routes are not modified
+	require.Empty(t, routes[1].Reason)
+}
+
+func Test_icVpcRoutes(t *testing.T) {
+	t.Parallel()
+
+	routes := []icVpcRoute{
+		{CIDR: "10.0.1.0/24", NextHop: "169.254.200.1", AZs: []string{}},
+		{CIDR: "fd00:10:1::/120", NextHop: "fe80:a9fe:c8::1", AZs: []string{"az2", "az3"}},
+	}
+	value := formatICVpcRoutes(routes)
+	require.Equal(t, "10.0.1.0/24,169.254.200.1;fd00:10:1::/120,fe80:a9fe:c8::1,az2,az3", value)
+	require.Equal(t, routes, parseICVpcRoutes(value))
+	require.Empty(t, parseICVpcRoutes(""))
+	require.Equal(t, routes[:1], parseICVpcRoutes("10.0.1.0/24,169.254.200.1;10.0.2.0/24;10.0.3.0/24,abc"))
+
+	tsExternalIDs := map[string]string{
+		util.OvnICVpcKey:                "true",
+		"subnet":                        "169.254.200.0/24",
+		icVpcRoutesKey("az1", "vpc1"):   "10.0.1.0/24,169.254.200.1",
+		icVpcRoutesKey("az2", "vpc1"):   "10.0.2.0/24,169.254.200.2;10.0.1.0/24,169.254.200.2",
+		icVpcRoutesKey("az-3", "vpc-1"): "10.0.3.0/24,169.254.200.3;10.0.4.0/24,169.254.200.3,az2;10.0.5.0/24,169.254.200.3,az2,az1",
+	}
+	require.Equal(t, []*kubeovnv1.VpcLearnedRoute{
+		{CIDR: "10.0.1.0/24", NextHopIP: "169.254.200.2", AvailabilityZone: "az2"},
+		{CIDR: "10.0.2.0/24", NextHopIP: "169.254.200.2", AvailabilityZone: "az2"},
+		{CIDR: "10.0.3.0/24", NextHopIP: "169.254.200.3", AvailabilityZone: "az-3"},
+		{CIDR: "10.0.5.0/24", NextHopIP: "169.254.200.3", AvailabilityZone: "az-3"},
+	}, peerICVpcRoutes(tsExternalIDs, "az1"))
+}
+
+func Test_vpcAdvertisedRoutes(t *testing.T) {
+	t.Parallel()
+
+	c := &Controller{config: &Configuration{ClusterRouter: util.DefaultVpc}}
+	subnets := []*kubeovnv1.Subnet{
+		{Spec: kubeovnv1.SubnetSpec{Vpc: "vpc1", CIDRBlock: "10.0.1.0/24,fd00:10:1::/120"}},
+		{Spec: kubeovnv1.SubnetSpec{Vpc: "vpc1", CIDRBlock: "10.1.0.0/24", InterConnectionAZs: []string{"az2"}}},
+		{Spec: kubeovnv1.SubnetSpec{Vpc: "vpc1", CIDRBlock: "10.2.0.0/24", DisableInterConnection: true}},
+		{Spec: kubeovnv1.SubnetSpec{Vpc: "vpc2", CIDRBlock: "10.3.0.0/24"}},
+	}
+	vpc := &kubeovnv1.Vpc{
+		ObjectMeta: metav1.ObjectMeta{Name: "vpc1"},
+		Spec: kubeovnv1.VpcSpec{
+			StaticRoutes: []*kubeovnv1.StaticRoute{
+				{CIDR: "10.0.2.1", NextHopIP: "10.0.1.254"},
+				{CIDR: "0.0.0.0/0", NextHopIP: "10.0.1.254"},
+				{CIDR: "10.4.0.0/24", NextHopIP: "10.0.1.254", RouteTable: "rtb1"},
+				{CIDR: "10.0.1.0/24", NextHopIP: "10.0.1.254", Policy: kubeovnv1.PolicySrc},
+			},
+			InterConnection: &kubeovnv1.VpcInterConnection{TransitSwitch: "tenant-a"},
+		},
+	}
+	lrpNetworks := []string{"169.254.200.1/24", "fe80:a9fe:c8::1/112"}
+
+	require.Equal(t, []icVpcRoute{
+		{CIDR: "10.0.1.0/24", NextHop: "169.254.200.1"},
+		{CIDR: "10.0.2.1", NextHop: "169.254.200.1"},
+		{CIDR: "10.1.0.0/24", NextHop: "169.254.200.1", AZs: []string{"az2"}},
+		{CIDR: "fd00:10:1::/120", NextHop: "fe80:a9fe:c8::1"},
+	}, c.vpcAdvertisedRoutes(vpc, subnets, lrpNetworks))
+
+	// the ipv6 routes are not advertised without an ipv6 address of the transit switch port
+	vpc.Spec.InterConnection.AdvertiseCIDRs = []string{"10.0.0.0/16", "fd00:10::/32"}
+	require.Equal(t, []icVpcRoute{
+		{CIDR: "10.0.1.0/24", NextHop: "169.254.200.1"},
+		{CIDR: "10.0.2.1", NextHop: "169.254.200.1"},
+	}, c.vpcAdvertisedRoutes(vpc, subnets, lrpNetworks[:1]))
+}
+
+func Test_syncVpcLearnedRoutes(t *testing.T) {
+	t.Parallel()
+
+	mockOvnClient := mockovs.NewMockNbClient(gomock.NewController(t))
+	c := &Controller{OVNNbClient: mockOvnClient}
+
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	existing := []*ovnnb.LogicalRouterStaticRoute{
+		{
+			IPPrefix:    "10.0.1.0/24",
+			Nexthop:     "169.254.200.2",
+			Policy:      &policy,
+			ExternalIDs: map[string]string{util.OvnICTransitSwitchKey: "tenant-a", util.OvnICAvailabilityZoneKey: "az2"},
+		},
+		{
+			IPPrefix:    "10.0.9.0/24",
+			Nexthop:     "169.254.200.2",
+			Policy:      &policy,
+			ExternalIDs: map[string]string{util.OvnICTransitSwitchKey: "tenant-a", util.OvnICAvailabilityZoneKey: "az2"},
+		},
+	}
+	routes := []*kubeovnv1.VpcLearnedRoute{
+		{CIDR: "10.0.1.0/24", NextHopIP: "169.254.200.2", AvailabilityZone: "az2"},
+		{CIDR: "10.0.3.0/24", NextHopIP: "169.254.200.3", AvailabilityZone: "az3"},
+	}
+
+	mockOvnClient.EXPECT().ListLogicalRouterStaticRoutes("vpc1", nil, nil, "", map[string]string{util.OvnICAvailabilityZoneKey: ""}).Return(existing, nil)
+	mockOvnClient.EXPECT().DeleteLogicalRouterStaticRoute("vpc1", &existing[1].RouteTable, &policy, "10.0.9.0/24", "169.254.200.2").Return(nil)
+	mockOvnClient.EXPECT().AddLogicalRouterStaticRouteWithExternalIDs("vpc1", util.MainRouteTable, policy, "10.0.3.0/24", "169.254.200.3",
+		map[string]string{util.OvnICTransitSwitchKey: "tenant-a", util.OvnICAvailabilityZoneKey: "az3"}).Return(nil)
+	require.NoError(t, c.syncVpcLearnedRoutes("vpc1", "tenant-a", routes))
+
+	// all the learned routes are removed
+	mockOvnClient.EXPECT().ListLogicalRouterStaticRoutes("vpc1", nil, nil, "", map[string]string{util.OvnICAvailabilityZoneKey: ""}).Return(existing[:1], nil)
+	mockOvnClient.EXPECT().DeleteLogicalRouterStaticRoute("vpc1", &existing[0].RouteTable, &policy, "10.0.1.0/24", "169.254.200.2").Return(nil)
+	require.NoError(t, c.syncVpcLearnedRoutes("vpc1", "", nil))
+}
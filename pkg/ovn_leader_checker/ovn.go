@@ -412,14 +412,21 @@ func getTSCidr(index int) (string, error) {
 }
 
 func updateTS() error {
-	cmd := exec.Command("ovn-ic-nbctl", "show")
+	cmd := exec.Command("ovn-ic-nbctl", "--format=csv", "--data=bare", "--no-heading", "--columns=name,external_ids", "list", "Transit_Switch")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("ovn-ic-nbctl show output: %s, err: %v", output, err)
+		return fmt.Errorf("ovn-ic-nbctl list Transit_Switch output: %s, err: %v", output, err)
 	}
+	tsExternalIDs, err := ovs.ParseTransitSwitches(string(output))
+	if err != nil {
+		return fmt.Errorf("failed to parse transit switches %s, err: %v", output, err)
+	}
+	// transit switches of custom vpcs are managed by ovn-ic-controller
 	var existTSCount int
-	if lines := strings.TrimSpace(string(output)); lines != "" {
-		existTSCount = len(strings.Split(lines, "\n"))
+	for _, externalIDs := range tsExternalIDs {
+		if externalIDs[util.OvnICVpcKey] != "true" {
+			existTSCount++
+		}
 	}
 	expectTSCount, err := strconv.Atoi(os.Getenv("TS_NUM"))
 	if err != nil {
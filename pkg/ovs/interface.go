@@ -2,6 +2,7 @@ package ovs
 
 import (
 	netv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/network-policy-api/apis/v1alpha1"
 
 	"github.com/ovn-org/libovsdb/ovsdb"
 
@@ -46,6 +47,7 @@ type LogicalRouterPort interface {
 }
 
 type BFD interface {
+	ListBFD(lrpName, dstIP string) ([]ovnnb.BFD, error)
 	CreateBFD(lrpName, dstIP string, minRx, minTx, detectMult int) (*ovnnb.BFD, error)
 	DeleteBFD(lrpName, dstIP string) error
 }
@@ -125,6 +127,7 @@ type PortGroup interface {
 
 type ACL interface {
 	UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol, aclName string, npp []netv1.NetworkPolicyPort, logEnable bool, logACLActions []ovnnb.ACLAction, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error)
+	UpdateAnpRuleACLOps(pgName, asName, protocol, aclName string, priority, tier int, aclAction ovnnb.ACLAction, rulePorts []v1alpha1.AdminNetworkPolicyPort, isIngress, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error)
 	UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, aclName string, npp []netv1.NetworkPolicyPort, logEnable bool, logACLActions []ovnnb.ACLAction, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error)
 	CreateGatewayACL(lsName, pgName, gateway, u2oInterconnectionIP string) error
 	CreateNodeACL(pgName, nodeIPStr, joinIPStr string) error
@@ -137,6 +140,8 @@ type ACL interface {
 	SGLostACL(sg *kubeovnv1.SecurityGroup) (bool, error)
 	DeleteAcls(parentName, parentType, direction string, externalIDs map[string]string) error
 	DeleteAclsOps(parentName, parentType, direction string, externalIDs map[string]string) ([]ovsdb.Operation, error)
+	ListAcls(direction string, externalIDs map[string]string) ([]ovnnb.ACL, error)
+	MigrateACLTier() error
 }
 
 type AddressSet interface {
@@ -149,6 +154,7 @@ type AddressSet interface {
 
 type LogicalRouterStaticRoute interface {
 	AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix string, bfdID *string, nexthops ...string) error
+	AddLogicalRouterStaticRouteWithExternalIDs(lrName, routeTable, policy, ipPrefix, nexthop string, externalIDs map[string]string) error
 	ClearLogicalRouterStaticRoute(lrName string) error
 	DeleteLogicalRouterStaticRoute(lrName string, routeTable, policy *string, ipPrefix, nextHop string) error
 	ListLogicalRouterStaticRoutesByOption(lrName, routeTable, key, value string) ([]*ovnnb.LogicalRouterStaticRoute, error)
@@ -171,8 +177,8 @@ type LogicalRouterPolicy interface {
 type NAT interface {
 	GetNATByUUID(uuid string) (*ovnnb.NAT, error)
 	AddNat(lrName, natType, externalIP, logicalIP, logicalMac, port string, options map[string]string) error
-	UpdateSnat(lrName, externalIP, logicalIP string) error
-	UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType string) error
+	UpdateSnat(lrName, externalIP, logicalIP, externalPortRange string) error
+	UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType string, stateless bool) error
 	DeleteNats(lrName, natType, logicalIP string) error
 	DeleteNat(lrName, natType, externalIP, logicalIP string) error
 	NatExists(lrName, natType, externalIP, logicalIP string) (bool, error)
@@ -180,10 +186,12 @@ type NAT interface {
 }
 
 type DHCPOptions interface {
-	UpdateDHCPOptions(subnet *kubeovnv1.Subnet, mtu int) (*DHCPOptionsUUIDs, error)
+	UpdateDHCPOptions(subnet *kubeovnv1.Subnet, mtu int, spec *kubeovnv1.DHCPOptionsSpec) (*DHCPOptionsUUIDs, error)
 	DeleteDHCPOptions(lsName, protocol string) error
 	DeleteDHCPOptionsByUUIDs(uuidList ...string) error
 	ListDHCPOptions(needVendorFilter bool, externalIDs map[string]string) ([]ovnnb.DHCPOptions, error)
+	UpdateLogicalSwitchPortDHCPv4Options(lsName, lspName string, options map[string]string) (string, error)
+	DeleteLogicalSwitchPortDHCPOptions(lspName string) error
 }
 
 type NbClient interface {
@@ -212,6 +220,7 @@ type NbClient interface {
 
 type SbClient interface {
 	Chassis
+	LogicalFlow
 	Common
 }
 
@@ -231,3 +240,7 @@ type Chassis interface {
 	UpdateChassis(chassis *ovnsb.Chassis, fields ...interface{}) error
 	ListChassis() (*[]ovnsb.Chassis, error)
 }
+
+type LogicalFlow interface {
+	GetLogicalFlowCookies(uuids []string) (map[string][]uint64, error)
+}
@@ -1,12 +1,16 @@
 package ovs
 
 import (
+	"encoding/csv"
 	"fmt"
 	"os/exec"
+	"sort"
 	"strings"
 	"time"
 
 	"k8s.io/klog/v2"
+
+	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
 func (c LegacyClient) ovnIcNbCommand(cmdArgs ...string) (string, error) {
@@ -46,19 +50,127 @@ func (c LegacyClient) GetTsSubnet(ts string) (string, error) {
 	return subnet, nil
 }
 
+// GetTs returns the transit switches connected to the default vpc
 func (c LegacyClient) GetTs() ([]string, error) {
-	cmd := []string{"--format=csv", "--data=bare", "--no-heading", "--columns=name", "list", "Transit_Switch"}
+	tsExternalIDs, err := c.listTs()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(tsExternalIDs))
+	for ts, externalIDs := range tsExternalIDs {
+		if externalIDs[util.OvnICVpcKey] != "true" {
+			result = append(result, ts)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// GetVpcTs returns the transit switches connected to the custom vpcs
+func (c LegacyClient) GetVpcTs() ([]string, error) {
+	tsExternalIDs, err := c.listTs()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(tsExternalIDs))
+	for ts, externalIDs := range tsExternalIDs {
+		if externalIDs[util.OvnICVpcKey] == "true" {
+			result = append(result, ts)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (c LegacyClient) listTs() (map[string]map[string]string, error) {
+	cmd := []string{"--format=csv", "--data=bare", "--no-heading", "--columns=name,external_ids", "list", "Transit_Switch"}
 	output, err := c.ovnIcNbCommand(cmd...)
 	if err != nil {
 		klog.Errorf("failed to list transit switch: %v", err)
 		return nil, err
 	}
-	lines := strings.Split(output, "\n")
-	result := make([]string, 0, len(lines))
-	for _, l := range lines {
-		if l = strings.TrimSpace(l); len(l) != 0 {
-			result = append(result, l)
+	result, err := ParseTransitSwitches(output)
+	if err != nil {
+		klog.Errorf("failed to parse transit switches: %v", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateVpcTs creates the transit switch for custom vpcs if it does not exist
+func (c LegacyClient) CreateVpcTs(ts, subnet string) error {
+	cmd := []string{MayExist, "ts-add", ts, "--", "set", "Transit_Switch", ts, fmt.Sprintf("external_ids:%s=true", util.OvnICVpcKey)}
+	if subnet != "" {
+		cmd = append(cmd, fmt.Sprintf(`external_ids:subnet="%s"`, subnet))
+	}
+	if _, err := c.ovnIcNbCommand(cmd...); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("failed to create transit switch %s, %v", ts, err)
+	}
+	return nil
+}
+
+// ListVpcTsExternalIDs returns the external ids of the transit switches connected to the custom vpcs
+func (c LegacyClient) ListVpcTsExternalIDs() (map[string]map[string]string, error) {
+	tsExternalIDs, err := c.listTs()
+	if err != nil {
+		return nil, err
+	}
+	for ts, externalIDs := range tsExternalIDs {
+		if externalIDs[util.OvnICVpcKey] != "true" {
+			delete(tsExternalIDs, ts)
+		}
+	}
+	return tsExternalIDs, nil
+}
+
+// SetTsExternalIDs sets the external ids of the transit switch, the keys with empty values are removed
+func (c LegacyClient) SetTsExternalIDs(ts string, externalIDs map[string]string) error {
+	keys := make([]string, 0, len(externalIDs))
+	for k := range externalIDs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var cmd []string
+	for _, k := range keys {
+		if len(cmd) != 0 {
+			cmd = append(cmd, "--")
+		}
+		if v := externalIDs[k]; v != "" {
+			cmd = append(cmd, "set", "Transit_Switch", ts, fmt.Sprintf(`external_ids:"%s"="%s"`, k, v))
+		} else {
+			cmd = append(cmd, "remove", "Transit_Switch", ts, "external_ids", fmt.Sprintf(`"%s"`, k))
+		}
+	}
+	if len(cmd) == 0 {
+		return nil
+	}
+	if _, err := c.ovnIcNbCommand(cmd...); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("failed to set external ids of transit switch %s, %v", ts, err)
+	}
+	return nil
+}
+
+// ParseTransitSwitches parses the csv output of listing the name and external_ids of transit switches
+func ParseTransitSwitches(output string) (map[string]map[string]string, error) {
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]string, len(records))
+	for _, record := range records {
+		if len(record) != 2 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		externalIDs := make(map[string]string)
+		for _, kv := range strings.Fields(record[1]) {
+			if k, v, found := strings.Cut(kv, "="); found {
+				externalIDs[k] = strings.Trim(v, `"`)
+			}
 		}
+		result[strings.TrimSpace(record[0])] = externalIDs
 	}
 	return result, nil
 }
@@ -0,0 +1,29 @@
+package ovs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransitSwitches(t *testing.T) {
+	t.Parallel()
+
+	output := `ts,subnet=169.254.100.0/24
+ts1,"subnet=169.254.101.0/24,fe80:a9fe:65::/112"
+tenant-a,ic-vpc=true subnet=169.254.200.0/24
+ts2,
+`
+	tsExternalIDs, err := ParseTransitSwitches(output)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"ts":       {"subnet": "169.254.100.0/24"},
+		"ts1":      {"subnet": "169.254.101.0/24,fe80:a9fe:65::/112"},
+		"tenant-a": {"ic-vpc": "true", "subnet": "169.254.200.0/24"},
+		"ts2":      {},
+	}, tsExternalIDs)
+
+	tsExternalIDs, err = ParseTransitSwitches("")
+	require.NoError(t, err)
+	require.Empty(t, tsExternalIDs)
+}
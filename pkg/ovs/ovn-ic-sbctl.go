@@ -1,6 +1,7 @@
 package ovs
 
 import (
+	"encoding/csv"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -138,3 +139,60 @@ func (c LegacyClient) DestroyChassis(uuid string) error {
 	}
 	return nil
 }
+
+// ICRoute is a route advertised to the OVN-IC southbound database
+type ICRoute struct {
+	AvailabilityZone string
+	TransitSwitch    string
+	RouteTable       string
+	IPPrefix         string
+	Nexthop          string
+	Origin           string
+}
+
+// ListICRoutes lists the routes advertised by all the availability zones
+func (c LegacyClient) ListICRoutes() ([]ICRoute, error) {
+	output, err := c.ovnIcSbCommand("--format=csv", "--no-heading", "--data=bare", "--columns=_uuid,name", "list", "Availability_Zone")
+	if err != nil {
+		klog.Errorf("failed to list availability zones: %v", err)
+		return nil, err
+	}
+	azs, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		klog.Errorf("failed to parse availability zones: %v", err)
+		return nil, err
+	}
+	azNames := make(map[string]string, len(azs))
+	for _, az := range azs {
+		if len(az) == 2 {
+			azNames[strings.TrimSpace(az[0])] = strings.TrimSpace(az[1])
+		}
+	}
+
+	output, err = c.ovnIcSbCommand("--format=csv", "--no-heading", "--data=bare",
+		"--columns=availability_zone,transit_switch,route_table,ip_prefix,nexthop,origin", "list", "Route")
+	if err != nil {
+		klog.Errorf("failed to list routes: %v", err)
+		return nil, err
+	}
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		klog.Errorf("failed to parse routes: %v", err)
+		return nil, err
+	}
+	routes := make([]ICRoute, 0, len(records))
+	for _, record := range records {
+		if len(record) != 6 {
+			continue
+		}
+		routes = append(routes, ICRoute{
+			AvailabilityZone: azNames[strings.TrimSpace(record[0])],
+			TransitSwitch:    record[1],
+			RouteTable:       record[2],
+			IPPrefix:         record[3],
+			Nexthop:          record[4],
+			Origin:           record[5],
+		})
+	}
+	return routes, nil
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/ovn-org/libovsdb/model"
 	"github.com/ovn-org/libovsdb/ovsdb"
 	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/set"
+	"sigs.k8s.io/network-policy-api/apis/v1alpha1"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	ovsclient "github.com/kubeovn/kube-ovn/pkg/ovsdb/client"
@@ -32,6 +34,7 @@ func (c *OVNNbClient) UpdateIngressACLOps(pgName, asIngressName, asExceptName, p
 			NewACLMatch("ip", "", "", ""),
 		)
 		options := func(acl *ovnnb.ACL) {
+			acl.ExternalIDs[util.NetworkPolicyRuleKey] = networkPolicyRule("ingress", "default")
 			if logEnable {
 				acl.Log = true
 				acl.Severity = &ovnnb.ACLSeverityWarning
@@ -50,6 +53,7 @@ func (c *OVNNbClient) UpdateIngressACLOps(pgName, asIngressName, asExceptName, p
 	matches := newNetworkPolicyACLMatch(pgName, asIngressName, asExceptName, protocol, ovnnb.ACLDirectionToLport, npp, namedPortMap)
 	for _, m := range matches {
 		options := func(acl *ovnnb.ACL) {
+			acl.ExternalIDs[util.NetworkPolicyRuleKey] = networkPolicyRule("ingress", aclName)
 			if logEnable && slices.Contains(logACLActions, ovnnb.ACLActionAllow) {
 				acl.Name = &aclName
 				acl.Log = true
@@ -71,6 +75,12 @@ func (c *OVNNbClient) UpdateIngressACLOps(pgName, asIngressName, asExceptName, p
 	return ops, nil
 }
 
+// networkPolicyRule returns the rule of a network policy acl from the acl name,
+// e.g. np/test.default/ingress/IPv4/0 -> ingress/0
+func networkPolicyRule(direction, aclName string) string {
+	return direction + "/" + aclName[strings.LastIndex(aclName, "/")+1:]
+}
+
 // UpdateEgressACLOps return operation that creates an egress ACL
 func (c *OVNNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, aclName string, npp []netv1.NetworkPolicyPort, logEnable bool, logACLActions []ovnnb.ACLAction, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
 	acls := make([]*ovnnb.ACL, 0)
@@ -83,6 +93,7 @@ func (c *OVNNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, pro
 			NewACLMatch("ip", "", "", ""),
 		)
 		options := func(acl *ovnnb.ACL) {
+			acl.ExternalIDs[util.NetworkPolicyRuleKey] = networkPolicyRule("egress", "default")
 			if logEnable {
 				acl.Log = true
 				acl.Severity = &ovnnb.ACLSeverityWarning
@@ -107,6 +118,7 @@ func (c *OVNNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, pro
 	matches := newNetworkPolicyACLMatch(pgName, asEgressName, asExceptName, protocol, ovnnb.ACLDirectionFromLport, npp, namedPortMap)
 	for _, m := range matches {
 		allowACL, err := c.newACLWithoutCheck(pgName, ovnnb.ACLDirectionFromLport, util.EgressAllowPriority, m, ovnnb.ACLActionAllowRelated, func(acl *ovnnb.ACL) {
+			acl.ExternalIDs[util.NetworkPolicyRuleKey] = networkPolicyRule("egress", aclName)
 			if acl.Options == nil {
 				acl.Options = make(map[string]string)
 			}
@@ -133,6 +145,46 @@ func (c *OVNNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, pro
 	return ops, nil
 }
 
+// UpdateAnpRuleACLOps return operations that create the acls of an admin network policy rule,
+// traffic matching pass acls is handed over to the next tier
+func (c *OVNNbClient) UpdateAnpRuleACLOps(pgName, asName, protocol, aclName string, priority, tier int, aclAction ovnnb.ACLAction, rulePorts []v1alpha1.AdminNetworkPolicyPort, isIngress, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
+	direction := ovnnb.ACLDirectionToLport
+	if !isIngress {
+		direction = ovnnb.ACLDirectionFromLport
+	}
+
+	acls := make([]*ovnnb.ACL, 0)
+	for _, m := range NewAnpRuleACLMatches(pgName, asName, protocol, direction, rulePorts, namedPortMap) {
+		acl, err := c.newACLWithoutCheck(pgName, direction, strconv.Itoa(priority), m, aclAction, func(acl *ovnnb.ACL) {
+			acl.Tier = tier
+			if !isIngress {
+				if acl.Options == nil {
+					acl.Options = make(map[string]string)
+				}
+				acl.Options["apply-after-lb"] = "true"
+			}
+			if logEnable {
+				acl.Name = &aclName
+				acl.Log = true
+			}
+		})
+		if err != nil {
+			klog.Error(err)
+			return nil, fmt.Errorf("new admin network policy acl for port group %s: %v", pgName, err)
+		}
+
+		acls = append(acls, acl)
+	}
+
+	ops, err := c.CreateAclsOps(pgName, portGroupKey, acls...)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	return ops, nil
+}
+
 // CreateGatewayACL create allow acl for subnet gateway
 func (c *OVNNbClient) CreateGatewayACL(lsName, pgName, gateway, u2oInterconnectionIP string) error {
 	acls := make([]*ovnnb.ACL, 0)
@@ -377,11 +429,16 @@ func (c *OVNNbClient) UpdateSgACL(sg *kubeovnv1.SecurityGroup, direction string)
 	acls := make([]*ovnnb.ACL, 0, 2)
 
 	// ingress rule
-	srcOrDst, portDirection, sgRules := "src", "outport", sg.Spec.IngressRules
+	srcOrDst, portDirection, sgRules, reverseRules := "src", "outport", sg.Spec.IngressRules, sg.Spec.EgressRules
 	if direction == ovnnb.ACLDirectionFromLport { // egress rule
 		srcOrDst = "dst"
 		portDirection = "inport"
-		sgRules = sg.Spec.EgressRules
+		sgRules, reverseRules = sg.Spec.EgressRules, sg.Spec.IngressRules
+	}
+
+	allowAction := ovnnb.ACLActionAllowRelated
+	if sg.Spec.Stateless {
+		allowAction = ovnnb.ACLActionAllowStateless
 	}
 
 	/* create port_group associated acl */
@@ -397,7 +454,7 @@ func (c *OVNNbClient) UpdateSgACL(sg *kubeovnv1.SecurityGroup, direction string)
 				NewACLMatch(ipSuffix, "", "", ""),
 				NewACLMatch(ipSuffix+"."+srcOrDst, "==", "$"+asName, ""),
 			)
-			acl, err := c.newACL(pgName, direction, util.SecurityGroupAllowPriority, match.String(), ovnnb.ACLActionAllowRelated)
+			acl, err := c.newACL(pgName, direction, util.SecurityGroupAllowPriority, match.String(), allowAction)
 			if err != nil {
 				klog.Error(err)
 				return fmt.Errorf("new allow acl for security group %s: %v", sg.Name, err)
@@ -408,15 +465,41 @@ func (c *OVNNbClient) UpdateSgACL(sg *kubeovnv1.SecurityGroup, direction string)
 	}
 
 	/* create rule acl */
-	for _, rule := range sgRules {
-		acl, err := c.newSgRuleACL(sg.Name, direction, rule)
+	for i, rule := range sgRules {
+		acl, err := c.newSgRuleACL(sg.Name, direction, rule, sgRuleLogOption(sg.Name, direction, i, rule))
 		if err != nil {
 			klog.Error(err)
 			return fmt.Errorf("new rule acl for security group %s: %v", sg.Name, err)
 		}
+		if acl != nil && acl.Action == ovnnb.ACLActionAllowRelated {
+			acl.Action = allowAction
+		}
 		acls = append(acls, acl)
 	}
 
+	/* create acl allowing the reply traffic of the allow rules in the reverse direction, which is not tracked by conntrack,
+	the reply acl has the priority of the rule it is generated from, so the drop rules with higher priorities still take effect */
+	if sg.Spec.Stateless {
+		replyMatches := set.New[string]()
+		for _, rule := range reverseRules {
+			if rule.Policy != kubeovnv1.PolicyAllow {
+				continue
+			}
+			priority, match := sgRulePriority(rule), newSgRuleMatch(sg.Name, direction, rule, true).String()
+			if replyMatches.Has(priority + "/" + match) {
+				continue
+			}
+			replyMatches.Insert(priority + "/" + match)
+
+			acl, err := c.newACL(pgName, direction, priority, match, ovnnb.ACLActionAllowStateless)
+			if err != nil {
+				klog.Error(err)
+				return fmt.Errorf("new reply acl for security group %s: %v", sg.Name, err)
+			}
+			acls = append(acls, acl)
+		}
+	}
+
 	if err := c.CreateAcls(pgName, portGroupKey, acls...); err != nil {
 		return fmt.Errorf("add acl to port group %s: %v", pgName, err)
 	}
@@ -443,12 +526,35 @@ func (c *OVNNbClient) UpdateLogicalSwitchACL(lsName string, subnetAcls []kubeovn
 
 	/* recreate logical switch acl */
 	for _, subnetACL := range subnetAcls {
-		acl, err := c.newACL(lsName, subnetACL.Direction, strconv.Itoa(subnetACL.Priority), subnetACL.Match, subnetACL.Action, options)
+		action := subnetACL.Action
+		stateless := subnetACL.Stateless && (action == ovnnb.ACLActionAllow || action == ovnnb.ACLActionAllowRelated || action == ovnnb.ACLActionAllowStateless)
+		if stateless {
+			action = ovnnb.ACLActionAllowStateless
+		}
+
+		acl, err := c.newACL(lsName, subnetACL.Direction, strconv.Itoa(subnetACL.Priority), subnetACL.Match, action, options)
 		if err != nil {
 			klog.Error(err)
 			return fmt.Errorf("new acl for logical switch %s: %v", lsName, err)
 		}
 		acls = append(acls, acl)
+
+		if !stateless {
+			continue
+		}
+
+		// allow the reply traffic which is not tracked by conntrack
+		reverseMatch, err := util.ReverseACLMatch(subnetACL.Match)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("reverse stateless acl for logical switch %s: %v", lsName, err)
+		}
+		reverseACL, err := c.newACL(lsName, util.ReverseACLDirection(subnetACL.Direction), strconv.Itoa(subnetACL.Priority), reverseMatch, ovnnb.ACLActionAllowStateless, options)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("new reverse acl for logical switch %s: %v", lsName, err)
+		}
+		acls = append(acls, reverseACL)
 	}
 
 	if err := c.CreateAcls(lsName, logicalSwitchKey, acls...); err != nil {
@@ -783,6 +889,39 @@ func (c *OVNNbClient) ListAcls(direction string, externalIDs map[string]string)
 	return aclList, nil
 }
 
+// MigrateACLTier move the acls created before acl tiers were used to the default tier,
+// otherwise they would be evaluated before the acls of admin network policies
+func (c *OVNNbClient) MigrateACLTier() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	aclList := make([]ovnnb.ACL, 0)
+	if err := c.WhereCache(func(acl *ovnnb.ACL) bool {
+		return acl.Tier == 0
+	}).List(ctx, &aclList); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("list acls without tier: %v", err)
+	}
+
+	ops := make([]ovsdb.Operation, 0, len(aclList))
+	for i := range aclList {
+		acl := &aclList[i]
+		acl.Tier = util.DefaultACLTier
+		op, err := c.Where(acl).Update(acl, &acl.Tier)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for updating tier of acl %s: %v", acl.UUID, err)
+		}
+		ops = append(ops, op...)
+	}
+
+	if err := c.Transact("acls-migrate-tier", ops); err != nil {
+		return fmt.Errorf("migrate acl tier: %v", err)
+	}
+
+	return nil
+}
+
 func (c *OVNNbClient) ACLExists(parent, direction, priority, match string) (bool, error) {
 	acl, err := c.GetACL(parent, direction, priority, match, true)
 	return acl != nil, err
@@ -817,6 +956,7 @@ func (c *OVNNbClient) newACL(parent, direction, priority, match, action string,
 		Direction: direction,
 		Match:     match,
 		Priority:  intPriority,
+		Tier:      util.DefaultACLTier,
 		ExternalIDs: map[string]string{
 			aclParentKey: parent,
 		},
@@ -849,6 +989,7 @@ func (c *OVNNbClient) newACLWithoutCheck(parent, direction, priority, match, act
 		Direction: direction,
 		Match:     match,
 		Priority:  intPriority,
+		Tier:      util.DefaultACLTier,
 		ExternalIDs: map[string]string{
 			aclParentKey: parent,
 		},
@@ -862,7 +1003,68 @@ func (c *OVNNbClient) newACLWithoutCheck(parent, direction, priority, match, act
 }
 
 // createSgRuleACL create security group rule acl
-func (c *OVNNbClient) newSgRuleACL(sgName, direction string, rule *kubeovnv1.SgRule) (*ovnnb.ACL, error) {
+func (c *OVNNbClient) newSgRuleACL(sgName, direction string, rule *kubeovnv1.SgRule, options ...func(acl *ovnnb.ACL)) (*ovnnb.ACL, error) {
+	pgName := GetSgPortGroupName(sgName)
+	match := newSgRuleMatch(sgName, direction, rule, false)
+
+	action := ovnnb.ACLActionDrop
+	if rule.Policy == kubeovnv1.PolicyAllow {
+		action = ovnnb.ACLActionAllowRelated
+	}
+
+	acl, err := c.newACL(pgName, direction, sgRulePriority(rule), match.String(), action, options...)
+	if err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("new security group acl for port group %s: %v", pgName, err)
+	}
+
+	return acl, nil
+}
+
+// sgRuleLogOption enables logging of the security group rule acl, the acl name encodes the security group name
+// and the rule index, e.g. sg/web/ingress/0
+func sgRuleLogOption(sgName, direction string, index int, rule *kubeovnv1.SgRule) func(acl *ovnnb.ACL) {
+	return func(acl *ovnnb.ACL) {
+		if !rule.Log {
+			return
+		}
+		ruleDirection := "ingress"
+		if direction == ovnnb.ACLDirectionFromLport {
+			ruleDirection = "egress"
+		}
+		aclName := sgRuleACLName(sgName, ruleDirection, index)
+		severity := ovnnb.ACLSeverityInfo
+		if rule.LogSeverity != "" {
+			severity = rule.LogSeverity
+		}
+		acl.Name = &aclName
+		acl.Log = true
+		acl.Severity = &severity
+	}
+}
+
+// sgRuleACLName returns the name of the security group rule acl, e.g. sg/web/ingress/0.
+// The name of ovn acl is limited to 63 characters, so a long security group name is truncated
+// and suffixed with the hash of the full name to keep the acl names of different security groups apart
+func sgRuleACLName(sgName, direction string, index int) string {
+	suffix := fmt.Sprintf("/%s/%d", direction, index)
+	name := "sg/" + sgName + suffix
+	if len(name) <= aclNameMaxLength {
+		return name
+	}
+	hash := util.Sha256Hash([]byte(sgName))[:8]
+	return "sg/" + sgName[:aclNameMaxLength-len("sg/")-len(suffix)-len(hash)-1] + "." + hash + suffix
+}
+
+// sgRulePriority returns the acl priority of a security group rule
+func sgRulePriority(rule *kubeovnv1.SgRule) string {
+	highestPriority, _ := strconv.Atoi(util.SecurityGroupHighestPriority)
+	return strconv.Itoa(highestPriority - rule.Priority)
+}
+
+// newSgRuleMatch returns the match of the traffic allowed or denied by a security group rule,
+// if reply is true, the match of the reply traffic is returned and the direction should be the reverse of the rule's
+func newSgRuleMatch(sgName, direction string, rule *kubeovnv1.SgRule, reply bool) ACLMatch {
 	ipSuffix := "ip4"
 	if rule.IPVersion == "ipv6" {
 		ipSuffix = "ip6"
@@ -904,43 +1106,121 @@ func (c *OVNNbClient) newSgRuleACL(sgName, direction string, rule *kubeovnv1.SgR
 		)
 	}
 
+	// type addressGroup
+	if rule.RemoteType == kubeovnv1.SgRemoteTypeAddressGroup {
+		protocol := kubeovnv1.ProtocolIPv4
+		if rule.IPVersion == "ipv6" {
+			protocol = kubeovnv1.ProtocolIPv6
+		}
+		allowedIPMatch = NewAndACLMatch(
+			allIPMatch,
+			NewACLMatch(ipKey, "==", "$"+GetAddressGroupAsName(rule.RemoteAddressGroup, protocol), ""),
+		)
+	}
+
 	/* allow layer 4 traffic */
 	// allow all layer 4 traffic
 	match := allowedIPMatch
 
 	switch rule.Protocol {
 	case kubeovnv1.ProtocolICMP:
-		match = NewAndACLMatch(
-			allowedIPMatch,
-			NewACLMatch("icmp4", "", "", ""),
-		)
+		icmpKey := "icmp4"
 		if ipSuffix == "ip6" {
-			match = NewAndACLMatch(
-				allowedIPMatch,
-				NewACLMatch("icmp6", "", "", ""),
-			)
+			icmpKey = "icmp6"
+		}
+		matches := []ACLMatch{allowedIPMatch, NewACLMatch(icmpKey, "", "", "")}
+		if rule.ICMPType != nil {
+			icmpType := *rule.ICMPType
+			if reply {
+				icmpType = icmpEchoReplyType(ipSuffix, icmpType)
+			}
+			matches = append(matches, NewACLMatch(icmpKey+".type", "==", strconv.Itoa(icmpType), ""))
+		}
+		if rule.ICMPCode != nil {
+			matches = append(matches, NewACLMatch(icmpKey+".code", "==", strconv.Itoa(*rule.ICMPCode), ""))
 		}
+		match = NewAndACLMatch(matches...)
 	case kubeovnv1.ProtocolTCP, kubeovnv1.ProtocolUDP:
 		match = NewAndACLMatch(
 			allowedIPMatch,
-			NewACLMatch(string(rule.Protocol)+".dst", "<=", strconv.Itoa(rule.PortRangeMin), strconv.Itoa(rule.PortRangeMax)),
+			newSgRulePortMatch(rule, reply),
 		)
 	}
 
-	action := ovnnb.ACLActionDrop
-	if rule.Policy == kubeovnv1.PolicyAllow {
-		action = ovnnb.ACLActionAllowRelated
+	return match
+}
+
+// icmpEchoReplyType returns the type of the echo reply to an echo request,
+// other types are returned as they are
+func icmpEchoReplyType(ipSuffix string, icmpType int) int {
+	switch {
+	case ipSuffix == "ip4" && icmpType == 8:
+		return 0
+	case ipSuffix == "ip6" && icmpType == 128:
+		return 129
 	}
+	return icmpType
+}
 
-	highestPriority, _ := strconv.Atoi(util.SecurityGroupHighestPriority)
+// newSgRulePortMatch returns a compact match of the destination ports of a tcp or udp security group rule,
+// overlapping ranges are merged and single ports are put into a set,
+// e.g. 'tcp.dst == {22, 80} || 1000 <= tcp.dst <= 2000'. The source ports are matched for the reply traffic.
+func newSgRulePortMatch(rule *kubeovnv1.SgRule, reply bool) ACLMatch {
+	key := string(rule.Protocol) + ".dst"
+	if reply {
+		key = string(rule.Protocol) + ".src"
+	}
+	portRanges := rule.PortRanges
+	if len(portRanges) == 0 {
+		portRanges = []kubeovnv1.SgPortRange{{Min: rule.PortRangeMin, Max: rule.PortRangeMax}}
+	}
 
-	acl, err := c.newACL(pgName, direction, strconv.Itoa(highestPriority-rule.Priority), match.String(), action)
-	if err != nil {
-		klog.Error(err)
-		return nil, fmt.Errorf("new security group acl for port group %s: %v", pgName, err)
+	var ports []string
+	var matches []ACLMatch
+	for _, portRange := range mergeSgPortRanges(portRanges) {
+		if portRange.Min == portRange.Max {
+			ports = append(ports, strconv.Itoa(portRange.Min))
+			continue
+		}
+		matches = append(matches, NewACLMatch(key, "<=", strconv.Itoa(portRange.Min), strconv.Itoa(portRange.Max)))
 	}
 
-	return acl, nil
+	switch len(ports) {
+	case 0:
+	case 1:
+		matches = append([]ACLMatch{NewACLMatch(key, "==", ports[0], "")}, matches...)
+	default:
+		matches = append([]ACLMatch{NewACLMatch(key, "==", "{"+strings.Join(ports, ", ")+"}", "")}, matches...)
+	}
+
+	if len(matches) == 1 {
+		return matches[0]
+	}
+	return NewOrACLMatch(matches...)
+}
+
+// mergeSgPortRanges sorts the port ranges and merges the overlapping and adjacent ones
+func mergeSgPortRanges(portRanges []kubeovnv1.SgPortRange) []kubeovnv1.SgPortRange {
+	sorted := make([]kubeovnv1.SgPortRange, 0, len(portRanges))
+	for _, portRange := range portRanges {
+		if portRange.Max < portRange.Min {
+			portRange.Max = portRange.Min
+		}
+		sorted = append(sorted, portRange)
+	}
+	slices.SortFunc(sorted, func(a, b kubeovnv1.SgPortRange) int {
+		return a.Min - b.Min
+	})
+
+	merged := make([]kubeovnv1.SgPortRange, 0, len(sorted))
+	for _, portRange := range sorted {
+		if last := len(merged) - 1; last >= 0 && portRange.Min <= merged[last].Max+1 {
+			merged[last].Max = max(merged[last].Max, portRange.Max)
+			continue
+		}
+		merged = append(merged, portRange)
+	}
+	return merged
 }
 
 func newNetworkPolicyACLMatch(pgName, asAllowName, asExceptName, protocol, direction string, npp []netv1.NetworkPolicyPort, namedPortMap map[string]*util.NamedPortInfo) []string {
@@ -1031,6 +1311,72 @@ func newNetworkPolicyACLMatch(pgName, asAllowName, asExceptName, protocol, direc
 	return matches
 }
 
+// NewAnpRuleACLMatches generate the matches of an admin network policy rule, one match for each port,
+// ingress rule matches traffic from the peers in asName to pgName and egress rule matches traffic from pgName to the peers
+func NewAnpRuleACLMatches(pgName, asName, protocol, direction string, rulePorts []v1alpha1.AdminNetworkPolicyPort, namedPortMap map[string]*util.NamedPortInfo) []string {
+	ipSuffix := "ip4"
+	if protocol == kubeovnv1.ProtocolIPv6 {
+		ipSuffix = "ip6"
+	}
+
+	// ingress rule
+	srcOrDst, portDirection := "src", "outport"
+	if direction == ovnnb.ACLDirectionFromLport { // egress rule
+		srcOrDst = "dst"
+		portDirection = "inport"
+	}
+
+	peerIPMatch := NewAndACLMatch(
+		NewACLMatch(portDirection, "==", "@"+pgName, ""),
+		NewACLMatch("ip", "", "", ""),
+		NewACLMatch(ipSuffix+"."+srcOrDst, "==", "$"+asName, ""),
+	)
+
+	if len(rulePorts) == 0 {
+		return []string{peerIPMatch.String()}
+	}
+
+	matches := make([]string, 0, len(rulePorts))
+	for _, port := range rulePorts {
+		switch {
+		case port.PortNumber != nil:
+			matches = append(matches, NewAndACLMatch(
+				peerIPMatch,
+				NewACLMatch(anpPortProtocol(port.PortNumber.Protocol)+".dst", "==", strconv.Itoa(int(port.PortNumber.Port)), ""),
+			).String())
+		case port.PortRange != nil:
+			matches = append(matches, NewAndACLMatch(
+				peerIPMatch,
+				NewACLMatch(anpPortProtocol(port.PortRange.Protocol)+".dst", "<=", strconv.Itoa(int(port.PortRange.Start)), strconv.Itoa(int(port.PortRange.End))),
+			).String())
+		case port.NamedPort != nil:
+			info, ok := namedPortMap[*port.NamedPort]
+			if !ok {
+				// the rule matches nothing if no named port defined
+				klog.Errorf("no named port with name %s found", *port.NamedPort)
+				continue
+			}
+			// named port does not carry the protocol
+			for _, l4 := range []string{"tcp", "udp", "sctp"} {
+				matches = append(matches, NewAndACLMatch(
+					peerIPMatch,
+					NewACLMatch(l4+".dst", "==", strconv.Itoa(int(info.PortID)), ""),
+				).String())
+			}
+		}
+	}
+
+	return matches
+}
+
+// anpPortProtocol return the lower case protocol of an admin network policy port, which defaults to tcp
+func anpPortProtocol(protocol v1.Protocol) string {
+	if protocol == "" {
+		return "tcp"
+	}
+	return strings.ToLower(string(protocol))
+}
+
 // aclFilter filter acls which match the given externalIDs,
 // result should include all to-lport and from-lport acls when direction is empty,
 // result should include all acls when externalIDs is empty,
@@ -1157,71 +1503,10 @@ func (c *OVNNbClient) DeleteAclsOps(parentName, parentType, direction string, ex
 
 // sgRuleNoACL check if security group rule has acl
 func (c *OVNNbClient) sgRuleNoACL(sgName, direction string, rule *kubeovnv1.SgRule) (bool, error) {
-	ipSuffix := "ip4"
-	if rule.IPVersion == "ipv6" {
-		ipSuffix = "ip6"
-	}
-
 	pgName := GetSgPortGroupName(sgName)
+	match := newSgRuleMatch(sgName, direction, rule, false)
 
-	// ingress rule
-	srcOrDst, portDirection := "src", "outport"
-	if direction == ovnnb.ACLDirectionFromLport { // egress rule
-		srcOrDst = "dst"
-		portDirection = "inport"
-	}
-
-	ipKey := ipSuffix + "." + srcOrDst
-
-	/* match all traffic to or from pgName */
-	allIPMatch := NewAndACLMatch(
-		NewACLMatch(portDirection, "==", "@"+pgName, ""),
-		NewACLMatch(ipSuffix, "", "", ""),
-	)
-
-	/* allow allowed ip traffic */
-	// type address
-	allowedIPMatch := NewAndACLMatch(
-		allIPMatch,
-		NewACLMatch(ipKey, "==", rule.RemoteAddress, ""),
-	)
-
-	// type securityGroup
-	remotePgName := GetSgV4AssociatedName(rule.RemoteSecurityGroup)
-	if rule.IPVersion == "ipv6" {
-		remotePgName = GetSgV6AssociatedName(rule.RemoteSecurityGroup)
-	}
-	if rule.RemoteType == kubeovnv1.SgRemoteTypeSg {
-		allowedIPMatch = NewAndACLMatch(
-			allIPMatch,
-			NewACLMatch(ipKey, "==", "$"+remotePgName, ""),
-		)
-	}
-
-	/* allow layer 4 traffic */
-	// allow all layer 4 traffic
-	match := allowedIPMatch
-
-	switch rule.Protocol {
-	case kubeovnv1.ProtocolICMP:
-		match = NewAndACLMatch(
-			allowedIPMatch,
-			NewACLMatch("icmp4", "", "", ""),
-		)
-		if ipSuffix == "ip6" {
-			match = NewAndACLMatch(
-				allowedIPMatch,
-				NewACLMatch("icmp6", "", "", ""),
-			)
-		}
-	case kubeovnv1.ProtocolTCP, kubeovnv1.ProtocolUDP:
-		match = NewAndACLMatch(
-			allowedIPMatch,
-			NewACLMatch(string(rule.Protocol)+".dst", "<=", strconv.Itoa(rule.PortRangeMin), strconv.Itoa(rule.PortRangeMax)),
-		)
-	}
-
-	exists, err := c.ACLExists(pgName, direction, strconv.Itoa(rule.Priority), match.String())
+	exists, err := c.ACLExists(pgName, direction, sgRulePriority(rule), match.String())
 	if err != nil {
 		err = fmt.Errorf("failed to check acl rule for security group %s: %v", sgName, err)
 		klog.Error(err)
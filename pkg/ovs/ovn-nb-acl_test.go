@@ -12,6 +12,7 @@ import (
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/network-policy-api/apis/v1alpha1"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	ovsclient "github.com/kubeovn/kube-ovn/pkg/ovsdb/client"
@@ -50,6 +51,7 @@ func newACL(parentName, direction, priority, match, action string, options ...fu
 		Direction: direction,
 		Match:     match,
 		Priority:  intPriority,
+		Tier:      util.DefaultACLTier,
 		ExternalIDs: map[string]string{
 			aclParentKey: parentName,
 		},
@@ -96,6 +98,8 @@ func (suite *OvnClientTestSuite) testUpdateIngressACLOps() {
 		require.Len(t, ops, 4)
 
 		expect(ops[0].Row, "drop", ovnnb.ACLDirectionToLport, fmt.Sprintf("outport == @%s && ip", pgName), util.IngressDefaultDrop)
+		require.Equal(t, "ingress/default", ops[0].Row["external_ids"].(ovsdb.OvsMap).GoMap[util.NetworkPolicyRuleKey])
+		require.Equal(t, "ingress/"+aclName, ops[1].Row["external_ids"].(ovsdb.OvsMap).GoMap[util.NetworkPolicyRuleKey])
 
 		matches := newNetworkPolicyACLMatch(pgName, asIngressName, asExceptName, protocol, ovnnb.ACLDirectionToLport, npp, nil)
 		i := 1
@@ -206,6 +210,111 @@ func (suite *OvnClientTestSuite) testUpdateEgressACLOps() {
 	})
 }
 
+func (suite *OvnClientTestSuite) testUpdateAnpRuleACLOps() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+
+	pgName := "test_update_anp_rule_acl_pg"
+	asName := "test_update_anp_rule_acl_pg.ingress.0.IPv4"
+	err := ovnClient.CreatePortGroup(pgName, nil)
+	require.NoError(t, err)
+
+	namedPort := "http"
+	rulePorts := []v1alpha1.AdminNetworkPolicyPort{
+		{PortNumber: &v1alpha1.Port{Protocol: v1.ProtocolTCP, Port: 80}},
+		{PortRange: &v1alpha1.PortRange{Protocol: v1.ProtocolUDP, Start: 1000, End: 2000}},
+		{NamedPort: &namedPort},
+	}
+
+	t.Run("ingress acl", func(t *testing.T) {
+		matches := NewAnpRuleACLMatches(pgName, asName, kubeovnv1.ProtocolIPv4, ovnnb.ACLDirectionToLport, rulePorts, nil)
+		require.Equal(t, []string{
+			fmt.Sprintf("outport == @%s && ip && ip4.src == $%s && tcp.dst == 80", pgName, asName),
+			fmt.Sprintf("outport == @%s && ip && ip4.src == $%s && 1000 <= udp.dst <= 2000", pgName, asName),
+		}, matches)
+
+		ops, err := ovnClient.UpdateAnpRuleACLOps(pgName, asName, kubeovnv1.ProtocolIPv4, "anp/test/ingress/IPv4/0", 30000, util.AnpACLTier, ovnnb.ACLActionDrop, rulePorts, true, false, nil)
+		require.NoError(t, err)
+		require.Len(t, ops, 3)
+		for i, m := range matches {
+			require.Equal(t, m, ops[i].Row["match"])
+			require.Equal(t, ovnnb.ACLActionDrop, ops[i].Row["action"])
+			require.Equal(t, ovnnb.ACLDirectionToLport, ops[i].Row["direction"])
+			require.Equal(t, 30000, ops[i].Row["priority"])
+			require.Equal(t, util.AnpACLTier, ops[i].Row["tier"])
+		}
+	})
+
+	t.Run("egress pass acl with named port", func(t *testing.T) {
+		namedPortMap := map[string]*util.NamedPortInfo{namedPort: {PortID: 8080}}
+		ops, err := ovnClient.UpdateAnpRuleACLOps(pgName, asName, kubeovnv1.ProtocolIPv4, "anp/test/egress/IPv4/0", 29999, util.AnpACLTier, ovnnb.ACLActionPass, rulePorts[2:], false, true, namedPortMap)
+		require.NoError(t, err)
+		require.Len(t, ops, 4)
+		for i, l4 := range []string{"tcp", "udp", "sctp"} {
+			require.Equal(t, fmt.Sprintf("inport == @%s && ip && ip4.dst == $%s && %s.dst == 8080", pgName, asName, l4), ops[i].Row["match"])
+			require.Equal(t, ovnnb.ACLActionPass, ops[i].Row["action"])
+			require.Equal(t, ovnnb.ACLDirectionFromLport, ops[i].Row["direction"])
+			require.Equal(t, util.AnpACLTier, ops[i].Row["tier"])
+			require.Equal(t, true, ops[i].Row["log"])
+		}
+	})
+
+	t.Run("baseline admin network policy acl", func(t *testing.T) {
+		ops, err := ovnClient.UpdateAnpRuleACLOps(pgName, asName, kubeovnv1.ProtocolIPv4, "banp/test/ingress/IPv4/0", 1900, util.DefaultACLTier, ovnnb.ACLActionAllowRelated, nil, true, false, nil)
+		require.NoError(t, err)
+		require.Len(t, ops, 2)
+		require.Equal(t, fmt.Sprintf("outport == @%s && ip && ip4.src == $%s", pgName, asName), ops[0].Row["match"])
+		require.Equal(t, util.DefaultACLTier, ops[0].Row["tier"])
+	})
+
+	t.Run("pass acl hands traffic over to the next tier", func(t *testing.T) {
+		ops, err := ovnClient.UpdateAnpRuleACLOps(pgName, asName, kubeovnv1.ProtocolIPv4, "anp/test/ingress/IPv4/1", 29998, util.AnpACLTier, ovnnb.ACLActionPass, rulePorts[:1], true, false, nil)
+		require.NoError(t, err)
+		require.NoError(t, ovnClient.Transact("acls-add", ops))
+
+		pg, err := ovnClient.GetPortGroup(pgName, false)
+		require.NoError(t, err)
+		require.Len(t, pg.ACLs, 1)
+		acl, err := ovnClient.GetACL(pgName, ovnnb.ACLDirectionToLport, "29998", fmt.Sprintf("outport == @%s && ip && ip4.src == $%s && tcp.dst == 80", pgName, asName), false)
+		require.NoError(t, err)
+		require.Equal(t, ovnnb.ACLActionPass, acl.Action)
+		require.Equal(t, util.AnpACLTier, acl.Tier)
+	})
+}
+
+func (suite *OvnClientTestSuite) testMigrateACLTier() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+
+	pgName := "test_migrate_acl_tier_pg"
+	err := ovnClient.CreatePortGroup(pgName, nil)
+	require.NoError(t, err)
+
+	match := fmt.Sprintf("outport == @%s && ip", pgName)
+	acl, err := ovnClient.newACL(pgName, ovnnb.ACLDirectionToLport, "1000", match, ovnnb.ACLActionDrop, func(acl *ovnnb.ACL) {
+		// acl created by previous versions
+		acl.Tier = 0
+	})
+	require.NoError(t, err)
+	err = ovnClient.CreateAcls(pgName, portGroupKey, acl)
+	require.NoError(t, err)
+
+	err = ovnClient.MigrateACLTier()
+	require.NoError(t, err)
+
+	acl, err = ovnClient.GetACL(pgName, ovnnb.ACLDirectionToLport, "1000", match, false)
+	require.NoError(t, err)
+	require.Equal(t, util.DefaultACLTier, acl.Tier)
+
+	// nothing to migrate
+	err = ovnClient.MigrateACLTier()
+	require.NoError(t, err)
+}
+
 func (suite *OvnClientTestSuite) testCreateGatewayACL() {
 	t := suite.T()
 	t.Parallel()
@@ -651,6 +760,120 @@ func (suite *OvnClientTestSuite) testUpdateSgACL() {
 		require.Equal(t, expect, rulACL)
 		require.Contains(t, pg.ACLs, rulACL.UUID)
 	})
+
+	t.Run("update stateless securityGroup acl", func(t *testing.T) {
+		statelessSgName := "test_update_stateless_sg_acl_pg"
+		statelessPgName := GetSgPortGroupName(statelessSgName)
+		icmpType := 8
+		statelessSg := &kubeovnv1.SecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: statelessSgName,
+			},
+			Spec: kubeovnv1.SecurityGroupSpec{
+				Stateless: true,
+				IngressRules: []*kubeovnv1.SgRule{
+					{
+						IPVersion:     "ipv4",
+						RemoteType:    kubeovnv1.SgRemoteTypeAddress,
+						RemoteAddress: "10.0.0.0/8",
+						Protocol:      "udp",
+						PortRanges:    []kubeovnv1.SgPortRange{{Min: 53}},
+						Priority:      1,
+						Policy:        "allow",
+					},
+					{
+						IPVersion:     "ipv4",
+						RemoteType:    kubeovnv1.SgRemoteTypeAddress,
+						RemoteAddress: "10.0.0.0/8",
+						Protocol:      "icmp",
+						ICMPType:      &icmpType,
+						Priority:      2,
+						Policy:        "allow",
+					},
+				},
+			},
+		}
+
+		err := ovnClient.CreatePortGroup(statelessPgName, nil)
+		require.NoError(t, err)
+		err = ovnClient.UpdateSgACL(statelessSg, ovnnb.ACLDirectionToLport)
+		require.NoError(t, err)
+		err = ovnClient.UpdateSgACL(statelessSg, ovnnb.ACLDirectionFromLport)
+		require.NoError(t, err)
+
+		pg, err := ovnClient.GetPortGroup(statelessPgName, false)
+		require.NoError(t, err)
+		require.Len(t, pg.ACLs, 4)
+
+		// rule acl
+		match := fmt.Sprintf("outport == @%s && ip4 && ip4.src == 10.0.0.0/8 && udp.dst == 53", statelessPgName)
+		rulACL, err := ovnClient.GetACL(statelessPgName, ovnnb.ACLDirectionToLport, "2299", match, false)
+		require.NoError(t, err)
+		require.Equal(t, ovnnb.ACLActionAllowStateless, rulACL.Action)
+
+		// reply acl
+		match = fmt.Sprintf("inport == @%s && ip4 && ip4.dst == 10.0.0.0/8 && udp.src == 53", statelessPgName)
+		replyACL, err := ovnClient.GetACL(statelessPgName, ovnnb.ACLDirectionFromLport, "2299", match, false)
+		require.NoError(t, err)
+		require.Equal(t, ovnnb.ACLActionAllowStateless, replyACL.Action)
+
+		match = fmt.Sprintf("inport == @%s && ip4 && ip4.dst == 10.0.0.0/8 && icmp4 && icmp4.type == 0", statelessPgName)
+		replyACL, err = ovnClient.GetACL(statelessPgName, ovnnb.ACLDirectionFromLport, "2298", match, false)
+		require.NoError(t, err)
+		require.Equal(t, ovnnb.ACLActionAllowStateless, replyACL.Action)
+	})
+
+	t.Run("stateless reply acl does not override drop rule with higher priority", func(t *testing.T) {
+		statelessSgName := "test_stateless_sg_reply_acl_drop"
+		statelessPgName := GetSgPortGroupName(statelessSgName)
+		statelessSg := &kubeovnv1.SecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: statelessSgName,
+			},
+			Spec: kubeovnv1.SecurityGroupSpec{
+				Stateless: true,
+				IngressRules: []*kubeovnv1.SgRule{
+					{
+						IPVersion:     "ipv4",
+						RemoteType:    kubeovnv1.SgRemoteTypeAddress,
+						RemoteAddress: "10.0.0.0/8",
+						Protocol:      "udp",
+						PortRanges:    []kubeovnv1.SgPortRange{{Min: 53}},
+						Priority:      10,
+						Policy:        "allow",
+					},
+				},
+				EgressRules: []*kubeovnv1.SgRule{
+					{
+						IPVersion:     "ipv4",
+						RemoteType:    kubeovnv1.SgRemoteTypeAddress,
+						RemoteAddress: "10.1.0.0/16",
+						Protocol:      "all",
+						Priority:      1,
+						Policy:        "drop",
+					},
+				},
+			},
+		}
+
+		err := ovnClient.CreatePortGroup(statelessPgName, nil)
+		require.NoError(t, err)
+		err = ovnClient.UpdateSgACL(statelessSg, ovnnb.ACLDirectionFromLport)
+		require.NoError(t, err)
+
+		match := fmt.Sprintf("inport == @%s && ip4 && ip4.dst == 10.1.0.0/16", statelessPgName)
+		dropACL, err := ovnClient.GetACL(statelessPgName, ovnnb.ACLDirectionFromLport, "2299", match, false)
+		require.NoError(t, err)
+		require.Equal(t, ovnnb.ACLActionDrop, dropACL.Action)
+
+		match = fmt.Sprintf("inport == @%s && ip4 && ip4.dst == 10.0.0.0/8 && udp.src == 53", statelessPgName)
+		replyACL, err := ovnClient.GetACL(statelessPgName, ovnnb.ACLDirectionFromLport, "2290", match, false)
+		require.NoError(t, err)
+		require.Equal(t, ovnnb.ACLActionAllowStateless, replyACL.Action)
+
+		// the reply to 10.1.0.0/16 matches both acls, the drop acl is evaluated first
+		require.Greater(t, dropACL.Priority, replyACL.Priority)
+	})
 }
 
 func (suite *OvnClientTestSuite) testUpdateLogicalSwitchACL() {
@@ -693,6 +916,35 @@ func (suite *OvnClientTestSuite) testUpdateLogicalSwitchACL() {
 		require.Equal(t, expect, acl)
 		require.Contains(t, ls.ACLs, acl.UUID)
 	}
+
+	t.Run("stateless acl", func(t *testing.T) {
+		statelessLsName := "test_update_stateless_acl_ls"
+		err := ovnClient.CreateBareLogicalSwitch(statelessLsName)
+		require.NoError(t, err)
+
+		err = ovnClient.UpdateLogicalSwitchACL(statelessLsName, []kubeovnv1.ACL{
+			{
+				Direction: ovnnb.ACLDirectionToLport,
+				Priority:  1112,
+				Match:     "ip4.src == 192.168.111.0/24 && udp.dst == 53",
+				Action:    ovnnb.ACLActionAllowRelated,
+				Stateless: true,
+			},
+		})
+		require.NoError(t, err)
+
+		ls, err := ovnClient.GetLogicalSwitch(statelessLsName, false)
+		require.NoError(t, err)
+		require.Len(t, ls.ACLs, 2)
+
+		acl, err := ovnClient.GetACL(statelessLsName, ovnnb.ACLDirectionToLport, "1112", "ip4.src == 192.168.111.0/24 && udp.dst == 53", false)
+		require.NoError(t, err)
+		require.Equal(t, ovnnb.ACLActionAllowStateless, acl.Action)
+
+		acl, err = ovnClient.GetACL(statelessLsName, ovnnb.ACLDirectionFromLport, "1112", "ip4.dst == 192.168.111.0/24 && udp.src == 53", false)
+		require.NoError(t, err)
+		require.Equal(t, ovnnb.ACLActionAllowStateless, acl.Action)
+	})
 }
 
 func (suite *OvnClientTestSuite) testSetACLLog() {
@@ -999,6 +1251,35 @@ func (suite *OvnClientTestSuite) testNewSgRuleACL() {
 		require.Equal(t, expect, acl)
 	})
 
+	t.Run("create sg acl with log", func(t *testing.T) {
+		t.Parallel()
+
+		sgRule := &kubeovnv1.SgRule{
+			IPVersion:     "ipv4",
+			RemoteType:    kubeovnv1.SgRemoteTypeAddress,
+			RemoteAddress: "10.10.10.13/24",
+			Protocol:      "icmp",
+			Priority:      22,
+			Policy:        "drop",
+			Log:           true,
+			LogSeverity:   ovnnb.ACLSeverityWarning,
+		}
+		priority := strconv.Itoa(highestPriority - sgRule.Priority)
+
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionFromLport, sgRule, sgRuleLogOption(sgName, ovnnb.ACLDirectionFromLport, 3, sgRule))
+		require.NoError(t, err)
+
+		match := fmt.Sprintf("inport == @%s && ip4 && ip4.dst == %s && icmp4", pgName, sgRule.RemoteAddress)
+		expect := newACL(pgName, ovnnb.ACLDirectionFromLport, priority, match, ovnnb.ACLActionDrop, func(acl *ovnnb.ACL) {
+			aclName := "sg/" + sgName + "/egress/3"
+			acl.Name = &aclName
+			acl.Log = true
+			acl.Severity = &ovnnb.ACLSeverityWarning
+		})
+		expect.UUID = acl.UUID
+		require.Equal(t, expect, acl)
+	})
+
 	t.Run("create drop sg acl", func(t *testing.T) {
 		t.Parallel()
 
@@ -1044,6 +1325,54 @@ func (suite *OvnClientTestSuite) testNewSgRuleACL() {
 		expect.UUID = acl.UUID
 		require.Equal(t, expect, acl)
 	})
+
+	t.Run("create address group sg acl with multiple port ranges", func(t *testing.T) {
+		t.Parallel()
+
+		sgRule := &kubeovnv1.SgRule{
+			IPVersion:          "ipv6",
+			RemoteType:         kubeovnv1.SgRemoteTypeAddressGroup,
+			RemoteAddressGroup: "office-net",
+			Protocol:           "udp",
+			Priority:           12,
+			Policy:             "allow",
+			PortRanges:         []kubeovnv1.SgPortRange{{Min: 8080, Max: 8090}, {Min: 53}, {Min: 8085, Max: 8100}, {Min: 123}, {Min: 8101}},
+		}
+		priority := strconv.Itoa(highestPriority - sgRule.Priority)
+
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionFromLport, sgRule)
+		require.NoError(t, err)
+
+		match := fmt.Sprintf("inport == @%s && ip6 && ip6.dst == $ovn.ag.office.net.v6 && (udp.dst == {53, 123} || 8080 <= udp.dst <= 8101)", pgName)
+		expect := newACL(pgName, ovnnb.ACLDirectionFromLport, priority, match, ovnnb.ACLActionAllowRelated)
+		expect.UUID = acl.UUID
+		require.Equal(t, expect, acl)
+	})
+
+	t.Run("create icmp type and code sg acl", func(t *testing.T) {
+		t.Parallel()
+
+		icmpType, icmpCode := 8, 0
+		sgRule := &kubeovnv1.SgRule{
+			IPVersion:     "ipv4",
+			RemoteType:    kubeovnv1.SgRemoteTypeAddress,
+			RemoteAddress: "10.10.10.12/24",
+			Protocol:      "icmp",
+			Priority:      12,
+			Policy:        "allow",
+			ICMPType:      &icmpType,
+			ICMPCode:      &icmpCode,
+		}
+		priority := strconv.Itoa(highestPriority - sgRule.Priority)
+
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, sgRule)
+		require.NoError(t, err)
+
+		match := fmt.Sprintf("outport == @%s && ip4 && ip4.src == %s && icmp4 && icmp4.type == 8 && icmp4.code == 0", pgName, sgRule.RemoteAddress)
+		expect := newACL(pgName, ovnnb.ACLDirectionToLport, priority, match, ovnnb.ACLActionAllowRelated)
+		expect.UUID = acl.UUID
+		require.Equal(t, expect, acl)
+	})
 }
 
 func (suite *OvnClientTestSuite) testCreateAcls() {
@@ -1518,6 +1847,7 @@ func (suite *OvnClientTestSuite) testNewACL() {
 		Direction: ovnnb.ACLDirectionToLport,
 		Match:     match,
 		Priority:  1000,
+		Tier:      util.DefaultACLTier,
 		ExternalIDs: map[string]string{
 			aclParentKey: pgName,
 		},
@@ -1762,3 +2092,16 @@ func (suite *OvnClientTestSuite) testACLFilter() {
 		require.False(t, filterFunc(acl))
 	})
 }
+
+func Test_sgRuleACLName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "sg/web/ingress/0", sgRuleACLName("web", "ingress", 0))
+
+	longName := strings.Repeat("security-group-", 10)
+	name := sgRuleACLName(longName, "egress", 12)
+	require.Len(t, name, aclNameMaxLength)
+	require.True(t, strings.HasPrefix(name, "sg/security-group-"))
+	require.True(t, strings.HasSuffix(name, "/egress/12"))
+	require.NotEqual(t, name, sgRuleACLName(longName+"x", "egress", 12))
+}
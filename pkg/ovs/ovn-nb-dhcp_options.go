@@ -3,6 +3,10 @@ package ovs
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/ovn-org/libovsdb/ovsdb"
@@ -13,12 +17,19 @@ import (
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
+// overrideOptionsKey records the options overridden by the dhcp options dedicated to a logical switch port
+const overrideOptionsKey = "override_options"
+
 type DHCPOptionsUUIDs struct {
 	DHCPv4OptionsUUID string
 	DHCPv6OptionsUUID string
 }
 
 func (c *OVNNbClient) CreateDHCPOptions(lsName, cidr, options string) error {
+	return c.createDHCPOptions(lsName, cidr, parseDHCPOptions(options))
+}
+
+func (c *OVNNbClient) createDHCPOptions(lsName, cidr string, options map[string]string) error {
 	dhcpOpt, err := newDHCPOptions(lsName, cidr, options)
 	if err != nil {
 		klog.Error(err)
@@ -28,18 +39,20 @@ func (c *OVNNbClient) CreateDHCPOptions(lsName, cidr, options string) error {
 	op, err := c.ovsDbClient.Create(dhcpOpt)
 	if err != nil {
 		klog.Error(err)
-		return fmt.Errorf("generate operations for creating dhcp options 'cidr %s options %s': %v", cidr, options, err)
+		return fmt.Errorf("generate operations for creating dhcp options 'cidr %s options %v': %v", cidr, options, err)
 	}
 
 	if err = c.Transact("dhcp-create", op); err != nil {
 		klog.Error(err)
-		return fmt.Errorf("create dhcp options with cidr %q options %q: %v", cidr, options, err)
+		return fmt.Errorf("create dhcp options with cidr %q options %v: %v", cidr, options, err)
 	}
 
 	return nil
 }
 
-func (c *OVNNbClient) UpdateDHCPOptions(subnet *kubeovnv1.Subnet, mtu int) (*DHCPOptionsUUIDs, error) {
+// UpdateDHCPOptions update dhcp options of the subnet,
+// the options are rendered from spec when the subnet has no free-form options and spec is not nil
+func (c *OVNNbClient) UpdateDHCPOptions(subnet *kubeovnv1.Subnet, mtu int, spec *kubeovnv1.DHCPOptionsSpec) (*DHCPOptionsUUIDs, error) {
 	lsName := subnet.Name
 	cidrBlock := subnet.Spec.CIDRBlock
 	gateway := subnet.Spec.Gateway
@@ -74,7 +87,7 @@ func (c *OVNNbClient) UpdateDHCPOptions(subnet *kubeovnv1.Subnet, mtu int) (*DHC
 
 	dhcpOptionsUUIDs := &DHCPOptionsUUIDs{}
 	if len(v4CIDR) != 0 {
-		dhcpV4OptUUID, err := c.updateDHCPv4Options(lsName, v4CIDR, v4Gateway, subnet.Spec.DHCPv4Options, mtu)
+		dhcpV4OptUUID, err := c.updateDHCPv4Options(lsName, v4CIDR, v4Gateway, subnet.Spec.DHCPv4Options, spec, mtu)
 		if err != nil {
 			klog.Error(err)
 			return nil, fmt.Errorf("update IPv4 dhcp options for logical switch %s: %v", lsName, err)
@@ -83,7 +96,7 @@ func (c *OVNNbClient) UpdateDHCPOptions(subnet *kubeovnv1.Subnet, mtu int) (*DHC
 	}
 
 	if len(v6CIDR) != 0 {
-		dhcpV6OptUUID, err := c.updateDHCPv6Options(lsName, v6CIDR, subnet.Spec.DHCPv6Options)
+		dhcpV6OptUUID, err := c.updateDHCPv6Options(lsName, v6CIDR, subnet.Spec.DHCPv6Options, spec)
 		if err != nil {
 			klog.Error(err)
 			return nil, fmt.Errorf("update IPv6 dhcp options for logical switch %s: %v", lsName, err)
@@ -94,7 +107,7 @@ func (c *OVNNbClient) UpdateDHCPOptions(subnet *kubeovnv1.Subnet, mtu int) (*DHC
 	return dhcpOptionsUUIDs, nil
 }
 
-func (c *OVNNbClient) updateDHCPv4Options(lsName, cidr, gateway, options string, mtu int) (uuid string, err error) {
+func (c *OVNNbClient) updateDHCPv4Options(lsName, cidr, gateway, options string, spec *kubeovnv1.DHCPOptionsSpec, mtu int) (uuid string, err error) {
 	protocol := util.CheckProtocol(cidr)
 	if protocol != kubeovnv1.ProtocolIPv4 {
 		return "", fmt.Errorf("cidr %s must be a valid ipv4 address", cidr)
@@ -106,24 +119,33 @@ func (c *OVNNbClient) updateDHCPv4Options(lsName, cidr, gateway, options string,
 		return
 	}
 
+	dhcpOptions := parseDHCPOptions(options)
 	if len(options) == 0 {
 		mac := util.GenerateMac()
 		if dhcpOpt != nil && len(dhcpOpt.Options) != 0 {
 			mac = dhcpOpt.Options["server_mac"]
 		}
 
-		options = fmt.Sprintf("lease_time=%d,router=%s,server_id=%s,server_mac=%s,mtu=%d", 3600, gateway, "169.254.0.254", mac, mtu)
+		if spec != nil {
+			dhcpOptions = dhcpV4OptionsFromSpec(spec, gateway, mac, mtu)
+		} else {
+			dhcpOptions = parseDHCPOptions(fmt.Sprintf("lease_time=%d,router=%s,server_id=%s,server_mac=%s,mtu=%d", 3600, gateway, "169.254.0.254", mac, mtu))
+		}
 	}
 
 	/* update */
 	if dhcpOpt != nil {
 		dhcpOpt.Cidr = cidr
-		dhcpOpt.Options = parseDHCPOptions(options)
-		return dhcpOpt.UUID, c.updateDHCPOptions(dhcpOpt, &dhcpOpt.Cidr, &dhcpOpt.Options)
+		dhcpOpt.Options = dhcpOptions
+		if err = c.updateDHCPOptions(dhcpOpt, &dhcpOpt.Cidr, &dhcpOpt.Options); err != nil {
+			klog.Error(err)
+			return "", err
+		}
+		return dhcpOpt.UUID, c.refreshLogicalSwitchPortDHCPOptions(dhcpOpt)
 	}
 
 	/* create */
-	if err := c.CreateDHCPOptions(lsName, cidr, options); err != nil {
+	if err := c.createDHCPOptions(lsName, cidr, dhcpOptions); err != nil {
 		return "", fmt.Errorf("create dhcp options: %v", err)
 	}
 
@@ -136,7 +158,7 @@ func (c *OVNNbClient) updateDHCPv4Options(lsName, cidr, gateway, options string,
 	return dhcpOpt.UUID, nil
 }
 
-func (c *OVNNbClient) updateDHCPv6Options(lsName, cidr, options string) (uuid string, err error) {
+func (c *OVNNbClient) updateDHCPv6Options(lsName, cidr, options string, spec *kubeovnv1.DHCPOptionsSpec) (uuid string, err error) {
 	protocol := util.CheckProtocol(cidr)
 	if protocol != kubeovnv1.ProtocolIPv6 {
 		return "", fmt.Errorf("cidr %s must be a valid ipv4 address", cidr)
@@ -148,24 +170,29 @@ func (c *OVNNbClient) updateDHCPv6Options(lsName, cidr, options string) (uuid st
 		return
 	}
 
+	dhcpOptions := parseDHCPOptions(options)
 	if len(options) == 0 {
 		mac := util.GenerateMac()
 		if dhcpOpt != nil && len(dhcpOpt.Options) != 0 {
 			mac = dhcpOpt.Options["server_id"]
 		}
 
-		options = fmt.Sprintf("server_id=%s", mac)
+		if spec != nil {
+			dhcpOptions = dhcpV6OptionsFromSpec(spec, mac)
+		} else {
+			dhcpOptions = parseDHCPOptions(fmt.Sprintf("server_id=%s", mac))
+		}
 	}
 
 	/* update */
 	if dhcpOpt != nil {
 		dhcpOpt.Cidr = cidr
-		dhcpOpt.Options = parseDHCPOptions(options)
+		dhcpOpt.Options = dhcpOptions
 		return dhcpOpt.UUID, c.updateDHCPOptions(dhcpOpt, &dhcpOpt.Cidr, &dhcpOpt.Options)
 	}
 
 	/* create */
-	if err := c.CreateDHCPOptions(lsName, cidr, options); err != nil {
+	if err := c.createDHCPOptions(lsName, cidr, dhcpOptions); err != nil {
 		return "", fmt.Errorf("create dhcp options: %v", err)
 	}
 
@@ -178,6 +205,128 @@ func (c *OVNNbClient) updateDHCPv6Options(lsName, cidr, options string) (uuid st
 	return dhcpOpt.UUID, nil
 }
 
+// UpdateLogicalSwitchPortDHCPv4Options creates or updates the ipv4 dhcp options dedicated to the logical switch port,
+// which copy the ipv4 dhcp options of the logical switch with the given options overridden.
+// An empty uuid is returned when the logical switch has no ipv4 dhcp options.
+func (c *OVNNbClient) UpdateLogicalSwitchPortDHCPv4Options(lsName, lspName string, options map[string]string) (string, error) {
+	dhcpOpt, err := c.GetDHCPOptions(lsName, kubeovnv1.ProtocolIPv4, true)
+	if err != nil {
+		klog.Error(err)
+		return "", err
+	}
+	if dhcpOpt == nil {
+		return "", nil
+	}
+
+	overrides := make([]string, 0, len(options))
+	for key := range options {
+		overrides = append(overrides, key)
+	}
+	sort.Strings(overrides)
+	externalIDs := map[string]string{
+		logicalSwitchKey:     lsName,
+		logicalSwitchPortKey: lspName,
+		"protocol":           kubeovnv1.ProtocolIPv4,
+		"vendor":             util.CniTypeName,
+		overrideOptionsKey:   strings.Join(overrides, ","),
+	}
+	portDHCPOpts, err := c.ListDHCPOptions(true, map[string]string{logicalSwitchPortKey: lspName, "protocol": kubeovnv1.ProtocolIPv4})
+	if err != nil {
+		klog.Error(err)
+		return "", err
+	}
+
+	/* update */
+	if len(portDHCPOpts) != 0 {
+		portDHCPOpt := &portDHCPOpts[0]
+		portDHCPOpt.Cidr = dhcpOpt.Cidr
+		portDHCPOpt.Options = mergeDHCPOptions(dhcpOpt.Options, options)
+		portDHCPOpt.ExternalIDs = externalIDs
+		return portDHCPOpt.UUID, c.updateDHCPOptions(portDHCPOpt, &portDHCPOpt.Cidr, &portDHCPOpt.Options, &portDHCPOpt.ExternalIDs)
+	}
+
+	/* create */
+	op, err := c.ovsDbClient.Create(&ovnnb.DHCPOptions{
+		Cidr:        dhcpOpt.Cidr,
+		ExternalIDs: externalIDs,
+		Options:     mergeDHCPOptions(dhcpOpt.Options, options),
+	})
+	if err != nil {
+		klog.Error(err)
+		return "", fmt.Errorf("generate operations for creating dhcp options of logical switch port %s: %v", lspName, err)
+	}
+	if err = c.Transact("dhcp-create", op); err != nil {
+		klog.Error(err)
+		return "", fmt.Errorf("create dhcp options of logical switch port %s: %v", lspName, err)
+	}
+
+	if portDHCPOpts, err = c.ListDHCPOptions(true, map[string]string{logicalSwitchPortKey: lspName, "protocol": kubeovnv1.ProtocolIPv4}); err != nil {
+		klog.Error(err)
+		return "", err
+	}
+	if len(portDHCPOpts) == 0 {
+		return "", fmt.Errorf("not found dhcp options of logical switch port %s", lspName)
+	}
+
+	return portDHCPOpts[0].UUID, nil
+}
+
+// DeleteLogicalSwitchPortDHCPOptions delete dhcp options dedicated to the logical switch port
+func (c *OVNNbClient) DeleteLogicalSwitchPortDHCPOptions(lspName string) error {
+	op, err := c.WhereCache(dhcpOptionsFilter(true, map[string]string{logicalSwitchPortKey: lspName})).Delete()
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operation for deleting dhcp options of logical switch port %s: %v", lspName, err)
+	}
+
+	if err = c.Transact("dhcp-options-del", op); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("delete dhcp options of logical switch port %s: %v", lspName, err)
+	}
+
+	return nil
+}
+
+// refreshLogicalSwitchPortDHCPOptions copy the updated dhcp options of the logical switch
+// to the dhcp options dedicated to its ports, the overridden options are kept
+func (c *OVNNbClient) refreshLogicalSwitchPortDHCPOptions(dhcpOpt *ovnnb.DHCPOptions) error {
+	portDHCPOpts, err := c.ListDHCPOptions(true, map[string]string{
+		logicalSwitchKey:     dhcpOpt.ExternalIDs[logicalSwitchKey],
+		logicalSwitchPortKey: "",
+		"protocol":           dhcpOpt.ExternalIDs["protocol"],
+	})
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	for _, portDHCPOpt := range portDHCPOpts {
+		overrides := make(map[string]string)
+		for _, key := range strings.Split(portDHCPOpt.ExternalIDs[overrideOptionsKey], ",") {
+			if value, ok := portDHCPOpt.Options[key]; ok {
+				overrides[key] = value
+			}
+		}
+		portDHCPOpt.Cidr = dhcpOpt.Cidr
+		portDHCPOpt.Options = mergeDHCPOptions(dhcpOpt.Options, overrides)
+		if err = c.updateDHCPOptions(&portDHCPOpt, &portDHCPOpt.Cidr, &portDHCPOpt.Options); err != nil {
+			klog.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func mergeDHCPOptions(options, overrides map[string]string) map[string]string {
+	merged := maps.Clone(options)
+	if merged == nil {
+		merged = make(map[string]string, len(overrides))
+	}
+	maps.Copy(merged, overrides)
+	return merged
+}
+
 // updateDHCPOptions update dhcp options
 func (c *OVNNbClient) updateDHCPOptions(dhcpOpt *ovnnb.DHCPOptions, fields ...interface{}) error {
 	if dhcpOpt == nil {
@@ -263,6 +412,10 @@ func (c *OVNNbClient) GetDHCPOptions(lsName, protocol string, ignoreNotFound boo
 		klog.Error(err)
 		return nil, fmt.Errorf("get logical switch %s %s dhcp options: %v", lsName, protocol, err)
 	}
+	// skip the dhcp options dedicated to the logical switch ports
+	dhcpOptList = slices.DeleteFunc(dhcpOptList, func(dhcpOpt ovnnb.DHCPOptions) bool {
+		return len(dhcpOpt.ExternalIDs[logicalSwitchPortKey]) != 0
+	})
 
 	// not found
 	if len(dhcpOptList) == 0 {
@@ -300,7 +453,7 @@ func (c *OVNNbClient) DHCPOptionsExists(lsName, cidr string) (bool, error) {
 }
 
 // newDHCPOptions return dhcp options with basic information
-func newDHCPOptions(lsName, cidr, options string) (*ovnnb.DHCPOptions, error) {
+func newDHCPOptions(lsName, cidr string, options map[string]string) (*ovnnb.DHCPOptions, error) {
 	if len(cidr) == 0 || len(lsName) == 0 {
 		return nil, fmt.Errorf("logical switch name %s and cidr %s is required", lsName, cidr)
 	}
@@ -317,10 +470,92 @@ func newDHCPOptions(lsName, cidr, options string) (*ovnnb.DHCPOptions, error) {
 			"protocol":       protocol,
 			"vendor":         util.CniTypeName,
 		},
-		Options: parseDHCPOptions(options),
+		Options: options,
 	}, nil
 }
 
+// dhcpV4OptionsFromSpec render the ipv4 dhcp options of a DHCPOptions
+func dhcpV4OptionsFromSpec(spec *kubeovnv1.DHCPOptionsSpec, gateway, serverMAC string, mtu int) map[string]string {
+	leaseTime := 3600
+	if spec.LeaseTime > 0 {
+		leaseTime = int(spec.LeaseTime)
+	}
+	if spec.MTU > 0 {
+		mtu = int(spec.MTU)
+	}
+
+	options := map[string]string{
+		"lease_time": strconv.Itoa(leaseTime),
+		"router":     gateway,
+		"server_id":  "169.254.0.254",
+		"server_mac": serverMAC,
+		"mtu":        strconv.Itoa(mtu),
+	}
+	if len(spec.Routers) != 0 {
+		options["router"] = dhcpOptionSet(spec.Routers)
+	}
+	if dnsServers := filterIPsByProtocol(spec.DNSServers, kubeovnv1.ProtocolIPv4); len(dnsServers) != 0 {
+		options["dns_server"] = dhcpOptionSet(dnsServers)
+	}
+	if len(spec.DomainSearch) != 0 {
+		options["domain_search_list"] = strconv.Quote(strings.Join(spec.DomainSearch, ","))
+	}
+	if len(spec.NTPServers) != 0 {
+		options["ntp_server"] = dhcpOptionSet(spec.NTPServers)
+	}
+	if len(spec.ClasslessStaticRoutes) != 0 {
+		routes := make([]string, 0, len(spec.ClasslessStaticRoutes))
+		for _, route := range spec.ClasslessStaticRoutes {
+			routes = append(routes, route.Destination+","+route.NextHop)
+		}
+		options["classless_static_route"] = "{" + strings.Join(routes, ", ") + "}"
+	}
+	if spec.Bootfile != "" {
+		options["bootfile_name"] = strconv.Quote(spec.Bootfile)
+	}
+	if spec.IPXEBootfile != "" {
+		options["bootfile_name_alt"] = strconv.Quote(spec.IPXEBootfile)
+	}
+	if spec.NextServer != "" {
+		options["next_server"] = spec.NextServer
+	}
+
+	return options
+}
+
+// dhcpV6OptionsFromSpec render the ipv6 dhcp options of a DHCPOptions
+func dhcpV6OptionsFromSpec(spec *kubeovnv1.DHCPOptionsSpec, serverID string) map[string]string {
+	options := map[string]string{
+		"server_id": serverID,
+	}
+	if dnsServers := filterIPsByProtocol(spec.DNSServers, kubeovnv1.ProtocolIPv6); len(dnsServers) != 0 {
+		options["dns_server"] = dhcpOptionSet(dnsServers)
+	}
+	if len(spec.DomainSearch) != 0 {
+		options["domain_search"] = strconv.Quote(spec.DomainSearch[0])
+	}
+
+	return options
+}
+
+// dhcpOptionSet format values as an ovn dhcp option value, more than one value is formatted as a set
+func dhcpOptionSet(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return "{" + strings.Join(values, ", ") + "}"
+}
+
+func filterIPsByProtocol(ips []string, protocol string) []string {
+	var result []string
+	for _, ip := range ips {
+		if util.CheckProtocol(ip) == protocol {
+			result = append(result, ip)
+		}
+	}
+	return result
+}
+
 // dhcpOptionsFilter filter dhcp options which match the given externalIDs,
 // result should include all dhcp options when externalIDs is empty,
 // result should include all dhcp options which externalIDs[key] is not empty when externalIDs[key] is ""
@@ -35,7 +35,7 @@ func (suite *OvnClientTestSuite) testUpdateDHCPOptions() {
 	subnet := mockSubnet(lsName, true)
 
 	t.Run("update dhcp options", func(t *testing.T) {
-		uuid, err := ovnClient.UpdateDHCPOptions(subnet, 1500)
+		uuid, err := ovnClient.UpdateDHCPOptions(subnet, 1500, nil)
 		require.NoError(t, err)
 
 		v4DHCPOpt, err := ovnClient.GetDHCPOptions(lsName, "IPv4", false)
@@ -51,7 +51,7 @@ func (suite *OvnClientTestSuite) testUpdateDHCPOptions() {
 	t.Run("delete dhcp options", func(t *testing.T) {
 		subnet.Spec.EnableDHCP = false
 
-		uuid, err := ovnClient.UpdateDHCPOptions(subnet, 1500)
+		uuid, err := ovnClient.UpdateDHCPOptions(subnet, 1500, nil)
 		require.NoError(t, err)
 		require.Empty(t, uuid.DHCPv4OptionsUUID)
 		require.Empty(t, uuid.DHCPv6OptionsUUID)
@@ -76,7 +76,7 @@ func (suite *OvnClientTestSuite) testUpdateDHCPv4Options() {
 
 	t.Run("create dhcp options", func(t *testing.T) {
 		t.Run("without options", func(t *testing.T) {
-			uuid, err := ovnClient.updateDHCPv4Options(lsName, cidr, gateway, "", 1500)
+			uuid, err := ovnClient.updateDHCPv4Options(lsName, cidr, gateway, "", nil, 1500)
 			require.NoError(t, err)
 
 			dhcpOpt, err := ovnClient.GetDHCPOptions(lsName, "IPv4", false)
@@ -98,7 +98,7 @@ func (suite *OvnClientTestSuite) testUpdateDHCPv4Options() {
 		t.Run("with options", func(t *testing.T) {
 			lsName := "test-update-v4-dhcp-opt-ls-with-opt"
 			options := fmt.Sprintf("lease_time=%d,router=%s,server_id=%s,server_mac=%s", 7200, gateway, "169.254.0.1", "00:00:00:11:22:33")
-			uuid, err := ovnClient.updateDHCPv4Options(lsName, cidr, gateway, options, 1500)
+			uuid, err := ovnClient.updateDHCPv4Options(lsName, cidr, gateway, options, nil, 1500)
 			require.NoError(t, err)
 
 			dhcpOpt, err := ovnClient.GetDHCPOptions(lsName, "IPv4", false)
@@ -113,10 +113,48 @@ func (suite *OvnClientTestSuite) testUpdateDHCPv4Options() {
 				"server_mac": "00:00:00:11:22:33",
 			}, dhcpOpt.Options)
 		})
+
+		t.Run("with spec", func(t *testing.T) {
+			lsName := "test-update-v4-dhcp-opt-ls-with-spec"
+			spec := &kubeovnv1.DHCPOptionsSpec{
+				DNSServers:   []string{"8.8.8.8", "1.1.1.1", "2001:4860:4860::8888"},
+				DomainSearch: []string{"example.com", "example.org"},
+				NTPServers:   []string{"192.168.30.2"},
+				LeaseTime:    7200,
+				ClasslessStaticRoutes: []kubeovnv1.DHCPStaticRoute{
+					{Destination: "10.0.0.0/8", NextHop: "192.168.30.254"},
+					{Destination: "0.0.0.0/0", NextHop: gateway},
+				},
+				Bootfile:     "undionly.kpxe",
+				IPXEBootfile: "http://192.168.30.3/boot.ipxe",
+				NextServer:   "192.168.30.3",
+			}
+			uuid, err := ovnClient.updateDHCPv4Options(lsName, cidr, gateway, "", spec, 1500)
+			require.NoError(t, err)
+
+			dhcpOpt, err := ovnClient.GetDHCPOptions(lsName, "IPv4", false)
+			require.NoError(t, err)
+
+			require.Equal(t, uuid, dhcpOpt.UUID)
+			require.Equal(t, map[string]string{
+				"lease_time":             "7200",
+				"router":                 "192.168.30.1",
+				"server_id":              "169.254.0.254",
+				"server_mac":             dhcpOpt.Options["server_mac"],
+				"mtu":                    "1500",
+				"dns_server":             "{8.8.8.8, 1.1.1.1}",
+				"domain_search_list":     `"example.com,example.org"`,
+				"ntp_server":             "192.168.30.2",
+				"classless_static_route": "{10.0.0.0/8,192.168.30.254, 0.0.0.0/0,192.168.30.1}",
+				"bootfile_name":          `"undionly.kpxe"`,
+				"bootfile_name_alt":      `"http://192.168.30.3/boot.ipxe"`,
+				"next_server":            "192.168.30.3",
+			}, dhcpOpt.Options)
+		})
 	})
 
 	t.Run("update dhcp options", func(t *testing.T) {
-		uuid, err := ovnClient.updateDHCPv4Options(lsName, cidr, gateway, "", 1500)
+		uuid, err := ovnClient.updateDHCPv4Options(lsName, cidr, gateway, "", nil, 1500)
 		require.NoError(t, err)
 
 		dhcpOpt, err := ovnClient.GetDHCPOptions(lsName, "IPv4", false)
@@ -145,7 +183,7 @@ func (suite *OvnClientTestSuite) testUpdateDHCPv6Options() {
 
 	t.Run("create dhcp options", func(t *testing.T) {
 		t.Run("without options", func(t *testing.T) {
-			uuid, err := ovnClient.updateDHCPv6Options(lsName, cidr, "")
+			uuid, err := ovnClient.updateDHCPv6Options(lsName, cidr, "", nil)
 			require.NoError(t, err)
 
 			dhcpOpt, err := ovnClient.GetDHCPOptions(lsName, "IPv6", false)
@@ -163,7 +201,7 @@ func (suite *OvnClientTestSuite) testUpdateDHCPv6Options() {
 		t.Run("with options", func(t *testing.T) {
 			lsName := "test-update-v6-dhcp-opt-ls-with-opt"
 			options := fmt.Sprintf("server_id=%s", "00:00:00:55:22:33")
-			uuid, err := ovnClient.updateDHCPv6Options(lsName, cidr, options)
+			uuid, err := ovnClient.updateDHCPv6Options(lsName, cidr, options, nil)
 			require.NoError(t, err)
 
 			dhcpOpt, err := ovnClient.GetDHCPOptions(lsName, "IPv6", false)
@@ -178,7 +216,7 @@ func (suite *OvnClientTestSuite) testUpdateDHCPv6Options() {
 	})
 
 	t.Run("update dhcp options", func(t *testing.T) {
-		uuid, err := ovnClient.updateDHCPv6Options(lsName, cidr, "")
+		uuid, err := ovnClient.updateDHCPv6Options(lsName, cidr, "", nil)
 		require.NoError(t, err)
 
 		dhcpOpt, err := ovnClient.GetDHCPOptions(lsName, "IPv6", false)
@@ -192,6 +230,70 @@ func (suite *OvnClientTestSuite) testUpdateDHCPv6Options() {
 	})
 }
 
+func (suite *OvnClientTestSuite) testUpdateLogicalSwitchPortDHCPv4Options() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+	lsName := "test-update-lsp-v4-dhcp-opt-ls"
+	lspName := "test-update-lsp-v4-dhcp-opt-lsp"
+	cidr := "192.168.60.0/24"
+	gateway := "192.168.60.1"
+
+	t.Run("logical switch without dhcp options", func(t *testing.T) {
+		uuid, err := ovnClient.UpdateLogicalSwitchPortDHCPv4Options(lsName, lspName, map[string]string{"next_server": "192.168.60.2"})
+		require.NoError(t, err)
+		require.Empty(t, uuid)
+	})
+
+	lsUUID, err := ovnClient.updateDHCPv4Options(lsName, cidr, gateway, "", nil, 1500)
+	require.NoError(t, err)
+
+	t.Run("create and refresh dhcp options of logical switch port", func(t *testing.T) {
+		uuid, err := ovnClient.UpdateLogicalSwitchPortDHCPv4Options(lsName, lspName, map[string]string{"next_server": "192.168.60.2", "bootfile_name": `"pxelinux.0"`})
+		require.NoError(t, err)
+		require.NotEqual(t, lsUUID, uuid)
+
+		// the dhcp options of the logical switch are still unique
+		dhcpOpt, err := ovnClient.GetDHCPOptions(lsName, "IPv4", false)
+		require.NoError(t, err)
+		require.Equal(t, lsUUID, dhcpOpt.UUID)
+
+		portDHCPOpts, err := ovnClient.ListDHCPOptions(true, map[string]string{logicalSwitchPortKey: lspName})
+		require.NoError(t, err)
+		require.Len(t, portDHCPOpts, 1)
+		require.Equal(t, uuid, portDHCPOpts[0].UUID)
+		require.Equal(t, cidr, portDHCPOpts[0].Cidr)
+		require.Equal(t, "192.168.60.2", portDHCPOpts[0].Options["next_server"])
+		require.Equal(t, `"pxelinux.0"`, portDHCPOpts[0].Options["bootfile_name"])
+		require.Equal(t, "3600", portDHCPOpts[0].Options["lease_time"])
+
+		_, err = ovnClient.updateDHCPv4Options(lsName, cidr, gateway, "lease_time=7200,router=192.168.60.1,next_server=192.168.60.3", nil, 1500)
+		require.NoError(t, err)
+
+		portDHCPOpts, err = ovnClient.ListDHCPOptions(true, map[string]string{logicalSwitchPortKey: lspName})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"lease_time":    "7200",
+			"router":        "192.168.60.1",
+			"next_server":   "192.168.60.2",
+			"bootfile_name": `"pxelinux.0"`,
+		}, portDHCPOpts[0].Options)
+	})
+
+	t.Run("delete dhcp options of logical switch port", func(t *testing.T) {
+		err := ovnClient.DeleteLogicalSwitchPortDHCPOptions(lspName)
+		require.NoError(t, err)
+
+		portDHCPOpts, err := ovnClient.ListDHCPOptions(true, map[string]string{logicalSwitchPortKey: lspName})
+		require.NoError(t, err)
+		require.Empty(t, portDHCPOpts)
+
+		_, err = ovnClient.GetDHCPOptions(lsName, "IPv4", false)
+		require.NoError(t, err)
+	})
+}
+
 func (suite *OvnClientTestSuite) testDeleteDHCPOptionsByUUIDs() {
 	t := suite.T()
 	t.Parallel()
@@ -372,21 +474,21 @@ func (suite *OvnClientTestSuite) testDhcpOptionsFilter() {
 
 		// create three ipv4 dhcp options
 		for _, cidr := range v4CidrBlock {
-			dhcpOpt, err := newDHCPOptions(lsName, cidr, "")
+			dhcpOpt, err := newDHCPOptions(lsName, cidr, nil)
 			require.NoError(t, err)
 			dhcpOpts = append(dhcpOpts, dhcpOpt)
 		}
 
 		// create two ipv6 dhcp options
 		for _, cidr := range v6CidrBlock {
-			dhcpOpt, err := newDHCPOptions(lsName, cidr, "")
+			dhcpOpt, err := newDHCPOptions(lsName, cidr, nil)
 			require.NoError(t, err)
 			dhcpOpts = append(dhcpOpts, dhcpOpt)
 		}
 
 		// create three ipv4 dhcp options with other logical switch name
 		for _, cidr := range v4CidrBlock {
-			dhcpOpt, err := newDHCPOptions(lsName, cidr, "")
+			dhcpOpt, err := newDHCPOptions(lsName, cidr, nil)
 			dhcpOpt.ExternalIDs[logicalSwitchKey] = lsName + "-test"
 			require.NoError(t, err)
 			dhcpOpts = append(dhcpOpts, dhcpOpt)
@@ -394,7 +496,7 @@ func (suite *OvnClientTestSuite) testDhcpOptionsFilter() {
 
 		// create three ipv4 dhcp options with other vendor
 		for _, cidr := range v4CidrBlock {
-			dhcpOpt, err := newDHCPOptions(lsName, cidr, "")
+			dhcpOpt, err := newDHCPOptions(lsName, cidr, nil)
 			dhcpOpt.ExternalIDs["vendor"] = util.CniTypeName + "-test"
 			require.NoError(t, err)
 			dhcpOpts = append(dhcpOpts, dhcpOpt)
@@ -454,7 +556,7 @@ func (suite *OvnClientTestSuite) testDhcpOptionsFilter() {
 	t.Run("result should exclude dhcp options when externalIDs's length is not equal", func(t *testing.T) {
 		t.Parallel()
 
-		dhcpOpt, err := newDHCPOptions(lsName, "192.168.30.0/24", "")
+		dhcpOpt, err := newDHCPOptions(lsName, "192.168.30.0/24", nil)
 		require.NoError(t, err)
 
 		filterFunc := dhcpOptionsFilter(true, map[string]string{
@@ -115,6 +115,25 @@ func (c *OVNNbClient) AddLogicalRouterStaticRoute(lrName, routeTable, policy, ip
 	return nil
 }
 
+// AddLogicalRouterStaticRouteWithExternalIDs add a logical router static route with the external ids if it does not exist,
+// the other routes of the same prefix are not changed
+func (c *OVNNbClient) AddLogicalRouterStaticRouteWithExternalIDs(lrName, routeTable, policy, ipPrefix, nexthop string, externalIDs map[string]string) error {
+	route, err := c.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, nil, func(route *ovnnb.LogicalRouterStaticRoute) {
+		route.ExternalIDs = externalIDs
+	})
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if route == nil {
+		return nil
+	}
+	if err = c.CreateLogicalRouterStaticRoutes(lrName, route); err != nil {
+		return fmt.Errorf("failed to add static route %s to logical router %s: %v", ipPrefix, lrName, err)
+	}
+	return nil
+}
+
 // UpdateLogicalRouterStaticRoute update logical router static route
 func (c *OVNNbClient) UpdateLogicalRouterStaticRoute(route *ovnnb.LogicalRouterStaticRoute, fields ...interface{}) error {
 	if route == nil {
@@ -132,6 +132,39 @@ func (suite *OvnClientTestSuite) testAddLogicalRouterStaticRoute() {
 	})
 }
 
+func (suite *OvnClientTestSuite) testAddLogicalRouterStaticRouteWithExternalIDs() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+	lrName := "test-add-route-ext-ids-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	ipPrefix := "192.168.40.0/24"
+	externalIDs := map[string]string{"key": "value"}
+
+	err := ovnClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	err = ovnClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, "192.168.30.1")
+	require.NoError(t, err)
+
+	err = ovnClient.AddLogicalRouterStaticRouteWithExternalIDs(lrName, routeTable, policy, ipPrefix, "192.168.30.2", externalIDs)
+	require.NoError(t, err)
+	// add the route again
+	err = ovnClient.AddLogicalRouterStaticRouteWithExternalIDs(lrName, routeTable, policy, ipPrefix, "192.168.30.2", externalIDs)
+	require.NoError(t, err)
+
+	routes, err := ovnClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+
+	routes, err = ovnClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, externalIDs)
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	require.Equal(t, "192.168.30.2", routes[0].Nexthop)
+}
+
 func (suite *OvnClientTestSuite) testDeleteLogicalRouterStaticRoute() {
 	t := suite.T()
 	t.Parallel()
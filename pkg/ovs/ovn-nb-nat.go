@@ -84,24 +84,31 @@ func (c *OVNNbClient) CreateNats(lrName string, nats ...*ovnnb.NAT) error {
 	return nil
 }
 
-// UpdateSnat update snat rule
-func (c *OVNNbClient) UpdateSnat(lrName, externalIP, logicalIP string) error {
+// UpdateSnat update snat rule identified by router, logical ip and external ip,
+// the source ports are limited to externalPortRange when it is not empty, eg: 1024-65535
+func (c *OVNNbClient) UpdateSnat(lrName, externalIP, logicalIP, externalPortRange string) error {
 	natType := ovnnb.NATTypeSNAT
 
-	nat, err := c.GetNat(lrName, natType, "", logicalIP, true)
+	nat, err := c.GetNat(lrName, natType, externalIP, logicalIP, true)
 	if err != nil {
 		klog.Error(err)
 		return err
 	}
 
-	// update external ip when nat exists
+	// update external port range when nat exists
 	if nat != nil {
-		nat.ExternalIP = externalIP
-		return c.UpdateNat(nat, &nat.ExternalIP)
+		if nat.ExternalPortRange == externalPortRange {
+			return nil
+		}
+		nat.ExternalPortRange = externalPortRange
+		return c.UpdateNat(nat, &nat.ExternalPortRange)
 	}
 
 	/* create nat */
-	if nat, err = c.newNat(lrName, natType, externalIP, logicalIP, "", ""); err != nil {
+	options := func(nat *ovnnb.NAT) {
+		nat.ExternalPortRange = externalPortRange
+	}
+	if nat, err = c.newNat(lrName, natType, externalIP, logicalIP, "", "", options); err != nil {
 		klog.Error(err)
 		return fmt.Errorf("new logical router %s nat 'type %s external ip %s logical ip %s': %v", lrName, natType, externalIP, logicalIP, err)
 	}
@@ -114,8 +121,9 @@ func (c *OVNNbClient) UpdateSnat(lrName, externalIP, logicalIP string) error {
 	return nil
 }
 
-// UpdateDnatAndSnat update dnat_and_snat rule
-func (c *OVNNbClient) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType string) error {
+// UpdateDnatAndSnat update dnat_and_snat rule,
+// distributed dnat_and_snat bypasses conntrack when stateless is true
+func (c *OVNNbClient) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType string, stateless bool) error {
 	natType := ovnnb.NATTypeDNATAndSNAT
 
 	nat, err := c.GetNat(lrName, natType, externalIP, "", true)
@@ -130,9 +138,24 @@ func (c *OVNNbClient) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName,
 			// clear lspName and externalMac when they are empty
 			nat.LogicalPort = &lspName
 			nat.ExternalMAC = &externalMac
-			return c.UpdateNat(nat, &nat.LogicalPort, &nat.ExternalMAC)
+			if stateless {
+				if nat.Options == nil {
+					nat.Options = make(map[string]string, 1)
+				}
+				nat.Options["stateless"] = "true"
+			} else {
+				delete(nat.Options, "stateless")
+			}
+			return c.UpdateNat(nat, &nat.LogicalPort, &nat.ExternalMAC, &nat.Options)
 		}
-		return nil // do nothing when gw is centralized
+		if nat.LogicalPort == nil && nat.ExternalMAC == nil && nat.Options["stateless"] == "" {
+			return nil // do nothing when gw is centralized
+		}
+		// switch from distributed to centralized
+		nat.LogicalPort = nil
+		nat.ExternalMAC = nil
+		delete(nat.Options, "stateless")
+		return c.UpdateNat(nat, &nat.LogicalPort, &nat.ExternalMAC, &nat.Options)
 	}
 
 	options := func(nat *ovnnb.NAT) {
@@ -140,10 +163,12 @@ func (c *OVNNbClient) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName,
 			nat.LogicalPort = &lspName
 			nat.ExternalMAC = &externalMac
 
-			if nat.Options == nil {
-				nat.Options = make(map[string]string, 1)
+			if stateless {
+				if nat.Options == nil {
+					nat.Options = make(map[string]string, 1)
+				}
+				nat.Options["stateless"] = "true"
 			}
-			nat.Options["stateless"] = "true"
 		}
 	}
 
@@ -283,7 +308,8 @@ func (c *OVNNbClient) GetNat(lrName, natType, externalIP, logicalIP string, igno
 			return nat.LogicalIP == logicalIP
 		}
 		if natType == ovnnb.NATTypeSNAT {
-			return nat.Type == natType && nat.LogicalIP == logicalIP
+			// the same logical ip may be translated to different external ips by different snat rules
+			return nat.Type == natType && nat.LogicalIP == logicalIP && (externalIP == "" || nat.ExternalIP == externalIP)
 		}
 		return nat.Type == natType && nat.ExternalIP == externalIP
 	}
@@ -91,7 +91,7 @@ func (suite *OvnClientTestSuite) testUpdateSnat() {
 	require.NoError(t, err)
 
 	t.Run("create snat", func(t *testing.T) {
-		err = ovnClient.UpdateSnat(lrName, externalIP, logicalIP)
+		err = ovnClient.UpdateSnat(lrName, externalIP, logicalIP, "")
 		require.NoError(t, err)
 
 		lr, err := ovnClient.GetLogicalRouter(lrName, false)
@@ -103,14 +103,35 @@ func (suite *OvnClientTestSuite) testUpdateSnat() {
 		require.Contains(t, lr.Nat, nat.UUID)
 	})
 
-	t.Run("update snat", func(t *testing.T) {
-		externalIP := "192.168.30.253"
-		err = ovnClient.UpdateSnat(lrName, externalIP, logicalIP)
+	t.Run("snat of another external ip", func(t *testing.T) {
+		anotherExternalIP := "192.168.30.253"
+		err = ovnClient.UpdateSnat(lrName, anotherExternalIP, logicalIP, "")
+		require.NoError(t, err)
+
+		// the snat of the same logical ip and another external ip is not overwritten
+		nat, err := ovnClient.GetNat(lrName, natType, externalIP, logicalIP, false)
+		require.NoError(t, err)
+		require.Equal(t, externalIP, nat.ExternalIP)
+
+		nat, err = ovnClient.GetNat(lrName, natType, anotherExternalIP, logicalIP, false)
+		require.NoError(t, err)
+		require.Equal(t, anotherExternalIP, nat.ExternalIP)
+
+		_, err = ovnClient.GetNat(lrName, natType, "", logicalIP, false)
+		require.ErrorContains(t, err, "more than one nat")
+
+		err = ovnClient.DeleteNat(lrName, natType, anotherExternalIP, logicalIP)
+		require.NoError(t, err)
+	})
+
+	t.Run("update snat external port range", func(t *testing.T) {
+		err = ovnClient.UpdateSnat(lrName, externalIP, logicalIP, "1024-65535")
 		require.NoError(t, err)
 
 		nat, err := ovnClient.GetNat(lrName, natType, "", logicalIP, false)
 		require.NoError(t, err)
 		require.Equal(t, externalIP, nat.ExternalIP)
+		require.Equal(t, "1024-65535", nat.ExternalPortRange)
 	})
 }
 
@@ -131,7 +152,7 @@ func (suite *OvnClientTestSuite) testUpdateDnatAndSnat() {
 
 	t.Run("create dnat_and_snat", func(t *testing.T) {
 		t.Run("distributed gw", func(t *testing.T) {
-			err = ovnClient.UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, kubeovnv1.GWDistributedType)
+			err = ovnClient.UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, kubeovnv1.GWDistributedType, true)
 			require.NoError(t, err)
 
 			lr, err := ovnClient.GetLogicalRouter(lrName, false)
@@ -149,7 +170,7 @@ func (suite *OvnClientTestSuite) testUpdateDnatAndSnat() {
 		t.Run("centralized gw", func(t *testing.T) {
 			externalIP := "192.168.30.250"
 
-			err = ovnClient.UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, kubeovnv1.GWCentralizedType)
+			err = ovnClient.UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, kubeovnv1.GWCentralizedType, true)
 			require.NoError(t, err)
 
 			lr, err := ovnClient.GetLogicalRouter(lrName, false)
@@ -168,7 +189,7 @@ func (suite *OvnClientTestSuite) testUpdateDnatAndSnat() {
 			lspName := "test-update-dnat-and-snat-lrp-1"
 			externalMac := "00:00:00:08:0a:ff"
 
-			err = ovnClient.UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, kubeovnv1.GWDistributedType)
+			err = ovnClient.UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, kubeovnv1.GWDistributedType, true)
 			require.NoError(t, err)
 
 			lr, err := ovnClient.GetLogicalRouter(lrName, false)
@@ -182,6 +203,39 @@ func (suite *OvnClientTestSuite) testUpdateDnatAndSnat() {
 
 			require.Contains(t, lr.Nat, nat.UUID)
 		})
+
+		t.Run("switch distributed gw to centralized gw", func(t *testing.T) {
+			err = ovnClient.UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, kubeovnv1.GWCentralizedType, true)
+			require.NoError(t, err)
+
+			nat, err := ovnClient.GetNat(lrName, natType, externalIP, "", false)
+			require.NoError(t, err)
+			require.Nil(t, nat.LogicalPort)
+			require.Nil(t, nat.ExternalMAC)
+			require.Empty(t, nat.Options["stateless"])
+		})
+
+		t.Run("switch centralized gw to distributed gw", func(t *testing.T) {
+			err = ovnClient.UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, kubeovnv1.GWDistributedType, true)
+			require.NoError(t, err)
+
+			nat, err := ovnClient.GetNat(lrName, natType, externalIP, "", false)
+			require.NoError(t, err)
+			require.Equal(t, lspName, *nat.LogicalPort)
+			require.Equal(t, externalMac, *nat.ExternalMAC)
+			require.Equal(t, "true", nat.Options["stateless"])
+		})
+
+		t.Run("distributed gw without stateless", func(t *testing.T) {
+			err = ovnClient.UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, kubeovnv1.GWDistributedType, false)
+			require.NoError(t, err)
+
+			nat, err := ovnClient.GetNat(lrName, natType, externalIP, "", false)
+			require.NoError(t, err)
+			require.Equal(t, lspName, *nat.LogicalPort)
+			require.Equal(t, externalMac, *nat.ExternalMAC)
+			require.NotContains(t, nat.Options, "stateless")
+		})
 	})
 }
 
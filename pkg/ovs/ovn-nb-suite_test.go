@@ -466,6 +466,14 @@ func (suite *OvnClientTestSuite) Test_UpdateEgressAclOps() {
 	suite.testUpdateEgressACLOps()
 }
 
+func (suite *OvnClientTestSuite) Test_UpdateAnpRuleAclOps() {
+	suite.testUpdateAnpRuleACLOps()
+}
+
+func (suite *OvnClientTestSuite) Test_MigrateACLTier() {
+	suite.testMigrateACLTier()
+}
+
 func (suite *OvnClientTestSuite) Test_CreateGatewayAcl() {
 	suite.testCreateGatewayACL()
 }
@@ -609,6 +617,10 @@ func (suite *OvnClientTestSuite) Test_AddLogicalRouterStaticRoute() {
 	suite.testAddLogicalRouterStaticRoute()
 }
 
+func (suite *OvnClientTestSuite) Test_AddLogicalRouterStaticRouteWithExternalIDs() {
+	suite.testAddLogicalRouterStaticRouteWithExternalIDs()
+}
+
 func (suite *OvnClientTestSuite) Test_DeleteLogicalRouterStaticRoute() {
 	suite.testDeleteLogicalRouterStaticRoute()
 }
@@ -642,6 +654,10 @@ func (suite *OvnClientTestSuite) Test_updateDHCPv6Options() {
 	suite.testUpdateDHCPv6Options()
 }
 
+func (suite *OvnClientTestSuite) Test_UpdateLogicalSwitchPortDHCPv4Options() {
+	suite.testUpdateLogicalSwitchPortDHCPv4Options()
+}
+
 func (suite *OvnClientTestSuite) Test_DeleteDHCPOptionsByUUIDs() {
 	suite.testDeleteDHCPOptionsByUUIDs()
 }
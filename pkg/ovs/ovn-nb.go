@@ -15,11 +15,15 @@ import (
 const (
 	logicalRouterKey      = "lr"
 	logicalSwitchKey      = "ls"
+	logicalSwitchPortKey  = "lsp"
 	portGroupKey          = "pg"
-	aclParentKey          = "parent"
+	aclParentKey          = util.ACLParentKey
 	associatedSgKeyPrefix = "associated_sg_"
 	sgsKey                = "security_groups"
 	sgKey                 = "sg"
+
+	// the max length of the name of ovn acl
+	aclNameMaxLength = 63
 )
 
 // CreateGatewayLogicalSwitch create gateway switch connect external networks
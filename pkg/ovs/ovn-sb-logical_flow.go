@@ -0,0 +1,84 @@
+package ovs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"k8s.io/klog/v2"
+)
+
+// stageHintLen is the length of the northbound record uuid prefix stored in the stage-hint external id of logical flows
+const stageHintLen = 8
+
+// GetLogicalFlowCookies returns the openflow cookies of the logical flows generated from the northbound records,
+// key is the uuid of the northbound record. ovn-controller uses the first 32 bits of the logical flow uuid as the
+// cookie of the openflow flows translated from the logical flow. The Logical_Flow table is too large to be monitored,
+// so the flows are selected from the database directly.
+func (c *OVNSbClient) GetLogicalFlowCookies(uuids []string) (map[string][]uint64, error) {
+	if len(uuids) == 0 {
+		return nil, nil
+	}
+
+	ops := make([]ovsdb.Operation, 0, len(uuids))
+	for _, uuid := range uuids {
+		if len(uuid) < stageHintLen {
+			return nil, fmt.Errorf("invalid uuid %q", uuid)
+		}
+		hint, err := ovsdb.NewOvsMap(map[string]string{"stage-hint": uuid[:stageHintLen]})
+		if err != nil {
+			klog.Error(err)
+			return nil, err
+		}
+		ops = append(ops, ovsdb.Operation{
+			Op:      ovsdb.OperationSelect,
+			Table:   "Logical_Flow",
+			Where:   []ovsdb.Condition{{Column: "external_ids", Function: ovsdb.ConditionIncludes, Value: hint}},
+			Columns: []string{"_uuid"},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	results, err := c.Client.Transact(ctx, ops...)
+	if err != nil {
+		klog.Errorf("failed to select logical flows: %v", err)
+		return nil, err
+	}
+	if _, err = ovsdb.CheckOperationResults(results, ops); err != nil {
+		klog.Errorf("failed to select logical flows: %v", err)
+		return nil, err
+	}
+
+	cookies := make(map[string][]uint64, len(uuids))
+	for i, result := range results {
+		for _, row := range result.Rows {
+			cookie, err := logicalFlowCookie(row["_uuid"])
+			if err != nil {
+				klog.Error(err)
+				return nil, err
+			}
+			cookies[uuids[i]] = append(cookies[uuids[i]], cookie)
+		}
+	}
+	return cookies, nil
+}
+
+func logicalFlowCookie(value interface{}) (uint64, error) {
+	var uuid string
+	switch v := value.(type) {
+	case ovsdb.UUID:
+		uuid = v.GoUUID
+	case string:
+		uuid = v
+	}
+	if len(uuid) < stageHintLen {
+		return 0, fmt.Errorf("invalid logical flow uuid %v", value)
+	}
+	cookie, err := strconv.ParseUint(uuid[:stageHintLen], 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid logical flow uuid %s: %v", uuid, err)
+	}
+	return cookie, nil
+}
@@ -51,6 +51,14 @@ func GetSgV6AssociatedName(sgName string) string {
 	return strings.ReplaceAll(fmt.Sprintf("ovn.sg.%s.associated.v6", sgName), "-", ".")
 }
 
+func GetAddressGroupAsName(agName, protocol string) string {
+	suffix := "v4"
+	if protocol == kubeovnv1.ProtocolIPv6 {
+		suffix = "v6"
+	}
+	return strings.ReplaceAll(fmt.Sprintf("ovn.ag.%s.%s", agName, suffix), "-", ".")
+}
+
 // parseIpv6RaConfigs parses the ipv6 ra config,
 // return default Ipv6RaConfigs when raw="",
 // the raw config's format is: address_mode=dhcpv6_stateful,max_interval=30,min_interval=5,send_periodic=true
@@ -146,6 +154,11 @@ func (m AndACLMatch) Match() (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("generate match %s: %v", match, err)
 		}
+		// or match should be enclosed in parentheses, e.g. 'outport == @ovn.sg.test_sg && (tcp.dst == 80 || 1000 <= tcp.dst <= 2000)'
+		if _, ok := r.(OrACLMatch); ok && strings.Contains(match, "||") {
+			match = "(" + match + ")"
+		}
+
 		matches = append(matches, match)
 	}
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ovn-org/libovsdb/ovsdb"
 	"github.com/stretchr/testify/require"
 )
 
@@ -320,3 +321,13 @@ func Test_Limiter(t *testing.T) {
 		require.Equal(t, int32(2), limiter.Current())
 	})
 }
+
+func Test_logicalFlowCookie(t *testing.T) {
+	cookie, err := logicalFlowCookie(ovsdb.UUID{GoUUID: "1a2b3c4d-0000-4000-8000-000000000001"})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x1a2b3c4d), cookie)
+
+	_, err = logicalFlowCookie("invalid")
+	require.Error(t, err)
+	require.Equal(t, "egress/fqdn", networkPolicyRule("egress", "np/test.default/egress/IPv4/fqdn"))
+}
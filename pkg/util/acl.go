@@ -0,0 +1,71 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+)
+
+var (
+	// fields relying on connection tracking or on the state of a protocol, which can't be reversed statelessly
+	aclStatefulFieldRegex = regexp.MustCompile(`\b(ct[._][a-z_.]+|icmp[46]?\.(type|code)|icmp6\.nd[a-z_.]*|arp\.op|tcp\.flags)\b`)
+	// fields having a counterpart in the reply traffic
+	aclReversibleFieldRegex = regexp.MustCompile(`\b(inport|outport|(eth|ip4|ip6|tcp|udp|sctp)\.(src|dst)|arp\.(spa|tpa|sha|tha))\b`)
+	aclReversedFields       = map[string]string{
+		"inport":  "outport",
+		"outport": "inport",
+		"src":     "dst",
+		"dst":     "src",
+		"spa":     "tpa",
+		"tpa":     "spa",
+		"sha":     "tha",
+		"tha":     "sha",
+	}
+)
+
+// ReverseACLMatch returns the match of the reply traffic of the given acl match by swapping the source and
+// destination fields, e.g. 'ip4.src == 10.0.0.0/8 && tcp.dst == 53' is reversed to 'ip4.dst == 10.0.0.0/8 && tcp.src == 53'.
+// An error is returned if the match is not representable statelessly.
+func ReverseACLMatch(match string) (string, error) {
+	if locs := aclFieldIndexes(aclStatefulFieldRegex, match); len(locs) != 0 {
+		return "", fmt.Errorf("field %s in match %q can not be reversed statelessly", match[locs[0][0]:locs[0][1]], match)
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range aclFieldIndexes(aclReversibleFieldRegex, match) {
+		field := match[loc[0]:loc[1]]
+		prefix, name := "", field
+		if i := strings.LastIndexByte(field, '.'); i != -1 {
+			prefix, name = field[:i+1], field[i+1:]
+		}
+		b.WriteString(match[last:loc[0]])
+		b.WriteString(prefix + aclReversedFields[name])
+		last = loc[1]
+	}
+	b.WriteString(match[last:])
+	return b.String(), nil
+}
+
+// aclFieldIndexes returns the locations of the fields matched by the regex,
+// names of address sets and port groups such as '$as.ip4.src' are skipped
+func aclFieldIndexes(regex *regexp.Regexp, match string) [][]int {
+	var indexes [][]int
+	for _, loc := range regex.FindAllStringIndex(match, -1) {
+		if loc[0] != 0 && strings.ContainsRune("$@._", rune(match[loc[0]-1])) {
+			continue
+		}
+		indexes = append(indexes, loc)
+	}
+	return indexes
+}
+
+// ReverseACLDirection returns the opposite direction of an acl direction
+func ReverseACLDirection(direction string) string {
+	if direction == ovnnb.ACLDirectionToLport {
+		return ovnnb.ACLDirectionFromLport
+	}
+	return ovnnb.ACLDirectionToLport
+}
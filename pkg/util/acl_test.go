@@ -0,0 +1,63 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+)
+
+func TestReverseACLMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		match  string
+		expect string
+		err    bool
+	}{
+		{
+			name:   "ip and port",
+			match:  "ip4.src == 10.0.0.0/8 && udp.dst == 53",
+			expect: "ip4.dst == 10.0.0.0/8 && udp.src == 53",
+		},
+		{
+			name:   "port group and address set",
+			match:  "outport == @pg && ip6 && ip6.src == $as.ip6.src && tcp.dst == {80, 443}",
+			expect: "inport == @pg && ip6 && ip6.dst == $as.ip6.src && tcp.src == {80, 443}",
+		},
+		{
+			name:   "arp",
+			match:  "arp && arp.spa == 10.0.0.1 && eth.dst == ff:ff:ff:ff:ff:ff",
+			expect: "arp && arp.tpa == 10.0.0.1 && eth.src == ff:ff:ff:ff:ff:ff",
+		},
+		{
+			name:  "conntrack",
+			match: "ip4 && ct.est",
+			err:   true,
+		},
+		{
+			name:  "icmp type",
+			match: "icmp4 && icmp4.type == 8",
+			err:   true,
+		},
+		{
+			name:  "tcp flags",
+			match: "tcp && tcp.flags == 0x002",
+			err:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := ReverseACLMatch(tt.match)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, match)
+		})
+	}
+
+	require.Equal(t, ovnnb.ACLDirectionFromLport, ReverseACLDirection(ovnnb.ACLDirectionToLport))
+	require.Equal(t, ovnnb.ACLDirectionToLport, ReverseACLDirection(ovnnb.ACLDirectionFromLport))
+}
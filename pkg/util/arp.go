@@ -4,6 +4,7 @@
 package util
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"net"
@@ -12,6 +13,8 @@ import (
 	"time"
 
 	"github.com/mdlayher/arp"
+	"github.com/mdlayher/ethernet"
+	"github.com/mdlayher/packet"
 	"k8s.io/klog/v2"
 )
 
@@ -58,6 +61,103 @@ func ArpResolve(nic, _, dstIP string, timeout time.Duration, maxRetry int) (net.
 	return nil, count, fmt.Errorf("resolve MAC address of %s timeout: %v", dstIP, err)
 }
 
+// ArpProbe resolves the MAC address of dstIP with ARP probes sent from nic and tagged with vlanID if it is not zero.
+// The probes use 0.0.0.0 as the sender address, so nic is not required to have an IPv4 address,
+// which is the case for the bridges of provider networks.
+func ArpProbe(nic string, vlanID uint16, dstIP string, timeout time.Duration, maxRetry int) (net.HardwareAddr, int, error) {
+	target, err := netip.ParseAddr(dstIP)
+	if err != nil || !target.Is4() {
+		return nil, 0, fmt.Errorf("invalid target ipv4 address %s", dstIP)
+	}
+
+	ifi, err := net.InterfaceByName(nic)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get interface %s: %v", nic, err)
+	}
+	pkt, err := arp.NewPacket(arp.OperationRequest, ifi.HardwareAddr, netip.IPv4Unspecified(), net.HardwareAddr{0, 0, 0, 0, 0, 0}, target)
+	if err != nil {
+		return nil, 0, err
+	}
+	payload, err := pkt.MarshalBinary()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mac, count, err := probeNeighbor(ifi, vlanID, ethernet.Broadcast, ethernet.EtherTypeARP, payload, timeout, maxRetry, func(f *ethernet.Frame) net.HardwareAddr {
+		var reply arp.Packet
+		if err := reply.UnmarshalBinary(f.Payload); err != nil {
+			return nil
+		}
+		if reply.Operation == arp.OperationReply && reply.SenderIP == target {
+			return reply.SenderHardwareAddr
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, count, err
+	}
+	if mac == nil {
+		return nil, count, fmt.Errorf("no ARP reply from %s on interface %s", dstIP, nic)
+	}
+	return mac, count, nil
+}
+
+// probeNeighbor sends the payload in frames of etherType from ifi to dst up to maxRetry times,
+// and returns the MAC address returned by match for the first frame received within timeout of a probe
+func probeNeighbor(ifi *net.Interface, vlanID uint16, dst net.HardwareAddr, etherType ethernet.EtherType, payload []byte,
+	timeout time.Duration, maxRetry int, match func(*ethernet.Frame) net.HardwareAddr,
+) (net.HardwareAddr, int, error) {
+	frame := &ethernet.Frame{
+		Destination: dst,
+		Source:      ifi.HardwareAddr,
+		EtherType:   etherType,
+		Payload:     payload,
+	}
+	if vlanID != 0 {
+		frame.VLAN = &ethernet.VLAN{ID: vlanID}
+	}
+	request, err := frame.MarshalBinary()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conn, err := packet.Listen(ifi, packet.Raw, int(etherType), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to listen on interface %s: %v", ifi.Name, err)
+	}
+	defer conn.Close()
+
+	buf := make([]byte, ifi.MTU+32)
+	var count int
+	for ; count < maxRetry; count++ {
+		if _, err = conn.WriteTo(request, &packet.Addr{HardwareAddr: dst}); err != nil {
+			return nil, count, fmt.Errorf("failed to send probe on interface %s: %v", ifi.Name, err)
+		}
+		if err = conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, count, err
+		}
+		for {
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					break
+				}
+				return nil, count, err
+			}
+			var f ethernet.Frame
+			if err = f.UnmarshalBinary(buf[:n]); err != nil || f.EtherType != etherType {
+				continue
+			}
+			if mac := match(&f); mac != nil {
+				return mac, count + 1, nil
+			}
+		}
+	}
+
+	return nil, count, nil
+}
+
 func macEqual(a, b net.HardwareAddr) bool {
 	if len(a) != len(b) {
 		return false
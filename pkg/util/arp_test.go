@@ -0,0 +1,153 @@
+//go:build linux
+// +build linux
+
+package util
+
+import (
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/mdlayher/arp"
+	"github.com/mdlayher/ethernet"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+)
+
+// newProbeTestTap creates a tap device in a new network namespace,
+// frames sent from the device are read from the returned file, and frames written to the file are received by the device
+func newProbeTestTap(t *testing.T) (ns.NetNS, net.HardwareAddr, *os.File) {
+	t.Helper()
+	if os.Geteuid() != 0 {
+		t.Skip("creating network namespaces requires root privileges")
+	}
+
+	testNS, err := testutils.NewNS()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, testNS.Close())
+		require.NoError(t, testutils.UnmountNS(testNS))
+	})
+
+	var tap *netlink.Tuntap
+	err = testNS.Do(func(ns.NetNS) error {
+		tap = &netlink.Tuntap{
+			LinkAttrs:  netlink.LinkAttrs{Name: "br-test"},
+			Mode:       netlink.TUNTAP_MODE_TAP,
+			Flags:      netlink.TUNTAP_NO_PI,
+			Queues:     1,
+			NonPersist: true,
+		}
+		if err := netlink.LinkAdd(tap); err != nil {
+			return err
+		}
+		link, err := netlink.LinkByName(tap.Name)
+		if err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(link)
+	})
+	require.NoError(t, err)
+	require.Len(t, tap.Fds, 1)
+	t.Cleanup(func() { _ = tap.Fds[0].Close() })
+
+	iface, err := net.InterfaceByName("br-test")
+	if err == nil {
+		t.Fatal("the tap device is expected to be created in the test network namespace")
+	}
+	err = testNS.Do(func(ns.NetNS) error {
+		iface, err = net.InterfaceByName("br-test")
+		return err
+	})
+	require.NoError(t, err)
+	return testNS, iface.HardwareAddr, tap.Fds[0]
+}
+
+// respondProbe reads the frames sent from the tap device until reply returns a frame,
+// writes the frame back to the device and returns the request
+func respondProbe(tap *os.File, reply func(*ethernet.Frame) *ethernet.Frame) <-chan *ethernet.Frame {
+	ch := make(chan *ethernet.Frame, 1)
+	go func() {
+		defer close(ch)
+		buf := make([]byte, 2048)
+		for {
+			n, err := tap.Read(buf)
+			if err != nil {
+				return
+			}
+			var request ethernet.Frame
+			if err = request.UnmarshalBinary(buf[:n]); err != nil {
+				continue
+			}
+			response := reply(&request)
+			if response == nil {
+				continue
+			}
+			b, err := response.MarshalBinary()
+			if err != nil {
+				return
+			}
+			if _, err = tap.Write(b); err != nil {
+				return
+			}
+			ch <- &request
+			return
+		}
+	}()
+	return ch
+}
+
+func TestArpProbe(t *testing.T) {
+	_, _, err := ArpProbe("br-test", 0, "fd00::1", time.Second, 1)
+	require.ErrorContains(t, err, "invalid target ipv4 address fd00::1")
+
+	testNS, mac, tap := newProbeTestTap(t)
+	gatewayMAC := net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x01, 0x01}
+	requests := respondProbe(tap, func(f *ethernet.Frame) *ethernet.Frame {
+		if f.EtherType != ethernet.EtherTypeARP {
+			return nil
+		}
+		var request arp.Packet
+		if err := request.UnmarshalBinary(f.Payload); err != nil || request.Operation != arp.OperationRequest {
+			return nil
+		}
+		reply, err := arp.NewPacket(arp.OperationReply, gatewayMAC, request.TargetIP, request.SenderHardwareAddr, request.SenderIP)
+		if err != nil {
+			return nil
+		}
+		payload, err := reply.MarshalBinary()
+		if err != nil {
+			return nil
+		}
+		return &ethernet.Frame{Destination: f.Source, Source: gatewayMAC, VLAN: f.VLAN, EtherType: ethernet.EtherTypeARP, Payload: payload}
+	})
+
+	var resolved, unresolved net.HardwareAddr
+	var count int
+	var probeErr, timeoutErr error
+	err = testNS.Do(func(ns.NetNS) error {
+		resolved, count, probeErr = ArpProbe("br-test", 10, "10.0.10.1", time.Second, 3)
+		unresolved, _, timeoutErr = ArpProbe("br-test", 10, "10.0.10.2", 100*time.Millisecond, 2)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, probeErr)
+	require.Equal(t, gatewayMAC, resolved)
+	require.Equal(t, 1, count)
+	require.Nil(t, unresolved)
+	require.ErrorContains(t, timeoutErr, "no ARP reply from 10.0.10.2 on interface br-test")
+
+	request := <-requests
+	require.NotNil(t, request)
+	require.Equal(t, ethernet.Broadcast, request.Destination)
+	require.Equal(t, mac, request.Source)
+	require.NotNil(t, request.VLAN)
+	require.EqualValues(t, 10, request.VLAN.ID)
+	var pkt arp.Packet
+	require.NoError(t, pkt.UnmarshalBinary(request.Payload))
+	require.True(t, pkt.SenderIP.IsUnspecified())
+	require.Equal(t, "10.0.10.1", pkt.TargetIP.String())
+}
@@ -21,6 +21,10 @@ const (
 	ChassisAnnotation    = "ovn.kubernetes.io/chassis"
 	VMAnnotation         = "ovn.kubernetes.io/virtualmachine"
 
+	IPHandoffAnnotation     = "ovn.kubernetes.io/ip_handoff"      // name of the IPHandoff handing off an address to the pod
+	IPHandedOffAnnotation   = "ovn.kubernetes.io/ip_handed_off"   // the pod no longer owns its address after the IPHandoff
+	IPHandoffPortAnnotation = "ovn.kubernetes.io/ip_handoff_port" // name of the pod owning the logical switch port taken over by the IPHandoff
+
 	ExternalIPAnnotation         = "ovn.kubernetes.io/external_ip"
 	ExternalMacAnnotation        = "ovn.kubernetes.io/external_mac"
 	ExternalCidrAnnotation       = "ovn.kubernetes.io/external_cidr"
@@ -31,6 +35,7 @@ const (
 	VpcNatGatewayAnnotation                 = "ovn.kubernetes.io/vpc_nat_gw"
 	VpcNatGatewayInitAnnotation             = "ovn.kubernetes.io/vpc_nat_gw_init"
 	VpcNatGatewayContainerRestartAnnotation = "ovn.kubernetes.io/vpc_nat_gw_container_restarted"
+	VpcNatGatewayBackendAnnotation          = "ovn.kubernetes.io/vpc_nat_gw_backend"
 	VpcEipsAnnotation                       = "ovn.kubernetes.io/vpc_eips"
 	VpcFloatingIPMd5Annotation              = "ovn.kubernetes.io/vpc_floating_ips"
 	VpcDnatMd5Annotation                    = "ovn.kubernetes.io/vpc_dnat_md5"
@@ -61,6 +66,7 @@ const (
 	AllocatedAnnotationTemplate     = "%s.kubernetes.io/allocated"
 	RoutedAnnotationTemplate        = "%s.kubernetes.io/routed"
 	RoutesAnnotationTemplate        = "%s.kubernetes.io/routes"
+	PolicyRoutingAnnotationTemplate = "%s.kubernetes.io/policy_routing"
 	MacAddressAnnotationTemplate    = "%s.kubernetes.io/mac_address"
 	IPAddressAnnotationTemplate     = "%s.kubernetes.io/ip_address"
 	CidrAnnotationTemplate          = "%s.kubernetes.io/cidr"
@@ -85,6 +91,10 @@ const (
 	PodNicAnnotationTemplate          = "%s.kubernetes.io/pod_nic_type"
 	VMAnnotationTemplate              = "%s.kubernetes.io/virtualmachine"
 
+	DHCPNextServerAnnotationTemplate   = "%s.kubernetes.io/dhcp_next_server"
+	DHCPBootfileAnnotationTemplate     = "%s.kubernetes.io/dhcp_bootfile"
+	DHCPIPXEBootfileAnnotationTemplate = "%s.kubernetes.io/dhcp_ipxe_bootfile"
+
 	ExcludeIpsAnnotation = "ovn.kubernetes.io/exclude_ips"
 
 	IngressRateAnnotation = "ovn.kubernetes.io/ingress_rate"
@@ -104,13 +114,26 @@ const (
 	NodeExtGwLabel             = "ovn.kubernetes.io/node-ext-gw"
 	VpcNatGatewayLabel         = "ovn.kubernetes.io/vpc-nat-gw"
 	IPReservedLabel            = "ovn.kubernetes.io/ip_reserved"
+	ParentIPPoolLabel          = "ovn.kubernetes.io/parent_ippool"
+	IPPoolNamespaceLabel       = "ovn.kubernetes.io/ippool_namespace"
 	VpcNatGatewayNameLabel     = "ovn.kubernetes.io/vpc-nat-gw-name"
 	VpcLbLabel                 = "ovn.kubernetes.io/vpc_lb"
 	VpcDNSNameLabel            = "ovn.kubernetes.io/vpc-dns"
+	BootServerNameLabel        = "ovn.kubernetes.io/boot-server"
 	QoSLabel                   = "ovn.kubernetes.io/qos"
 	NodeNameLabel              = "ovn.kubernetes.io/node-name"
 	NetworkPolicyLogAnnotation = "ovn.kubernetes.io/enable_log"
 	ACLActionsLogAnnotation    = "ovn.kubernetes.io/log_acl_actions"
+	// comma separated domain names that the pods selected by a network policy with egress rules are allowed to access
+	NetworkPolicyEgressFQDNsAnnotation = "ovn.kubernetes.io/egress_fqdns"
+	// programming status of a network policy written back by kube-ovn-controller
+	NetworkPolicyStatusAnnotation = "ovn.kubernetes.io/network_policy_status"
+	// configmap recording the openflow cookies of network policy acls, used by kube-ovn-cni to export acl hit counters
+	NetworkPolicyACLCookiesConfigMap = "kube-ovn-np-acl-cookies"
+	// acl external id recording the port group or logical switch that an acl belongs to
+	ACLParentKey = "parent"
+	// acl external id recording the network policy rule that an acl is generated from, e.g. ingress/0
+	NetworkPolicyRuleKey = "np_rule"
 
 	VpcLastName     = "ovn.kubernetes.io/last_vpc_name"
 	VpcLastPolicies = "ovn.kubernetes.io/last_policies"
@@ -148,6 +171,17 @@ const (
 	SubnetAllowPriority = "1001"
 	DefaultDropPriority = "1000"
 
+	// acls of admin network policies are placed in a tier evaluated before all other acls,
+	// so that pass rules could hand the traffic over to the next tier,
+	// acls of baseline admin network policies are evaluated after network policy acls but before subnet acls
+	AnpMaxPriority     = 99
+	AnpMaxRules        = 100
+	AnpACLMaxPriority  = 30000
+	BanpACLMaxPriority = 1900
+
+	AnpACLTier     = 1
+	DefaultACLTier = 2
+
 	DefaultMTU = 1500
 
 	GeneveHeaderLength = 100
@@ -170,6 +204,7 @@ const (
 	VpcDNSConfig           = "vpc-dns-config"
 	VpcDNSDepTemplate      = "vpc-dns-dep"
 	VpcNatConfig           = "ovn-vpc-nat-config"
+	BootServerConfig       = "boot-server-config"
 
 	DefaultSecurityGroupName = "default-securitygroup"
 
@@ -192,6 +227,9 @@ const (
 	OvnFip      = "ovn"
 	IptablesFip = "iptables"
 
+	NatGwBackendIptables = "iptables"
+	NatGwBackendNftables = "nftables"
+
 	U2OSubnetPolicyPriority         = 29400
 	GatewayRouterPolicyPriority     = 29000
 	NorthGatewayRoutePolicyPriority = 29250
@@ -240,6 +278,14 @@ const (
 	OvnICStatic    = "static"
 	OvnICNone      = ""
 
+	// external id of the transit switches and transit switch ports created for vpcs
+	OvnICVpcKey           = "ic-vpc"
+	OvnICTransitSwitchKey = "ic-transit-switch"
+	// external id of the transit switches holding the routes advertised by the vpcs of each availability zone
+	OvnICVpcRoutesKey = "ic-vpc-routes"
+	// external id of the static routes learned by the vpcs from other availability zones
+	OvnICAvailabilityZoneKey = "ic-availability-zone"
+
 	MatchV4Src = "ip4.src"
 	MatchV4Dst = "ip4.dst"
 	MatchV6Src = "ip6.src"
@@ -298,4 +344,9 @@ const (
 	MigrationPhaseStarted         = "started"
 	MigrationPhaseSucceeded       = "succeeded"
 	MigrationPhaseFailed          = "failed"
+
+	SubnetAllocationPolicyAnnotation = "ovn.kubernetes.io/subnet_allocation_policy" // how to choose the subnet of the pods when the namespace is bound to multiple subnets
+	SubnetAllocationPolicyOrdered    = "ordered"                                    // use the next subnet in order when the previous one is exhausted
+	SubnetAllocationPolicyBalanced   = "balanced"                                   // use the subnet with the most available addresses
+	SubnetAllocationPolicyZoneAware  = "zone-aware"                                 // use the subnet labeled with the topology zone of the pod's node
 )
@@ -0,0 +1,187 @@
+package util
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const resolvConfPath = "/etc/resolv.conf"
+
+// LookupFQDN queries the A and AAAA records of name from the dns server,
+// and returns the resolved addresses with their ttl in seconds.
+// The first nameserver in /etc/resolv.conf is used if server is empty.
+func LookupFQDN(server, name string, timeout time.Duration) (map[string]uint32, error) {
+	var err error
+	if server == "" {
+		if server, err = resolvConfNameserver(resolvConfPath); err != nil {
+			return nil, err
+		}
+	}
+	if _, _, err = net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	qname, err := dnsmessage.NewName(strings.TrimSuffix(name, ".") + ".")
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain name %s: %w", name, err)
+	}
+
+	result := make(map[string]uint32)
+	for _, qtype := range []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA} {
+		answers, err := queryDNS(server, qname, qtype, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s record of %s from %s: %w", qtype, name, server, err)
+		}
+		for ip, ttl := range answers {
+			result[ip] = ttl
+		}
+	}
+	return result, nil
+}
+
+// errDNSTruncated is returned when the answer does not fit in the udp response
+var errDNSTruncated = errors.New("dns response is truncated")
+
+// queryDNS sends the query over udp, and retries over tcp if the response is truncated
+func queryDNS(server string, name dnsmessage.Name, qtype dnsmessage.Type, timeout time.Duration) (map[string]uint32, error) {
+	answers, err := exchangeDNS("udp", server, name, qtype, timeout)
+	if errors.Is(err, errDNSTruncated) {
+		return exchangeDNS("tcp", server, name, qtype, timeout)
+	}
+	return answers, err
+}
+
+func exchangeDNS(network, server string, name dnsmessage.Name, qtype dnsmessage.Type, timeout time.Duration) (map[string]uint32, error) {
+	id := uint16(rand.Uint32()) // #nosec G404
+	msg := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: name, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	req, err := msg.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.DialTimeout(network, server, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	if network == "udp" {
+		if _, err = conn.Write(req); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, 4096)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		return parseDNSResponse(buf[:n], id)
+	}
+
+	// messages over tcp are prefixed with a two byte length field
+	if _, err = conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(req))), req...)); err != nil {
+		return nil, err
+	}
+	var length [2]byte
+	if _, err = io.ReadFull(conn, length[:]); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err = io.ReadFull(conn, buf); err != nil {
+		return nil, err
+	}
+	return parseDNSResponse(buf, id)
+}
+
+// parseDNSResponse returns the addresses and ttl in A and AAAA answers, CNAME records are followed by the server
+func parseDNSResponse(resp []byte, id uint16) (map[string]uint32, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(resp); err != nil {
+		return nil, fmt.Errorf("failed to unpack dns response: %w", err)
+	}
+	if msg.ID != id {
+		return nil, fmt.Errorf("dns response id %d does not match query id %d", msg.ID, id)
+	}
+	if msg.Truncated {
+		return nil, errDNSTruncated
+	}
+
+	result := make(map[string]uint32)
+	switch msg.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return result, nil
+	default:
+		return nil, fmt.Errorf("dns response code %s", msg.RCode)
+	}
+
+	for _, answer := range msg.Answers {
+		switch body := answer.Body.(type) {
+		case *dnsmessage.AResource:
+			result[net.IP(body.A[:]).String()] = answer.Header.TTL
+		case *dnsmessage.AAAAResource:
+			result[net.IP(body.AAAA[:]).String()] = answer.Header.TTL
+		}
+	}
+	return result, nil
+}
+
+// ParseEgressFQDNs parses the comma separated domain names in the network policy egress fqdn annotation,
+// the valid names are returned together with the error of the invalid ones.
+// Wildcard names are not supported since the addresses they match can not be resolved in advance.
+func ParseEgressFQDNs(value string) ([]string, error) {
+	var names []string
+	var errs []error
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+		if name == "" {
+			continue
+		}
+		if strings.Contains(name, "*") {
+			errs = append(errs, fmt.Errorf("wildcard domain name %s is not supported", name))
+			continue
+		}
+		if msgs := validation.IsDNS1123Subdomain(name); len(msgs) != 0 {
+			errs = append(errs, fmt.Errorf("invalid domain name %s: %s", name, strings.Join(msgs, ", ")))
+			continue
+		}
+		names = append(names, name)
+	}
+	return UniqString(names), errors.Join(errs...)
+}
+
+func resolvConfNameserver(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			return fields[1], nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no nameserver found in %s", path)
+}
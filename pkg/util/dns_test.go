@@ -0,0 +1,169 @@
+package util
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func packDNSResponse(t *testing.T, req []byte, rcode dnsmessage.RCode) []byte {
+	var query dnsmessage.Message
+	require.NoError(t, query.Unpack(req))
+
+	resp := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: query.ID, Response: true, RCode: rcode},
+		Questions: query.Questions,
+	}
+	if rcode == dnsmessage.RCodeSuccess {
+		q := query.Questions[0]
+		cname := dnsmessage.MustNewName("edge.example.com.")
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{Name: q.Name, Type: dnsmessage.TypeCNAME, Class: dnsmessage.ClassINET, TTL: 600},
+			Body:   &dnsmessage.CNAMEResource{CNAME: cname},
+		})
+		if q.Type == dnsmessage.TypeA {
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: cname, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 60},
+				Body:   &dnsmessage.AResource{A: [4]byte{192, 0, 2, 10}},
+			})
+		} else {
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: cname, Type: dnsmessage.TypeAAAA, Class: dnsmessage.ClassINET, TTL: 30},
+				Body:   &dnsmessage.AAAAResource{AAAA: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 0x10}},
+			})
+		}
+	}
+	buf, err := resp.Pack()
+	require.NoError(t, err)
+	return buf
+}
+
+func TestLookupFQDN(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			rcode := dnsmessage.RCodeSuccess
+			var query dnsmessage.Message
+			if query.Unpack(buf[:n]) == nil && query.Questions[0].Name.String() == "missing.example.com." {
+				rcode = dnsmessage.RCodeNameError
+			}
+			_, _ = conn.WriteTo(packDNSResponse(t, buf[:n], rcode), addr)
+		}
+	}()
+
+	answers, err := LookupFQDN(conn.LocalAddr().String(), "api.example.com", time.Second)
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint32{"192.0.2.10": 60, "2001:db8::10": 30}, answers)
+
+	answers, err = LookupFQDN(conn.LocalAddr().String(), "missing.example.com.", time.Second)
+	require.NoError(t, err)
+	require.Empty(t, answers)
+
+	_, err = parseDNSResponse(packDNSResponse(t, mustPackQuery(t), dnsmessage.RCodeServerFailure), 1)
+	require.Error(t, err)
+	_, err = parseDNSResponse(packDNSResponse(t, mustPackQuery(t), dnsmessage.RCodeSuccess), 2)
+	require.Error(t, err)
+}
+
+func TestLookupFQDNTruncated(t *testing.T) {
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer tcpListener.Close()
+	udpConn, err := net.ListenPacket("udp", tcpListener.Addr().String())
+	require.NoError(t, err)
+	defer udpConn.Close()
+
+	// the udp server only returns truncated responses
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := udpConn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var query dnsmessage.Message
+			if query.Unpack(buf[:n]) != nil {
+				continue
+			}
+			resp := dnsmessage.Message{Header: dnsmessage.Header{ID: query.ID, Response: true, Truncated: true}, Questions: query.Questions}
+			data, err := resp.Pack()
+			if err != nil {
+				return
+			}
+			_, _ = udpConn.WriteTo(data, addr)
+		}
+	}()
+	go func() {
+		for {
+			conn, err := tcpListener.Accept()
+			if err != nil {
+				return
+			}
+			var length [2]byte
+			if _, err = io.ReadFull(conn, length[:]); err == nil {
+				req := make([]byte, binary.BigEndian.Uint16(length[:]))
+				if _, err = io.ReadFull(conn, req); err == nil {
+					resp := packDNSResponse(t, req, dnsmessage.RCodeSuccess)
+					_, _ = conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(resp))), resp...))
+				}
+			}
+			conn.Close()
+		}
+	}()
+
+	answers, err := LookupFQDN(tcpListener.Addr().String(), "api.example.com", time.Second)
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint32{"192.0.2.10": 60, "2001:db8::10": 30}, answers)
+}
+
+func TestParseEgressFQDNs(t *testing.T) {
+	names, err := ParseEgressFQDNs("api.github.com, API.github.com.,,")
+	require.NoError(t, err)
+	require.Equal(t, []string{"api.github.com"}, names)
+
+	names, err = ParseEgressFQDNs("api.github.com,*.s3.amazonaws.com,bad_name.com")
+	require.ErrorContains(t, err, "wildcard domain name *.s3.amazonaws.com is not supported")
+	require.ErrorContains(t, err, "invalid domain name bad_name.com")
+	require.Equal(t, []string{"api.github.com"}, names)
+
+	names, err = ParseEgressFQDNs("")
+	require.NoError(t, err)
+	require.Empty(t, names)
+}
+
+func mustPackQuery(t *testing.T) []byte {
+	msg := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: 1},
+		Questions: []dnsmessage.Question{{Name: dnsmessage.MustNewName("api.example.com."), Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET}},
+	}
+	buf, err := msg.Pack()
+	require.NoError(t, err)
+	return buf
+}
+
+func TestResolvConfNameserver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resolv.conf")
+	require.NoError(t, os.WriteFile(path, []byte("search default.svc.cluster.local\nnameserver 10.96.0.10\nnameserver 10.96.0.11\n"), 0o600))
+	server, err := resolvConfNameserver(path)
+	require.NoError(t, err)
+	require.Equal(t, "10.96.0.10", server)
+
+	require.NoError(t, os.WriteFile(path, []byte("search default.svc.cluster.local\n"), 0o600))
+	_, err = resolvConfNameserver(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,200 @@
+package util
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+const (
+	IPPoolOwnerKindDeployment  = "Deployment"
+	IPPoolOwnerKindStatefulSet = "StatefulSet"
+)
+
+// IPPoolSelectsPod returns whether the pod is selected by the pod selector or the owners of the ippool.
+// ownerKind and ownerName are the kind and name of the workload owning the pod, which may be empty.
+func IPPoolSelectsPod(spec *kubeovnv1.IPPoolSpec, namespace string, podLabels map[string]string, ownerKind, ownerName string) (bool, error) {
+	if len(spec.Namespaces) != 0 && !slices.Contains(spec.Namespaces, namespace) {
+		return false, nil
+	}
+	if ownerKind != "" {
+		for _, owner := range spec.Owners {
+			if owner.Kind == ownerKind && owner.Namespace == namespace && owner.Name == ownerName {
+				return true, nil
+			}
+		}
+	}
+	if spec.PodSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(spec.PodSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(podLabels)), nil
+}
+
+// IPPoolOwnerPodLabels returns the labels of the pods owned by the workload, or nil if the workload does not exist
+type IPPoolOwnerPodLabels func(owner kubeovnv1.IPPoolOwner) (map[string]string, error)
+
+// IPPoolSelectorsOverlap returns whether a pod may be selected by both ippools.
+// Selector overlap is detected by building a label set satisfying the match labels and
+// the In/Exists expressions of both selectors, so it may miss some complicated cases.
+// The owners of an ippool overlap with the pod selector of the other one if the pod template
+// of the workload returned by ownerPodLabels is selected.
+func IPPoolSelectorsOverlap(a, b *kubeovnv1.IPPoolSpec, ownerPodLabels IPPoolOwnerPodLabels) (bool, error) {
+	if a.Subnet != b.Subnet {
+		return false, nil
+	}
+	if len(a.Namespaces) != 0 && len(b.Namespaces) != 0 && !slices.ContainsFunc(a.Namespaces, func(ns string) bool {
+		return slices.Contains(b.Namespaces, ns)
+	}) {
+		return false, nil
+	}
+	for _, owner := range a.Owners {
+		if slices.Contains(b.Owners, owner) {
+			return true, nil
+		}
+	}
+	for _, pair := range [][2]*kubeovnv1.IPPoolSpec{{a, b}, {b, a}} {
+		overlap, err := ownersSelectedByIPPool(pair[0], pair[1], ownerPodLabels)
+		if err != nil || overlap {
+			return overlap, err
+		}
+	}
+	if a.PodSelector == nil || b.PodSelector == nil {
+		return false, nil
+	}
+	return labelSelectorsOverlap(a.PodSelector, b.PodSelector)
+}
+
+// ownersSelectedByIPPool returns whether the pods owned by the owners of ippool a are selected by the pod selector of ippool b
+func ownersSelectedByIPPool(a, b *kubeovnv1.IPPoolSpec, ownerPodLabels IPPoolOwnerPodLabels) (bool, error) {
+	if b.PodSelector == nil || ownerPodLabels == nil {
+		return false, nil
+	}
+	for _, owner := range a.Owners {
+		if len(a.Namespaces) != 0 && !slices.Contains(a.Namespaces, owner.Namespace) {
+			// the owner never takes effect
+			continue
+		}
+		podLabels, err := ownerPodLabels(owner)
+		if err != nil {
+			return false, err
+		}
+		if podLabels == nil {
+			continue
+		}
+		selected, err := IPPoolSelectsPod(b, owner.Namespace, podLabels, "", "")
+		if err != nil || selected {
+			return selected, err
+		}
+	}
+	return false, nil
+}
+
+func labelSelectorsOverlap(a, b *metav1.LabelSelector) (bool, error) {
+	selectorA, err := metav1.LabelSelectorAsSelector(a)
+	if err != nil {
+		return false, err
+	}
+	selectorB, err := metav1.LabelSelectorAsSelector(b)
+	if err != nil {
+		return false, err
+	}
+
+	set := make(labels.Set, len(a.MatchLabels)+len(b.MatchLabels))
+	for k, v := range a.MatchLabels {
+		set[k] = v
+	}
+	for k, v := range b.MatchLabels {
+		if value, ok := set[k]; ok && value != v {
+			return false, nil
+		}
+		set[k] = v
+	}
+	for _, expr := range append(slices.Clone(a.MatchExpressions), b.MatchExpressions...) {
+		if _, ok := set[expr.Key]; ok {
+			continue
+		}
+		switch expr.Operator {
+		case metav1.LabelSelectorOpIn:
+			if len(expr.Values) != 0 {
+				set[expr.Key] = expr.Values[0]
+			}
+		case metav1.LabelSelectorOpExists:
+			set[expr.Key] = ""
+		}
+	}
+	return selectorA.Matches(set) && selectorB.Matches(set), nil
+}
+
+// ValidateIPPoolSelector validates the pod selector and the owners of the ippool
+func ValidateIPPoolSelector(spec *kubeovnv1.IPPoolSpec) error {
+	if spec.PodSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.PodSelector); err != nil {
+			return fmt.Errorf("invalid pod selector: %w", err)
+		}
+	}
+	for _, owner := range spec.Owners {
+		if owner.Kind != IPPoolOwnerKindDeployment && owner.Kind != IPPoolOwnerKindStatefulSet {
+			return fmt.Errorf("unsupported owner kind %q, must be %s or %s", owner.Kind, IPPoolOwnerKindDeployment, IPPoolOwnerKindStatefulSet)
+		}
+		if owner.Namespace == "" || owner.Name == "" {
+			return fmt.Errorf("namespace and name of owner %s are required", owner.Kind)
+		}
+	}
+	return nil
+}
+
+// ValidateIPPoolAddresses validates the CIDR blocks, the excluded IPs and the auto allocate settings of the ippool
+func ValidateIPPoolAddresses(spec *kubeovnv1.IPPoolSpec) error {
+	for _, cidr := range spec.CIDRBlocks {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid CIDR block %q: %w", cidr, err)
+		}
+	}
+	for _, ip := range spec.ExcludeIPs {
+		if err := checkIPRangeOrCIDR(ip); err != nil {
+			return fmt.Errorf("invalid excluded IP %q: %w", ip, err)
+		}
+	}
+	if spec.AutoAllocate == nil {
+		if len(spec.IPs) == 0 && len(spec.CIDRBlocks) == 0 {
+			return fmt.Errorf("ips or cidrBlocks is required")
+		}
+		return nil
+	}
+	if len(spec.IPs) != 0 {
+		return fmt.Errorf("ips can not be set along with autoAllocate")
+	}
+	v4, v6 := spec.AutoAllocate.IPv4PrefixLength, spec.AutoAllocate.IPv6PrefixLength
+	if v4 == 0 && v6 == 0 {
+		return fmt.Errorf("at least one of ipv4PrefixLength and ipv6PrefixLength is required by autoAllocate")
+	}
+	if v4 < 0 || v4 > 32 {
+		return fmt.Errorf("invalid ipv4PrefixLength %d", v4)
+	}
+	if v6 < 0 || v6 > 128 {
+		return fmt.Errorf("invalid ipv6PrefixLength %d", v6)
+	}
+	return nil
+}
+
+func checkIPRangeOrCIDR(s string) error {
+	if _, _, err := net.ParseCIDR(s); err == nil {
+		return nil
+	}
+	for _, ip := range strings.Split(s, "..") {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("invalid IP %q", ip)
+		}
+	}
+	return nil
+}
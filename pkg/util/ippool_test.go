@@ -0,0 +1,173 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func TestIPPoolSelectsPod(t *testing.T) {
+	spec := &kubeovnv1.IPPoolSpec{
+		Subnet:      "db",
+		PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "mysql"}},
+		Owners:      []kubeovnv1.IPPoolOwner{{Kind: IPPoolOwnerKindStatefulSet, Namespace: "db", Name: "pg"}},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		labels    map[string]string
+		ownerKind string
+		ownerName string
+		selected  bool
+	}{
+		{"label", "db", map[string]string{"app": "mysql"}, "", "", true},
+		{"label mismatch", "db", map[string]string{"app": "redis"}, "", "", false},
+		{"owner", "db", nil, IPPoolOwnerKindStatefulSet, "pg", true},
+		{"owner in another namespace", "default", nil, IPPoolOwnerKindStatefulSet, "pg", false},
+		{"owner kind mismatch", "db", nil, IPPoolOwnerKindDeployment, "pg", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := IPPoolSelectsPod(spec, tt.namespace, tt.labels, tt.ownerKind, tt.ownerName)
+			require.NoError(t, err)
+			require.Equal(t, tt.selected, selected)
+		})
+	}
+
+	spec.Namespaces = []string{"other"}
+	selected, err := IPPoolSelectsPod(spec, "db", map[string]string{"app": "mysql"}, "", "")
+	require.NoError(t, err)
+	require.False(t, selected)
+}
+
+func TestIPPoolSelectorsOverlap(t *testing.T) {
+	selector := func(matchLabels map[string]string, exprs ...metav1.LabelSelectorRequirement) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: matchLabels, MatchExpressions: exprs}
+	}
+	tests := []struct {
+		name    string
+		a, b    kubeovnv1.IPPoolSpec
+		overlap bool
+	}{
+		{
+			name:    "same labels",
+			a:       kubeovnv1.IPPoolSpec{PodSelector: selector(map[string]string{"app": "mysql"})},
+			b:       kubeovnv1.IPPoolSpec{PodSelector: selector(map[string]string{"app": "mysql", "tier": "db"})},
+			overlap: true,
+		},
+		{
+			name: "conflicting labels",
+			a:    kubeovnv1.IPPoolSpec{PodSelector: selector(map[string]string{"app": "mysql"})},
+			b:    kubeovnv1.IPPoolSpec{PodSelector: selector(map[string]string{"app": "redis"})},
+		},
+		{
+			name: "different subnets",
+			a:    kubeovnv1.IPPoolSpec{Subnet: "a", PodSelector: selector(nil)},
+			b:    kubeovnv1.IPPoolSpec{Subnet: "b", PodSelector: selector(nil)},
+		},
+		{
+			name:    "empty selector",
+			a:       kubeovnv1.IPPoolSpec{PodSelector: selector(nil)},
+			b:       kubeovnv1.IPPoolSpec{PodSelector: selector(map[string]string{"app": "redis"})},
+			overlap: true,
+		},
+		{
+			name: "expression excludes labels",
+			a: kubeovnv1.IPPoolSpec{PodSelector: selector(nil, metav1.LabelSelectorRequirement{
+				Key: "app", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"mysql"},
+			})},
+			b: kubeovnv1.IPPoolSpec{PodSelector: selector(map[string]string{"app": "mysql"})},
+		},
+		{
+			name: "expression exists",
+			a: kubeovnv1.IPPoolSpec{PodSelector: selector(nil, metav1.LabelSelectorRequirement{
+				Key: "tier", Operator: metav1.LabelSelectorOpExists,
+			})},
+			b:       kubeovnv1.IPPoolSpec{PodSelector: selector(map[string]string{"app": "mysql"})},
+			overlap: true,
+		},
+		{
+			name:    "same owner",
+			a:       kubeovnv1.IPPoolSpec{Owners: []kubeovnv1.IPPoolOwner{{Kind: IPPoolOwnerKindDeployment, Namespace: "default", Name: "web"}}},
+			b:       kubeovnv1.IPPoolSpec{Owners: []kubeovnv1.IPPoolOwner{{Kind: IPPoolOwnerKindDeployment, Namespace: "default", Name: "web"}}},
+			overlap: true,
+		},
+		{
+			name: "disjoint namespaces",
+			a:    kubeovnv1.IPPoolSpec{Namespaces: []string{"a"}, PodSelector: selector(nil)},
+			b:    kubeovnv1.IPPoolSpec{Namespaces: []string{"b"}, PodSelector: selector(nil)},
+		},
+		{
+			name:    "owner selected",
+			a:       kubeovnv1.IPPoolSpec{Owners: []kubeovnv1.IPPoolOwner{{Kind: IPPoolOwnerKindDeployment, Namespace: "default", Name: "web"}}},
+			b:       kubeovnv1.IPPoolSpec{PodSelector: selector(map[string]string{"app": "web"})},
+			overlap: true,
+		},
+		{
+			name: "owner not selected",
+			a:    kubeovnv1.IPPoolSpec{Owners: []kubeovnv1.IPPoolOwner{{Kind: IPPoolOwnerKindStatefulSet, Namespace: "default", Name: "db"}}},
+			b:    kubeovnv1.IPPoolSpec{PodSelector: selector(map[string]string{"app": "web"})},
+		},
+		{
+			name: "owner selected in another namespace",
+			a:    kubeovnv1.IPPoolSpec{Owners: []kubeovnv1.IPPoolOwner{{Kind: IPPoolOwnerKindDeployment, Namespace: "default", Name: "web"}}},
+			b:    kubeovnv1.IPPoolSpec{Namespaces: []string{"other"}, PodSelector: selector(map[string]string{"app": "web"})},
+		},
+		{
+			name: "owner not found",
+			a:    kubeovnv1.IPPoolSpec{Owners: []kubeovnv1.IPPoolOwner{{Kind: IPPoolOwnerKindDeployment, Namespace: "default", Name: "api"}}},
+			b:    kubeovnv1.IPPoolSpec{PodSelector: selector(nil)},
+		},
+	}
+	ownerPodLabels := func(owner kubeovnv1.IPPoolOwner) (map[string]string, error) {
+		switch owner.Name {
+		case "web":
+			return map[string]string{"app": "web"}, nil
+		case "db":
+			return map[string]string{"app": "db"}, nil
+		}
+		return nil, nil
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlap, err := IPPoolSelectorsOverlap(&tt.a, &tt.b, ownerPodLabels)
+			require.NoError(t, err)
+			require.Equal(t, tt.overlap, overlap)
+			overlap, err = IPPoolSelectorsOverlap(&tt.b, &tt.a, ownerPodLabels)
+			require.NoError(t, err)
+			require.Equal(t, tt.overlap, overlap)
+		})
+	}
+}
+
+func TestValidateIPPoolAddresses(t *testing.T) {
+	tests := []struct {
+		name string
+		spec kubeovnv1.IPPoolSpec
+		err  string
+	}{
+		{"ips", kubeovnv1.IPPoolSpec{IPs: []string{"10.0.0.1..10.0.0.10"}}, ""},
+		{"cidr blocks", kubeovnv1.IPPoolSpec{CIDRBlocks: []string{"10.0.0.0/24"}, ExcludeIPs: []string{"10.0.0.1", "10.0.0.0/30"}}, ""},
+		{"no address", kubeovnv1.IPPoolSpec{}, "ips or cidrBlocks is required"},
+		{"invalid cidr block", kubeovnv1.IPPoolSpec{CIDRBlocks: []string{"10.0.0.0/33"}}, "invalid CIDR block"},
+		{"invalid excluded ip", kubeovnv1.IPPoolSpec{CIDRBlocks: []string{"10.0.0.0/24"}, ExcludeIPs: []string{"10.0.0.x"}}, "invalid excluded IP"},
+		{"auto allocate", kubeovnv1.IPPoolSpec{AutoAllocate: &kubeovnv1.IPPoolAutoAllocate{IPv4PrefixLength: 26}}, ""},
+		{"auto allocate with ips", kubeovnv1.IPPoolSpec{IPs: []string{"10.0.0.1"}, AutoAllocate: &kubeovnv1.IPPoolAutoAllocate{IPv4PrefixLength: 26}}, "can not be set along with autoAllocate"},
+		{"auto allocate without prefix length", kubeovnv1.IPPoolSpec{AutoAllocate: &kubeovnv1.IPPoolAutoAllocate{}}, "at least one of"},
+		{"invalid ipv6 prefix length", kubeovnv1.IPPoolSpec{AutoAllocate: &kubeovnv1.IPPoolAutoAllocate{IPv6PrefixLength: 129}}, "invalid ipv6PrefixLength"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIPPoolAddresses(&tt.spec)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+//go:build !windows
+// +build !windows
+
+package util
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/mdlayher/ethernet"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	ndpOptionTargetLinkLayerAddress = 2
+	ipv6HeaderLen                   = 40
+)
+
+// NdpProbe resolves the MAC address of dstIP with neighbor solicitations sent from nic and tagged with vlanID if it is not zero.
+// Like ArpProbe, the solicitations use the unspecified address as the source address, so nic is not required to have an IPv6 address,
+// and the neighbor advertisements are sent to the all-nodes multicast address.
+func NdpProbe(nic string, vlanID uint16, dstIP string, timeout time.Duration, maxRetry int) (net.HardwareAddr, int, error) {
+	target, err := netip.ParseAddr(dstIP)
+	if err != nil || !target.Is6() || target.Is4In6() {
+		return nil, 0, fmt.Errorf("invalid target ipv6 address %s", dstIP)
+	}
+
+	ifi, err := net.InterfaceByName(nic)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get interface %s: %v", nic, err)
+	}
+
+	// solicited-node multicast address of the target and the corresponding MAC address
+	t := target.As16()
+	dst := netip.AddrFrom16([16]byte{0: 0xff, 1: 0x02, 11: 0x01, 12: 0xff, 13: t[13], 14: t[14], 15: t[15]})
+	dstMAC := net.HardwareAddr{0x33, 0x33, 0xff, t[13], t[14], t[15]}
+
+	// the source link-layer address option must not be included when the source address is unspecified
+	msg := icmp.Message{
+		Type: ipv6.ICMPTypeNeighborSolicitation,
+		Body: &icmp.RawBody{Data: append(make([]byte, 4), t[:]...)},
+	}
+	src := netip.IPv6Unspecified()
+	body, err := msg.Marshal(icmp.IPv6PseudoHeader(src.AsSlice(), dst.AsSlice()))
+	if err != nil {
+		return nil, 0, err
+	}
+	payload := make([]byte, ipv6HeaderLen, ipv6HeaderLen+len(body))
+	payload[0] = 6 << 4
+	binary.BigEndian.PutUint16(payload[4:6], uint16(len(body)))
+	payload[6] = byte(ipv6.ICMPTypeNeighborSolicitation.Protocol())
+	payload[7] = 255
+	copy(payload[8:24], src.AsSlice())
+	copy(payload[24:40], dst.AsSlice())
+	payload = append(payload, body...)
+
+	mac, count, err := probeNeighbor(ifi, vlanID, dstMAC, ethernet.EtherTypeIPv6, payload, timeout, maxRetry, func(f *ethernet.Frame) net.HardwareAddr {
+		if len(f.Payload) < ipv6HeaderLen || f.Payload[6] != byte(ipv6.ICMPTypeNeighborAdvertisement.Protocol()) {
+			return nil
+		}
+		reply, err := icmp.ParseMessage(ipv6.ICMPTypeNeighborAdvertisement.Protocol(), f.Payload[ipv6HeaderLen:])
+		if err != nil || reply.Type != ipv6.ICMPTypeNeighborAdvertisement {
+			return nil
+		}
+		body, ok := reply.Body.(*icmp.RawBody)
+		if !ok || len(body.Data) < 20 {
+			return nil
+		}
+		data := body.Data
+		if netip.AddrFrom16([16]byte(data[4:20])) != target {
+			return nil
+		}
+		// prefer the target link-layer address option to the source address of the frame
+		for options := data[20:]; len(options) >= 8 && options[1] != 0 && len(options) >= int(options[1])*8; options = options[int(options[1])*8:] {
+			if options[0] == ndpOptionTargetLinkLayerAddress {
+				return net.HardwareAddr(options[2:8])
+			}
+		}
+		return f.Source
+	})
+	if err != nil {
+		return nil, count, err
+	}
+	if mac == nil {
+		return nil, count, fmt.Errorf("no neighbor advertisement from %s on interface %s", dstIP, nic)
+	}
+	return mac, count, nil
+}
@@ -0,0 +1,93 @@
+//go:build linux
+// +build linux
+
+package util
+
+import (
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/mdlayher/ethernet"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv6"
+)
+
+func TestNdpProbe(t *testing.T) {
+	_, _, err := NdpProbe("br-test", 0, "10.0.10.1", time.Second, 1)
+	require.ErrorContains(t, err, "invalid target ipv6 address 10.0.10.1")
+
+	testNS, mac, tap := newProbeTestTap(t)
+	gatewayMAC := net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x02, 0x01}
+	allNodes := netip.MustParseAddr("ff02::1")
+	var solicitation []byte
+	requests := respondProbe(tap, func(f *ethernet.Frame) *ethernet.Frame {
+		if f.EtherType != ethernet.EtherTypeIPv6 || len(f.Payload) < ipv6HeaderLen || f.Payload[6] != byte(ipv6.ICMPTypeNeighborSolicitation.Protocol()) {
+			return nil
+		}
+		request, err := icmp.ParseMessage(ipv6.ICMPTypeNeighborSolicitation.Protocol(), f.Payload[ipv6HeaderLen:])
+		if err != nil || request.Type != ipv6.ICMPTypeNeighborSolicitation {
+			return nil
+		}
+		solicitation = request.Body.(*icmp.RawBody).Data
+		target := solicitation[4:20]
+		if netip.AddrFrom16([16]byte(target)) != netip.MustParseAddr("fd00:10::1") {
+			return nil
+		}
+
+		// solicited and override flags, the target and the target link-layer address option
+		data := append([]byte{0x60, 0, 0, 0}, target...)
+		data = append(data, ndpOptionTargetLinkLayerAddress, 1)
+		data = append(data, gatewayMAC...)
+		msg := icmp.Message{Type: ipv6.ICMPTypeNeighborAdvertisement, Body: &icmp.RawBody{Data: data}}
+		body, err := msg.Marshal(icmp.IPv6PseudoHeader(target, allNodes.AsSlice()))
+		if err != nil {
+			return nil
+		}
+		payload := make([]byte, ipv6HeaderLen, ipv6HeaderLen+len(body))
+		payload[0] = 6 << 4
+		binary.BigEndian.PutUint16(payload[4:6], uint16(len(body)))
+		payload[6] = byte(ipv6.ICMPTypeNeighborAdvertisement.Protocol())
+		payload[7] = 255
+		copy(payload[8:24], target)
+		copy(payload[24:40], allNodes.AsSlice())
+		payload = append(payload, body...)
+		// the source address of the frame is not the address in the option
+		return &ethernet.Frame{
+			Destination: net.HardwareAddr{0x33, 0x33, 0, 0, 0, 1},
+			Source:      net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x02, 0x02},
+			EtherType:   ethernet.EtherTypeIPv6,
+			Payload:     payload,
+		}
+	})
+
+	var resolved, unresolved net.HardwareAddr
+	var count int
+	var probeErr, timeoutErr error
+	err = testNS.Do(func(ns.NetNS) error {
+		resolved, count, probeErr = NdpProbe("br-test", 0, "fd00:10::1", time.Second, 3)
+		unresolved, _, timeoutErr = NdpProbe("br-test", 0, "fd00:10::2", 100*time.Millisecond, 2)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, probeErr)
+	require.Equal(t, gatewayMAC, resolved)
+	require.Equal(t, 1, count)
+	require.Nil(t, unresolved)
+	require.ErrorContains(t, timeoutErr, "no neighbor advertisement from fd00:10::2 on interface br-test")
+
+	request := <-requests
+	require.NotNil(t, request)
+	require.Equal(t, net.HardwareAddr{0x33, 0x33, 0xff, 0x00, 0x00, 0x01}, request.Destination)
+	require.Equal(t, mac, request.Source)
+	require.Nil(t, request.VLAN)
+	require.EqualValues(t, 255, request.Payload[7])
+	require.True(t, netip.AddrFrom16([16]byte(request.Payload[8:24])).IsUnspecified())
+	require.Equal(t, netip.MustParseAddr("ff02::1:ff00:1"), netip.AddrFrom16([16]byte(request.Payload[24:40])))
+	// no source link-layer address option is included
+	require.Len(t, solicitation, 20)
+}
@@ -47,6 +47,19 @@ func GenerateMac() string {
 	return net.HardwareAddr(buf).String()
 }
 
+// IPv6LinkLocalAddress returns the ipv6 link local address generated from the mac address in modified EUI-64 format
+func IPv6LinkLocalAddress(mac string) (string, error) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return "", err
+	}
+	if len(hw) != 6 {
+		return "", fmt.Errorf("%s is not an EUI-48 mac address", mac)
+	}
+	ip := net.IP{0xfe, 0x80, 0, 0, 0, 0, 0, 0, hw[0] ^ 0x02, hw[1], hw[2], 0xff, 0xfe, hw[3], hw[4], hw[5]}
+	return ip.String(), nil
+}
+
 func IP2BigInt(ipStr string) *big.Int {
 	ipBigInt := big.NewInt(0)
 	if CheckProtocol(ipStr) == kubeovnv1.ProtocolIPv4 {
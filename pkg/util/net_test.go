@@ -1011,6 +1011,31 @@ func TestGatewayContains(t *testing.T) {
 	}
 }
 
+func TestIPv6LinkLocalAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		mac     string
+		want    string
+		wantErr bool
+	}{
+		{"universal", "00:00:00:11:22:33", "fe80::200:ff:fe11:2233", false},
+		{"local", "0a:58:0a:10:00:05", "fe80::858:aff:fe10:5", false},
+		{"invalid", "00:00:00:11:22", "", true},
+		{"eui64", "00:00:00:11:22:33:44:55", "", true},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := IPv6LinkLocalAddress(c.mac)
+			if c.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.want, got)
+		})
+	}
+}
+
 func TestJoinHostPort(t *testing.T) {
 	tests := []struct {
 		name string
@@ -8,8 +8,10 @@ import (
 	"strings"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
 )
 
 func ValidateSubnet(subnet kubeovnv1.Subnet) error {
@@ -110,6 +112,20 @@ func ValidateSubnet(subnet kubeovnv1.Subnet) error {
 		return fmt.Errorf("logicalGateway and u2oInterconnection can't be opened at the same time")
 	}
 
+	if err := validateStatelessAcls(subnet.Spec.Acls); err != nil {
+		return err
+	}
+
+	if subnet.Spec.DHCPOptions != "" && (subnet.Spec.DHCPv4Options != "" || subnet.Spec.DHCPv6Options != "") {
+		return fmt.Errorf("conflict configuration: dhcpOptions and dhcpV4Options/dhcpV6Options")
+	}
+
+	if subnet.Spec.IPv6AddressMode != "" {
+		if err := validateIPv6AddressMode(subnet); err != nil {
+			return err
+		}
+	}
+
 	if len(subnet.Spec.NatOutgoingPolicyRules) != 0 {
 		if err := validateNatOutgoingPolicyRules(subnet); err != nil {
 			return err
@@ -127,6 +143,50 @@ func ValidateSubnet(subnet kubeovnv1.Subnet) error {
 	return nil
 }
 
+// validateIPv6AddressMode checks whether the ipv6 addresses of the subnet can be learned from the upstream router
+func validateIPv6AddressMode(subnet kubeovnv1.Subnet) error {
+	mode := subnet.Spec.IPv6AddressMode
+	if mode != kubeovnv1.IPv6AddressModeSLAAC {
+		return fmt.Errorf("%s is not a valid ipv6 address mode", mode)
+	}
+	protocol := CheckProtocol(subnet.Spec.CIDRBlock)
+	if protocol != kubeovnv1.ProtocolIPv6 && protocol != kubeovnv1.ProtocolDual {
+		return fmt.Errorf("ipv6 address mode %s requires an ipv6 cidr", mode)
+	}
+	if subnet.Spec.Vlan == "" || subnet.Spec.LogicalGateway {
+		return fmt.Errorf("ipv6 address mode %s is only supported by underlay subnets with an upstream router", mode)
+	}
+	if subnet.Spec.EnableIPv6RA {
+		return fmt.Errorf("conflict configuration: ipv6AddressMode and enableIPv6RA")
+	}
+	_, v6CIDR := SplitStringIP(subnet.Spec.CIDRBlock)
+	if _, cidr, err := net.ParseCIDR(v6CIDR); err == nil {
+		if ones, _ := cidr.Mask.Size(); ones != 64 {
+			return fmt.Errorf("ipv6 address mode %s requires a /64 ipv6 cidr, but got %s", mode, v6CIDR)
+		}
+	}
+	return nil
+}
+
+// validateStatelessAcls checks whether the stateless acls are representable without conntrack
+func validateStatelessAcls(acls []kubeovnv1.ACL) error {
+	for _, acl := range acls {
+		if !acl.Stateless {
+			continue
+		}
+		switch acl.Action {
+		case ovnnb.ACLActionAllow, ovnnb.ACLActionAllowRelated, ovnnb.ACLActionAllowStateless:
+			if _, err := ReverseACLMatch(acl.Match); err != nil {
+				return fmt.Errorf("stateless acl is invalid: %v", err)
+			}
+		case ovnnb.ACLActionDrop, ovnnb.ACLActionReject:
+		default:
+			return fmt.Errorf("action %s of acl with match %q is not supported in stateless mode", acl.Action, acl.Match)
+		}
+	}
+	return nil
+}
+
 func validateNatOutgoingPolicyRules(subnet kubeovnv1.Subnet) error {
 	for _, rule := range subnet.Spec.NatOutgoingPolicyRules {
 		var srcProtocol, dstProtocol string
@@ -357,3 +417,88 @@ func ValidateVpc(vpc *kubeovnv1.Vpc) error {
 
 	return nil
 }
+
+func ValidateInterConnection(spec *kubeovnv1.InterConnectionSpec) error {
+	if !spec.Enable {
+		return nil
+	}
+	if spec.AzName == "" {
+		return fmt.Errorf("azName is required")
+	}
+	if len(spec.ICDBHosts) == 0 {
+		return fmt.Errorf("icDBHosts is required")
+	}
+	for _, host := range spec.ICDBHosts {
+		if net.ParseIP(host) == nil && len(validation.IsDNS1123Subdomain(host)) != 0 {
+			return fmt.Errorf("invalid ic db host %q", host)
+		}
+	}
+	if spec.ICNbPort <= 0 || spec.ICNbPort > 65535 {
+		return fmt.Errorf("invalid ic nb port %d", spec.ICNbPort)
+	}
+	if spec.ICSbPort <= 0 || spec.ICSbPort > 65535 {
+		return fmt.Errorf("invalid ic sb port %d", spec.ICSbPort)
+	}
+	if len(spec.GatewayNodes) == 0 {
+		return fmt.Errorf("gatewayNodes is required")
+	}
+	for _, node := range spec.GatewayNodes {
+		if strings.TrimSpace(node) == "" {
+			return fmt.Errorf("gateway node name should not be empty")
+		}
+	}
+	return nil
+}
+
+func ValidateDHCPOptions(spec *kubeovnv1.DHCPOptionsSpec) error {
+	for _, router := range spec.Routers {
+		if ip := net.ParseIP(router); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("router %q is not a valid ipv4 address", router)
+		}
+	}
+	for _, server := range spec.DNSServers {
+		if net.ParseIP(server) == nil {
+			return fmt.Errorf("dns server %q is not a valid ip address", server)
+		}
+	}
+	for _, domain := range spec.DomainSearch {
+		if errs := validation.IsDNS1123Subdomain(domain); len(errs) != 0 {
+			return fmt.Errorf("invalid search domain %q: %s", domain, strings.Join(errs, ", "))
+		}
+	}
+	for _, server := range spec.NTPServers {
+		if ip := net.ParseIP(server); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("ntp server %q is not a valid ipv4 address", server)
+		}
+	}
+	if spec.MTU != 0 && (spec.MTU < 68 || spec.MTU > 65535) {
+		return fmt.Errorf("invalid mtu %d", spec.MTU)
+	}
+	if spec.LeaseTime < 0 {
+		return fmt.Errorf("invalid lease time %d", spec.LeaseTime)
+	}
+	for _, route := range spec.ClasslessStaticRoutes {
+		if _, _, err := net.ParseCIDR(route.Destination); err != nil || CheckProtocol(route.Destination) != kubeovnv1.ProtocolIPv4 {
+			return fmt.Errorf("route destination %q is not a valid ipv4 cidr", route.Destination)
+		}
+		if ip := net.ParseIP(route.NextHop); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("route next hop %q is not a valid ipv4 address", route.NextHop)
+		}
+	}
+	return ValidateDHCPBootOptions(spec.NextServer, spec.Bootfile, spec.IPXEBootfile)
+}
+
+// ValidateDHCPBootOptions checks the network boot options offered to the PXE clients
+func ValidateDHCPBootOptions(nextServer, bootfile, ipxeBootfile string) error {
+	if nextServer != "" {
+		if ip := net.ParseIP(nextServer); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("next server %q is not a valid ipv4 address", nextServer)
+		}
+	}
+	for _, file := range []string{bootfile, ipxeBootfile} {
+		if strings.ContainsAny(file, `"\`) {
+			return fmt.Errorf("invalid bootfile %q", file)
+		}
+	}
+	return nil
+}
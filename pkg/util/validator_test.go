@@ -476,6 +476,126 @@ func TestValidateSubnet(t *testing.T) {
 			},
 			err: "ip 10.16.1 in excludeIps is not a valid address",
 		},
+		{
+			name: "StatelessAclErr",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Default:     true,
+					Vpc:         "ovn-cluster",
+					Protocol:    "IPv4",
+					CIDRBlock:   "10.16.0.0/16",
+					Gateway:     "10.16.0.1",
+					ExcludeIps:  []string{"10.16.0.1"},
+					Provider:    "ovn",
+					GatewayType: "distributed",
+					Acls: []kubeovnv1.ACL{
+						{Direction: "to-lport", Priority: 1000, Match: "ip4.src == 10.0.0.0/8 && udp.dst == 53", Action: "allow-related", Stateless: true},
+						{Direction: "to-lport", Priority: 1001, Match: "ip4 && ct.est", Action: "allow-related", Stateless: true},
+					},
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "field ct.est in match \"ip4 && ct.est\" can not be reversed statelessly",
+		},
+		{
+			name: "IPv6AddressModeCorrect",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Protocol:        "Dual",
+					CIDRBlock:       "10.16.0.0/16,fd00:10:16::/64",
+					Gateway:         "10.16.0.1,fd00:10:16::1",
+					Provider:        "ovn",
+					Vlan:            "vlan1",
+					IPv6AddressMode: "slaac",
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "",
+		},
+		{
+			name: "IPv6AddressModeOverlayErr",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Protocol:        "IPv6",
+					CIDRBlock:       "fd00:10:16::/64",
+					Gateway:         "fd00:10:16::1",
+					Provider:        "ovn",
+					IPv6AddressMode: "slaac",
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "ipv6 address mode slaac is only supported by underlay subnets with an upstream router",
+		},
+		{
+			name: "IPv6AddressModePrefixErr",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Protocol:        "IPv6",
+					CIDRBlock:       "fd00:10:16::/96",
+					Gateway:         "fd00:10:16::1",
+					Provider:        "ovn",
+					Vlan:            "vlan1",
+					IPv6AddressMode: "slaac",
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "ipv6 address mode slaac requires a /64 ipv6 cidr, but got fd00:10:16::/96",
+		},
+		{
+			name: "IPv6AddressModeInvalidErr",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Protocol:        "IPv6",
+					CIDRBlock:       "fd00:10:16::/64",
+					Gateway:         "fd00:10:16::1",
+					Provider:        "ovn",
+					Vlan:            "vlan1",
+					IPv6AddressMode: "dhcpv6-pd",
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "dhcpv6-pd is not a valid ipv6 address mode",
+		},
+		{
+			name: "DHCPOptionsConflictErr",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Protocol:      "IPv4",
+					CIDRBlock:     "10.16.0.0/16",
+					Gateway:       "10.16.0.1",
+					Provider:      "ovn",
+					EnableDHCP:    true,
+					DHCPv4Options: "lease_time=3600",
+					DHCPOptions:   "pxe",
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "conflict configuration: dhcpOptions and dhcpV4Options/dhcpV6Options",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -817,3 +937,142 @@ func TestValidateCidrConflict(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateInterConnection(t *testing.T) {
+	valid := kubeovnv1.InterConnectionSpec{
+		Enable:       true,
+		AzName:       "az1",
+		ICDBHosts:    []string{"192.168.0.2", "192.168.0.3"},
+		ICNbPort:     6645,
+		ICSbPort:     6646,
+		GatewayNodes: []string{"node1", "node2"},
+	}
+	tests := []struct {
+		name   string
+		update func(spec *kubeovnv1.InterConnectionSpec)
+		err    string
+	}{
+		{
+			name:   "correct",
+			update: func(_ *kubeovnv1.InterConnectionSpec) {},
+			err:    "",
+		},
+		{
+			name:   "disabled",
+			update: func(spec *kubeovnv1.InterConnectionSpec) { *spec = kubeovnv1.InterConnectionSpec{} },
+			err:    "",
+		},
+		{
+			name:   "noAzName",
+			update: func(spec *kubeovnv1.InterConnectionSpec) { spec.AzName = "" },
+			err:    "azName is required",
+		},
+		{
+			name: "hostname",
+			update: func(spec *kubeovnv1.InterConnectionSpec) {
+				spec.ICDBHosts = []string{"ovn-ic-db-0.example.com", "fd00::2"}
+			},
+			err: "",
+		},
+		{
+			name:   "invalidHost",
+			update: func(spec *kubeovnv1.InterConnectionSpec) { spec.ICDBHosts = []string{"ovn_ic_db"} },
+			err:    `invalid ic db host "ovn_ic_db"`,
+		},
+		{
+			name:   "invalidPort",
+			update: func(spec *kubeovnv1.InterConnectionSpec) { spec.ICSbPort = 65536 },
+			err:    "invalid ic sb port 65536",
+		},
+		{
+			name:   "noGatewayNodes",
+			update: func(spec *kubeovnv1.InterConnectionSpec) { spec.GatewayNodes = nil },
+			err:    "gatewayNodes is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := *valid.DeepCopy()
+			tt.update(&spec)
+			ret := ValidateInterConnection(&spec)
+			if !ErrorContains(ret, tt.err) {
+				t.Errorf("got %v, want a error %v", ret, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateDHCPOptions(t *testing.T) {
+	valid := kubeovnv1.DHCPOptionsSpec{
+		Routers:      []string{"10.16.0.1"},
+		DNSServers:   []string{"10.96.0.10", "fd00:10:96::a"},
+		DomainSearch: []string{"svc.cluster.local", "cluster.local"},
+		NTPServers:   []string{"10.16.0.2"},
+		MTU:          1400,
+		LeaseTime:    7200,
+		ClasslessStaticRoutes: []kubeovnv1.DHCPStaticRoute{
+			{Destination: "192.168.0.0/16", NextHop: "10.16.0.254"},
+		},
+		Bootfile:     "undionly.kpxe",
+		IPXEBootfile: "http://10.16.0.3/boot.ipxe",
+		NextServer:   "10.16.0.3",
+	}
+	tests := []struct {
+		name   string
+		update func(spec *kubeovnv1.DHCPOptionsSpec)
+		err    string
+	}{
+		{
+			name:   "correct",
+			update: func(_ *kubeovnv1.DHCPOptionsSpec) {},
+			err:    "",
+		},
+		{
+			name:   "ipv6Router",
+			update: func(spec *kubeovnv1.DHCPOptionsSpec) { spec.Routers = []string{"fd00::1"} },
+			err:    `router "fd00::1" is not a valid ipv4 address`,
+		},
+		{
+			name:   "invalidDNSServer",
+			update: func(spec *kubeovnv1.DHCPOptionsSpec) { spec.DNSServers = []string{"10.96.0.256"} },
+			err:    `dns server "10.96.0.256" is not a valid ip address`,
+		},
+		{
+			name:   "invalidSearchDomain",
+			update: func(spec *kubeovnv1.DHCPOptionsSpec) { spec.DomainSearch = []string{"Cluster_Local"} },
+			err:    `invalid search domain "Cluster_Local"`,
+		},
+		{
+			name:   "invalidMTU",
+			update: func(spec *kubeovnv1.DHCPOptionsSpec) { spec.MTU = 60 },
+			err:    "invalid mtu 60",
+		},
+		{
+			name: "ipv6Route",
+			update: func(spec *kubeovnv1.DHCPOptionsSpec) {
+				spec.ClasslessStaticRoutes = []kubeovnv1.DHCPStaticRoute{{Destination: "fd00::/64", NextHop: "10.16.0.254"}}
+			},
+			err: `route destination "fd00::/64" is not a valid ipv4 cidr`,
+		},
+		{
+			name:   "invalidNextServer",
+			update: func(spec *kubeovnv1.DHCPOptionsSpec) { spec.NextServer = "fd00::2" },
+			err:    `next server "fd00::2" is not a valid ipv4 address`,
+		},
+		{
+			name:   "invalidBootfile",
+			update: func(spec *kubeovnv1.DHCPOptionsSpec) { spec.Bootfile = `boot"file` },
+			err:    "invalid bootfile",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := *valid.DeepCopy()
+			tt.update(&spec)
+			ret := ValidateDHCPOptions(&spec)
+			if !ErrorContains(ret, tt.err) {
+				t.Errorf("got %v, want a error %v", ret, tt.err)
+			}
+		})
+	}
+}
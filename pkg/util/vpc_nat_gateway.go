@@ -1,6 +1,10 @@
 package util
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 func GenNatGwStsName(name string) string {
 	return fmt.Sprintf("vpc-nat-gw-%s", name)
@@ -9,3 +13,138 @@ func GenNatGwStsName(name string) string {
 func GenNatGwPodName(name string) string {
 	return fmt.Sprintf("vpc-nat-gw-%s-0", name)
 }
+
+// PortRange is an inclusive range of ports, a single port has the same start and end
+type PortRange struct {
+	Start int
+	End   int
+}
+
+func (r PortRange) Size() int {
+	return r.End - r.Start + 1
+}
+
+func (r PortRange) Overlaps(other PortRange) bool {
+	return r.Start <= other.End && other.Start <= r.End
+}
+
+func (r PortRange) String() string {
+	if r.Start == r.End {
+		return strconv.Itoa(r.Start)
+	}
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// ParsePortRanges parses a comma separated list of ports and port ranges, eg: "80,443,30000-30999"
+func ParsePortRanges(ports string) ([]PortRange, error) {
+	if strings.TrimSpace(ports) == "" {
+		return nil, fmt.Errorf("port is empty")
+	}
+
+	var ranges []PortRange
+	for _, field := range strings.Split(ports, ",") {
+		field = strings.TrimSpace(field)
+		start, end, isRange := strings.Cut(field, "-")
+		startPort, err := parsePort(start)
+		if err != nil {
+			return nil, err
+		}
+		endPort := startPort
+		if isRange {
+			if endPort, err = parsePort(end); err != nil {
+				return nil, err
+			}
+			if endPort < startPort {
+				return nil, fmt.Errorf("%s is not a valid port range", field)
+			}
+		}
+		ranges = append(ranges, PortRange{Start: startPort, End: endPort})
+	}
+	return ranges, nil
+}
+
+func parsePort(port string) (int, error) {
+	p, err := strconv.Atoi(strings.TrimSpace(port))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse port %q: %w", port, err)
+	}
+	if p < 1 || p > 65535 {
+		return 0, fmt.Errorf("%d is not a valid port", p)
+	}
+	return p, nil
+}
+
+// ParseDnatPortRanges parses the external and internal ports of a dnat rule.
+// The n-th internal port range is mapped to the n-th external port range port by port,
+// so both sides must have the same number of ranges and the paired ranges must have the same size.
+func ParseDnatPortRanges(externalPort, internalPort string) (external, internal []PortRange, err error) {
+	if external, err = ParsePortRanges(externalPort); err != nil {
+		return nil, nil, fmt.Errorf("invalid external port %q: %w", externalPort, err)
+	}
+	if internal, err = ParsePortRanges(internalPort); err != nil {
+		return nil, nil, fmt.Errorf("invalid internal port %q: %w", internalPort, err)
+	}
+	if len(external) != len(internal) {
+		return nil, nil, fmt.Errorf("external port %q and internal port %q have different number of port ranges", externalPort, internalPort)
+	}
+	for i := range external {
+		if external[i].Size() != internal[i].Size() {
+			return nil, nil, fmt.Errorf("external port range %s and internal port range %s have different sizes", external[i], internal[i])
+		}
+		for j := 0; j < i; j++ {
+			if external[i].Overlaps(external[j]) {
+				return nil, nil, fmt.Errorf("external port range %s overlaps with %s", external[i], external[j])
+			}
+		}
+	}
+	return external, internal, nil
+}
+
+// OvnDnatMaxPorts is the max number of external ports of an ovn dnat rule,
+// since each external port is programmed as a separate vip of the ovn load balancer
+const OvnDnatMaxPorts = 1024
+
+// ParseOvnDnatPortRanges parses the ports of an ovn dnat rule like ParseDnatPortRanges,
+// and limits the total number of the external ports to OvnDnatMaxPorts
+func ParseOvnDnatPortRanges(externalPort, internalPort string) (external, internal []PortRange, err error) {
+	if external, internal, err = ParseDnatPortRanges(externalPort, internalPort); err != nil {
+		return nil, nil, err
+	}
+	var count int
+	for _, r := range external {
+		count += r.Size()
+	}
+	if count > OvnDnatMaxPorts {
+		return nil, nil, fmt.Errorf("external port %q has %d ports, which exceeds the limit %d of ovn dnat", externalPort, count, OvnDnatMaxPorts)
+	}
+	return external, internal, nil
+}
+
+// PortRangesOverlap returns true if any range in a overlaps with any range in b
+func PortRangesOverlap(a, b []PortRange) bool {
+	for _, ra := range a {
+		for _, rb := range b {
+			if ra.Overlaps(rb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsDnatPortConflict returns true if two dnat rules of the same eip use the same protocol
+// and overlapping external ports, external ports which can not be parsed are compared literally
+func IsDnatPortConflict(protocol, externalPort, otherProtocol, otherExternalPort string) bool {
+	if !strings.EqualFold(protocol, otherProtocol) {
+		return false
+	}
+	ranges, err := ParsePortRanges(externalPort)
+	if err != nil {
+		return externalPort == otherExternalPort
+	}
+	otherRanges, err := ParsePortRanges(otherExternalPort)
+	if err != nil {
+		return externalPort == otherExternalPort
+	}
+	return PortRangesOverlap(ranges, otherRanges)
+}
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDnatPortRanges(t *testing.T) {
+	tests := []struct {
+		name         string
+		externalPort string
+		internalPort string
+		external     []PortRange
+		internal     []PortRange
+		expectErr    bool
+	}{
+		{
+			name:         "single port",
+			externalPort: "80",
+			internalPort: "8080",
+			external:     []PortRange{{80, 80}},
+			internal:     []PortRange{{8080, 8080}},
+		},
+		{
+			name:         "port range",
+			externalPort: "30000-30999",
+			internalPort: "30000-30999",
+			external:     []PortRange{{30000, 30999}},
+			internal:     []PortRange{{30000, 30999}},
+		},
+		{
+			name:         "port list",
+			externalPort: "80, 443,8000-8009",
+			internalPort: "8080,8443,9000-9009",
+			external:     []PortRange{{80, 80}, {443, 443}, {8000, 8009}},
+			internal:     []PortRange{{8080, 8080}, {8443, 8443}, {9000, 9009}},
+		},
+		{
+			name:         "empty internal port",
+			externalPort: "80",
+			expectErr:    true,
+		},
+		{
+			name:         "invalid port",
+			externalPort: "65536",
+			internalPort: "80",
+			expectErr:    true,
+		},
+		{
+			name:         "reversed range",
+			externalPort: "90-80",
+			internalPort: "90-80",
+			expectErr:    true,
+		},
+		{
+			name:         "different range sizes",
+			externalPort: "80-90",
+			internalPort: "80-89",
+			expectErr:    true,
+		},
+		{
+			name:         "different number of ranges",
+			externalPort: "80,443",
+			internalPort: "80",
+			expectErr:    true,
+		},
+		{
+			name:         "overlapping external ranges",
+			externalPort: "80-90,85",
+			internalPort: "80-90,85",
+			expectErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			external, internal, err := ParseDnatPortRanges(tt.externalPort, tt.internalPort)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.external, external)
+			require.Equal(t, tt.internal, internal)
+		})
+	}
+}
+
+func TestParseOvnDnatPortRanges(t *testing.T) {
+	external, internal, err := ParseOvnDnatPortRanges("30000-31023", "30000-31023")
+	require.NoError(t, err)
+	require.Equal(t, []PortRange{{30000, 31023}}, external)
+	require.Equal(t, []PortRange{{30000, 31023}}, internal)
+
+	_, _, err = ParseOvnDnatPortRanges("1-65535", "1-65535")
+	require.ErrorContains(t, err, "exceeds the limit")
+	_, _, err = ParseOvnDnatPortRanges("80,30000-31023", "80,30000-31023")
+	require.ErrorContains(t, err, "exceeds the limit")
+	_, _, err = ParseOvnDnatPortRanges("80", "")
+	require.Error(t, err)
+}
+
+func TestIsDnatPortConflict(t *testing.T) {
+	require.True(t, IsDnatPortConflict("tcp", "80", "TCP", "80"))
+	require.True(t, IsDnatPortConflict("tcp", "30000-30999", "tcp", "22,30500"))
+	require.False(t, IsDnatPortConflict("tcp", "30000-30999", "tcp", "31000-31999"))
+	require.False(t, IsDnatPortConflict("tcp", "53", "udp", "53"))
+	require.True(t, IsDnatPortConflict("tcp", "invalid", "tcp", "invalid"))
+}
@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+var dhcpOptionsGVK = metav1.GroupVersionKind{Group: ovnv1.SchemeGroupVersion.Group, Version: ovnv1.SchemeGroupVersion.Version, Kind: "DHCPOptions"}
+
+func (v *ValidatingHook) DHCPOptionsCreateOrUpdateHook(_ context.Context, req admission.Request) admission.Response {
+	dhcpOptions := ovnv1.DHCPOptions{}
+	if err := v.decoder.DecodeRaw(req.Object, &dhcpOptions); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if err := util.ValidateDHCPOptions(&dhcpOptions.Spec); err != nil {
+		return ctrlwebhook.Denied(err.Error())
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) DHCPOptionsDeleteHook(ctx context.Context, req admission.Request) admission.Response {
+	dhcpOptions := ovnv1.DHCPOptions{}
+	if err := v.decoder.DecodeRaw(req.OldObject, &dhcpOptions); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	subnetList := &ovnv1.SubnetList{}
+	if err := v.cache.List(ctx, subnetList); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	for _, subnet := range subnetList.Items {
+		if subnet.Spec.DHCPOptions == dhcpOptions.Name {
+			err := fmt.Errorf("can't delete dhcp options %s used by subnet %s", dhcpOptions.Name, subnet.Name)
+			return ctrlwebhook.Denied(err.Error())
+		}
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+var interConnectionGVK = metav1.GroupVersionKind{Group: ovnv1.SchemeGroupVersion.Group, Version: ovnv1.SchemeGroupVersion.Version, Kind: "InterConnection"}
+
+func (v *ValidatingHook) InterConnectionCreateHook(ctx context.Context, req admission.Request) admission.Response {
+	ic := ovnv1.InterConnection{}
+	if err := v.decoder.DecodeRaw(req.Object, &ic); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	icList := &ovnv1.InterConnectionList{}
+	if err := v.cache.List(ctx, icList); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	for _, item := range icList.Items {
+		if item.Name != ic.Name {
+			err := fmt.Errorf("inter connection %s already exists, only one inter connection is allowed", item.Name)
+			return ctrlwebhook.Denied(err.Error())
+		}
+	}
+
+	if err := util.ValidateInterConnection(&ic.Spec); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) InterConnectionUpdateHook(_ context.Context, req admission.Request) admission.Response {
+	ic := ovnv1.InterConnection{}
+	if err := v.decoder.DecodeRaw(req.Object, &ic); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := util.ValidateInterConnection(&ic.Spec); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+var ippoolGVK = metav1.GroupVersionKind{Group: ovnv1.SchemeGroupVersion.Group, Version: ovnv1.SchemeGroupVersion.Version, Kind: "IPPool"}
+
+func (v *ValidatingHook) IPPoolCreateHook(ctx context.Context, req admission.Request) admission.Response {
+	ippool := ovnv1.IPPool{}
+	if err := v.decoder.DecodeRaw(req.Object, &ippool); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if err := util.ValidateIPPoolAddresses(&ippool.Spec); err != nil {
+		return ctrlwebhook.Denied(err.Error())
+	}
+
+	return v.validateIPPoolSelector(ctx, &ippool)
+}
+
+func (v *ValidatingHook) IPPoolUpdateHook(ctx context.Context, req admission.Request) admission.Response {
+	ippool := ovnv1.IPPool{}
+	if err := v.decoder.DecodeRaw(req.Object, &ippool); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	oldIPPool := ovnv1.IPPool{}
+	if err := v.decoder.DecodeRaw(req.OldObject, &oldIPPool); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if err := util.ValidateIPPoolAddresses(&ippool.Spec); err != nil {
+		return ctrlwebhook.Denied(err.Error())
+	}
+	if ippool.Spec.Subnet == oldIPPool.Spec.Subnet &&
+		ippool.Spec.Priority == oldIPPool.Spec.Priority &&
+		equalIPPoolSelector(&ippool.Spec, &oldIPPool.Spec) {
+		return ctrlwebhook.Allowed("by pass")
+	}
+
+	return v.validateIPPoolSelector(ctx, &ippool)
+}
+
+func equalIPPoolSelector(a, b *ovnv1.IPPoolSpec) bool {
+	return reflect.DeepEqual(a.PodSelector, b.PodSelector) &&
+		reflect.DeepEqual(a.Owners, b.Owners) &&
+		reflect.DeepEqual(a.Namespaces, b.Namespaces)
+}
+
+// validateIPPoolSelector rejects the ippool whose selector overlaps with another ippool of the same subnet and priority,
+// since it's not determined which ippool is used by the pods selected by both
+func (v *ValidatingHook) validateIPPoolSelector(ctx context.Context, ippool *ovnv1.IPPool) admission.Response {
+	if err := util.ValidateIPPoolSelector(&ippool.Spec); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if ippool.Spec.PodSelector == nil && len(ippool.Spec.Owners) == 0 {
+		return ctrlwebhook.Allowed("by pass")
+	}
+
+	ippoolList := &ovnv1.IPPoolList{}
+	if err := v.cache.List(ctx, ippoolList); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	for _, item := range ippoolList.Items {
+		if item.Name == ippool.Name || item.Spec.Priority != ippool.Spec.Priority {
+			continue
+		}
+		overlap, err := util.IPPoolSelectorsOverlap(&ippool.Spec, &item.Spec, v.ippoolOwnerPodLabels(ctx))
+		if err != nil {
+			return ctrlwebhook.Errored(http.StatusBadRequest, err)
+		}
+		if overlap {
+			err = fmt.Errorf("selector of ippool %s overlaps with ippool %s of the same priority %d", ippool.Name, item.Name, item.Spec.Priority)
+			return ctrlwebhook.Denied(err.Error())
+		}
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+// ippoolOwnerPodLabels returns the labels in the pod template of the ippool owner
+func (v *ValidatingHook) ippoolOwnerPodLabels(ctx context.Context) util.IPPoolOwnerPodLabels {
+	return func(owner ovnv1.IPPoolOwner) (map[string]string, error) {
+		key := types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}
+		var err error
+		var podLabels map[string]string
+		switch owner.Kind {
+		case util.IPPoolOwnerKindDeployment:
+			deploy := &appsv1.Deployment{}
+			if err = v.cache.Get(ctx, key, deploy); err == nil {
+				podLabels = deploy.Spec.Template.Labels
+			}
+		case util.IPPoolOwnerKindStatefulSet:
+			sts := &appsv1.StatefulSet{}
+			if err = v.cache.Get(ctx, key, sts); err == nil {
+				podLabels = sts.Spec.Template.Labels
+			}
+		}
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		return podLabels, nil
+	}
+}
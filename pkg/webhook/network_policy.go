@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+var networkPolicyGVK = metav1.GroupVersionKind{Group: netv1.SchemeGroupVersion.Group, Version: netv1.SchemeGroupVersion.Version, Kind: "NetworkPolicy"}
+
+func (v *ValidatingHook) NetworkPolicyCreateOrUpdateHook(_ context.Context, req admission.Request) admission.Response {
+	np := netv1.NetworkPolicy{}
+	if err := v.decoder.DecodeRaw(req.Object, &np); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if _, err := util.ParseEgressFQDNs(np.Annotations[util.NetworkPolicyEgressFQDNsAnnotation]); err != nil {
+		return ctrlwebhook.Denied(err.Error())
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
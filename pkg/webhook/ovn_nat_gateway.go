@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"strconv"
+	"reflect"
+	"slices"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -92,6 +93,17 @@ func (v *ValidatingHook) isOvnEipInUse(ctx context.Context, eipV4IP string) (str
 	if len(snatList.Items) != 0 {
 		return util.SnatUsingEip, nil
 	}
+	// the eip may be shared by a snat which translates to multiple eips
+	snatList = ovnv1.OvnSnatRuleList{}
+	if err = v.cache.List(ctx, &snatList); err != nil {
+		klog.Errorf("failed to list ovn snat, %v", err)
+		return "", err
+	}
+	for _, snat := range snatList.Items {
+		if slices.Contains(snat.Status.V4Eips, eipV4IP) {
+			return util.SnatUsingEip, nil
+		}
+	}
 	return "", nil
 }
 
@@ -177,7 +189,7 @@ func (v *ValidatingHook) ovnSnatUpdateHook(ctx context.Context, req admission.Re
 		return ctrlwebhook.Errored(http.StatusBadRequest, err)
 	}
 
-	if snatOld.Spec != snatNew.Spec {
+	if !reflect.DeepEqual(snatOld.Spec, snatNew.Spec) {
 		if snatOld.Status.Ready {
 			err := fmt.Errorf("OvnSnat not support change")
 			return ctrlwebhook.Errored(http.StatusBadRequest, err)
@@ -215,7 +227,11 @@ func (v *ValidatingHook) ovnFipUpdateHook(ctx context.Context, req admission.Req
 	}
 
 	if fipNew.Spec != fipOld.Spec {
-		if fipOld.Status.Ready {
+		// fip type and stateless could be switched after the fip is ready
+		oldSpec := fipOld.Spec
+		oldSpec.Type = fipNew.Spec.Type
+		oldSpec.Stateless = fipNew.Spec.Stateless
+		if fipOld.Status.Ready && fipNew.Spec != oldSpec {
 			err := fmt.Errorf("OvnFip not support change")
 			return ctrlwebhook.Errored(http.StatusBadRequest, err)
 		}
@@ -288,20 +304,8 @@ func (v *ValidatingHook) ValidateOvnDnat(ctx context.Context, dnat *ovnv1.OvnDna
 		return err
 	}
 
-	if port, err := strconv.Atoi(dnat.Spec.ExternalPort); err != nil {
-		errMsg := fmt.Errorf("failed to parse spec externalPort %s: %v", dnat.Spec.ExternalPort, err)
-		return errMsg
-	} else if port < 0 || port > 65535 {
-		err := fmt.Errorf("spec externalPort %s is not a valid port", dnat.Spec.ExternalPort)
-		return err
-	}
-
-	if port, err := strconv.Atoi(dnat.Spec.InternalPort); err != nil {
-		errMsg := fmt.Errorf("failed to parse spec internalIP %s: %v", dnat.Spec.InternalPort, err)
-		return errMsg
-	} else if port < 0 || port > 65535 {
-		err := fmt.Errorf("spec internalIP %s is not a valid port", dnat.Spec.InternalPort)
-		return err
+	if _, _, err := util.ParseOvnDnatPortRanges(dnat.Spec.ExternalPort, dnat.Spec.InternalPort); err != nil {
+		return fmt.Errorf("invalid spec externalPort or internalPort: %w", err)
 	}
 
 	if !strings.EqualFold(dnat.Spec.Protocol, "tcp") &&
@@ -310,15 +314,37 @@ func (v *ValidatingHook) ValidateOvnDnat(ctx context.Context, dnat *ovnv1.OvnDna
 		return err
 	}
 
+	dnatList := ovnv1.OvnDnatRuleList{}
+	if err := v.cache.List(ctx, &dnatList); err != nil {
+		return err
+	}
+	for _, d := range dnatList.Items {
+		if d.Name != dnat.Name && d.Spec.OvnEip == dnat.Spec.OvnEip &&
+			util.IsDnatPortConflict(dnat.Spec.Protocol, dnat.Spec.ExternalPort, d.Spec.Protocol, d.Spec.ExternalPort) {
+			err := fmt.Errorf("spec externalPort %s overlaps with port %s of dnat %s on the same ovn eip %s", dnat.Spec.ExternalPort, d.Spec.ExternalPort, d.Name, dnat.Spec.OvnEip)
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (v *ValidatingHook) ValidateOvnSnat(ctx context.Context, snat *ovnv1.OvnSnatRule) error {
-	if snat.Spec.OvnEip == "" {
-		err := fmt.Errorf("should set spec OvnEip")
+	if snat.Spec.OvnEip == "" && len(snat.Spec.OvnEips) == 0 && len(snat.Spec.OvnEipSelector) == 0 {
+		err := fmt.Errorf("should set spec OvnEip or OvnEips or OvnEipSelector")
 		return err
 	}
 
+	if snat.Spec.ExternalPortRange != "" {
+		ranges, err := util.ParsePortRanges(snat.Spec.ExternalPortRange)
+		if err != nil {
+			return fmt.Errorf("invalid spec externalPortRange %q: %w", snat.Spec.ExternalPortRange, err)
+		}
+		if len(ranges) != 1 {
+			return fmt.Errorf("spec externalPortRange %q should be a single port range, eg: 1024-65535", snat.Spec.ExternalPortRange)
+		}
+	}
+
 	if snat.Spec.VpcSubnet != "" && snat.Spec.IPName != "" {
 		err := fmt.Errorf("should not set spec vpcSubnet and ipName at the same time")
 		return err
@@ -339,9 +365,18 @@ func (v *ValidatingHook) ValidateOvnSnat(ctx context.Context, snat *ovnv1.OvnSna
 		return err
 	}
 
-	eip := &ovnv1.OvnEip{}
-	key := types.NamespacedName{Name: snat.Spec.OvnEip}
-	return v.cache.Get(ctx, key, eip)
+	eips := snat.Spec.OvnEips
+	if snat.Spec.OvnEip != "" {
+		eips = append([]string{snat.Spec.OvnEip}, eips...)
+	}
+	for _, name := range eips {
+		eip := &ovnv1.OvnEip{}
+		key := types.NamespacedName{Name: name}
+		if err := v.cache.Get(ctx, key, eip); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (v *ValidatingHook) ValidateOvnFip(ctx context.Context, fip *ovnv1.OvnFip) error {
@@ -353,6 +388,22 @@ func (v *ValidatingHook) ValidateOvnFip(ctx context.Context, fip *ovnv1.OvnFip)
 		err := fmt.Errorf("should set spec ipName or v4Ip")
 		return err
 	}
+	switch fip.Spec.Type {
+	case "":
+	case ovnv1.GWCentralizedType:
+		if fip.Spec.Stateless {
+			err := fmt.Errorf("stateless is only supported by distributed fip")
+			return err
+		}
+	case ovnv1.GWDistributedType:
+		if fip.Spec.IPName == "" || fip.Spec.IPType == util.Vip {
+			err := fmt.Errorf("distributed fip should be bound to a pod ip by spec ipName")
+			return err
+		}
+	default:
+		err := fmt.Errorf("spec type should be %s or %s", ovnv1.GWDistributedType, ovnv1.GWCentralizedType)
+		return err
+	}
 	eip := &ovnv1.OvnEip{}
 	key := types.NamespacedName{Name: fip.Spec.OvnEip}
 	return v.cache.Get(ctx, key, eip)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -458,19 +457,7 @@ func (v *ValidatingHook) ValidateIptablesDnat(ctx context.Context, dnat *ovnv1.I
 		return err
 	}
 
-	if port, err := strconv.Atoi(dnat.Spec.ExternalPort); err != nil {
-		errMsg := fmt.Errorf("failed to parse externalPort %s: %v", dnat.Spec.ExternalPort, err)
-		return errMsg
-	} else if port < 0 || port > 65535 {
-		err := fmt.Errorf("externalPort %s is not a valid port", dnat.Spec.ExternalPort)
-		return err
-	}
-
-	if port, err := strconv.Atoi(dnat.Spec.InternalPort); err != nil {
-		errMsg := fmt.Errorf("failed to parse internalIP %s: %v", dnat.Spec.InternalPort, err)
-		return errMsg
-	} else if port < 0 || port > 65535 {
-		err := fmt.Errorf("internalIP %s is not a valid port", dnat.Spec.InternalPort)
+	if _, _, err := util.ParseDnatPortRanges(dnat.Spec.ExternalPort, dnat.Spec.InternalPort); err != nil {
 		return err
 	}
 
@@ -485,6 +472,18 @@ func (v *ValidatingHook) ValidateIptablesDnat(ctx context.Context, dnat *ovnv1.I
 		return err
 	}
 
+	dnatList := ovnv1.IptablesDnatRuleList{}
+	if err := v.cache.List(ctx, &dnatList); err != nil {
+		return err
+	}
+	for _, d := range dnatList.Items {
+		if d.Name != dnat.Name && d.Spec.EIP == dnat.Spec.EIP &&
+			util.IsDnatPortConflict(dnat.Spec.Protocol, dnat.Spec.ExternalPort, d.Spec.Protocol, d.Spec.ExternalPort) {
+			err := fmt.Errorf("external port %s overlaps with port %s of dnat %s on the same eip %s", dnat.Spec.ExternalPort, d.Spec.ExternalPort, d.Name, dnat.Spec.EIP)
+			return err
+		}
+	}
+
 	return nil
 }
 
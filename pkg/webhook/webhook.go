@@ -48,9 +48,22 @@ func NewValidatingHook(client client.Client, scheme *runtime.Scheme, cache cache
 	updateHooks[vpcGVK] = v.VpcUpdateHook
 	deleteHooks[vpcGVK] = v.VpcDeleteHook
 
+	createHooks[interConnectionGVK] = v.InterConnectionCreateHook
+	updateHooks[interConnectionGVK] = v.InterConnectionUpdateHook
+
+	createHooks[ippoolGVK] = v.IPPoolCreateHook
+	updateHooks[ippoolGVK] = v.IPPoolUpdateHook
+
 	createHooks[vipGVK] = v.VipCreateHook
 	updateHooks[vipGVK] = v.VipUpdateHook
 
+	createHooks[networkPolicyGVK] = v.NetworkPolicyCreateOrUpdateHook
+	updateHooks[networkPolicyGVK] = v.NetworkPolicyCreateOrUpdateHook
+
+	createHooks[dhcpOptionsGVK] = v.DHCPOptionsCreateOrUpdateHook
+	updateHooks[dhcpOptionsGVK] = v.DHCPOptionsCreateOrUpdateHook
+	deleteHooks[dhcpOptionsGVK] = v.DHCPOptionsDeleteHook
+
 	createHooks[vpcNatGatewayGVK] = v.VpcNatGwCreateOrUpdateHook
 	updateHooks[vpcNatGatewayGVK] = v.VpcNatGwCreateOrUpdateHook
 	deleteHooks[vpcNatGatewayGVK] = v.VpcNatGwDeleteHook